@@ -0,0 +1,43 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// alternativesOf returns the movos that can stand in for movo: those it lists in
+// alternatives, then those listing it (variations work both ways). Codes that
+// match no movo are ignored.
+func alternativesOf(snacks []Movo, movo *Movo) []*Movo {
+	var alternatives []*Movo
+	add := func(candidate *Movo) {
+		if candidate != nil && candidate.FullCode != movo.FullCode && !slices.Contains(alternatives, candidate) {
+			alternatives = append(alternatives, candidate)
+		}
+	}
+	for _, code := range movo.Alternatives {
+		add(findMovo(snacks, strings.TrimSpace(code)))
+	}
+	for i := range snacks {
+		for _, code := range snacks[i].Alternatives {
+			if strings.EqualFold(strings.TrimSpace(code), movo.FullCode) {
+				add(&snacks[i])
+			}
+		}
+	}
+	return alternatives
+}
+
+// nextAlternative picks the first alternative not offered yet this session, or the
+// first one if they all have been (nil if there are none)
+func nextAlternative(alternatives []*Movo, offered []string) *Movo {
+	for _, alternative := range alternatives {
+		if !slices.Contains(offered, alternative.FullCode) {
+			return alternative
+		}
+	}
+	if len(alternatives) == 0 {
+		return nil
+	}
+	return alternatives[0]
+}
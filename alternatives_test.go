@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestAlternativesOf(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "MOB-floor-angels", Alternatives: []string{"MOB-wall-angels", "MOB-missing"}},
+		{FullCode: "MOB-wall-angels"},
+		{FullCode: "MOB-doorway-stretch", Alternatives: []string{"MOB-floor-angels"}},
+		{FullCode: "BR-box-breathing"},
+	}
+
+	alternatives := alternativesOf(snacks, &snacks[0])
+	if len(alternatives) != 2 || alternatives[0].FullCode != "MOB-wall-angels" || alternatives[1].FullCode != "MOB-doorway-stretch" {
+		t.Errorf("alternatives of floor angels = %v", codesOf(alternatives))
+	}
+	// The link works both ways
+	if back := alternativesOf(snacks, &snacks[1]); len(back) != 1 || back[0].FullCode != "MOB-floor-angels" {
+		t.Errorf("alternatives of wall angels = %v", codesOf(back))
+	}
+	if none := alternativesOf(snacks, &snacks[3]); len(none) != 0 {
+		t.Errorf("expected no alternatives, got %v", codesOf(none))
+	}
+}
+
+func TestNextAlternative(t *testing.T) {
+	wall, doorway := &Movo{FullCode: "MOB-wall-angels"}, &Movo{FullCode: "MOB-doorway-stretch"}
+	alternatives := []*Movo{wall, doorway}
+
+	if got := nextAlternative(alternatives, []string{"MOB-floor-angels"}); got != wall {
+		t.Errorf("expected the first alternative, got %s", got.FullCode)
+	}
+	if got := nextAlternative(alternatives, []string{"MOB-floor-angels", "MOB-wall-angels"}); got != doorway {
+		t.Errorf("expected the one not offered yet, got %s", got.FullCode)
+	}
+	if got := nextAlternative(alternatives, []string{"MOB-wall-angels", "MOB-doorway-stretch"}); got != wall {
+		t.Errorf("expected to start over, got %s", got.FullCode)
+	}
+	if got := nextAlternative(nil, nil); got != nil {
+		t.Errorf("expected nil without alternatives, got %s", got.FullCode)
+	}
+}
+
+func codesOf(movos []*Movo) []string {
+	var codes []string
+	for _, movo := range movos {
+		codes = append(codes, movo.FullCode)
+	}
+	return codes
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// collapseFactor flags movos whose expected share is below this fraction of a uniform share
+const collapseFactor = 0.05
+
+// weightShare is one movo's place in the current selection distribution
+type weightShare struct {
+	Code      string  `json:"code"`
+	Title     string  `json:"title"`
+	Weight    float64 `json:"weight"`   // Final weight after boosts
+	Expected  float64 `json:"expected"` // Probability from weights (and exploration rate)
+	Observed  float64 `json:"observed"` // Share of simulated selections
+	Count     int     `json:"count"`
+	Collapsed bool    `json:"collapsed"`
+}
+
+// weightAnalysis is the result of simulating many selections
+type weightAnalysis struct {
+	Iterations      int           `json:"iterations"`
+	ExplorationRate float64       `json:"exploration_rate"`
+	RecoveryMode    bool          `json:"recovery_mode"`
+	Excluded        int           `json:"excluded"` // Movos filtered out entirely
+	Movos           []weightShare `json:"movos"`
+}
+
+// expectedShare is the chance a candidate is picked: an even share of
+// exploration picks plus its weighted share of the rest
+func expectedShare(weight, total, n, explorationRate float64) float64 {
+	expected := explorationRate / n
+	if total > 0 {
+		expected += (1 - explorationRate) * weight / total
+	}
+	return expected
+}
+
+// analyzeWeights computes each candidate's expected probability and simulates
+// selections with the same weighted/exploration pick SelectSnack uses
+func analyzeWeights(r *rand.Rand, weighted []weightedSnack, iterations int, explorationRate float64) []weightShare {
+	total := 0.0
+	for _, w := range weighted {
+		total += w.weight
+	}
+
+	n := float64(len(weighted))
+	shares := make([]weightShare, len(weighted))
+	index := make(map[string]int, len(weighted))
+	for i, w := range weighted {
+		expected := expectedShare(w.weight, total, n, explorationRate)
+		shares[i] = weightShare{
+			Code:      w.snack.FullCode,
+			Title:     w.snack.Title,
+			Weight:    w.weight,
+			Expected:  expected,
+			Collapsed: expected < collapseFactor/n,
+		}
+		index[w.snack.FullCode] = i
+	}
+
+	for i := 0; i < iterations; i++ {
+		var picked Movo
+		if explorationRate > 0 && r.Float64() < explorationRate {
+			picked = weighted[r.IntN(len(weighted))].snack
+		} else {
+			picked = weightedRandomSelect(r, weighted)
+		}
+		shares[index[picked.FullCode]].Count++
+	}
+
+	for i := range shares {
+		if iterations > 0 {
+			shares[i].Observed = float64(shares[i].Count) / float64(iterations)
+		}
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool { return shares[i].Expected > shares[j].Expected })
+	return shares
+}
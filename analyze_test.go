@@ -0,0 +1,45 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestAnalyzeWeights(t *testing.T) {
+	weighted := []weightedSnack{
+		{snack: Movo{FullCode: "TS-pushups"}, weight: 6},
+		{snack: Movo{FullCode: "TB-box-breath"}, weight: 3.99},
+		{snack: Movo{FullCode: "TS-forgotten"}, weight: 0.01},
+	}
+
+	shares := analyzeWeights(rand.New(rand.NewPCG(1, 2)), weighted, 1000, 0)
+	if len(shares) != 3 || shares[0].Code != "TS-pushups" || shares[2].Code != "TS-forgotten" {
+		t.Fatalf("expected shares sorted by expected probability, got %+v", shares)
+	}
+
+	totalExpected, totalCount := 0.0, 0
+	for _, s := range shares {
+		totalExpected += s.Expected
+		totalCount += s.Count
+	}
+	if math.Abs(totalExpected-1) > 1e-9 {
+		t.Errorf("expected probabilities should sum to 1, got %f", totalExpected)
+	}
+	if totalCount != 1000 {
+		t.Errorf("expected 1000 simulated picks, got %d", totalCount)
+	}
+
+	if !shares[2].Collapsed || shares[0].Collapsed {
+		t.Errorf("only TS-forgotten should be flagged as collapsed: %+v", shares)
+	}
+
+	// Exploration gives every candidate a floor
+	shares = analyzeWeights(rand.New(rand.NewPCG(1, 2)), weighted, 0, 0.3)
+	if shares[2].Collapsed {
+		t.Errorf("with 30%% exploration nothing should collapse, got %+v", shares[2])
+	}
+	if want := 0.3/3 + 0.7*0.6; math.Abs(shares[0].Expected-want) > 1e-9 {
+		t.Errorf("expected %f for TS-pushups, got %f", want, shares[0].Expected)
+	}
+}
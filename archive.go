@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// archiveDirName is the logs subdirectory holding monthly archives (YYYYMM.csv),
+// each the daily logs of that month moved into one file by 'archive'
+const archiveDirName = "archive"
+
+// GetArchiveLogPath returns the path of the archive for the month containing date
+func GetArchiveLogPath(logsDir string, date time.Time) string {
+	return filepath.Join(logsDir, archiveDirName, date.Format("200601")+".csv")
+}
+
+// readLogRecords reads a log file's rows without the header. A missing file has none.
+func readLogRecords(path string) ([][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Older rows have no extras column
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", filepath.Base(path), err)
+	}
+	if len(records) > 0 && len(records[0]) > 0 && records[0][0] == "timestamp" {
+		records = records[1:]
+	}
+	return records, nil
+}
+
+// loadArchivedDay returns the entries archived for date, matched on their local day
+func loadArchivedDay(logsDir string, date time.Time) ([]HistoryEntry, error) {
+	records, err := readLogRecords(GetArchiveLogPath(logsDir, date))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []HistoryEntry{}
+	day := dayKey(date)
+	for _, record := range records {
+		entry, err := parseCSVRecord(record)
+		if err != nil || dayKey(entry.Timestamp.In(date.Location())) != day {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// archiveResult counts what archiveLogs moved (or would move)
+type archiveResult struct {
+	Files   int
+	Entries int
+	Months  []string // YYYYMM, oldest first
+}
+
+// archiveLogs moves the daily logs of days before the cutoff into monthly archive
+// files, appending to any archive already there. Each month's archive is written in
+// full before its daily logs are removed. With dryRun nothing is changed.
+func archiveLogs(logsDir string, before time.Time, dryRun bool) (archiveResult, error) {
+	var result archiveResult
+
+	files, err := filepath.Glob(filepath.Join(logsDir, "*.csv"))
+	if err != nil {
+		return result, fmt.Errorf("error finding log files: %w", err)
+	}
+	sort.Strings(files)
+
+	byMonth := make(map[string][]string)
+	for _, path := range files {
+		date, ok := logFileDate(filepath.Base(path))
+		if !ok || !date.Before(before) {
+			continue
+		}
+		month := date.Format("200601")
+		if byMonth[month] == nil {
+			result.Months = append(result.Months, month)
+		}
+		byMonth[month] = append(byMonth[month], path)
+	}
+
+	for _, month := range result.Months {
+		monthStart, _ := time.ParseInLocation("200601", month, time.Local)
+		archivePath := GetArchiveLogPath(logsDir, monthStart)
+
+		records, err := readLogRecords(archivePath)
+		if err != nil {
+			return result, err
+		}
+		for _, path := range byMonth[month] {
+			daily, err := readLogRecords(path)
+			if err != nil {
+				return result, err
+			}
+			records = append(records, daily...)
+			result.Entries += len(daily)
+			result.Files++
+		}
+		if dryRun {
+			continue
+		}
+
+		if err := writeLogRecords(archivePath, records); err != nil {
+			return result, err
+		}
+		for _, path := range byMonth[month] {
+			if err := os.Remove(path); err != nil {
+				return result, fmt.Errorf("error removing %s: %w", filepath.Base(path), err)
+			}
+			if err := forgetChecksum(logsDir, path); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// writeLogRecords replaces a log or archive file with the given rows, via a temporary
+// file so an interrupted write leaves the old one intact
+func writeLogRecords(path string, records [][]string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", filepath.Base(path), err)
+	}
+	writer := csv.NewWriter(file)
+	writer.Write(logHeader)
+	writer.WriteAll(records)
+	if err := writer.Error(); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("error writing %s: %w", filepath.Base(path), err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error writing %s: %w", filepath.Base(path), err)
+	}
+	return os.Rename(tmp, path)
+}
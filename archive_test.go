@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArchiveLogs(t *testing.T) {
+	logsDir := t.TempDir()
+	day := func(m time.Month, d, h int) time.Time { return time.Date(2024, m, d, h, 0, 0, 0, time.Local) }
+	for _, ts := range []time.Time{day(11, 3, 9), day(11, 3, 15), day(11, 20, 8), day(12, 1, 10), day(12, 31, 12)} {
+		if err := AppendDailyLog(logsDir, doneAt("KB-swings", ts)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before, err := LoadAllHistory(logsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cutoff := day(12, 31, 0)
+	dry, err := archiveLogs(logsDir, cutoff, true)
+	if err != nil || dry.Files != 3 || dry.Entries != 4 || len(dry.Months) != 2 {
+		t.Fatalf("expected 3 files and 4 entries over 2 months, got %+v %v", dry, err)
+	}
+	if _, err := os.Stat(GetDailyLogPath(logsDir, day(11, 3, 0))); err != nil {
+		t.Fatal("a dry run shouldn't remove daily logs")
+	}
+
+	if _, err := archiveLogs(logsDir, cutoff, false); err != nil {
+		t.Fatal(err)
+	}
+	remaining, _ := filepath.Glob(filepath.Join(logsDir, "*.csv"))
+	if len(remaining) != 1 {
+		t.Errorf("expected only the Dec 31 daily log left, got %v", remaining)
+	}
+
+	after, err := LoadAllHistory(logsDir)
+	if err != nil || len(after) != len(before) {
+		t.Fatalf("expected all %d entries after archiving, got %d %v", len(before), len(after), err)
+	}
+	for i := range after {
+		if !after[i].Timestamp.Equal(before[i].Timestamp) {
+			t.Errorf("entry %d: got %v, want %v", i, after[i].Timestamp, before[i].Timestamp)
+		}
+	}
+
+	// Days read from the archive, alongside a daily log written later
+	if err := AppendDailyLog(logsDir, doneAt("MOB-hips", day(11, 3, 20))); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadDailyLog(logsDir, day(11, 3, 0))
+	if err != nil || len(entries) != 3 {
+		t.Errorf("expected 2 archived entries and 1 new one for Nov 3, got %v %v", entries, err)
+	}
+	if week, _ := LoadHistoryRange(logsDir, day(11, 18, 0), day(11, 24, 0)); len(week) != 1 {
+		t.Errorf("expected the Nov 20 entry in its week, got %v", week)
+	}
+
+	// Archiving again appends to the month
+	if _, err := archiveLogs(logsDir, cutoff, false); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := LoadDailyLog(logsDir, day(11, 3, 0)); len(entries) != 3 {
+		t.Errorf("expected 3 entries for Nov 3 after archiving again, got %v", entries)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import "strings"
+
+// Contraindicated reports whether the snack has any of the contraindications in avoid
+func (s *Movo) Contraindicated(avoid []string) bool {
+	for _, a := range avoid {
+		for _, c := range s.Contraindications {
+			if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(a)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterContraindicated removes snacks conflicting with the avoid list from config.yaml
+func filterContraindicated(snacks []Movo, avoid []string) []Movo {
+	if len(avoid) == 0 {
+		return snacks
+	}
+	var filtered []Movo
+	for i := range snacks {
+		if !snacks[i].Contraindicated(avoid) {
+			filtered = append(filtered, snacks[i])
+		}
+	}
+	return filtered
+}
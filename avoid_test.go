@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWeighCandidatesAvoidsContraindications(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "avoid: [Spinal-Flexion]\nprofiles:\n  shoulder:\n    avoid: [overhead]\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	movos := []Movo{
+		{FullCode: "CORE-situps", Contraindications: []string{"spinal-flexion"}, Weight: 1, MinPerDay: 1},
+		{FullCode: "KB-press", Contraindications: []string{"overhead"}, Weight: 1},
+		{FullCode: "MOB-hips", Weight: 1},
+	}
+	eligible := func() []string {
+		weighted, _, err := weighCandidates(movos, FilterOptions{}, 30)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var codes []string
+		for _, w := range weighted {
+			codes = append(codes, w.snack.FullCode)
+		}
+		return codes
+	}
+
+	// The sit-ups' everyday minimum doesn't bring them back
+	if got := eligible(); !reflect.DeepEqual(got, []string{"KB-press", "MOB-hips"}) {
+		t.Errorf("expected sit-ups avoided, got %v", got)
+	}
+	t.Setenv("MOVODORO_PROFILE", "shoulder")
+	if got := eligible(); !reflect.DeepEqual(got, []string{"CORE-situps"}) {
+		t.Errorf("expected the profile's avoid list to replace the default, got %v", got)
+	}
+
+	if _, _, err := weighCandidates(movos[1:2], FilterOptions{}, 30); err == nil {
+		t.Error("expected an error when every match is contraindicated")
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseLogTime works out when a backdated entry happened from --date (today,
+// yesterday or YYYY-MM-DD) and --time (HH:MM). A date without a time keeps now's
+// time of day, and a time without a date is today. Times in the future are refused.
+func parseLogTime(date, clock string, now time.Time) (time.Time, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(date) {
+	case "", "today":
+	case "yesterday":
+		day = day.AddDate(0, 0, -1)
+	default:
+		var err error
+		if day, err = time.ParseInLocation(dayKeyFormat, date, now.Location()); err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q (use today, yesterday or YYYY-MM-DD)", date)
+		}
+	}
+
+	hour, minute, second := now.Hour(), now.Minute(), now.Second()
+	if clock != "" {
+		offset, err := parseTimeOfDay(clock)
+		if err != nil {
+			return time.Time{}, err
+		}
+		hour, minute, second = int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0
+	}
+
+	when := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, now.Location())
+	if when.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the future", when.Format("2006-01-02 15:04"))
+	}
+	return when, nil
+}
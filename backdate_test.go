@@ -0,0 +1,91 @@
+package main
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2025, 10, 12, 9, 15, 30, 0, time.Local)
+	tests := []struct {
+		date, clock string
+		want        time.Time
+	}{
+		{"", "", now},
+		{"2025-10-11", "14:30", time.Date(2025, 10, 11, 14, 30, 0, 0, time.Local)},
+		{"yesterday", "", time.Date(2025, 10, 11, 9, 15, 30, 0, time.Local)},
+		{"", "08:00", time.Date(2025, 10, 12, 8, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := parseLogTime(tt.date, tt.clock, now)
+		if err != nil {
+			t.Fatalf("%q %q: %v", tt.date, tt.clock, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%q %q: got %v, want %v", tt.date, tt.clock, got, tt.want)
+		}
+	}
+
+	for _, bad := range [][2]string{{"", "10:00"}, {"2025-10-13", ""}, {"last week", ""}, {"", "2pm"}} {
+		if _, err := parseLogTime(bad[0], bad[1], now); err == nil {
+			t.Errorf("expected an error for %q %q", bad[0], bad[1])
+		}
+	}
+}
+
+func TestAppendLogEntryBackdated(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	entry := HistoryEntry{Timestamp: yesterday, Code: "KB-swings", Status: "done", Duration: 5, RPE: 7}
+	if err := appendLogEntry(entry, &Movo{FullCode: "KB-swings"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries, _ := LoadDailyLog(appConfig.LogsDir, yesterday); len(entries) != 1 {
+		t.Errorf("expected the entry in yesterday's log, got %v", entries)
+	}
+	if entries, _ := LoadDailyLog(appConfig.LogsDir, time.Now()); len(entries) != 0 {
+		t.Errorf("expected nothing in today's log, got %v", entries)
+	}
+}
+
+func TestPrintDayTotalsBackdated(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	appConfig.DateFormat = "2006-01-02"
+	defer func() { appConfig = originalConfig }()
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	entry := HistoryEntry{Timestamp: yesterday, Code: "KB-swings", Status: "done", Duration: 5, RPE: 7}
+	if err := AppendDailyLog(appConfig.LogsDir, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	printed := func(when time.Time) string {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdout := os.Stdout
+		os.Stdout = w
+		printDayTotals(when)
+		os.Stdout = stdout
+		w.Close()
+		out, _ := io.ReadAll(r)
+		return string(out)
+	}
+
+	// A backdated entry shows its own day's totals, named by date
+	want := "📊 " + yesterday.Format("2006-01-02") + ": 1 movos, 5 minutes, 7 RPE\n"
+	if got := printed(yesterday); got != want {
+		t.Errorf("backdated totals = %q, want %q", got, want)
+	}
+	if got := printed(time.Now()); got != "📊 Today: 0 movos, 0 minutes, 0 RPE\n" {
+		t.Errorf("today's totals = %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Weekly balance: movos from categories (or tags) under-represented in the last
+// balanceDays of completions are boosted, and over-represented ones damped
+const (
+	balanceCategory      = "category"
+	balanceTag           = "tag"
+	balanceDays          = 7
+	minBalanceMultiplier = 0.5 // At twice an even share or more
+	maxBalanceMultiplier = 2.0 // Not done at all this week
+)
+
+// parseBalanceBy validates a balance_by value ("" turns balancing off)
+func parseBalanceBy(s string) (string, error) {
+	switch by := strings.ToLower(strings.TrimSpace(s)); by {
+	case "", balanceCategory, balanceTag:
+		return by, nil
+	default:
+		return "", fmt.Errorf("balance_by must be category or tag, got %q", s)
+	}
+}
+
+// balanceGroups are the categories or tags a movo counts toward
+func balanceGroups(movo *Movo, code, by string) []string {
+	if by == balanceTag {
+		if movo == nil {
+			return nil
+		}
+		return movo.AllTags
+	}
+	category, _, _ := strings.Cut(code, "-")
+	return []string{category}
+}
+
+// balanceMultipliers maps each movo's code to its balance multiplier: 2 minus its
+// group's share of completions over an even share, clamped. A movo in several
+// groups (tags) takes the most neglected one's. No completions means no balancing.
+func balanceMultipliers(snacks []Movo, history []HistoryEntry, by string) map[string]float64 {
+	movos := make(map[string]*Movo, len(snacks))
+	groups := make(map[string]int)
+	for i := range snacks {
+		movos[snacks[i].FullCode] = &snacks[i]
+		for _, group := range balanceGroups(&snacks[i], snacks[i].FullCode, by) {
+			groups[strings.ToLower(group)] = 0
+		}
+	}
+
+	total := 0
+	for _, entry := range history {
+		if entry.Status != "done" && entry.Status != "partial" {
+			continue
+		}
+		for _, group := range balanceGroups(entryMovo(entry, movos), entry.Code, by) {
+			if _, ok := groups[strings.ToLower(group)]; ok {
+				groups[strings.ToLower(group)]++
+				total++
+			}
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	even := 1 / float64(len(groups))
+	multipliers := make(map[string]float64, len(snacks))
+	for _, snack := range snacks {
+		best := 0.0
+		for _, group := range balanceGroups(&snack, snack.FullCode, by) {
+			share := float64(groups[strings.ToLower(group)]) / float64(total)
+			multiplier := min(max(2-share/even, minBalanceMultiplier), maxBalanceMultiplier)
+			best = max(best, multiplier)
+		}
+		if best > 0 {
+			multipliers[snack.FullCode] = best
+		}
+	}
+	return multipliers
+}
+
+// loadBalance works out the balance multipliers from the last balanceDays of
+// history, or nil if balance_by is off
+func loadBalance(cfg *Config, snacks []Movo, now time.Time) (map[string]float64, error) {
+	if cfg.BalanceBy == "" {
+		return nil, nil
+	}
+	history, err := LoadHistoryRange(cfg.LogsDir, now.AddDate(0, 0, -(balanceDays-1)), now)
+	if err != nil {
+		return nil, err
+	}
+	return balanceMultipliers(snacks, history, cfg.BalanceBy), nil
+}
+
+// balanceFor is a movo's balance multiplier (1 when it isn't balanced)
+func balanceFor(balance map[string]float64, code string) float64 {
+	if multiplier, ok := balance[code]; ok {
+		return multiplier
+	}
+	return 1
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBalanceMultipliers(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "KB-swings", CategoryCode: "KB", AllTags: []string{"kbx", "hingex"}},
+		{FullCode: "TS-pushups", CategoryCode: "TS", AllTags: []string{"pushx"}},
+		{FullCode: "TB-box-breath", CategoryCode: "TB", AllTags: []string{"breathx"}},
+	}
+	history := []HistoryEntry{
+		{Code: "KB-swings", Status: "done"},
+		{Code: "KB-swings", Status: "partial"},
+		{Code: "TS-pushups", Status: "done"},
+		{Code: "TB-box-breath", Status: "skip"},
+	}
+
+	// Even share is a third: KB has two thirds, TS a third, TB none
+	byCategory := balanceMultipliers(snacks, history, balanceCategory)
+	want := map[string]float64{"KB-swings": minBalanceMultiplier, "TS-pushups": 1, "TB-box-breath": maxBalanceMultiplier}
+	for code, multiplier := range want {
+		if math.Abs(byCategory[code]-multiplier) > 1e-9 {
+			t.Errorf("by category, %s: got %f, want %f", code, byCategory[code], multiplier)
+		}
+	}
+
+	// Four tags, so an even share is a quarter: kbx and hingex have 2 of 5 hits each,
+	// pushx 1 and breathx none
+	byTag := balanceMultipliers(snacks, history, balanceTag)
+	if byTag["TB-box-breath"] != maxBalanceMultiplier {
+		t.Errorf("expected breathx, never done, to get the full boost, got %f", byTag["TB-box-breath"])
+	}
+	if byTag["KB-swings"] >= byTag["TS-pushups"] {
+		t.Errorf("expected swings, done most, boosted less than push-ups: %v", byTag)
+	}
+
+	if got := balanceMultipliers(snacks, nil, balanceCategory); got != nil {
+		t.Errorf("expected no balancing without completions, got %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// indefiniteBan marks a ban without an end date in the bans file
+const indefiniteBan = "-"
+
+// loadBans reads the bans file ("CODE UNTIL" per line, UNTIL "-" for no end date),
+// returning the movos still banned at now; the zero time means indefinitely. A
+// missing file means nothing is banned.
+func loadBans(path string, now time.Time) (map[string]time.Time, error) {
+	bans := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return bans, nil
+		}
+		return nil, fmt.Errorf("error reading bans: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if fields[1] == indefiniteBan {
+			bans[fields[0]] = time.Time{}
+			continue
+		}
+		until, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil || !until.After(now) {
+			continue
+		}
+		bans[fields[0]] = until
+	}
+	return bans, nil
+}
+
+// saveBans writes the bans file, sorted by code
+func saveBans(path string, bans map[string]time.Time) error {
+	codes := make([]string, 0, len(bans))
+	for code := range bans {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var b strings.Builder
+	for _, code := range codes {
+		until := indefiniteBan
+		if !bans[code].IsZero() {
+			until = bans[code].Format(time.RFC3339)
+		}
+		fmt.Fprintf(&b, "%s %s\n", code, until)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// parseBanUntil reads --until: a YYYY-MM-DD date the ban lasts through ("" for no
+// end date). The ban ends at the start of the following day.
+func parseBanUntil(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.ParseInLocation(dayKeyFormat, s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --until date %q (use YYYY-MM-DD)", s)
+	}
+	until := day.AddDate(0, 0, 1)
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("--until %s is already past", s)
+	}
+	return until, nil
+}
+
+// filterBanned removes banned movos from selection
+func filterBanned(snacks []Movo, bans map[string]time.Time) []Movo {
+	if len(bans) == 0 {
+		return snacks
+	}
+	var filtered []Movo
+	for _, snack := range snacks {
+		if _, banned := bans[snack.FullCode]; !banned {
+			filtered = append(filtered, snack)
+		}
+	}
+	return filtered
+}
+
+// untilDay is the last banned day of a ban ending at until
+func untilDay(until time.Time) string {
+	return until.Add(-time.Second).Format(dayKeyFormat)
+}
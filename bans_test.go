@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBansRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned")
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+
+	until, err := parseBanUntil("2026-03-12", now)
+	if err != nil {
+		t.Fatalf("parseBanUntil: %v", err)
+	}
+	if untilDay(until) != "2026-03-12" {
+		t.Errorf("untilDay = %s, want 2026-03-12", untilDay(until))
+	}
+	bans := map[string]time.Time{"KB-swings": {}, "RUN-tempo": until}
+	if err := saveBans(path, bans); err != nil {
+		t.Fatalf("saveBans: %v", err)
+	}
+
+	loaded, err := loadBans(path, now)
+	if err != nil {
+		t.Fatalf("loadBans: %v", err)
+	}
+	if len(loaded) != 2 || !loaded["KB-swings"].IsZero() || !loaded["RUN-tempo"].Equal(until) {
+		t.Errorf("loaded = %v", loaded)
+	}
+
+	// The dated ban expires at the start of the following day
+	loaded, err = loadBans(path, time.Date(2026, 3, 13, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("loadBans: %v", err)
+	}
+	if _, ok := loaded["RUN-tempo"]; ok || len(loaded) != 1 {
+		t.Errorf("expected only the indefinite ban left, got %v", loaded)
+	}
+}
+
+func TestParseBanUntilRejectsPast(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	if _, err := parseBanUntil("2026-03-09", now); err == nil {
+		t.Error("expected an error for a past date")
+	}
+	if _, err := parseBanUntil("next week", now); err == nil {
+		t.Error("expected an error for a malformed date")
+	}
+	if _, err := parseBanUntil("2026-03-10", now); err != nil {
+		t.Errorf("today should be allowed: %v", err)
+	}
+}
+
+func TestFilterBanned(t *testing.T) {
+	snacks := []Movo{{FullCode: "A"}, {FullCode: "B"}, {FullCode: "C"}}
+	filtered := filterBanned(snacks, map[string]time.Time{"B": {}})
+	if len(filtered) != 2 || filtered[0].FullCode != "A" || filtered[1].FullCode != "C" {
+		t.Errorf("filtered = %v", filtered)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// batchSession holds state shared across the commands of one batch run
+type batchSession struct {
+	movos   []Movo
+	current string // Code of the most recently selected movo
+	out     io.Writer
+}
+
+// runBatch executes one command per line from r, returning the number of failed lines
+func runBatch(r io.Reader, session *batchSession, errOut io.Writer) (int, error) {
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	failed := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip blank lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := session.exec(strings.Fields(line)); err != nil {
+			fmt.Fprintf(errOut, tr("line %d: %v\n"), lineNum, err)
+			failed++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return failed, fmt.Errorf("error reading batch input: %w", err)
+	}
+
+	return failed, nil
+}
+
+// exec runs a single batch command
+func (b *batchSession) exec(fields []string) error {
+	command, args := fields[0], fields[1:]
+
+	switch command {
+	case "get":
+		return b.get(args)
+	case "done":
+		return b.done(args)
+	case "skip":
+		return b.skip(args)
+	default:
+		return fmt.Errorf("unknown batch command: %s (use: get, done, skip)", command)
+	}
+}
+
+// get selects a movo using the same flags as the 'get' command
+func (b *batchSession) get(args []string) error {
+	fs, g := newGetFlagSet("get", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	snack, notes, err := pickSnack(b.movos, g.filterOptions(), appConfig.MaxDailyRPE)
+	if err != nil {
+		return err
+	}
+	if !quietOutput {
+		writeSelectionNotes(b.out, notes)
+	}
+
+	b.current = snack.FullCode
+	if err := saveCurrentPick(snack.FullCode, notes.Exploration); err != nil {
+		return fmt.Errorf("could not save current snack: %w", err)
+	}
+
+	fmt.Fprintf(b.out, "▶️  %s - %s\n", snack.FullCode, snack.Title)
+	return nil
+}
+
+// done logs a completion without prompting: done [CODE] [--duration N] [--rpe N] [--partial]
+func (b *batchSession) done(args []string) error {
+	fs := flag.NewFlagSet("done", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var duration, rpe int
+	fs.IntVar(&duration, "duration", 0, "Actual duration in minutes")
+	fs.IntVar(&duration, "d", 0, "Actual duration in minutes")
+	fs.IntVar(&rpe, "rpe", 0, "Actual RPE")
+	fs.IntVar(&rpe, "r", 0, "Actual RPE")
+	partial := fs.Bool("partial", false, "Log a partial completion")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	movo, err := b.resolve(positional)
+	if err != nil {
+		return err
+	}
+
+	status := "done"
+	if *partial {
+		status = "partial"
+	}
+
+	if duration == 0 {
+		duration = usualDuration(movo)
+		if *partial {
+			duration = partialDefaultDuration(movo)
+		}
+	}
+	if rpe == 0 {
+		rpe = movo.EffectiveRPE
+	}
+
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      movo.FullCode,
+		Status:    status,
+		Duration:  duration,
+		RPE:       rpe,
+		Subset:    appConfig.ActiveSubset,
+	}
+	if err := appendLogEntry(entry, movo); err != nil {
+		return fmt.Errorf("error saving to history: %w", err)
+	}
+
+	b.clearCurrent(movo.FullCode)
+	icon := "✅"
+	if *partial {
+		icon = "◐"
+	}
+	fmt.Fprintf(b.out, "%s %s (%d minutes, RPE %d)\n", icon, movo.FullCode, duration, rpe)
+	return nil
+}
+
+// skip logs a skip: skip [CODE]
+func (b *batchSession) skip(args []string) error {
+	movo, err := b.resolve(args)
+	if err != nil {
+		return err
+	}
+
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      movo.FullCode,
+		Status:    "skip",
+		Subset:    appConfig.ActiveSubset,
+	}
+	if err := appendLogEntry(entry, movo); err != nil {
+		return fmt.Errorf("error saving to history: %w", err)
+	}
+
+	b.clearCurrent(movo.FullCode)
+	fmt.Fprintf(b.out, "⏭️  %s\n", movo.FullCode)
+	return nil
+}
+
+// resolve finds the movo named in args, falling back to the current selection
+func (b *batchSession) resolve(args []string) (*Movo, error) {
+	code := b.current
+	if len(args) > 0 {
+		code = args[0]
+	}
+	if code == "" {
+		return nil, fmt.Errorf("no current snack; run 'get' first or specify a code")
+	}
+
+	movo := findMovo(b.movos, code)
+	if movo == nil {
+		return nil, fmt.Errorf("snack code '%s' not found", code)
+	}
+	return movo, nil
+}
+
+// clearCurrent forgets the current selection once it has been logged
+func (b *batchSession) clearCurrent(code string) {
+	if b.current == code {
+		b.current = ""
+	}
+}
+
+// readCodes reads movo codes separated by spaces or newlines, ignoring # comments
+func readCodes(r io.Reader) ([]string, error) {
+	var codes []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		codes = append(codes, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading codes: %w", err)
+	}
+	return codes, nil
+}
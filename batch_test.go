@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunBatch(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	movos := []Movo{
+		{FullCode: "TB-box-breath", Title: "Box breathing", DurationMin: 3, DurationMax: 5, EffectiveRPE: 1},
+		{FullCode: "TS-pushups", Title: "Pushups", DurationMin: 3, DurationMax: 5, EffectiveRPE: 7},
+	}
+
+	input := strings.Join([]string{
+		"# morning session",
+		"done TB-box-breath --duration 6",
+		"",
+		"done TS-pushups -r 8",
+		"skip TB-box-breath",
+		"done TS-unknown",
+		"jump",
+	}, "\n")
+
+	var out, errOut bytes.Buffer
+	session := &batchSession{movos: movos, out: &out}
+	failed, err := runBatch(strings.NewReader(input), session, &errOut)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if failed != 2 {
+		t.Errorf("expected 2 failed lines, got %d (stderr: %s)", failed, errOut.String())
+	}
+	if !strings.Contains(errOut.String(), "line 6:") || !strings.Contains(errOut.String(), "line 7:") {
+		t.Errorf("expected errors for lines 6 and 7, got: %s", errOut.String())
+	}
+
+	entries, err := LoadDailyLog(appConfig.LogsDir, time.Now())
+	if err != nil {
+		t.Fatalf("failed to load log: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	if entries[0].Duration != 6 || entries[0].RPE != 1 {
+		t.Errorf("box breath: expected 6m RPE 1, got %dm RPE %d", entries[0].Duration, entries[0].RPE)
+	}
+	if entries[1].Duration != 4 || entries[1].RPE != 8 {
+		t.Errorf("pushups: expected default 4m and RPE 8, got %dm RPE %d", entries[1].Duration, entries[1].RPE)
+	}
+	if entries[2].Status != "skip" {
+		t.Errorf("expected skip entry, got %s", entries[2].Status)
+	}
+}
+
+func TestRunBatchDoneWithoutCurrent(t *testing.T) {
+	session := &batchSession{out: &bytes.Buffer{}}
+	var errOut bytes.Buffer
+
+	failed, err := runBatch(strings.NewReader("done\n"), session, &errOut)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failed != 1 || !strings.Contains(errOut.String(), "no current snack") {
+		t.Errorf("expected 'no current snack' failure, got %d: %s", failed, errOut.String())
+	}
+}
+
+func TestRunBatchGetNotesGoToOut(t *testing.T) {
+	// pickSnack reads exploration_rate from the config in HOME
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".movodoro"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".movodoro", "config.yaml"), []byte("exploration_rate: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalConfig, originalQuiet := appConfig, quietOutput
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig, quietOutput = originalConfig, originalQuiet }()
+
+	movos := []Movo{{FullCode: "TB-box-breath", Title: "Box breathing", DurationMin: 3, DurationMax: 5, EffectiveRPE: 1, Weight: 1}}
+	for _, quiet := range []bool{false, true} {
+		quietOutput = quiet
+		var out, errOut bytes.Buffer
+		session := &batchSession{movos: movos, out: &out}
+		if failed, err := runBatch(strings.NewReader("get\n"), session, &errOut); err != nil || failed != 0 {
+			t.Fatalf("unexpected failure: %v %s", err, errOut.String())
+		}
+		if got := strings.Contains(out.String(), "Exploration pick"); got == quiet {
+			t.Errorf("quiet %v: expected the exploration note in out %v, got %q", quiet, !quiet, out.String())
+		}
+		if !strings.Contains(out.String(), "TB-box-breath") {
+			t.Errorf("expected the pick in out, got %q", out.String())
+		}
+	}
+}
+
+func TestReadCodes(t *testing.T) {
+	codes, err := readCodes(strings.NewReader("KB-swings MOB-hips\n# warm-up\n\nBR-box  # after lunch\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(codes, ",") != "KB-swings,MOB-hips,BR-box" {
+		t.Errorf("got %v", codes)
+	}
+}
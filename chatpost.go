@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Discord rejects messages longer than this
+const discordMessageLimit = 2000
+
+// PostConfig holds the incoming webhook URLs from the post section of config.yaml
+type PostConfig struct {
+	Slack   string `yaml:"slack"`
+	Discord string `yaml:"discord"`
+}
+
+// webhook returns the URL for a chat service, or an error naming what to set
+func (p PostConfig) webhook(service string) (string, error) {
+	var url string
+	switch service {
+	case "slack":
+		url = p.Slack
+	case "discord":
+		url = p.Discord
+	default:
+		return "", fmt.Errorf("unknown --post service: %s (use: slack, discord)", service)
+	}
+	if url == "" {
+		return "", fmt.Errorf("set post: %s: in config.yaml to an incoming webhook URL", service)
+	}
+	return url, nil
+}
+
+var (
+	markdownHeading = regexp.MustCompile(`(?m)^#{1,6} +(.+)$`)
+	markdownBold    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+)
+
+// chatMessage builds the webhook payload carrying a markdown report
+func chatMessage(service, markdown string) any {
+	text := strings.TrimSpace(markdown)
+	if service == "slack" {
+		// Slack's mrkdwn has no headings and bolds with single asterisks
+		text = markdownHeading.ReplaceAllString(text, "*$1*")
+		text = markdownBold.ReplaceAllString(text, "*$1*")
+		return map[string]string{"text": text}
+	}
+
+	if runes := []rune(text); len(runes) > discordMessageLimit {
+		text = string(runes[:discordMessageLimit-1]) + "…"
+	}
+	return map[string]string{"content": text}
+}
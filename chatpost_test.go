@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChatMessageSlack(t *testing.T) {
+	markdown := "# Movodoro Report - Monday\n\n## Summary\n\n- **Total movos:** 2\n- **09:00** - `BR-box` (3 min, RPE 1)\n"
+	msg := chatMessage("slack", markdown).(map[string]string)
+
+	want := "*Movodoro Report - Monday*\n\n*Summary*\n\n- *Total movos:* 2\n- *09:00* - `BR-box` (3 min, RPE 1)"
+	if msg["text"] != want {
+		t.Errorf("expected slack mrkdwn\n%s\ngot\n%s", want, msg["text"])
+	}
+}
+
+func TestChatMessageDiscord(t *testing.T) {
+	markdown := "# Movodoro Report\n\n- **Total movos:** 2\n"
+	msg := chatMessage("discord", markdown).(map[string]string)
+	if msg["content"] != strings.TrimSpace(markdown) {
+		t.Errorf("expected discord to keep the markdown, got %q", msg["content"])
+	}
+
+	long := chatMessage("discord", strings.Repeat("é", 3000)).(map[string]string)
+	if n := len([]rune(long["content"])); n != discordMessageLimit || !strings.HasSuffix(long["content"], "…") {
+		t.Errorf("expected a truncated %d character message, got %d", discordMessageLimit, n)
+	}
+}
+
+func TestPostConfigWebhook(t *testing.T) {
+	post := PostConfig{Slack: "https://hooks.slack.com/services/x"}
+
+	if url, err := post.webhook("slack"); err != nil || url != post.Slack {
+		t.Errorf("expected the slack URL, got %q, %v", url, err)
+	}
+	if _, err := post.webhook("discord"); err == nil {
+		t.Error("expected an error for an unset discord webhook")
+	}
+	if _, err := post.webhook("teams"); err == nil {
+		t.Error("expected an error for an unknown service")
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	// statusCheckin marks a log entry recording an energy check-in rather than a movo
+	statusCheckin = "checkin"
+	extraEnergy   = "energy"
+
+	minEnergy     = 1
+	maxEnergy     = 5
+	neutralEnergy = 3
+	checkinHours  = 4   // How long a check-in keeps steering selection
+	energyRPEStep = 1.1 // Weight factor per energy point times RPE point away from neutral
+	midRPE        = 5
+)
+
+// newCheckin builds the log entry for an energy check-in
+func newCheckin(energy int, now time.Time, profile string) HistoryEntry {
+	entry := HistoryEntry{
+		Timestamp: now,
+		Code:      statusCheckin,
+		Status:    statusCheckin,
+		Extras:    map[string]string{extraEnergy: strconv.Itoa(energy)},
+	}
+	if profile != "" {
+		entry.Extras["profile"] = profile
+	}
+	return entry
+}
+
+// parseEnergy checks a --energy value
+func parseEnergy(energy int) error {
+	if energy < minEnergy || energy > maxEnergy {
+		return fmt.Errorf("energy must be between %d and %d, got %d", minEnergy, maxEnergy, energy)
+	}
+	return nil
+}
+
+// latestCheckin finds the newest check-in for profile within checkinHours of now,
+// returning its energy and time (energy 0 if there is none)
+func latestCheckin(logsDir, profile string, now time.Time) (int, time.Time, error) {
+	since := now.Add(-checkinHours * time.Hour)
+	var entries []HistoryEntry
+	for day := since; !dayStart(day).After(now); day = day.AddDate(0, 0, 1) {
+		dayEntries, err := loadDayEntries(logsDir, day)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		entries = append(entries, dayEntries...)
+	}
+
+	energy, at := 0, time.Time{}
+	for _, entry := range entries {
+		if entry.Status != statusCheckin || entry.Extras["profile"] != profile {
+			continue
+		}
+		if entry.Timestamp.Before(since) || entry.Timestamp.After(now) || entry.Timestamp.Before(at) {
+			continue
+		}
+		value, err := strconv.Atoi(entry.Extras[extraEnergy])
+		if err != nil || parseEnergy(value) != nil {
+			continue
+		}
+		energy, at = value, entry.Timestamp
+	}
+	return energy, at, nil
+}
+
+// energyMultiplier leans selection toward easier movos when energy is low and harder
+// ones when it's high, more so the further both sit from the middle. No check-in
+// (energy 0) leaves the weight alone.
+func energyMultiplier(energy, rpe int) float64 {
+	if energy == 0 {
+		return 1.0
+	}
+	return math.Pow(energyRPEStep, float64((energy-neutralEnergy)*(rpe-midRPE)))
+}
+
+// dayStart is local midnight on t's day
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatestCheckin(t *testing.T) {
+	logsDir := t.TempDir()
+	now := time.Date(2026, 3, 10, 1, 30, 0, 0, time.Local)
+
+	// Across midnight, the newest check-in within checkinHours wins
+	for _, entry := range []HistoryEntry{
+		newCheckin(5, now.Add(-5*time.Hour), ""),
+		newCheckin(2, now.Add(-3*time.Hour), ""),
+		newCheckin(4, now.Add(-2*time.Hour), ""),
+		newCheckin(1, now.Add(-time.Hour), "kid"),
+	} {
+		if err := AppendDailyLog(logsDir, entry); err != nil {
+			t.Fatalf("AppendDailyLog: %v", err)
+		}
+	}
+
+	energy, at, err := latestCheckin(logsDir, "", now)
+	if err != nil {
+		t.Fatalf("latestCheckin: %v", err)
+	}
+	if energy != 4 || !at.Equal(now.Add(-2*time.Hour).Truncate(time.Second)) {
+		t.Errorf("latestCheckin = %d at %v, want 4 two hours ago", energy, at)
+	}
+	if energy, _, _ := latestCheckin(logsDir, "kid", now); energy != 1 {
+		t.Errorf("kid profile energy = %d, want 1", energy)
+	}
+	if energy, _, _ := latestCheckin(logsDir, "", now.Add(3*time.Hour)); energy != 0 {
+		t.Errorf("expected the check-ins to have lapsed, got energy %d", energy)
+	}
+}
+
+func TestCheckinsLeftOutOfHistory(t *testing.T) {
+	logsDir := t.TempDir()
+	now := time.Now()
+	AppendDailyLog(logsDir, newCheckin(2, now, ""))
+	AppendDailyLog(logsDir, HistoryEntry{Timestamp: now, Code: "TS-pushups", Status: "done", Duration: 5, RPE: 6})
+
+	entries, err := LoadDailyLog(logsDir, now)
+	if err != nil {
+		t.Fatalf("LoadDailyLog: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Code != "TS-pushups" {
+		t.Errorf("LoadDailyLog = %+v, want only the movo", entries)
+	}
+	all, err := LoadAllHistory(logsDir)
+	if err != nil {
+		t.Fatalf("LoadAllHistory: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("LoadAllHistory returned %d entries, want 1", len(all))
+	}
+}
+
+func TestEnergyMultiplier(t *testing.T) {
+	if m := energyMultiplier(0, 9); m != 1 {
+		t.Errorf("no check-in: got %g, want 1", m)
+	}
+	if m := energyMultiplier(neutralEnergy, 9); m != 1 {
+		t.Errorf("neutral energy: got %g, want 1", m)
+	}
+	if low, high := energyMultiplier(1, 2), energyMultiplier(1, 9); low <= 1 || high >= 1 {
+		t.Errorf("low energy should favor easy movos: RPE 2 %g, RPE 9 %g", low, high)
+	}
+	if low, high := energyMultiplier(5, 2), energyMultiplier(5, 9); low >= 1 || high <= 1 {
+		t.Errorf("high energy should favor hard movos: RPE 2 %g, RPE 9 %g", low, high)
+	}
+	if err := parseEnergy(6); err == nil {
+		t.Error("expected an error for energy 6")
+	}
+}
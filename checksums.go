@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// checksumIndex is the file (inside the logs dir) holding one line per daily log:
+// "NAME SHA256 SIZE". Checksums are only maintained once it exists.
+const checksumIndex = ".checksums"
+
+// fileChecksum is the recorded state of a daily log file
+type fileChecksum struct {
+	Sum  string
+	Size int64
+}
+
+// historyProblem describes a daily log that doesn't match its recorded checksum
+type historyProblem struct {
+	File    string
+	Problem string
+}
+
+// checksumIndexPath returns the path of the checksum index
+func checksumIndexPath(logsDir string) string {
+	return filepath.Join(logsDir, checksumIndex)
+}
+
+// loadChecksums reads the checksum index; enabled is false if it doesn't exist
+func loadChecksums(logsDir string) (sums map[string]fileChecksum, enabled bool, err error) {
+	file, err := os.Open(checksumIndexPath(logsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("error opening checksum index: %w", err)
+	}
+	defer file.Close()
+
+	sums = make(map[string]fileChecksum)
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, true, fmt.Errorf("checksum index line %d: expected 3 fields, got %d", lineNum, len(fields))
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, true, fmt.Errorf("checksum index line %d: invalid size: %w", lineNum, err)
+		}
+		sums[fields[0]] = fileChecksum{Sum: fields[1], Size: size}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, true, fmt.Errorf("error reading checksum index: %w", err)
+	}
+
+	return sums, true, nil
+}
+
+// saveChecksums writes the checksum index, replacing it atomically
+func saveChecksums(logsDir string, sums map[string]fileChecksum) error {
+	names := make([]string, 0, len(sums))
+	for name := range sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %s %d\n", name, sums[name].Sum, sums[name].Size)
+	}
+
+	path := checksumIndexPath(logsDir)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("error writing checksum index: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// hashFile returns the SHA-256 of the first limit bytes of a file (limit < 0 hashes it all)
+func hashFile(path string, limit int64) (fileChecksum, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return fileChecksum{}, err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if limit >= 0 {
+		r = io.LimitReader(file, limit)
+	}
+
+	h := sha256.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return fileChecksum{}, err
+	}
+	return fileChecksum{Sum: hex.EncodeToString(h.Sum(nil)), Size: size}, nil
+}
+
+// recordChecksum updates the index entry for a daily log (no-op if checksums aren't enabled)
+func recordChecksum(logsDir, path string) error {
+	sums, enabled, err := loadChecksums(logsDir)
+	if err != nil || !enabled {
+		return err
+	}
+
+	sum, err := hashFile(path, -1)
+	if err != nil {
+		return fmt.Errorf("error hashing %s: %w", filepath.Base(path), err)
+	}
+	sums[filepath.Base(path)] = sum
+	return saveChecksums(logsDir, sums)
+}
+
+// forgetChecksum drops a deliberately removed daily log from the index
+func forgetChecksum(logsDir, path string) error {
+	sums, enabled, err := loadChecksums(logsDir)
+	if err != nil || !enabled {
+		return err
+	}
+
+	delete(sums, filepath.Base(path))
+	return saveChecksums(logsDir, sums)
+}
+
+// initChecksums records checksums for every daily log, enabling maintenance on append
+func initChecksums(logsDir string) (int, error) {
+	if err := ensureReachableLogsDir(logsDir); err != nil {
+		return 0, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(logsDir, "*.csv"))
+	if err != nil {
+		return 0, fmt.Errorf("error finding log files: %w", err)
+	}
+
+	sums := make(map[string]fileChecksum)
+	for _, path := range files {
+		sum, err := hashFile(path, -1)
+		if err != nil {
+			return 0, fmt.Errorf("error hashing %s: %w", filepath.Base(path), err)
+		}
+		sums[filepath.Base(path)] = sum
+	}
+
+	return len(sums), saveChecksums(logsDir, sums)
+}
+
+// verifyHistory compares every daily log against the checksum index.
+// Returns the number of files checked and any suspect files.
+func verifyHistory(logsDir string) (checked int, problems []historyProblem, err error) {
+	sums, enabled, err := loadChecksums(logsDir)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !enabled {
+		return 0, nil, fmt.Errorf("history checksums are not enabled (run 'movodoro verify-history --init')")
+	}
+
+	files, err := filepath.Glob(filepath.Join(logsDir, "*.csv"))
+	if err != nil {
+		return 0, nil, fmt.Errorf("error finding log files: %w", err)
+	}
+
+	present := make(map[string]bool)
+	for _, path := range files {
+		name := filepath.Base(path)
+		present[name] = true
+		checked++
+
+		recorded, ok := sums[name]
+		if !ok {
+			problems = append(problems, historyProblem{name, "not tracked (created outside movodoro)"})
+			continue
+		}
+
+		current, err := hashFile(path, -1)
+		if err != nil {
+			problems = append(problems, historyProblem{name, fmt.Sprintf("unreadable: %v", err)})
+			continue
+		}
+		if current == recorded {
+			continue
+		}
+
+		switch {
+		case current.Size < recorded.Size:
+			problems = append(problems, historyProblem{name,
+				fmt.Sprintf("truncated (%d bytes, expected %d)", current.Size, recorded.Size)})
+		case current.Size > recorded.Size:
+			// Intact prefix means lines were added by something other than movodoro
+			prefix, err := hashFile(path, recorded.Size)
+			if err == nil && prefix == recorded {
+				problems = append(problems, historyProblem{name, "lines appended outside movodoro"})
+			} else {
+				problems = append(problems, historyProblem{name, "modified"})
+			}
+		default:
+			problems = append(problems, historyProblem{name, "modified"})
+		}
+	}
+
+	for name := range sums {
+		if !present[name] {
+			checked++
+			problems = append(problems, historyProblem{name, "missing"})
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool { return problems[i].File < problems[j].File })
+	return checked, problems, nil
+}
+
+// logFileDate parses the date from a daily log name such as 20251012.csv
+func logFileDate(name string) (time.Time, bool) {
+	date, err := time.ParseInLocation("20060102", strings.TrimSuffix(name, ".csv"), time.Local)
+	return date, err == nil
+}
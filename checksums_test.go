@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestVerifyHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := TestConfig(tmpDir)
+
+	// Not enabled until --init
+	if _, _, err := verifyHistory(cfg.LogsDir); err == nil {
+		t.Fatal("expected an error before checksums are enabled")
+	}
+
+	old := filepath.Join(cfg.LogsDir, "20251010.csv")
+	if err := os.MkdirAll(cfg.LogsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(old, []byte("timestamp,code,status,duration,rpe,subset\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := initChecksums(cfg.LogsDir); err != nil {
+		t.Fatalf("initChecksums: %v", err)
+	}
+
+	// Appends keep the index current
+	if err := AppendTodayLog(cfg.LogsDir, doneAt("TS-pushups", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	checked, problems, err := verifyHistory(cfg.LogsDir)
+	if err != nil {
+		t.Fatalf("verifyHistory: %v", err)
+	}
+	if checked != 2 || len(problems) != 0 {
+		t.Fatalf("expected 2 clean files, got %d checked, problems %v", checked, problems)
+	}
+
+	// External append, then truncation
+	f, err := os.OpenFile(old, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("2025-10-10T09:00:00Z,TS-pushups,done,5,4,\n")
+	f.Close()
+	_, problems, _ = verifyHistory(cfg.LogsDir)
+	if len(problems) != 1 || problems[0].Problem != "lines appended outside movodoro" {
+		t.Errorf("expected an external append, got %v", problems)
+	}
+
+	today := GetTodayLogPath(cfg.LogsDir)
+	if err := os.Truncate(today, 10); err != nil {
+		t.Fatal(err)
+	}
+	_, problems, _ = verifyHistory(cfg.LogsDir)
+	if len(problems) != 2 || problems[1].File != filepath.Base(today) {
+		t.Fatalf("expected today's log flagged, got %v", problems)
+	}
+
+	// Clearing today forgets its checksum; a deleted older file is reported missing
+	if err := ClearTodayLog(cfg.LogsDir); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(old)
+	_, problems, _ = verifyHistory(cfg.LogsDir)
+	if len(problems) != 1 || problems[0].Problem != "missing" {
+		t.Errorf("expected only the deleted file to be missing, got %v", problems)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// drawCandidates picks up to n different movos, each weighted among those not yet drawn
+func drawCandidates(r *rand.Rand, weighted []weightedSnack, n int) []Movo {
+	remaining := append([]weightedSnack(nil), weighted...)
+	var drawn []Movo
+	for len(drawn) < n && len(remaining) > 0 {
+		picked := weightedRandomSelect(r, remaining)
+		drawn = append(drawn, picked)
+		for i := range remaining {
+			if remaining[i].snack.FullCode == picked.FullCode {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	return drawn
+}
+
+// writeCandidates lists drawn movos, numbered from 1
+func writeCandidates(w io.Writer, candidates []Movo) {
+	for i, movo := range candidates {
+		fmt.Fprintf(w, "  [%d] %s [%s] %d-%dm, RPE %d\n",
+			i+1, movo.Title, movo.FullCode, movo.DurationMin, movo.DurationMax, movo.EffectiveRPE)
+	}
+}
+
+// parsePick reads a 1-based choice among n candidates (0 means none)
+func parsePick(s string, n int) (int, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "q" {
+		return 0, true
+	}
+	pick, err := strconv.Atoi(s)
+	if err != nil || pick < 1 || pick > n {
+		return 0, false
+	}
+	return pick, true
+}
+
+// promptPick asks which of n candidates to take: a single number key on a
+// terminal, otherwise a line. It returns 0 if the user quits.
+func promptPick(n int) int {
+	fmt.Printf(tr("\nPick 1-%d (q to quit): "), n)
+
+	key, ok := readKey(func(key string) bool {
+		_, ok := parsePick(key, n)
+		return ok
+	}, fmt.Sprintf("Invalid choice. Pick 1-%d (q to quit): ", n))
+	if !ok {
+		input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		key = input
+	}
+	pick, _ := parsePick(key, n)
+	return pick
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDrawCandidates(t *testing.T) {
+	weighted := []weightedSnack{
+		{snack: Movo{FullCode: "TS-pushups"}, weight: 100},
+		{snack: Movo{FullCode: "TB-box-breath"}, weight: 1},
+		{snack: Movo{FullCode: "KB-swings"}, weight: 1},
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+
+	drawn := drawCandidates(r, weighted, 3)
+	seen := make(map[string]bool)
+	for _, movo := range drawn {
+		if seen[movo.FullCode] {
+			t.Fatalf("expected different candidates, got %s twice", movo.FullCode)
+		}
+		seen[movo.FullCode] = true
+	}
+	if len(drawn) != 3 {
+		t.Fatalf("expected 3 candidates, got %d", len(drawn))
+	}
+
+	if drawn := drawCandidates(r, weighted, 5); len(drawn) != 3 {
+		t.Errorf("expected no more candidates than the pool, got %d", len(drawn))
+	}
+	if len(weighted) != 3 || weighted[0].snack.FullCode != "TS-pushups" {
+		t.Errorf("drawing shouldn't change the pool: %+v", weighted)
+	}
+}
+
+func TestParsePick(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+		ok    bool
+	}{
+		{"2", 2, true},
+		{" 3\n", 3, true},
+		{"q", 0, true},
+		{"4", 0, false},
+		{"0", 0, false},
+		{"x", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := parsePick(tt.input, 3); got != tt.want || ok != tt.ok {
+			t.Errorf("parsePick(%q) = %d, %v; want %d, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
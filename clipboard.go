@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommand returns the command used to write to the system clipboard
+func clipboardCommand() (*exec.Cmd, error) {
+	args, err := clipboardTool(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "", exec.LookPath)
+	if err != nil {
+		return nil, err
+	}
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+// clipboardTool picks the clipboard command and its arguments for goos, looking
+// tools up with lookPath (exec.LookPath outside tests)
+func clipboardTool(goos string, wayland bool, lookPath func(string) (string, error)) ([]string, error) {
+	switch goos {
+	case "darwin":
+		return []string{"pbcopy"}, nil
+	case "windows":
+		return []string{"clip"}, nil
+	}
+
+	// Linux/BSD: prefer Wayland, then the common X11 tools
+	candidates := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if wayland {
+		candidates = append([][]string{{"wl-copy"}}, candidates...)
+	}
+
+	for _, c := range candidates {
+		if _, err := lookPath(c[0]); err == nil {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no clipboard tool found (install wl-copy, xclip or xsel)")
+}
+
+// copyToClipboard places text on the system clipboard
+func copyToClipboard(text string) error {
+	cmd, err := clipboardCommand()
+	if err != nil {
+		return err
+	}
+
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %v %s", cmd.Path, err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
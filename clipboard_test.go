@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClipboardTool(t *testing.T) {
+	tests := []struct {
+		name      string
+		goos      string
+		wayland   bool
+		installed []string
+		want      string // Empty for the "no tool" error
+	}{
+		{"macOS", "darwin", false, nil, "pbcopy"},
+		{"Windows", "windows", true, nil, "clip"},
+		{"Wayland first", "linux", true, []string{"wl-copy", "xclip", "xsel"}, "wl-copy"},
+		{"wl-copy ignored on X11", "linux", false, []string{"wl-copy", "xclip", "xsel"}, "xclip -selection clipboard"},
+		{"Wayland without wl-copy", "linux", true, []string{"xclip", "xsel"}, "xclip -selection clipboard"},
+		{"xsel last", "freebsd", false, []string{"xsel"}, "xsel --clipboard --input"},
+		{"no tool", "linux", true, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookPath := func(name string) (string, error) {
+				if slices.Contains(tt.installed, name) {
+					return "/usr/bin/" + name, nil
+				}
+				return "", errors.New("not found")
+			}
+			args, err := clipboardTool(tt.goos, tt.wayland, lookPath)
+			if tt.want == "" {
+				if err == nil || !strings.Contains(err.Error(), "no clipboard tool") {
+					t.Errorf("expected the no-tool error, got %v, %v", args, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(args, " "); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		t.Skip("uses a stand-in xclip on PATH")
+	}
+	bin := t.TempDir()
+	copied := filepath.Join(t.TempDir(), "clipboard")
+	script := "#!/bin/sh\ncat > '" + copied + "'\n"
+	if err := os.WriteFile(filepath.Join(bin, "xclip"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	if err := copyToClipboard("# Today\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(copied); err != nil || string(got) != "# Today\n" {
+		t.Errorf("expected the text piped to xclip, got %q, %v", got, err)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if err := copyToClipboard("# Today\n"); err == nil {
+		t.Error("expected an error with no clipboard tool installed")
+	}
+}
@@ -2,11 +2,17 @@ package main
 
 import (
 	"bufio"
-	"encoding/csv"
+	"bytes"
 	"flag"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -20,1276 +26,4450 @@ const (
 	maxDailyRPEDefault = 30
 )
 
-// handleGet implements the 'get' command
-func handleGet(args []string) {
-	fs := flag.NewFlagSet("get", flag.ExitOnError)
-
-	var (
-		tags         string
-		category     string
-		duration     int
-		minDuration  int
-		maxDuration  int
-		minRPE       int
-		maxRPE       int
-		skipMinimums bool
-		subset       string
-	)
-
-	fs.StringVar(&tags, "tags", "", "Filter by tags (comma-separated)")
-	fs.StringVar(&tags, "t", "", "Filter by tags (comma-separated)")
-	fs.StringVar(&category, "category", "", "Filter by category code")
-	fs.StringVar(&category, "c", "", "Filter by category code")
-	fs.IntVar(&duration, "duration", 0, "Exact duration in minutes")
-	fs.IntVar(&duration, "d", 0, "Exact duration in minutes")
-	fs.IntVar(&minDuration, "min-duration", 0, "Minimum duration")
-	fs.IntVar(&minDuration, "m", 0, "Minimum duration")
-	fs.IntVar(&maxDuration, "max-duration", 0, "Maximum duration")
-	fs.IntVar(&maxDuration, "M", 0, "Maximum duration")
-	fs.IntVar(&minRPE, "min-rpe", 0, "Minimum RPE")
-	fs.IntVar(&minRPE, "r", 0, "Minimum RPE")
-	fs.IntVar(&maxRPE, "max-rpe", 0, "Maximum RPE")
-	fs.IntVar(&maxRPE, "R", 0, "Maximum RPE")
-	fs.BoolVar(&skipMinimums, "skip-minimums", false, "Skip min_per_day priority")
-	fs.StringVar(&subset, "subset", "", "Use a named subset from subsets.yaml")
-
-	fs.Parse(args)
+// getFlags holds the selection flags shared by 'get' and batch mode
+type getFlags struct {
+	tags         string
+	anyTags      string
+	category     string
+	excludeCat   string
+	target       string
+	duration     int
+	minDuration  int
+	maxDuration  int
+	minRPE       int
+	maxRPE       int
+	skipMinimums bool
+	subset       string
+	equipment    string
+	noEquipment  bool
+	anyTime      bool
+	variety      string
+	fit          int
+	rotate       bool
+}
 
-	// Load snacks
-	snacks, err := LoadSnacks()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-		os.Exit(1)
-	}
+// newGetFlagSet registers the selection flags on a new flag set
+func newGetFlagSet(name string, errorHandling flag.ErrorHandling) (*flag.FlagSet, *getFlags) {
+	fs := flag.NewFlagSet(name, errorHandling)
+	g := &getFlags{}
+
+	fs.StringVar(&g.tags, "tags", "", "Filter by tags (comma-separated)")
+	fs.StringVar(&g.tags, "t", "", "Filter by tags (comma-separated)")
+	fs.StringVar(&g.anyTags, "any-tags", "", "Filter by any of these tags (comma-separated)")
+	fs.StringVar(&g.category, "category", "", "Filter by category codes (comma-separated)")
+	fs.StringVar(&g.category, "c", "", "Filter by category codes (comma-separated)")
+	fs.StringVar(&g.excludeCat, "exclude-category", "", "Leave out category codes (comma-separated)")
+	fs.StringVar(&g.target, "target", "", "Filter by body regions worked (comma-separated, any may match)")
+	fs.IntVar(&g.duration, "duration", 0, "Exact duration in minutes")
+	fs.IntVar(&g.duration, "d", 0, "Exact duration in minutes")
+	fs.IntVar(&g.minDuration, "min-duration", 0, "Minimum duration")
+	fs.IntVar(&g.minDuration, "m", 0, "Minimum duration")
+	fs.IntVar(&g.maxDuration, "max-duration", 0, "Maximum duration")
+	fs.IntVar(&g.maxDuration, "M", 0, "Maximum duration")
+	fs.IntVar(&g.minRPE, "min-rpe", 0, "Minimum RPE")
+	fs.IntVar(&g.minRPE, "r", 0, "Minimum RPE")
+	fs.IntVar(&g.maxRPE, "max-rpe", 0, "Maximum RPE")
+	fs.IntVar(&g.maxRPE, "R", 0, "Maximum RPE")
+	fs.BoolVar(&g.skipMinimums, "skip-minimums", false, "Skip min_per_day priority")
+	fs.StringVar(&g.subset, "subset", "", "Use a named subset from subsets.yaml")
+	fs.StringVar(&g.equipment, "equipment", "", "Equipment on hand (comma-separated, or 'any')")
+	fs.BoolVar(&g.noEquipment, "no-equipment", false, "Only movos that need no equipment")
+	fs.BoolVar(&g.anyTime, "ignore-time-windows", false, "Select movos outside their time_window too")
+	fs.StringVar(&g.variety, "variety", "", "Favor high-weight movos (low) or spread picks evenly (high)")
+	fs.IntVar(&g.fit, "fit", 0, "Minutes available: prefer movos that fill them")
+	fs.BoolVar(&g.rotate, "rotate", false, "Pick from the next category in rotation")
+
+	return fs, g
+}
 
+// filterOptions converts parsed flags into FilterOptions
+func (g *getFlags) filterOptions() FilterOptions {
 	// Determine active subset: command flag takes precedence over env var
-	activeSubset := subset
+	activeSubset := g.subset
 	if activeSubset == "" {
 		activeSubset = appConfig.ActiveSubset
 	}
 
-	// Parse filters
 	filters := FilterOptions{
-		Category:      strings.TrimSpace(strings.ToUpper(category)),
-		MinDuration:   minDuration,
-		MaxDuration:   maxDuration,
-		ExactDuration: duration,
-		MinRPE:        minRPE,
-		MaxRPE:        maxRPE,
-		SkipMinimums:  skipMinimums,
-		Subset:        activeSubset,
-	}
-
-	if tags != "" {
-		filters.Tags = strings.Split(tags, ",")
+		Categories:        categoryCodes(g.category),
+		ExcludeCategories: categoryCodes(g.excludeCat),
+		MinDuration:       g.minDuration,
+		MaxDuration:       g.maxDuration,
+		ExactDuration:     g.duration,
+		MinRPE:            g.minRPE,
+		MaxRPE:            g.maxRPE,
+		SkipMinimums:      g.skipMinimums,
+		Subset:            activeSubset,
+		IgnoreTimeWindows: g.anyTime,
+		LimitEquipment:    appConfig.LimitEquipment,
+		Equipment:         appConfig.Equipment,
+		Variety:           appConfig.Variety,
+		Fit:               g.fit,
+		Rotate:            g.rotate || appConfig.SelectionMode == selectionRotation,
+	}
+	if g.variety != "" {
+		filters.Variety = g.variety
+	}
+
+	// Equipment flags override the config.yaml default
+	switch {
+	case g.noEquipment:
+		filters.LimitEquipment, filters.Equipment = true, nil
+	case strings.EqualFold(g.equipment, "any"):
+		filters.LimitEquipment, filters.Equipment = false, nil
+	case g.equipment != "":
+		filters.LimitEquipment, filters.Equipment = true, parseEquipmentList(g.equipment)
+	}
+
+	if g.tags != "" {
+		filters.Tags = strings.Split(g.tags, ",")
 		// Trim whitespace
 		for i := range filters.Tags {
 			filters.Tags[i] = strings.TrimSpace(filters.Tags[i])
 		}
 	}
-
-	// Select a snack
-	snack, err := SelectSnack(snacks, filters, maxDailyRPEDefault)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error selecting snack: %v\n", err)
-		os.Exit(1)
+	if g.anyTags != "" {
+		filters.AnyTags = strings.Split(g.anyTags, ",")
+		for i := range filters.AnyTags {
+			filters.AnyTags[i] = strings.TrimSpace(filters.AnyTags[i])
+		}
 	}
-
-	// Save as current snack
-	if err := saveCurrentSnack(snack.FullCode); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not save current snack: %v\n", err)
+	if g.target != "" {
+		filters.Targets = strings.Split(g.target, ",")
+		for i := range filters.Targets {
+			filters.Targets[i] = strings.TrimSpace(filters.Targets[i])
+		}
 	}
 
-	// Display the movo
-	displayMovo(snack)
+	return filters
 }
 
-// handleDone implements the 'done' command
-func handleDone(args []string) {
-	var code string
-
-	// Check if code was provided as argument
-	if len(args) > 0 {
-		code = args[0]
-	} else {
-		// Use current snack
-		var err error
-		code, err = loadCurrentSnack()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: no current snack. Use 'movodoro get' first or specify a code.\n")
-			os.Exit(1)
+// categoryCodes splits a comma-separated list of category codes
+func categoryCodes(s string) []string {
+	var codes []string
+	for _, code := range strings.Split(s, ",") {
+		if code = strings.TrimSpace(strings.ToUpper(code)); code != "" {
+			codes = append(codes, code)
 		}
 	}
+	return codes
+}
 
-	// Load snacks to get RPE
-	snacks, err := LoadSnacks()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-		os.Exit(1)
+// handleGet implements the 'get' command
+func handleGet(args []string) {
+	fs, g := newGetFlagSet("get", flag.ContinueOnError)
+	var timer, explain bool
+	fs.BoolVar(&timer, "timer", false, "Count down the movo's duration, then log it")
+	fs.BoolVar(&explain, "explain", false, "Show the candidate pool and weights instead of picking")
+	var pair bool
+	fs.BoolVar(&pair, "pair", false, "Pick a second movo that complements the first")
+	var count, pick int
+	fs.IntVar(&count, "n", 1, "Offer this many candidates to choose from")
+	fs.IntVar(&pick, "pick", 0, "Take this candidate (1-based) instead of asking")
+	parseFlags(fs, args)
+
+	if pair && timer {
+		fmt.Fprintln(os.Stderr, tr("Error: --pair can't be combined with --timer"))
+		os.Exit(exitError)
 	}
-
-	// Find the snack
-	var snack *Movo
-	for _, s := range snacks {
-		if s.FullCode == code {
-			snack = &s
-			break
-		}
+	if quietOutput && timer {
+		fmt.Fprintln(os.Stderr, tr("Error: --quiet can't be combined with --timer"))
+		os.Exit(exitError)
 	}
-
-	if snack == nil {
-		fmt.Fprintf(os.Stderr, "Error: snack code '%s' not found\n", code)
-		os.Exit(1)
+	switch {
+	case count < 1:
+		fmt.Fprintln(os.Stderr, tr("Error: -n must be at least 1"))
+		os.Exit(exitError)
+	case pick < 0 || pick > count || (pick > 0 && count == 1):
+		fmt.Fprintln(os.Stderr, tr("Error: --pick must be between 1 and -n"))
+		os.Exit(exitError)
+	case count > 1 && pair:
+		fmt.Fprintln(os.Stderr, tr("Error: -n can't be combined with --pair"))
+		os.Exit(exitError)
 	}
 
-	reader := bufio.NewReader(os.Stdin)
-
-	// Prompt for actual duration
-	defaultDuration := snack.GetDefaultDuration()
-	fmt.Printf("How many minutes did you spend? (default: %d): ", defaultDuration)
+	// Load snacks
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
 
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	filters := g.filterOptions()
 
-	duration := defaultDuration
-	if input != "" {
-		parsed, err := strconv.Atoi(input)
+	// A dry run: show what selection would weigh up, without picking or saving
+	if explain {
+		explanation, err := explainSelection(snacks, filters, appConfig.MaxDailyRPE)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Invalid duration, using default: %d\n", defaultDuration)
+			fmt.Fprintf(os.Stderr, tr("Error selecting snack: %v\n"), err)
+			os.Exit(exitCodeOf(err, exitError))
+		}
+		if outputJSON {
+			writeJSON(os.Stdout, explanation)
 		} else {
-			duration = parsed
+			writeExplanation(os.Stdout, explanation)
 		}
+		return
 	}
 
-	// Prompt for RPE
-	defaultRPE := snack.EffectiveRPE
-	fmt.Printf("How hard was it? RPE (default: %d): ", defaultRPE)
-
-	input, _ = reader.ReadString('\n')
-	input = strings.TrimSpace(input)
-
-	rpe := defaultRPE
-	if input != "" {
-		parsed, err := strconv.Atoi(input)
+	// Movos lined up with 'queue add' come before random selection (but not a choice)
+	var snack *Movo
+	if count == 1 {
+		snack, err = takeQueued(appConfig.QueuePath, snacks, filters, time.Now())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Invalid RPE, using default: %d\n", defaultRPE)
-		} else {
-			rpe = parsed
+			fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
+		}
+		if snack != nil && !outputJSON && !quietOutput {
+			fmt.Println(tr("📋 Next in your queue"))
 		}
 	}
 
-	// Create history entry
-	entry := HistoryEntry{
-		Timestamp: time.Now(),
-		Code:      code,
-		Status:    "done",
-		Duration:  duration,
-		RPE:       rpe,
-		Subset:    appConfig.ActiveSubset,
-	}
-
-	// Save to history
-	if err := AppendTodayLog(appConfig.LogsDir, entry); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving to history: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("✅ Marked '%s' as completed (%d minutes, RPE %d)\n", snack.Title, duration, rpe)
-
-	// Show updated daily stats
-	stats, _ := GetTodayStatsDaily(appConfig.LogsDir)
-	fmt.Printf("📊 Today: %d movos, %d minutes, %d RPE\n", stats.TotalMovos, stats.TotalDuration, stats.TotalRPE)
-}
-
-// handleSkip implements the 'skip' command
-func handleSkip(args []string) {
-	var code string
-
-	// Check if code was provided as argument
-	if len(args) > 0 {
-		code = args[0]
-	} else {
-		// Use current snack
-		var err error
-		code, err = loadCurrentSnack()
+	// Select a snack
+	var notes selectionNotes
+	if count > 1 {
+		if snack = chooseSnack(snacks, filters, count, pick); snack == nil {
+			return
+		}
+	} else if snack == nil {
+		snack, notes, err = pickSnack(snacks, filters, appConfig.MaxDailyRPE)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: no current snack. Use 'movodoro get' first or specify a code.\n")
-			os.Exit(1)
+			fmt.Fprintf(os.Stderr, tr("Error selecting snack: %v\n"), err)
+			os.Exit(exitCodeOf(err, exitError))
 		}
+		printSelectionNotes(notes)
 	}
 
-	// Load snacks to verify code exists
-	snacks, err := LoadSnacks()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-		os.Exit(1)
+	// Save as current snack
+	if err := saveCurrentPick(snack.FullCode, notes.Exploration); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Warning: could not save current snack: %v\n"), err)
 	}
 
-	// Find the snack
-	var snack *Movo
-	for _, s := range snacks {
-		if s.FullCode == code {
-			snack = &s
-			break
-		}
+	if pair {
+		showPair(snacks, filters, snack)
+		return
 	}
 
-	if snack == nil {
-		fmt.Fprintf(os.Stderr, "Error: snack code '%s' not found\n", code)
-		os.Exit(1)
+	if outputJSON {
+		out := newMovoJSON(snack)
+		out.RecoveryMode, out.RestDay, out.Exploration = notes.RecoveryMode, notes.RestDay, notes.Exploration
+		writeJSON(os.Stdout, out)
+		return
 	}
-
-	// Create history entry with 0 duration and RPE
-	entry := HistoryEntry{
-		Timestamp: time.Now(),
-		Code:      code,
-		Status:    "skip",
-		Duration:  0,
-		RPE:       0,
-		Subset:    appConfig.ActiveSubset,
+	if quietOutput {
+		fmt.Println(snack.FullCode)
+		return
 	}
 
-	// Save to history
-	if err := AppendTodayLog(appConfig.LogsDir, entry); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving to history: %v\n", err)
-		os.Exit(1)
+	if timer {
+		displayMovoInteractive(snack)
+		handleDoneInteractive(snack, false, timeMovo(snack))
+		os.Remove(appConfig.CurrentPath)
+		return
 	}
 
-	fmt.Printf("⏭️  Skipped '%s'\n", snack.Title)
+	// Display the movo
+	displayMovo(snack)
+	if filters.Fit > 0 && usualDuration(snack) > filters.Fit {
+		fmt.Printf(tr("⏱  Do it for %d minutes to fit your time\n"), fitMinutes(*snack, filters.Fit))
+	}
 }
 
-// handleReport implements the 'report' command
-func handleReport(args []string) {
-	fs := flag.NewFlagSet("report", flag.ExitOnError)
-	var markdown bool
-	var verbose bool
-	fs.BoolVar(&markdown, "markdown", false, "Output in markdown format")
-	fs.BoolVar(&markdown, "md", false, "Output in markdown format")
-	fs.BoolVar(&verbose, "verbose", false, "Show titles and tags (great for workout logs)")
-	fs.BoolVar(&verbose, "v", false, "Show titles and tags (great for workout logs)")
-
-	fs.Parse(args)
+// chooseSnack draws count candidates and returns the one picked, asking unless pick
+// is set. The others count as shown for the anti-repeat window but aren't logged as
+// skips. It returns nil if JSON output just lists them; picking nothing exits with
+// exitCancelled.
+func chooseSnack(snacks []Movo, filters FilterOptions, count, pick int) *Movo {
+	weighted, _, err := weighCandidates(snacks, filters, appConfig.MaxDailyRPE)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error selecting snack: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+	candidates := drawCandidates(selectorRand, weighted, count)
 
-	remaining := fs.Args()
-	period := "day"
-	if len(remaining) > 0 {
-		period = remaining[0]
+	if pick == 0 {
+		if outputJSON {
+			list := make([]movoJSON, len(candidates))
+			for i := range candidates {
+				list[i] = newMovoJSON(&candidates[i])
+			}
+			writeJSON(os.Stdout, list)
+			return nil
+		}
+		fmt.Println(tr("🎲 Pick one:"))
+		writeCandidates(os.Stdout, candidates)
+		if pick = promptPick(len(candidates)); pick == 0 {
+			fmt.Println(tr("👋 Nothing picked"))
+			os.Exit(exitCancelled)
+		}
+	}
+	if pick > len(candidates) {
+		fmt.Fprintf(os.Stderr, tr("Error: only %d movo(s) match, can't pick %d\n"), len(candidates), pick)
+		os.Exit(exitError)
 	}
 
-	switch period {
-	case "day", "today":
-		if markdown {
-			showDayReportMarkdown(verbose)
-		} else {
-			showDayReport(verbose)
+	now := time.Now()
+	for i, movo := range candidates {
+		if i == pick-1 {
+			continue
+		}
+		if err := recordShown(appConfig.RecentPath, movo.FullCode, now); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
 		}
-	case "week":
-		fmt.Println("Week report - not yet implemented")
-	case "month":
-		fmt.Println("Month report - not yet implemented")
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown report period: %s (use: day, week, month)\n", period)
-		os.Exit(1)
 	}
+	return &candidates[pick-1]
 }
 
-func showDayReport(verbose bool) {
-	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+// showPair picks a movo to complement first and queues it to come up next
+func showPair(snacks []Movo, filters FilterOptions, first *Movo) {
+	// Today's dailies still get their boost, but needn't crowd out a partner
+	filters.SkipMinimums = true
+	weighted, _, err := weighCandidates(snacks, filters, appConfig.MaxDailyRPE)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading stats: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, tr("Error selecting snack: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
 	}
 
-	// Load snacks for verbose mode
-	var movoMap map[string]*Movo
-	if verbose {
-		snacks, err := LoadSnacks()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-			os.Exit(1)
-		}
-		movoMap = make(map[string]*Movo)
-		for i := range snacks {
-			movoMap[snacks[i].FullCode] = &snacks[i]
+	partner := pickPartner(selectorRand, weighted, first)
+	if partner != nil {
+		if _, err := addToQueue(appConfig.QueuePath, []string{partner.FullCode}, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
 		}
 	}
 
-	// Detect active subset(s) from entries
-	subsetMap := make(map[string]bool)
-	for _, entry := range stats.CompletedSnacks {
-		if entry.Subset != "" {
-			subsetMap[entry.Subset] = true
+	if outputJSON {
+		pairJSON := []movoJSON{newMovoJSON(first)}
+		if partner != nil {
+			pairJSON = append(pairJSON, newMovoJSON(partner))
 		}
+		writeJSON(os.Stdout, pairJSON)
+		return
 	}
-	for _, entry := range stats.SkippedSnacks {
-		if entry.Subset != "" {
-			subsetMap[entry.Subset] = true
+	if quietOutput {
+		if partner != nil {
+			fmt.Println(first.FullCode, partner.FullCode)
+		} else {
+			fmt.Println(first.FullCode)
 		}
+		return
 	}
 
-	var subsets []string
-	for subset := range subsetMap {
-		subsets = append(subsets, subset)
+	displayMovo(first)
+	if partner == nil {
+		fmt.Println(tr("\nNo other movo matches to pair with it."))
+		return
 	}
+	fmt.Println(tr("\n🤝 Then pair it with:"))
+	displayMovo(partner)
+	fmt.Printf(tr("📋 Queued %s to come up on your next get\n"), partner.FullCode)
+}
 
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Printf("  TODAY'S MOVODORO REPORT\n")
-	fmt.Printf("  %s\n", stats.Date.Format("Monday, January 2, 2006"))
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println()
+// handleSession implements the 'session' command: plan movos for a block of
+// minutes, then walk through them one at a time
+func handleSession(args []string) {
+	fs, g := newGetFlagSet("session", flag.ContinueOnError)
+	var minutes int
+	fs.IntVar(&minutes, "minutes", 30, "Minutes to fill")
+	parseFlags(fs, args)
+
+	if minutes <= 0 {
+		fmt.Fprintln(os.Stderr, tr("Error: --minutes must be positive"))
+		os.Exit(exitError)
+	}
 
-	if len(subsets) > 0 {
-		fmt.Println("Active subset(s):")
-		for _, subset := range subsets {
-			fmt.Printf("  - %s\n", subset)
-		}
-		fmt.Println()
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
 	}
 
-	fmt.Printf("📊 Summary:\n")
-	fmt.Printf("   Total movos:     %d\n", len(stats.CompletedSnacks))
-	fmt.Printf("   Total duration:  %d minutes\n", stats.TotalDuration)
-	fmt.Printf("   Total RPE:       %d / %d\n", stats.TotalRPE, maxDailyRPEDefault)
-	fmt.Println()
+	// --fit packs several movos into the minutes instead of preferring one long one
+	filters := g.filterOptions()
+	if filters.Fit > 0 {
+		minutes, filters.Fit = filters.Fit, 0
+	}
 
-	if len(stats.CompletedSnacks) > 0 {
-		fmt.Printf("✅ Completed:\n")
-		for _, entry := range stats.CompletedSnacks {
-			subsetStr := ""
-			if entry.Subset != "" {
-				subsetStr = ", " + entry.Subset
-			}
+	plan, err := planSession(snacks, filters, minutes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error planning session: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
 
-			if verbose {
-				movo := movoMap[entry.Code]
-				if movo != nil {
-					tagsStr := formatMovoTags(movo)
-					fmt.Printf("   %s - %s [%s] (%dm, RPE %d%s)%s\n",
-						entry.Timestamp.Format("15:04"),
-						movo.Title,
-						entry.Code,
-						entry.Duration,
-						entry.RPE,
-						subsetStr,
-						tagsStr)
-				} else {
-					// Fallback if snack not found
-					fmt.Printf("   %s - %s (%dm, RPE %d%s)\n",
-						entry.Timestamp.Format("15:04"),
-						entry.Code,
-						entry.Duration,
-						entry.RPE,
-						subsetStr)
-				}
-			} else {
-				fmt.Printf("   %s - %s (%dm, RPE %d%s)\n",
-					entry.Timestamp.Format("15:04"),
-					entry.Code,
-					entry.Duration,
-					entry.RPE,
-					subsetStr)
-			}
+	if outputJSON {
+		writeJSON(os.Stdout, plan)
+		return
+	}
+
+	fmt.Printf(tr("📋 Session plan: %d movos, %d of %d minutes, RPE %d\n\n"), len(plan.Items), plan.TotalMinutes, plan.Minutes, plan.TotalRPE)
+	for i, item := range plan.Items {
+		daily := ""
+		if item.Daily {
+			daily = " (daily)"
 		}
-		fmt.Println()
+		fmt.Printf("  %d. %s [%s] %dm, RPE %d%s\n", i+1, item.Title, item.Code, item.Minutes, item.RPE, daily)
 	}
 
-	if len(stats.SkippedSnacks) > 0 {
-		fmt.Printf("⏭️  Skipped:\n")
-		for _, entry := range stats.SkippedSnacks {
-			if verbose {
-				movo := movoMap[entry.Code]
-				if movo != nil {
-					fmt.Printf("   %s - %s [%s]\n",
-						entry.Timestamp.Format("15:04"),
-						movo.Title,
-						entry.Code)
-				} else {
-					fmt.Printf("   %s - %s\n",
-						entry.Timestamp.Format("15:04"),
-						entry.Code)
-				}
+	done, skipped := 0, 0
+	for i, item := range plan.Items {
+		fmt.Printf(tr("\n▶️  %d of %d\n"), i+1, len(plan.Items))
+		displayMovoInteractive(item.movo)
+
+		choice := getInteractiveChoice(false, false, false)
+		timed := 0
+		for choice == "t" || choice == "i" {
+			if choice == "i" {
+				printMovoInfo(item.movo, snacks)
 			} else {
-				fmt.Printf("   %s - %s\n",
-					entry.Timestamp.Format("15:04"),
-					entry.Code)
+				timed = timeMovo(item.movo)
+				fmt.Println()
 			}
+			choice = getInteractiveChoice(false, false, false)
 		}
-		fmt.Println()
-	}
 
-	if stats.TotalRPE >= maxDailyRPEDefault {
-		fmt.Println("🔋 Auto-recovery mode active (RPE limit reached)")
+		switch choice {
+		case "d", "p":
+			handleDoneInteractive(item.movo, choice == "p", timed)
+			done++
+		case "s":
+			handleSkipInteractive(item.movo)
+			skipped++
+		default: // Quit
+			fmt.Printf(tr("\n👋 Session stopped: %d done, %d skipped, %d not started\n"), done, skipped, len(plan.Items)-i)
+			return
+		}
 	}
+
+	fmt.Printf(tr("\n🏁 Session complete: %d done, %d skipped\n"), done, skipped)
 }
 
-func showDayReportMarkdown(verbose bool) {
-	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+// handleList implements the 'list' command: every movo matching the get filters
+func handleList(args []string) {
+	fs, g := newGetFlagSet("list", flag.ContinueOnError)
+	parseFlags(fs, args)
+
+	snacks, err := LoadSnacks()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading stats: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
 	}
 
-	// Load snacks for verbose mode
-	var movoMap map[string]*Movo
-	if verbose {
-		snacks, err := LoadSnacks()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-			os.Exit(1)
-		}
-		movoMap = make(map[string]*Movo)
+	matches, err := matchingMovos(snacks, g.filterOptions(), appConfig.MovosDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error applying subset filter: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	if outputJSON {
+		list := make([]movoJSON, 0, len(matches))
+		for i := range matches {
+			list = append(list, newMovoJSON(&matches[i]))
+		}
+		writeJSON(os.Stdout, list)
+		return
+	}
+
+	if len(matches) == 0 {
+		fmt.Println(tr("No movos match these filters."))
+		return
+	}
+	writeMovoList(os.Stdout, matches)
+	fmt.Printf(tr("\n%d of %d movos match\n"), len(matches), len(snacks))
+}
+
+// handleSearch implements the 'search' command: find movos by words in their title,
+// tags, code or description
+func handleSearch(args []string) {
+	query := strings.Join(args, " ")
+	if strings.TrimSpace(query) == "" {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro search QUERY"))
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	results := searchMovos(snacks, query)
+	if outputJSON {
+		list := make([]movoJSON, 0, len(results))
+		for _, r := range results {
+			list = append(list, newMovoJSON(r.Movo))
+		}
+		writeJSON(os.Stdout, list)
+		return
+	}
+
+	if len(results) == 0 {
+		fmt.Printf(tr("No movos match %q\n"), query)
+		return
+	}
+
+	width := 0
+	for _, r := range results {
+		width = max(width, len(r.Movo.FullCode))
+	}
+	for _, r := range results {
+		fmt.Printf("%-*s  %s\n", width, r.Movo.FullCode, r.Movo.Title)
+	}
+}
+
+// handleStatus implements the 'status' command
+func handleStatus(args []string) {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	var xbar bool
+	fs.BoolVar(&xbar, "xbar", false, "Output an xbar/SwiftBar menu bar plugin")
+	parseFlags(fs, args)
+
+	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading stats: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	// The current movo may be stale (e.g. removed from the library); treat that as none
+	var current *Movo
+	if code, err := loadCurrentSnack(); err == nil {
+		if snacks, err := LoadSnacks(); err == nil {
+			for i := range snacks {
+				if snacks[i].FullCode == code {
+					current = &snacks[i]
+					break
+				}
+			}
+		}
+	}
+
+	if xbar {
+		exe, err := os.Executable()
+		if err != nil {
+			exe = "movodoro"
+		}
+		writeXbar(os.Stdout, xbarStatus{
+			Exe:     exe,
+			Profile: appConfig.Profile,
+			Current: current,
+			Stats:   stats,
+			MaxRPE:  appConfig.MaxDailyRPE,
+		})
+		return
+	}
+
+	if outputJSON {
+		writeJSON(os.Stdout, newStatusJSON(current, stats, appConfig.MaxDailyRPE))
+		return
+	}
+
+	if current != nil {
+		fmt.Printf(tr("Current: %s (%s)\n"), current.Title, current.FullCode)
+	} else {
+		fmt.Println(tr("Current: none (run 'movodoro get')"))
+	}
+	fmt.Printf(tr("Today:   %d movos, %d minutes, RPE %d/%d\n"),
+		len(stats.CompletedSnacks), stats.TotalDuration, stats.TotalRPE, appConfig.MaxDailyRPE)
+}
+
+// handleSandbox implements the 'sandbox' command: interactive mode in a temporary
+// home with demo movos and synthetic history
+func handleSandbox(args []string) {
+	fs := flag.NewFlagSet("sandbox", flag.ContinueOnError)
+	var keep bool
+	var days int
+	fs.BoolVar(&keep, "keep", false, "Keep the sandbox directory afterwards")
+	fs.IntVar(&days, "days", 14, "Days of synthetic history")
+	parseFlags(fs, args)
+
+	dir, err := os.MkdirTemp("", "movodoro-sandbox-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error creating sandbox: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	if !keep {
+		defer os.RemoveAll(dir)
+	}
+
+	if err := setupSandbox(dir, time.Now(), days, uint64(time.Now().UnixNano())); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error creating sandbox: %v\n"), err)
+		os.RemoveAll(dir)
+		os.Exit(exitError)
+	}
+	enterSandbox(dir)
+
+	fmt.Println(sandboxNote(dir, keep))
+	handleInteractive(fs.Args())
+}
+
+// handleSay implements the 'say' command: log a completion from one spoken sentence
+func handleSay(args []string) {
+	text := strings.Join(args, " ")
+	if strings.TrimSpace(text) == "" {
+		fmt.Fprintln(os.Stderr, tr(`Usage: movodoro say "did box breathing five minutes easy"`))
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	u := parseUtterance(text)
+	movo, err := matchSpokenMovo(u.Words, snacks)
+	if err == nil {
+		err = checkSpokenDuration(u.Duration)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Sorry, %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	duration := u.Duration
+	if duration == 0 {
+		duration = usualDuration(movo)
+	}
+	rpe := u.RPE
+	if rpe == 0 {
+		rpe = movo.EffectiveRPE
+	}
+
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      movo.FullCode,
+		Status:    "done",
+		Duration:  duration,
+		RPE:       rpe,
+		Subset:    appConfig.ActiveSubset,
+	}
+	if err := appendLogEntry(entry, movo); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving to history: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitStorage))
+	}
+
+	if appConfig.KidMode {
+		fmt.Println(kidCheer(movo.Title))
+		return
+	}
+	fmt.Printf(tr("✅ Logged %s, %d minutes, RPE %d\n"), movo.Title, duration, rpe)
+}
+
+// handleQR implements the 'qr' command
+func handleQR(args []string) {
+	fs := flag.NewFlagSet("qr", flag.ContinueOnError)
+	var pngPath, base, token string
+	var command, invert bool
+	fs.StringVar(&pngPath, "png", "", "Save the QR code as a PNG file")
+	fs.StringVar(&base, "url", os.Getenv("MOVODORO_SERVE_URL"), "Address of 'movodoro serve' to link to (http://HOST:PORT)")
+	fs.StringVar(&token, "token", os.Getenv("MOVODORO_SERVE_TOKEN"), "Serve's token, added to the link so a scan can log with GET")
+	fs.BoolVar(&command, "command", false, "Encode the 'movodoro done CODE' command instead of a link")
+	fs.BoolVar(&invert, "invert", false, "Draw for a light terminal background")
+
+	remaining := parseFlagsInterspersed(fs, args)
+	if len(remaining) != 1 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro qr CODE [--url http://HOST:PORT] [--token SECRET] [--png FILE] [--command] [--invert]"))
+		os.Exit(exitError)
+	}
+	code := remaining[0]
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+	var movo *Movo
+	for i := range snacks {
+		if snacks[i].FullCode == code {
+			movo = &snacks[i]
+			break
+		}
+	}
+	if movo == nil {
+		fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+		os.Exit(exitError)
+	}
+
+	payload, err := qrPayload(code, base, token, command)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	if !command && token == "" {
+		fmt.Fprintln(os.Stderr, tr("Note: without --token, serve only logs the link with a POST (a phone shortcut); opening it in a browser is refused"))
+	}
+
+	q, err := encodeQR(payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	if pngPath != "" {
+		if err := writeQRPNG(pngPath, q); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		fmt.Printf(tr("Saved QR code for %s to %s\n"), movo.Title, pngPath)
+		return
+	}
+
+	writeQRText(os.Stdout, q, invert)
+	fmt.Printf("%s\n%s\n", movo.Title, payload)
+}
+
+// qrPayload is what a QR code for code holds: serve's /done/CODE under base (with the
+// token, so a camera scan's GET is accepted), or the CLI command with command set
+func qrPayload(code, base, token string, command bool) (string, error) {
+	if command {
+		return "movodoro done " + code, nil
+	}
+	if base == "" {
+		return "", fmt.Errorf("qr needs --url http://HOST:PORT (where 'movodoro serve' listens) or --command")
+	}
+	link, err := url.Parse(base)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return "", fmt.Errorf("--url must be http://HOST:PORT or https://HOST, got %q", base)
+	}
+	link = link.JoinPath("done", code)
+	if token != "" {
+		link.RawQuery = url.Values{"token": {token}}.Encode()
+	}
+	return link.String(), nil
+}
+
+// handleDone implements the 'done' command
+func handleDone(args []string) {
+	fs := flag.NewFlagSet("done", flag.ContinueOnError)
+	var partial, batch bool
+	var duration, rpe int
+	var date, clock, setsReps, load, distance string
+	fs.BoolVar(&partial, "partial", false, "Log a partial completion (stopped early)")
+	fs.BoolVar(&batch, "batch", false, "Read codes to log from stdin")
+	fs.IntVar(&duration, "duration", 0, "Minutes for each movo (default: its usual duration)")
+	fs.IntVar(&duration, "d", 0, "Minutes for each movo (default: its usual duration)")
+	fs.IntVar(&rpe, "rpe", 0, "RPE for each movo (default: its own)")
+	fs.IntVar(&rpe, "r", 0, "RPE for each movo (default: its own)")
+	fs.StringVar(&date, "date", "", "Log for an earlier day (yesterday or YYYY-MM-DD)")
+	fs.StringVar(&clock, "time", "", "Log at this time of day (HH:MM)")
+	fs.StringVar(&setsReps, "sets", "", "Sets×reps done, e.g. 3x10")
+	fs.StringVar(&load, "load", "", "Load used in kg, e.g. 24")
+	fs.StringVar(&distance, "distance", "", "Distance covered, e.g. 2.5km, 800m or 4000steps")
+	args = parseFlagsInterspersed(fs, args)
+
+	when, err := parseLogTime(date, clock, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	if (date != "" || clock != "") && !quietOutput {
+		fmt.Printf(tr("📅 Logging for %s %s\n"), appConfig.FormatDate(when), appConfig.FormatClock(when))
+	}
+
+	// Several codes, or the effort given up front, are logged without prompts
+	if batch {
+		codes, err := readCodes(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+		args = append(args, codes...)
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, tr("Error: no codes on stdin"))
+			os.Exit(exitError)
+		}
+	}
+	var details doneDetails
+	if setsReps != "" {
+		if details.Sets, details.Reps, err = parseSetsReps(setsReps); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+	}
+	if load != "" {
+		if details.Load, err = parseLoad(load); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+	}
+	if distance != "" {
+		if details.Distance, details.Steps, err = parseDistance(distance); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+	}
+	if len(args) > 1 || batch || quietOutput || duration > 0 || rpe > 0 || details != (doneDetails{}) {
+		logDoneMany(args, partial, duration, rpe, details, when)
+		return
+	}
+
+	var code string
+
+	// Check if code was provided as argument
+	if len(args) > 0 {
+		code = args[0]
+	} else {
+		// Use current snack
+		var err error
+		code, err = loadCurrentSnack()
+		if err != nil {
+			fmt.Fprint(os.Stderr, tr("Error: no current snack. Use 'movodoro get' first or specify a code.\n"))
+			os.Exit(exitError)
+		}
+	}
+
+	// Load snacks to get RPE
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	// Find the snack
+	var snack *Movo
+	for _, s := range snacks {
+		if s.FullCode == code {
+			snack = &s
+			break
+		}
+	}
+
+	if snack == nil {
+		fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+		os.Exit(exitError)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	defaultDuration := usualDuration(snack)
+	if partial {
+		defaultDuration = partialDefaultDuration(snack)
+	}
+	duration, rpe = promptEffort(reader, defaultDuration, snack.EffectiveRPE)
+
+	status := "done"
+	if partial {
+		status = "partial"
+	}
+
+	// Create history entry
+	entry := HistoryEntry{
+		Timestamp: when,
+		Code:      code,
+		Status:    status,
+		Duration:  duration,
+		RPE:       rpe,
+		Subset:    appConfig.ActiveSubset,
+	}
+	promptDetails(reader, snack).record(&entry)
+	if appConfig.RateAfterDone {
+		recordRating(&entry, promptRating(reader))
+	}
+
+	// Save to history
+	if err := appendLogEntry(entry, snack); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving to history: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitStorage))
+	}
+
+	if appConfig.KidMode {
+		fmt.Println(kidCheer(snack.Title))
+	} else if partial {
+		fmt.Printf(tr("◐ Marked '%s' as partially completed (%d minutes, RPE %d)\n"), snack.Title, duration, rpe)
+	} else {
+		fmt.Printf(tr("✅ Marked '%s' as completed (%d minutes, RPE %d)\n"), snack.Title, duration, rpe)
+	}
+
+	// Show updated daily stats
+	printDayTotals(when)
+}
+
+// logDoneMany logs each code (or the current snack if none) as done or partial
+// without prompting, using duration and rpe where given and each movo's defaults
+// otherwise. Nothing is logged unless every code is found.
+func logDoneMany(codes []string, partial bool, duration, rpe int, details doneDetails, when time.Time) {
+	if len(codes) == 0 {
+		code, err := loadCurrentSnack()
+		if err != nil {
+			fmt.Fprint(os.Stderr, tr("Error: no current snack. Use 'movodoro get' first or specify a code.\n"))
+			os.Exit(exitError)
+		}
+		codes = []string{code}
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+	movos := make([]*Movo, len(codes))
+	for i, code := range codes {
+		if movos[i] = findMovo(snacks, code); movos[i] == nil {
+			fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+			os.Exit(exitError)
+		}
+		if movos[i].Deprecated && !quietOutput {
+			fmt.Printf("ℹ️  %s\n", deprecationMessage(snacks, movos[i]))
+		}
+	}
+
+	status := "done"
+	if partial {
+		status = "partial"
+	}
+	for i, movo := range movos {
+		entry := HistoryEntry{
+			Timestamp: when,
+			Code:      movo.FullCode,
+			Status:    status,
+			Duration:  duration,
+			RPE:       rpe,
+			Subset:    appConfig.ActiveSubset,
+		}
+		if entry.Duration == 0 {
+			entry.Duration = usualDuration(movo)
+			if partial {
+				entry.Duration = partialDefaultDuration(movo)
+			}
+		}
+		if entry.RPE == 0 {
+			entry.RPE = movo.EffectiveRPE
+		}
+		details.record(&entry)
+
+		if err := appendLogEntry(entry, movo); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error saving to history: %v (logged %d of %d)\n"), err, i, len(movos))
+			os.Exit(exitCodeOf(err, exitStorage))
+		}
+		if quietOutput {
+			continue
+		}
+		if appConfig.KidMode {
+			fmt.Println(kidCheer(movo.Title))
+		} else {
+			mark := "✅"
+			if partial {
+				mark = "◐"
+			}
+			fmt.Printf(tr("%s %s (%d minutes, RPE %d)\n"), mark, movo.Title, entry.Duration, entry.RPE)
+		}
+	}
+	if quietOutput {
+		return
+	}
+	printDayTotals(when)
+}
+
+// printDayTotals shows the totals for the day an entry was logged to: today's, or
+// the named day's for a backdated entry
+func printDayTotals(when time.Time) {
+	stats, _ := GetDayStatsDaily(appConfig.LogsDir, when)
+	stickers := len(stats.CompletedSnacks) + len(stats.PartialSnacks)
+	backdated := !dayStart(when).Equal(dayStart(time.Now()))
+	switch {
+	case backdated && appConfig.KidMode:
+		fmt.Printf(tr("⭐ Stickers on %s: %d\n"), appConfig.FormatDate(when), stickers)
+	case backdated:
+		fmt.Printf(tr("📊 %s: %d movos, %d minutes, %d RPE\n"), appConfig.FormatDate(when), stats.TotalMovos, stats.TotalDuration, stats.TotalRPE)
+	case appConfig.KidMode:
+		fmt.Printf(tr("⭐ Stickers today: %d\n"), stickers)
+	default:
+		fmt.Printf(tr("📊 Today: %d movos, %d minutes, %d RPE\n"), stats.TotalMovos, stats.TotalDuration, stats.TotalRPE)
+	}
+}
+
+// handleSkip implements the 'skip' command
+func handleSkip(args []string) {
+	var code string
+
+	// Check if code was provided as argument
+	if len(args) > 0 {
+		code = args[0]
+	} else {
+		// Use current snack
+		var err error
+		code, err = loadCurrentSnack()
+		if err != nil {
+			fmt.Fprint(os.Stderr, tr("Error: no current snack. Use 'movodoro get' first or specify a code.\n"))
+			os.Exit(exitError)
+		}
+	}
+
+	// Load snacks to verify code exists
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	// Find the snack
+	var snack *Movo
+	for _, s := range snacks {
+		if s.FullCode == code {
+			snack = &s
+			break
+		}
+	}
+
+	if snack == nil {
+		fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+		os.Exit(exitError)
+	}
+
+	// Create history entry with 0 duration and RPE
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      code,
+		Status:    "skip",
+		Duration:  0,
+		RPE:       0,
+		Subset:    appConfig.ActiveSubset,
+	}
+
+	// Save to history
+	if err := appendLogEntry(entry, snack); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving to history: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitStorage))
+	}
+
+	if !quietOutput {
+		fmt.Printf(tr("⏭️  Skipped '%s'\n"), snack.Title)
+	}
+}
+
+// handleSnooze implements the 'snooze' command: put off the current snack without
+// logging it, leaving it out of selection for a while
+func handleSnooze(args []string) {
+	if len(args) > 1 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro snooze [DURATION]   (default 30m)"))
+		os.Exit(exitError)
+	}
+	var arg string
+	if len(args) == 1 {
+		arg = args[0]
+	}
+	window, err := parseSnoozeDuration(arg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	code, err := loadCurrentSnack()
+	if err != nil || code == "" {
+		fmt.Fprint(os.Stderr, tr("Error: no current snack. Use 'movodoro get' first.\n"))
+		os.Exit(exitError)
+	}
+
+	now := time.Now()
+	until := now.Add(window)
+	if err := snoozeMovo(appConfig.SnoozePath, code, until, now); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving snooze: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	os.Remove(appConfig.CurrentPath)
+
+	title := code
+	if snacks, err := LoadSnacks(); err == nil {
+		if movo := findMovo(snacks, code); movo != nil {
+			title = movo.Title
+		}
+	}
+	fmt.Printf(tr("💤 Snoozed '%s' until %s\n"), title, appConfig.FormatClock(until))
+}
+
+// handleQueue implements the 'queue' command: line up specific movos for later
+// today, which 'get' then hands out in order
+func handleQueue(args []string) {
+	usage := tr("Usage: movodoro queue add CODE... | list | next | clear")
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+	now := time.Now()
+
+	switch args[0] {
+	case "add":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, tr("Usage: movodoro queue add CODE..."))
+			os.Exit(exitError)
+		}
+		for _, code := range args[1:] {
+			if findMovo(snacks, code) == nil {
+				fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+				os.Exit(exitError)
+			}
+		}
+		q, err := addToQueue(appConfig.QueuePath, args[1:], now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		for _, code := range args[1:] {
+			fmt.Printf(tr("📋 Queued '%s'\n"), findMovo(snacks, code).Title)
+		}
+		fmt.Printf(tr("%d in today's queue\n"), len(q.Codes))
+
+	case "list", "next":
+		q, err := loadQueue(appConfig.QueuePath, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		var queued []*Movo
+		for _, code := range q.Codes {
+			if movo := findMovo(snacks, code); movo != nil {
+				queued = append(queued, movo)
+			}
+		}
+
+		if args[0] == "next" {
+			if len(queued) == 0 {
+				fmt.Println(tr("The queue is empty; 'get' picks at random"))
+				return
+			}
+			if outputJSON {
+				writeJSON(os.Stdout, newMovoJSON(queued[0]))
+				return
+			}
+			printMovoCard(queued[0])
+			fmt.Println()
+			return
+		}
+
+		if outputJSON {
+			items := make([]movoJSON, len(queued))
+			for i, movo := range queued {
+				items[i] = newMovoJSON(movo)
+			}
+			writeJSON(os.Stdout, items)
+			return
+		}
+		if len(queued) == 0 {
+			fmt.Println(tr("The queue is empty"))
+			return
+		}
+		fmt.Println(tr("📋 Today's queue:"))
+		for i, movo := range queued {
+			fmt.Printf(tr("  %d. %s [%s] %d-%d min, RPE %d\n"), i+1, movo.Title, movo.FullCode, movo.DurationMin, movo.DurationMax, movo.EffectiveRPE)
+		}
+
+	case "clear":
+		if err := os.Remove(appConfig.QueuePath); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		fmt.Println(tr("Cleared the queue"))
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+}
+
+// handleFav implements the 'fav' command: personal favorites that get a weight boost
+func handleFav(args []string) {
+	usage := tr("Usage: movodoro fav add CODE... | remove CODE... | list")
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	switch args[0] {
+	case "add", "remove":
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, tr("Usage: movodoro fav %s CODE...\n"), args[0])
+			os.Exit(exitError)
+		}
+		remove := args[0] == "remove"
+		if !remove {
+			for _, code := range args[1:] {
+				if findMovo(snacks, code) == nil {
+					fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+					os.Exit(exitError)
+				}
+			}
+		}
+		favorites, err := updateFavorites(appConfig.FavoritesPath, args[1:], remove)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		for _, code := range args[1:] {
+			if remove {
+				fmt.Printf(tr("Removed '%s' from favorites\n"), code)
+			} else {
+				fmt.Printf(tr("⭐ Favorited '%s'\n"), findMovo(snacks, code).Title)
+			}
+		}
+		fmt.Printf(tr("%d favorite(s), weighted %gx\n"), len(favorites), appConfig.FavoriteBoost)
+
+	case "list":
+		favorites, err := loadFavorites(appConfig.FavoritesPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		if outputJSON {
+			items := []movoJSON{}
+			for _, code := range favorites {
+				if movo := findMovo(snacks, code); movo != nil {
+					items = append(items, newMovoJSON(movo))
+				}
+			}
+			writeJSON(os.Stdout, items)
+			return
+		}
+		if len(favorites) == 0 {
+			fmt.Println(tr("No favorites yet (movodoro fav add CODE)"))
+			return
+		}
+		fmt.Printf(tr("⭐ Favorites (weighted %gx):\n"), appConfig.FavoriteBoost)
+		for _, code := range favorites {
+			if movo := findMovo(snacks, code); movo != nil {
+				fmt.Printf(tr("  %s [%s] %d-%d min, RPE %d\n"), movo.Title, movo.FullCode, movo.DurationMin, movo.DurationMax, movo.EffectiveRPE)
+			} else {
+				fmt.Printf(tr("  %s (no longer in the library)\n"), code)
+			}
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+}
+
+// handleStats implements the 'stats' command: a movo's completions and load history
+func handleStats(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro stats CODE"))
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+	movo := findMovo(snacks, args[0])
+	if movo == nil {
+		fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), args[0])
+		os.Exit(exitError)
+	}
+	entries, err := LoadAllHistory(appConfig.LogsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	done := 0
+	for _, entry := range entries {
+		if entry.Code == movo.FullCode && entry.Status == "done" {
+			done++
+		}
+	}
+	history := loadHistory(entries, movo.FullCode)
+
+	if outputJSON {
+		writeJSON(os.Stdout, newMovoStatsJSON(movo, done, history))
+		return
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Printf("  %s [%s]\n", movo.Title, movo.FullCode)
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf(tr("Completed:  %d times\n"), done)
+	if len(history) == 0 {
+		if movo.tracksLoad() {
+			fmt.Println(tr("No loads logged yet (done asks for one, or use done --load KG)"))
+		}
+		return
+	}
+
+	latest, heaviest := history[len(history)-1], history[0]
+	for _, entry := range history {
+		if entryLoad(entry) > entryLoad(heaviest) {
+			heaviest = entry
+		}
+	}
+	fmt.Printf(tr("Latest:     %s (%s)\n"), formatLoad(entryLoad(latest)), appConfig.FormatDate(latest.Timestamp))
+	fmt.Printf(tr("Heaviest:   %s (%s)\n"), formatLoad(entryLoad(heaviest)), appConfig.FormatDate(heaviest.Timestamp))
+	fmt.Println()
+	fmt.Println(tr("🏋️  Load history:"))
+	for _, entry := range history {
+		setsReps := ""
+		if sets, reps, ok := entrySetsReps(entry); ok {
+			setsReps = fmt.Sprintf("%d×%d", sets, reps)
+		}
+		fmt.Printf("   %-9s %-7s %s\n", formatLoad(entryLoad(entry)), setsReps, appConfig.FormatDate(entry.Timestamp))
+	}
+}
+
+// handleBan implements the 'ban' command
+func handleBan(args []string) {
+	usage := tr("Usage: movodoro ban CODE [--until YYYY-MM-DD] | ban list")
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+	now := time.Now()
+	bans, err := loadBans(appConfig.BansPath, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	if args[0] == "list" {
+		showBans(snacks, bans)
+		return
+	}
+
+	// Accept --until on either side of the code
+	fs := flag.NewFlagSet("ban", flag.ContinueOnError)
+	var untilStr string
+	fs.StringVar(&untilStr, "until", "", "Last day of the ban (YYYY-MM-DD); indefinite if omitted")
+	parseFlags(fs, args)
+	if fs.NArg() > 0 {
+		code := fs.Arg(0)
+		parseFlags(fs, fs.Args()[1:])
+		args = append([]string{code}, fs.Args()...)
+	}
+	if len(args) != 1 || fs.NArg() != 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+
+	movo := findMovo(snacks, args[0])
+	if movo == nil {
+		fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), args[0])
+		os.Exit(exitError)
+	}
+	until, err := parseBanUntil(untilStr, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	bans[movo.FullCode] = until
+	if err := saveBans(appConfig.BansPath, bans); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving bans: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	if until.IsZero() {
+		fmt.Printf(tr("🚫 Banned '%s' until you unban it\n"), movo.Title)
+	} else {
+		fmt.Printf(tr("🚫 Banned '%s' through %s\n"), movo.Title, untilDay(until))
+	}
+}
+
+// handleUnban implements the 'unban' command
+func handleUnban(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro unban CODE..."))
+		os.Exit(exitError)
+	}
+
+	bans, err := loadBans(appConfig.BansPath, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	for _, code := range args {
+		if _, banned := bans[code]; !banned {
+			fmt.Fprintf(os.Stderr, tr("Error: '%s' is not banned\n"), code)
+			os.Exit(exitError)
+		}
+		delete(bans, code)
+	}
+	if err := saveBans(appConfig.BansPath, bans); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving bans: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	for _, code := range args {
+		fmt.Printf(tr("Unbanned '%s'\n"), code)
+	}
+}
+
+// showBans lists the active bans for 'ban list'
+func showBans(snacks []Movo, bans map[string]time.Time) {
+	codes := make([]string, 0, len(bans))
+	for code := range bans {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	if outputJSON {
+		type banJSON struct {
+			Code  string `json:"code"`
+			Title string `json:"title,omitempty"`
+			Until string `json:"until,omitempty"`
+		}
+		items := []banJSON{}
+		for _, code := range codes {
+			item := banJSON{Code: code}
+			if movo := findMovo(snacks, code); movo != nil {
+				item.Title = movo.Title
+			}
+			if !bans[code].IsZero() {
+				item.Until = untilDay(bans[code])
+			}
+			items = append(items, item)
+		}
+		writeJSON(os.Stdout, items)
+		return
+	}
+
+	if len(codes) == 0 {
+		fmt.Println(tr("No banned movos (movodoro ban CODE)"))
+		return
+	}
+	fmt.Println(tr("🚫 Banned:"))
+	for _, code := range codes {
+		title := "(no longer in the library)"
+		if movo := findMovo(snacks, code); movo != nil {
+			title = movo.Title
+		}
+		until := "indefinitely"
+		if !bans[code].IsZero() {
+			until = "through " + untilDay(bans[code])
+		}
+		fmt.Printf("  %s [%s] %s\n", title, code, until)
+	}
+}
+
+// handlePacks installs, removes and lists movo packs
+func handlePacks(args []string) {
+	usage := tr("Usage: movodoro packs [list] | packs available | packs install NAME|URL|FILE | packs remove NAME")
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	switch {
+	case sub == "list" && len(args) <= 1:
+		packs, err := installedPacks(appConfig.MovosDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitLibrary)
+		}
+		if outputJSON {
+			if packs == nil {
+				packs = []installedPack{}
+			}
+			writeJSON(os.Stdout, packs)
+			return
+		}
+		if len(packs) == 0 {
+			fmt.Println(tr("No packs installed (see 'movodoro packs available')"))
+			return
+		}
+		fmt.Println(tr("📦 Installed packs:"))
+		for _, pack := range packs {
+			fmt.Printf(tr("  %-20s %-5s %2d movos  %s\n"), pack.Name, pack.CategoryCode, pack.Movos, pack.Source)
+		}
+
+	case sub == "available" && len(args) == 1:
+		registry, err := loadPackRegistry(appConfig.PackRegistry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+		installed := make(map[string]bool)
+		if packs, err := installedPacks(appConfig.MovosDir); err == nil {
+			for _, pack := range packs {
+				installed[pack.Name] = true
+			}
+		}
+		fmt.Println(tr("📦 Available packs:"))
+		for _, entry := range registry.Packs {
+			mark := " "
+			if installed[entry.Name] {
+				mark = "✓"
+			}
+			fmt.Printf("  %s %-20s %s\n", mark, entry.Name, entry.Description)
+		}
+
+	case sub == "install" && len(args) == 2:
+		name, source, err := resolvePack(args[1], appConfig.PackRegistry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+		data, err := fetchPackSource(source)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error fetching pack: %v\n"), err)
+			os.Exit(exitError)
+		}
+		category, err := installPack(appConfig.MovosDir, name, source, data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitLibrary)
+		}
+		fmt.Printf(tr("📦 Installed pack '%s': %d movos under %s (%s)\n"), name, len(category.Movos), category.Code, category.Category)
+
+	case sub == "remove" && len(args) == 2:
+		if err := removePack(appConfig.MovosDir, args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitCodeOf(err, exitError))
+		}
+		fmt.Printf(tr("🗑️  Removed pack '%s'\n"), args[1])
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(exitError)
+	}
+}
+
+// handleCheckin implements the 'checkin' command
+func handleCheckin(args []string) {
+	fs := flag.NewFlagSet("checkin", flag.ContinueOnError)
+	var energy int
+	fs.IntVar(&energy, "energy", 0, "How much energy you have, 1 (drained) to 5 (raring to go)")
+	parseFlags(fs, args)
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro checkin [--energy 1-5]"))
+		os.Exit(exitError)
+	}
+
+	now := time.Now()
+	if energy == 0 {
+		current, at, err := latestCheckin(appConfig.LogsDir, appConfig.Profile, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
+		}
+		if current == 0 {
+			fmt.Printf(tr("No check-in in the last %d hours (movodoro checkin --energy 1-5)\n"), checkinHours)
+			return
+		}
+		fmt.Printf(tr("⚡ Energy %d/5, checked in at %s\n"), current, appConfig.FormatClock(at))
+		return
+	}
+
+	if err := parseEnergy(energy); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	if err := ensureStateDir(); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving check-in: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	if err := AppendDailyLog(appConfig.LogsDir, newCheckin(energy, now, appConfig.Profile)); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving check-in: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	switch {
+	case energy < neutralEnergy:
+		fmt.Printf(tr("⚡ Energy %d/5: leaning toward easier movos for the next %d hours\n"), energy, checkinHours)
+	case energy > neutralEnergy:
+		fmt.Printf(tr("⚡ Energy %d/5: leaning toward harder movos for the next %d hours\n"), energy, checkinHours)
+	default:
+		fmt.Printf(tr("⚡ Energy %d/5: selection unchanged\n"), energy)
+	}
+}
+
+// handleRest implements the 'rest' command
+func handleRest(args []string) {
+	fs := flag.NewFlagSet("rest", flag.ContinueOnError)
+	var cancel bool
+	fs.BoolVar(&cancel, "cancel", false, "Make the day a normal day again")
+	parseFlags(fs, args)
+	if fs.NArg() > 1 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro rest [--cancel] [today|tomorrow|YYYY-MM-DD]"))
+		os.Exit(exitError)
+	}
+
+	day, err := parseRestDay(fs.Arg(0), time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	if err := setRestDate(appConfig.RestDatesPath, day, !cancel); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving rest day: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	date := appConfig.FormatDate(day)
+	switch {
+	case !cancel:
+		fmt.Printf(tr("🛌 %s is a rest day: only RPE ≤ %d movos, and everyday minimums above RPE %d are waived\n"),
+			date, restDayMaxRPE, appConfig.RestEverydayRPE)
+	case isRestDay(appConfig, day):
+		fmt.Printf(tr("%s is still a rest day (rest_days in config.yaml)\n"), date)
+	default:
+		fmt.Printf(tr("%s is no longer a rest day\n"), date)
+	}
+}
+
+// handleProgram implements the 'program' command
+func handleProgram(args []string) {
+	if len(args) > 0 && args[0] != "status" {
+		fmt.Fprintf(os.Stderr, tr("Unknown program command: %s (use: status)\n"), args[0])
+		os.Exit(exitError)
+	}
+	pos := appConfig.Program
+	if pos == nil {
+		if _, err := LoadPrograms(appConfig.MovosDir); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitLibrary)
+		}
+		if outputJSON {
+			writeJSON(os.Stdout, nil)
+			return
+		}
+		fmt.Println(tr("No active program (set active: in programs.yaml in your movos directory)"))
+		return
+	}
+
+	if outputJSON {
+		writeJSON(os.Stdout, pos)
+		return
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Printf(tr("  PROGRAM: %s\n"), pos.Program)
+	fmt.Printf(tr("  Started %s\n"), appConfig.FormatDate(pos.Start))
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+
+	switch {
+	case pos.Finished:
+		fmt.Printf(tr("Finished all %d weeks; settings are back to config.yaml's\n"), pos.TotalWeeks)
+		return
+	case pos.Block == nil:
+		fmt.Printf(tr("Starts %s with %s\n"), appConfig.FormatDate(*pos.NextStart), pos.NextBlock)
+		return
+	}
+
+	fmt.Printf(tr("Block:            %s (block %d of %d)\n"), pos.summary(), pos.BlockNumber, pos.Blocks)
+	fmt.Printf(tr("Program week:     %d of %d"), pos.Week, pos.TotalWeeks)
+	if pos.Cycle > 1 {
+		fmt.Printf(tr(" (cycle %d)"), pos.Cycle)
+	}
+	fmt.Println()
+	if pos.NextStart != nil {
+		fmt.Printf(tr("Next:             %s from %s\n"), pos.NextBlock, appConfig.FormatDate(*pos.NextStart))
+	} else {
+		fmt.Println(tr("Next:             program ends after this block"))
+	}
+	fmt.Println()
+
+	// What the block is doing to selection right now
+	fmt.Println(tr("Settings in effect:"))
+	fmt.Printf(tr("   Max daily RPE:    %d\n"), appConfig.MaxDailyRPE)
+	if len(appConfig.CategoryWeights) > 0 {
+		codes := make([]string, 0, len(appConfig.CategoryWeights))
+		for code := range appConfig.CategoryWeights {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		var weights []string
+		for _, code := range codes {
+			weights = append(weights, fmt.Sprintf("%s ×%g", code, appConfig.CategoryWeights[code]))
+		}
+		fmt.Printf(tr("   Category weights: %s\n"), strings.Join(weights, ", "))
+	}
+	if appConfig.ActiveSubset != "" {
+		fmt.Printf(tr("   Subset:           %s\n"), appConfig.ActiveSubset)
+	}
+}
+
+// handleReport implements the 'report' command
+func handleReport(args []string) {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	var markdown bool
+	var verbose bool
+	fs.BoolVar(&markdown, "markdown", false, "Output in markdown format")
+	fs.BoolVar(&markdown, "md", false, "Output in markdown format")
+	fs.BoolVar(&verbose, "verbose", false, "Show titles and tags (great for workout logs)")
+	fs.BoolVar(&verbose, "v", false, "Show titles and tags (great for workout logs)")
+	var copyReport bool
+	fs.BoolVar(&copyReport, "copy", false, "Copy the markdown report to the clipboard")
+	var post string
+	fs.StringVar(&post, "post", "", "Post the day report to a chat webhook from config.yaml (slack, discord)")
+	var groupBy string
+	fs.StringVar(&groupBy, "group-by", "", "Group completed movos by category, session or hour")
+	var tz string
+	fs.StringVar(&tz, "tz", "", "Show times in this zone (e.g. local, Europe/London) instead of where they were logged")
+	var allProfiles bool
+	fs.BoolVar(&allProfiles, "all-profiles", false, "Combine every profile's day into one household report")
+	var from, to string
+	fs.StringVar(&from, "from", "", "Report on days from this date (YYYY-MM-DD)")
+	fs.StringVar(&to, "to", "", "Report on days up to this date (YYYY-MM-DD, default today)")
+	var byTag bool
+	fs.BoolVar(&byTag, "by-tag", false, "Total movos, minutes and RPE by tag over the period")
+	var comparePeriod, against string
+	fs.StringVar(&comparePeriod, "period", "week", "Period 'report compare' compares: day, week or month")
+	fs.StringVar(&against, "against", "previous", "What 'report compare' compares with (previous)")
+	var trendDays int
+	fs.IntVar(&trendDays, "days", defaultTrendDays, "Days the trend report covers, ending today")
+
+	remaining := parseFlagsInterspersed(fs, args)
+	period := "day"
+	if len(remaining) > 0 {
+		period = remaining[0]
+	}
+
+	if err := validateGrouping(groupBy); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	loc, err := loadReportLocation(tz)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	opts := reportOptions{Verbose: verbose, GroupBy: groupBy, Location: loc}
+
+	rng, err := parseReportRange(from, to, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	if period == "compare" {
+		if rng != nil || markdown || copyReport || post != "" || allProfiles || byTag {
+			fmt.Fprintln(os.Stderr, tr("Error: 'report compare' takes only --period, --against and --json"))
+			os.Exit(exitError)
+		}
+		showComparison(comparePeriod, against)
+		return
+	}
+	if period == "trend" && (markdown || copyReport || post != "" || allProfiles || byTag) {
+		fmt.Fprintln(os.Stderr, tr("Error: the trend report is a terminal chart (use --json for its data)"))
+		os.Exit(exitError)
+	}
+	if period == "skips" && (rng != nil || markdown || copyReport || post != "" || allProfiles || byTag) {
+		fmt.Fprintf(os.Stderr, tr("Error: 'report skips' always covers the last %d days and takes only --json\n"), skipRecoveryDays)
+		os.Exit(exitError)
+	}
+	if byTag {
+		if allProfiles || copyReport || post != "" {
+			fmt.Fprintln(os.Stderr, tr("Error: --by-tag can't be combined with --all-profiles, --copy or --post"))
+			os.Exit(exitError)
+		}
+		showTagReport(period, markdown, rng)
+		return
+	}
+	if rng != nil {
+		if allProfiles || copyReport || post != "" || period == "stickers" {
+			fmt.Fprintln(os.Stderr, tr("Error: --from/--to work with the day, week, month and profiles reports"))
+			os.Exit(exitError)
+		}
+		showRangeReport(period, markdown, verbose, *rng)
+		return
+	}
+
+	if outputJSON && (markdown || copyReport || post != "" || period == "stickers") {
+		fmt.Fprintln(os.Stderr, tr("Error: JSON output isn't available for markdown, --copy, --post or sticker reports"))
+		os.Exit(exitError)
+	}
+
+	if post != "" {
+		if (period != "day" && period != "today") || allProfiles || copyReport {
+			fmt.Fprintln(os.Stderr, tr("Error: --post only supports the day report"))
+			os.Exit(exitError)
+		}
+		postDayReport(post, opts)
+		return
+	}
+
+	if allProfiles {
+		if (period != "day" && period != "today") || markdown || copyReport {
+			fmt.Fprintln(os.Stderr, tr("Error: --all-profiles only supports the plain day report"))
+			os.Exit(exitError)
+		}
+		showHouseholdReport()
+		return
+	}
+
+	switch period {
+	case "day", "today":
+		if copyReport {
+			copyDayReport(opts)
+		} else if markdown {
+			showDayReportMarkdown(opts)
+		} else {
+			showDayReport(opts)
+		}
+	case "profiles":
+		showProfileReport(nil)
+	case "stickers":
+		showStickerChart()
+	case "week":
+		if appConfig.KidMode && !outputJSON {
+			showStickerChart()
+			return
+		}
+		showWeekReport(markdown, verbose)
+	case "month":
+		showMonthReport(markdown)
+	case "skips":
+		showSkipReport()
+	case "trend":
+		if trendDays <= 0 {
+			fmt.Fprintln(os.Stderr, tr("Error: --days must be positive"))
+			os.Exit(exitError)
+		}
+		showTrendReport(trendRange(trendDays, time.Now()))
+	default:
+		fmt.Fprintf(os.Stderr, tr("Unknown report period: %s (use: day, week, month, trend, compare, skips, profiles, stickers)\n"), period)
+		os.Exit(exitError)
+	}
+}
+
+// showRangeReport renders a report over --from/--to: the per-day table for day and
+// week, the rollups for month, or the profile comparison
+func showRangeReport(period string, markdown, verbose bool, rng reportRange) {
+	switch period {
+	case "day", "today", "week":
+		entries := loadRangeHistory(rng)
+		report := buildPeriodReport(entries, rng.From, rng.days())
+		rest, _ := loadRestSchedule(appConfig)
+		report.markRestDays(rest)
+		report.Targets = targetCoverage(entries, reportMovoMap())
+		if outputJSON {
+			writeJSON(os.Stdout, report)
+		} else if markdown {
+			writePeriodReportMarkdown(os.Stdout, "Movodoro Report - "+rng.String(), report)
+			if verbose {
+				fmt.Println()
+				writeCompletedTableMarkdown(os.Stdout, entries, reportMovoMap())
+			}
+		} else {
+			writePeriodReport(os.Stdout, tr("MOVODORO REPORT"), rng.String(), report)
+		}
+	case "month":
+		entries := loadRangeHistory(rng)
+		report := buildMonthReport(entries, rng.From, rng.days(), reportMovoMap())
+		if outputJSON {
+			writeJSON(os.Stdout, report)
+		} else if markdown {
+			writeMonthReportMarkdown(os.Stdout, "Movodoro Report - "+rng.String(), report)
+		} else {
+			writeMonthReport(os.Stdout, tr("MOVODORO REPORT"), rng.String(), report)
+		}
+	case "profiles":
+		showProfileReport(&rng)
+	case "trend":
+		showTrendReport(rng)
+	default:
+		fmt.Fprintf(os.Stderr, tr("Unknown report period for a date range: %s (use: day, week, month, trend, profiles)\n"), period)
+		os.Exit(exitError)
+	}
+}
+
+// showTagReport renders the by-tag totals for a day, week or month report, or
+// over --from/--to
+func showTagReport(period string, markdown bool, rng *reportRange) {
+	var days reportRange
+	var heading string
+	if rng != nil {
+		days, heading = *rng, rng.String()
+	} else {
+		var err error
+		if days, err = periodRange(period, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
+		}
+		switch period {
+		case "week":
+			heading = trf("Week of %s", appConfig.FormatDate(days.From))
+		case "month":
+			heading = localizeDate(days.From.Format("January 2006"))
+		default:
+			heading = appConfig.FormatDate(days.From)
+		}
+	}
+
+	report := buildTagReport(loadRangeHistory(days), reportMovoMap(), days)
+	if outputJSON {
+		writeJSON(os.Stdout, report)
+	} else if markdown {
+		writeTagReportMarkdown(os.Stdout, heading, report)
+	} else {
+		writeTagReport(os.Stdout, heading, report)
+	}
+}
+
+// showComparison compares the current day, week or month so far with the same days
+// of the previous one
+func showComparison(period, against string) {
+	if against != "previous" {
+		fmt.Fprintf(os.Stderr, tr("Error: unknown --against %q (use: previous)\n"), against)
+		os.Exit(exitError)
+	}
+	current, previous, err := compareRanges(period, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	entries := loadRangeHistory(reportRange{From: previous.From, To: current.To})
+	comparison := buildComparison(period, entries, current, previous, reportMovoMap())
+	if outputJSON {
+		writeJSON(os.Stdout, comparison)
+		return
+	}
+	writeComparison(os.Stdout, comparison)
+}
+
+// showSkipReport lists recently skipped movos and the weight they've lost
+func showSkipReport() {
+	now := time.Now()
+	skips, err := loadRecentSkips(appConfig.LogsDir, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	summaries := summarizeSkips(skips, reportMovoMap(), now)
+	if outputJSON {
+		writeJSON(os.Stdout, summaries)
+		return
+	}
+	writeSkipReport(os.Stdout, summaries)
+}
+
+// showTrendReport charts daily minutes and RPE over rng
+func showTrendReport(rng reportRange) {
+	report := buildPeriodReport(loadRangeHistory(rng), rng.From, rng.days())
+	if outputJSON {
+		writeJSON(os.Stdout, report)
+		return
+	}
+	writeTrend(os.Stdout, rng.String(), report, appConfig.MaxDailyRPE, useColor())
+}
+
+// handleHeatmap implements the 'heatmap' command: a calendar of a year's activity
+func handleHeatmap(args []string) {
+	now := time.Now()
+	fs := flag.NewFlagSet("heatmap", flag.ContinueOnError)
+	var year int
+	var metric string
+	fs.IntVar(&year, "year", now.Year(), "Year to draw")
+	fs.StringVar(&metric, "by", "minutes", "Shade days by minutes or movos")
+	parseFlags(fs, args)
+
+	if !slices.Contains(heatmapMetrics, metric) {
+		fmt.Fprintf(os.Stderr, tr("Error: unknown --by %q (use: %s)\n"), metric, strings.Join(heatmapMetrics, ", "))
+		os.Exit(exitError)
+	}
+	rng := reportRange{
+		From: time.Date(year, 1, 1, 0, 0, 0, 0, now.Location()),
+		To:   time.Date(year, 12, 31, 0, 0, 0, 0, now.Location()),
+	}
+	if rng.From.After(now) {
+		fmt.Fprintf(os.Stderr, tr("Error: %d hasn't started yet\n"), year)
+		os.Exit(exitError)
+	}
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()); rng.To.After(today) {
+		rng.To = today
+	}
+
+	report := buildPeriodReport(loadRangeHistory(rng), rng.From, rng.days())
+	if outputJSON {
+		writeJSON(os.Stdout, report)
+		return
+	}
+	writeHeatmap(os.Stdout, strconv.Itoa(year), report, metric, appConfig.WeekStart, useColor())
+}
+
+// loadRangeHistory loads the entries logged within rng, exiting on error
+func loadRangeHistory(rng reportRange) []HistoryEntry {
+	entries, err := LoadHistoryRange(appConfig.LogsDir, rng.From, rng.To)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	return entries
+}
+
+// loadWeekReport totals the week starting at weekStart
+func loadWeekReport(weekStart time.Time) (periodReport, error) {
+	entries, err := LoadHistoryRange(appConfig.LogsDir, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return periodReport{}, err
+	}
+	report := buildPeriodReport(entries, weekStart, 7)
+	report.Targets = targetCoverage(entries, reportMovoMap())
+	rest, err := loadRestSchedule(appConfig)
+	report.markRestDays(rest)
+	return report, err
+}
+
+// loadMonthReport totals the calendar month starting at monthStart
+func loadMonthReport(monthStart time.Time) (monthReport, error) {
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	entries, err := LoadHistoryRange(appConfig.LogsDir, monthStart, monthEnd)
+	if err != nil {
+		return monthReport{}, err
+	}
+	return buildMonthReport(entries, monthStart, monthEnd.Day(), reportMovoMap()), nil
+}
+
+// reportMovoMap indexes the movo library by code for titles. A library that fails to
+// load gives an empty map, leaving reports to each entry's snapshot.
+func reportMovoMap() map[string]*Movo {
+	movoMap := make(map[string]*Movo)
+	if movos, err := LoadSnacks(); err == nil {
+		for i := range movos {
+			movoMap[movos[i].FullCode] = &movos[i]
+		}
+	}
+	return movoMap
+}
+
+// showProfileReport compares outcomes between config profiles across all history,
+// or only the days in rng
+func showProfileReport(rng *reportRange) {
+	var history []HistoryEntry
+	var err error
+	if rng != nil {
+		history, err = LoadHistoryRange(appConfig.LogsDir, rng.From, rng.To)
+	} else {
+		history, err = LoadAllHistory(appConfig.LogsDir)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	if outputJSON {
+		outcomes := compareProfiles(history, appConfig.SkipPolicy)
+		if outcomes == nil {
+			outcomes = []profileOutcome{}
+		}
+		writeJSON(os.Stdout, outcomes)
+		return
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println(tr("  CONFIG PROFILE COMPARISON"))
+	if rng != nil {
+		fmt.Printf("  %s\n", rng)
+	}
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+
+	outcomes := compareProfiles(history, appConfig.SkipPolicy)
+	if len(outcomes) == 0 {
+		fmt.Println(tr("No history yet."))
+		return
+	}
+
+	fmt.Printf("%-16s %5s %9s %8s %8s %6s\n", tr("Profile"), tr("Days"), tr("Done/day"), tr("Min/day"), tr("RPE/day"), tr("Skips"))
+	for _, o := range outcomes {
+		fmt.Printf("%-16s %5d %9.1f %8.1f %8.1f %5.0f%%\n",
+			o.Profile,
+			o.Days,
+			o.perDay(o.Done),
+			o.perDay(o.Minutes),
+			o.perDay(o.RPE),
+			o.skipRate()*100)
+	}
+	fmt.Println()
+	if appConfig.SkipPolicy == skipExcuse {
+		fmt.Println(tr("Days count any day with an entry logged under the profile (skip_policy: excuse)."))
+	} else {
+		fmt.Println(tr("Days count days with a done or partial movo logged under the profile."))
+	}
+}
+
+// showHouseholdReport combines today's completions for every config profile
+func showHouseholdReport() {
+	members, err := householdMembers()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading config: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days, err := householdDay(members, today)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	if outputJSON {
+		writeJSON(os.Stdout, newHouseholdJSON(days))
+		return
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Printf("  %s\n", tr("HOUSEHOLD MOVODORO REPORT"))
+	fmt.Printf("  %s\n", appConfig.FormatDate(today))
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+
+	var together profileOutcome
+	for _, day := range days {
+		together.Done += day.Totals.Done
+		together.Partial += day.Totals.Partial
+		together.Minutes += day.Totals.Minutes
+	}
+	fmt.Printf(tr("👪 Together: %d movos, %d minutes\n\n"), together.Done+together.Partial, together.Minutes)
+
+	for _, day := range days {
+		t := day.Totals
+		if day.Member.KidMode {
+			// Kids see stickers rather than RPE
+			var row []string
+			for _, entry := range day.Entries {
+				if entry.Status == "done" || entry.Status == "partial" {
+					row = append(row, stickerFor(entry.Code))
+				}
+			}
+			fmt.Printf(tr("  %-12s %d movos, %dm"), day.Member.name(), t.Done+t.Partial, t.Minutes)
+			if len(row) > 0 {
+				fmt.Printf("  %s", strings.Join(row, " "))
+			}
+			fmt.Println()
+			continue
+		}
+		fmt.Printf(tr("  %-12s %d movos, %dm, RPE %d"), day.Member.name(), t.Done+t.Partial, t.Minutes, t.RPE)
+		if t.Skipped > 0 {
+			fmt.Printf(tr(" (%d skipped)"), t.Skipped)
+		}
+		fmt.Println()
+	}
+}
+
+// showStickerChart prints this week's sticker chart (kid mode's weekly report)
+func showStickerChart() {
+	weekStart := appConfig.WeekStartDate(time.Now())
+	entries, err := LoadHistoryRange(appConfig.LogsDir, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	fmt.Printf(tr("🌟 STICKER CHART - week of %s\n\n"), appConfig.FormatDate(weekStart))
+	fmt.Print(stickerChart(entries, weekStart))
+}
+
+// showWeekReport prints per-day and total movos, minutes and RPE for this week.
+// Verbose markdown adds a table of every completed movo.
+func showWeekReport(markdown, verbose bool) {
+	weekStart := appConfig.WeekStartDate(time.Now())
+	report, err := loadWeekReport(weekStart)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	heading := trf("Week of %s", appConfig.FormatDate(weekStart))
+	if outputJSON {
+		writeJSON(os.Stdout, report)
+	} else if markdown {
+		writePeriodReportMarkdown(os.Stdout, "Movodoro Week Report - "+heading, report)
+		if verbose {
+			fmt.Println()
+			writeCompletedTableMarkdown(os.Stdout, loadRangeHistory(reportRange{From: weekStart, To: weekStart.AddDate(0, 0, 6)}), reportMovoMap())
+		}
+	} else {
+		writePeriodReport(os.Stdout, tr("WEEKLY MOVODORO REPORT"), heading, report)
+	}
+}
+
+// showMonthReport prints this calendar month's ISO week totals, categories and most frequent movos
+func showMonthReport(markdown bool) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	report, err := loadMonthReport(monthStart)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading history: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	heading := localizeDate(monthStart.Format("January 2006"))
+	if outputJSON {
+		writeJSON(os.Stdout, report)
+	} else if markdown {
+		writeMonthReportMarkdown(os.Stdout, "Movodoro Month Report - "+heading, report)
+	} else {
+		writeMonthReport(os.Stdout, tr("MONTHLY MOVODORO REPORT"), heading, report)
+	}
+}
+
+// reportOptions controls how reports are rendered
+type reportOptions struct {
+	Verbose bool   // Show titles and tags
+	GroupBy string // "", category, session or hour
+	// Zone to show times in; nil keeps each entry's original logged offset
+	Location *time.Location
+}
+
+func showDayReport(opts reportOptions) {
+	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading stats: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	stats.In(opts.Location)
+
+	if outputJSON {
+		writeJSON(os.Stdout, newDayReportJSON(stats, reportMovoMap()))
+		return
+	}
+
+	// Load snacks for verbose mode
+	var movoMap map[string]*Movo
+	if opts.Verbose {
+		snacks, err := LoadSnacks()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+			os.Exit(exitLibrary)
+		}
+		movoMap = make(map[string]*Movo)
+		for i := range snacks {
+			movoMap[snacks[i].FullCode] = &snacks[i]
+		}
+	}
+
+	// Detect active subset(s) from entries
+	subsetMap := make(map[string]bool)
+	for _, entry := range stats.CompletedSnacks {
+		if entry.Subset != "" {
+			subsetMap[entry.Subset] = true
+		}
+	}
+	for _, entry := range stats.PartialSnacks {
+		if entry.Subset != "" {
+			subsetMap[entry.Subset] = true
+		}
+	}
+	for _, entry := range stats.SkippedSnacks {
+		if entry.Subset != "" {
+			subsetMap[entry.Subset] = true
+		}
+	}
+
+	var subsets []string
+	for subset := range subsetMap {
+		subsets = append(subsets, subset)
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Printf("  %s\n", tr("TODAY'S MOVODORO REPORT"))
+	fmt.Printf("  %s\n", appConfig.FormatDate(stats.Date))
+	if isRestDay(appConfig, stats.Date) {
+		fmt.Println("  " + tr("🛌 Rest day"))
+	}
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+
+	if len(subsets) > 0 {
+		fmt.Println(tr("Active subset(s):"))
+		for _, subset := range subsets {
+			fmt.Printf("  - %s\n", subset)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println(tr("📊 Summary:"))
+	fmt.Printf(tr("   Total movos:     %d\n"), len(stats.CompletedSnacks))
+	fmt.Printf(tr("   Total duration:  %d minutes\n"), stats.TotalDuration)
+	fmt.Printf(tr("   Total RPE:       %d / %d\n"), stats.TotalRPE, appConfig.MaxDailyRPE)
+	fmt.Printf(tr("   RPE budget:      %s\n"), rpeBar(stats.TotalRPE, appConfig.MaxDailyRPE, useColor()))
+	fmt.Println()
+
+	if len(stats.CompletedSnacks) > 0 {
+		fmt.Println(tr("✅ Completed:"))
+		for _, group := range groupEntries(stats.CompletedSnacks, opts.GroupBy) {
+			if group.Label != "" {
+				duration, rpe := group.totals()
+				fmt.Printf(tr("  ▸ %s: %d movos, %dm, RPE %d\n"), group.Label, len(group.Entries), duration, rpe)
+			}
+			for _, entry := range group.Entries {
+				detailStr := entryDetails(entry)
+				if entry.Subset != "" {
+					detailStr += ", " + entry.Subset
+				}
+
+				if opts.Verbose {
+					movo := entryMovo(entry, movoMap)
+					if movo != nil {
+						detailStr += deprecationNote(movo)
+						tagsStr := formatMovoTags(movo)
+						fmt.Printf("   %s - %s [%s] (%dm, RPE %d%s)%s\n",
+							appConfig.FormatClock(entry.Timestamp),
+							movo.Title,
+							entry.Code,
+							entry.Duration,
+							entry.RPE,
+							detailStr,
+							tagsStr)
+					} else {
+						// Fallback if snack not found
+						fmt.Printf("   %s - %s (%dm, RPE %d%s)\n",
+							appConfig.FormatClock(entry.Timestamp),
+							entry.Code,
+							entry.Duration,
+							entry.RPE,
+							detailStr)
+					}
+				} else {
+					fmt.Printf("   %s - %s (%dm, RPE %d%s)\n",
+						appConfig.FormatClock(entry.Timestamp),
+						entry.Code,
+						entry.Duration,
+						entry.RPE,
+						detailStr)
+				}
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(stats.PartialSnacks) > 0 {
+		fmt.Println(tr("◐ Partial:"))
+		for _, entry := range stats.PartialSnacks {
+			name := entry.Code
+			if movo := entryMovo(entry, movoMap); opts.Verbose && movo != nil {
+				name = fmt.Sprintf("%s [%s]", movo.Title, entry.Code)
+			}
+			fmt.Printf("   %s - %s (%dm, RPE %d%s)\n",
+				appConfig.FormatClock(entry.Timestamp),
+				name,
+				entry.Duration,
+				entry.RPE,
+				entryDetails(entry))
+		}
+		fmt.Println()
+	}
+
+	if len(stats.SkippedSnacks) > 0 {
+		fmt.Println(tr("⏭️  Skipped:"))
+		for _, entry := range stats.SkippedSnacks {
+			if opts.Verbose {
+				movo := entryMovo(entry, movoMap)
+				if movo != nil {
+					fmt.Printf("   %s - %s [%s]\n",
+						appConfig.FormatClock(entry.Timestamp),
+						movo.Title,
+						entry.Code)
+				} else {
+					fmt.Printf("   %s - %s\n",
+						appConfig.FormatClock(entry.Timestamp),
+						entry.Code)
+				}
+			} else {
+				fmt.Printf("   %s - %s\n",
+					appConfig.FormatClock(entry.Timestamp),
+					entry.Code)
+			}
+		}
+		fmt.Println()
+	}
+
+	if stats.TotalRPE >= appConfig.MaxDailyRPE {
+		fmt.Println(tr("🔋 Auto-recovery mode active (RPE limit reached)"))
+	}
+}
+
+func showDayReportMarkdown(opts reportOptions) {
+	if err := writeDayReportMarkdown(os.Stdout, opts); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+}
+
+// copyDayReport places today's markdown report on the system clipboard
+func copyDayReport(opts reportOptions) {
+	var buf bytes.Buffer
+	if err := writeDayReportMarkdown(&buf, opts); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+
+	if err := copyToClipboard(buf.String()); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error copying report: %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	fmt.Println(tr("📋 Copied markdown report to clipboard"))
+}
+
+// postDayReport sends today's markdown report to a chat service's incoming webhook
+func postDayReport(service string, opts reportOptions) {
+	url, err := appConfig.Post.webhook(service)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+
+	var buf bytes.Buffer
+	if err := writeDayReportMarkdown(&buf, opts); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if err := postJSON(client, url, chatMessage(service, buf.String())); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error posting report: %v\n"), err)
+		os.Exit(exitError)
+	}
+	fmt.Printf(tr("📣 Posted today's report to %s\n"), service)
+}
+
+// writeDayReportMarkdown renders today's report in markdown format to w
+func writeDayReportMarkdown(w io.Writer, opts reportOptions) error {
+	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		return withExitCode(exitStorage, fmt.Errorf("error loading stats: %w", err))
+	}
+	stats.In(opts.Location)
+
+	// Load snacks for verbose mode
+	var movoMap map[string]*Movo
+	if opts.Verbose {
+		snacks, err := LoadSnacks()
+		if err != nil {
+			return withExitCode(exitLibrary, fmt.Errorf("error loading snacks: %w", err))
+		}
+		movoMap = make(map[string]*Movo)
 		for i := range snacks {
 			movoMap[snacks[i].FullCode] = &snacks[i]
 		}
 	}
 
-	// Detect active subset(s) from entries
-	subsetMap := make(map[string]bool)
-	for _, entry := range stats.CompletedSnacks {
-		if entry.Subset != "" {
-			subsetMap[entry.Subset] = true
-		}
+	// Detect active subset(s) from entries
+	subsetMap := make(map[string]bool)
+	for _, entry := range stats.CompletedSnacks {
+		if entry.Subset != "" {
+			subsetMap[entry.Subset] = true
+		}
+	}
+	for _, entry := range stats.PartialSnacks {
+		if entry.Subset != "" {
+			subsetMap[entry.Subset] = true
+		}
+	}
+	for _, entry := range stats.SkippedSnacks {
+		if entry.Subset != "" {
+			subsetMap[entry.Subset] = true
+		}
+	}
+
+	var subsets []string
+	for subset := range subsetMap {
+		subsets = append(subsets, subset)
+	}
+
+	fmt.Fprintf(w, "# Movodoro Report - %s\n\n", appConfig.FormatDate(stats.Date))
+	if isRestDay(appConfig, stats.Date) {
+		fmt.Fprintln(w, "🛌 *Rest day*")
+		fmt.Fprintln(w)
+	}
+
+	if len(subsets) > 0 {
+		fmt.Fprintln(w, "**Active subset(s):**")
+		fmt.Fprintln(w)
+		for _, subset := range subsets {
+			fmt.Fprintf(w, "- %s\n", subset)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "## Summary")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Total movos:** %d\n", len(stats.CompletedSnacks))
+	fmt.Fprintf(w, "- **Total duration:** %d minutes\n", stats.TotalDuration)
+	fmt.Fprintf(w, "- **Total RPE:** %d / %d\n", stats.TotalRPE, appConfig.MaxDailyRPE)
+	fmt.Fprintln(w)
+
+	if len(stats.CompletedSnacks) > 0 {
+		fmt.Fprintln(w, "## Completed")
+		fmt.Fprintln(w)
+		for i, group := range groupEntries(stats.CompletedSnacks, opts.GroupBy) {
+			if group.Label != "" {
+				if i > 0 {
+					fmt.Fprintln(w)
+				}
+				duration, rpe := group.totals()
+				fmt.Fprintf(w, "### %s\n\n_%d movos, %d min, RPE %d_\n\n", group.Label, len(group.Entries), duration, rpe)
+			}
+			for _, entry := range group.Entries {
+				detailStr := entryDetails(entry)
+				if entry.Subset != "" {
+					detailStr += ", " + entry.Subset
+				}
+
+				if opts.Verbose {
+					movo := entryMovo(entry, movoMap)
+					if movo != nil {
+						detailStr += deprecationNote(movo)
+						tagsStr := formatMovoTags(movo)
+						fmt.Fprintf(w, "- **%s** - %s [`%s`] (%d min, RPE %d%s)%s\n",
+							appConfig.FormatClock(entry.Timestamp),
+							movo.Title,
+							entry.Code,
+							entry.Duration,
+							entry.RPE,
+							detailStr,
+							tagsStr)
+					} else {
+						// Fallback if snack not found
+						fmt.Fprintf(w, "- **%s** - `%s` (%d min, RPE %d%s)\n",
+							appConfig.FormatClock(entry.Timestamp),
+							entry.Code,
+							entry.Duration,
+							entry.RPE,
+							detailStr)
+					}
+				} else {
+					fmt.Fprintf(w, "- **%s** - `%s` (%d min, RPE %d%s)\n",
+						appConfig.FormatClock(entry.Timestamp),
+						entry.Code,
+						entry.Duration,
+						entry.RPE,
+						detailStr)
+				}
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(stats.PartialSnacks) > 0 {
+		fmt.Fprintln(w, "## Partial")
+		fmt.Fprintln(w)
+		for _, entry := range stats.PartialSnacks {
+			name := fmt.Sprintf("`%s`", entry.Code)
+			if movo := entryMovo(entry, movoMap); opts.Verbose && movo != nil {
+				name = fmt.Sprintf("%s [`%s`]", movo.Title, entry.Code)
+			}
+			fmt.Fprintf(w, "- **%s** - %s (%d min, RPE %d%s)\n",
+				appConfig.FormatClock(entry.Timestamp),
+				name,
+				entry.Duration,
+				entry.RPE,
+				entryDetails(entry))
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(stats.SkippedSnacks) > 0 {
+		fmt.Fprintln(w, "## Skipped")
+		fmt.Fprintln(w)
+		for _, entry := range stats.SkippedSnacks {
+			if opts.Verbose {
+				movo := entryMovo(entry, movoMap)
+				if movo != nil {
+					fmt.Fprintf(w, "- **%s** - %s [`%s`]\n",
+						appConfig.FormatClock(entry.Timestamp),
+						movo.Title,
+						entry.Code)
+				} else {
+					fmt.Fprintf(w, "- **%s** - `%s`\n",
+						appConfig.FormatClock(entry.Timestamp),
+						entry.Code)
+				}
+			} else {
+				fmt.Fprintf(w, "- **%s** - `%s`\n",
+					appConfig.FormatClock(entry.Timestamp),
+					entry.Code)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	if stats.TotalRPE >= appConfig.MaxDailyRPE {
+		fmt.Fprintln(w, "*Auto-recovery mode active (RPE limit reached)*")
+	}
+
+	return nil
+}
+
+// formatMovoTags formats tags for verbose report output
+func formatMovoTags(movo *Movo) string {
+	if len(movo.AllTags) == 0 && movo.MinPerDay == 0 {
+		return ""
+	}
+
+	// Format tags with # prefix
+	tagList := make([]string, len(movo.AllTags))
+	for i, tag := range movo.AllTags {
+		tagList[i] = "#" + tag
+	}
+
+	// Add #daily tag if this is an everyday movo
+	if movo.MinPerDay > 0 {
+		tagList = append(tagList, "#daily")
+	}
+
+	return fmt.Sprintf(" | %s", strings.Join(tagList, ", "))
+}
+
+func displayMovo(movo *Movo) {
+	printMovoCard(movo)
+	printRPEBudget()
+
+	fmt.Println()
+	fmt.Println(tr("When done, run:"))
+	fmt.Printf("  movodoro done\n")
+	fmt.Println(tr("Or skip with:"))
+	fmt.Printf("  movodoro skip\n")
+	fmt.Println()
+}
+
+// appendLogEntry logs an entry for movo to today's history, tagged with the active config
+// profile, whether it was an exploration pick, and a snapshot of the movo's details. If
+// the logs dir is unavailable the entry is queued in the local spool instead.
+// Either way, the on_done or on_skip hook is then called.
+func appendLogEntry(entry HistoryEntry, movo *Movo) error {
+	if err := confirmEntryCap(entry.Timestamp); err != nil {
+		return err
+	}
+	return writeLogEntry(entry, movo, os.Stderr)
+}
+
+// writeLogEntry is appendLogEntry once the daily entry cap is settled, writing the
+// spool notice and hook warnings to warn
+func writeLogEntry(entry HistoryEntry, movo *Movo, warn io.Writer) error {
+	snapshotMovo(&entry, movo)
+	if appConfig.Profile != "" {
+		if entry.Extras == nil {
+			entry.Extras = make(map[string]string)
+		}
+		entry.Extras["profile"] = appConfig.Profile
+	}
+	if currentIsExploration(entry.Code) {
+		if entry.Extras == nil {
+			entry.Extras = make(map[string]string)
+		}
+		entry.Extras[extraExploration] = "1"
+	}
+
+	if err := ensureStateDir(); err != nil {
+		return err
+	}
+
+	// Earlier entries still queued go first; if they can't, neither can this one.
+	// Backdated entries go to their own day's log.
+	_, pending, err := flushSpool(appConfig.SpoolPath, appConfig.LogsDir)
+	if pending == 0 {
+		err = AppendDailyLog(appConfig.LogsDir, entry)
+		if err == nil {
+			runHooks(appConfig.Hooks, entry, movo, warn)
+			return nil
+		}
+	}
+
+	queued, spoolErr := spoolEntry(appConfig.SpoolPath, entry)
+	if spoolErr != nil {
+		return fmt.Errorf("%v (and couldn't queue it locally: %w)", err, spoolErr)
+	}
+	fmt.Fprintf(warn, tr("📥 Logs unavailable (%v); queued locally, %d pending\n"), err, queued)
+	runHooks(appConfig.Hooks, entry, movo, warn)
+	return nil
+}
+
+// overEntryCap reports whether count entries already reach the daily cap (0 means no cap)
+func overEntryCap(count, limit int) bool {
+	return limit > 0 && count >= limit
+}
+
+// confirmEntryCap asks before logging past max_entries_per_day on day. Without a
+// terminal to ask (scripts, batch mode, --quiet) the entry is refused.
+func confirmEntryCap(day time.Time) error {
+	count, which, over := entryCapReached(day)
+	if !over {
+		return nil
+	}
+
+	if quietOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return withExitCode(exitError, fmt.Errorf("already %d entries %s (max_entries_per_day: %d); not logging without confirmation",
+			count, which, appConfig.MaxEntriesPerDay))
+	}
+
+	fmt.Printf(tr("⚠️  Already %d entries %s (max_entries_per_day: %d). Log anyway? [y/N]: "),
+		count, which, appConfig.MaxEntriesPerDay)
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if answer := strings.ToLower(strings.TrimSpace(input)); answer != "y" && answer != "yes" {
+		return withExitCode(exitCancelled, fmt.Errorf("not logged: daily entry cap reached"))
+	}
+	return nil
+}
+
+// entryCapReached reports whether day's log already holds max_entries_per_day entries,
+// with the count and the day as a warning names it ("today" or "on <date>")
+func entryCapReached(day time.Time) (count int, which string, over bool) {
+	entries, err := LoadDailyLog(appConfig.LogsDir, day)
+	if err != nil || !overEntryCap(len(entries), appConfig.MaxEntriesPerDay) {
+		// An unreadable logs dir is left to the spool
+		return len(entries), "", false
+	}
+	which = tr("today")
+	if dayKey(day) != dayKey(time.Now()) {
+		which = trf("on %s", appConfig.FormatDate(day))
+	}
+	return len(entries), which, true
+}
+
+// ensureStateDir creates the local ~/.movodoro dir, which holds the spool and, by
+// default, the logs dir. A logs dir configured elsewhere is only created once its
+// parent is reachable.
+func ensureStateDir() error {
+	return os.MkdirAll(filepath.Dir(appConfig.SpoolPath), 0755)
+}
+
+// deliverSpool flushes queued entries at startup and reports what's still pending
+func deliverSpool() {
+	flushed, pending, err := flushSpool(appConfig.SpoolPath, appConfig.LogsDir)
+	if flushed > 0 {
+		fmt.Fprintf(os.Stderr, tr("📤 Delivered %d queued entries to %s\n"), flushed, appConfig.LogsDir)
+	}
+	if pending > 0 {
+		fmt.Fprintf(os.Stderr, tr("⏳ %d entries queued locally until the logs dir is reachable (%v)\n"), pending, err)
+	}
+}
+
+// saveCurrentSnack saves the current snack code to a file
+func saveCurrentSnack(code string) error {
+	return saveCurrentPick(code, false)
+}
+
+// saveCurrentPick saves the current snack code, with a second line marking an
+// exploration pick so logging it can say so
+func saveCurrentPick(code string, exploration bool) error {
+	data := code
+	if exploration {
+		data += "\n" + extraExploration
+	}
+	if err := os.WriteFile(appConfig.CurrentPath, []byte(data), 0644); err != nil {
+		return err
+	}
+	// Remember it for the anti-repeat window
+	return recordShown(appConfig.RecentPath, code, time.Now())
+}
+
+// loadCurrentSnack loads the current snack code from file
+func loadCurrentSnack() (string, error) {
+	data, err := os.ReadFile(appConfig.CurrentPath)
+	if err != nil {
+		return "", err
+	}
+
+	code, _, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+	return strings.TrimSpace(code), nil
+}
+
+// currentIsExploration reports whether code is the current snack and was an exploration pick
+func currentIsExploration(code string) bool {
+	data, err := os.ReadFile(appConfig.CurrentPath)
+	if err != nil {
+		return false
+	}
+	current, marker, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+	return strings.TrimSpace(current) == code && strings.TrimSpace(marker) == extraExploration
+}
+
+// handleClear implements the 'clear' command
+func handleClear(args []string) {
+	// Get today's stats first
+	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading stats: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	// Show what will be cleared
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println(tr("  CLEAR TODAY'S HISTORY"))
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+
+	if stats.TotalMovos == 0 {
+		fmt.Println(tr("No entries for today to clear."))
+		return
+	}
+
+	fmt.Printf(tr("This will delete today's log file with %d entries:\n"), stats.TotalMovos)
+	fmt.Printf(tr("  - %d completed (%d minutes, %d RPE)\n"),
+		len(stats.CompletedSnacks), stats.TotalDuration, stats.TotalRPE)
+	if len(stats.PartialSnacks) > 0 {
+		fmt.Printf(tr("  - %d partial\n"), len(stats.PartialSnacks))
+	}
+	fmt.Printf(tr("  - %d skipped\n"), len(stats.SkippedSnacks))
+	fmt.Println()
+
+	// Prompt for confirmation
+	fmt.Print(tr("Are you sure you want to clear today's history? (yes/no): "))
+	reader := bufio.NewReader(os.Stdin)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+
+	if input != "yes" && input != "y" {
+		fmt.Println(tr("Cancelled."))
+		os.Exit(exitCancelled)
+	}
+
+	// Delete today's log file
+	if err := ClearTodayLog(appConfig.LogsDir); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error clearing today's log: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	fmt.Printf(tr("✅ Cleared %d entries from today's history\n"), stats.TotalMovos)
+}
+
+// handleConfig implements the 'config' command
+func handleConfig(args []string) {
+	cfg := appConfig
+
+	if outputJSON {
+		writeJSON(os.Stdout, newConfigJSON(cfg))
+		return
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println(tr("  MOVODORO CONFIGURATION"))
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+	fmt.Printf(tr("Movos directory:  %s\n"), cfg.MovosDir)
+	fmt.Printf(tr("Logs directory:   %s\n"), cfg.LogsDir)
+	fmt.Printf(tr("Current file:     %s\n"), cfg.CurrentPath)
+	if cfg.Profile != "" {
+		fmt.Printf(tr("Config profile:   %s\n"), cfg.Profile)
+	}
+	if cfg.Program != nil {
+		fmt.Printf(tr("Program:          %s (%s)\n"), cfg.Program.Program, cfg.Program.summary())
+	}
+	fmt.Printf(tr("Max daily RPE:    %d\n"), cfg.MaxDailyRPE)
+	if cfg.ActiveSubset != "" {
+		fmt.Printf(tr("Active subset:    %s\n"), cfg.ActiveSubset)
+	}
+	fmt.Printf(tr("Week starts on:   %s\n"), cfg.WeekStart)
+	if len(cfg.Avoid) > 0 {
+		fmt.Printf(tr("Avoiding:         %s\n"), strings.Join(cfg.Avoid, ", "))
+	}
+	if cfg.LimitEquipment {
+		equipment := strings.Join(cfg.Equipment, ", ")
+		if equipment == "" {
+			equipment = noEquipment
+		}
+		fmt.Printf(tr("Equipment:        %s\n"), equipment)
+	}
+	if cfg.GraceUntil > 0 {
+		fmt.Printf(tr("Streak grace:     until %02d:%02d\n"), int(cfg.GraceUntil.Hours()), int(cfg.GraceUntil.Minutes())%60)
+	}
+	if cfg.EverydayQueue {
+		fmt.Print(tr("Everyday queue:   on\n"))
+	}
+	fmt.Printf(tr("Skip policy:      %s\n"), cfg.SkipPolicy)
+	fmt.Printf(tr("Config file:      %s\n"), cfg.ConfigPath)
+	if cfg.EODDir != "" {
+		fmt.Printf(tr("EOD summaries:    %s\n"), cfg.EODDir)
+	}
+	if cfg.EODAt > 0 {
+		fmt.Printf(tr("EOD in watch:     at %02d:%02d\n"), int(cfg.EODAt.Hours()), int(cfg.EODAt.Minutes())%60)
+	}
+	if cfg.EODEmail != "" {
+		fmt.Printf(tr("EOD email:        %s\n"), cfg.EODEmail)
+	}
+	if queued, err := loadSpool(cfg.SpoolPath); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	} else if len(queued) > 0 {
+		fmt.Printf(tr("Queued entries:   %d (waiting for the logs dir, in %s)\n"), len(queued), cfg.SpoolPath)
+	}
+	if cfg.ConfigErr != nil {
+		fmt.Printf("⚠️  %v\n", cfg.ConfigErr)
+	}
+	fmt.Println()
+
+	// Check if movos directory exists
+	if _, err := os.Stat(cfg.MovosDir); os.IsNotExist(err) {
+		fmt.Printf(tr("⚠️  Movos directory does not exist: %s\n"), cfg.MovosDir)
+		fmt.Println()
+		fmt.Println(tr("To set a custom movos directory, use:"))
+		fmt.Println("  export MOVODORO_MOVOS_DIR=/path/to/your/movos")
+	} else {
+		// Count snacks
+		snacks, err := LoadSnacks()
+		if err != nil {
+			fmt.Printf(tr("⚠️  Error loading snacks: %v\n"), err)
+		} else {
+			fmt.Printf(tr("✅ Found %d movement snacks\n"), len(snacks))
+			showOverrides(cfg, snacks)
+		}
+	}
+	fmt.Println()
+}
+
+// showOverrides notes how many movos overrides.yaml personalizes, and any codes
+// in it the library no longer has
+func showOverrides(cfg *Config, snacks []Movo) {
+	overrides, err := loadOverrides(cfg.OverridesPath)
+	if err != nil || len(overrides) == 0 {
+		return
+	}
+	unknown := unknownOverrides(snacks, overrides)
+	fmt.Printf(tr("✏️  %d movo(s) overridden in %s\n"), len(overrides)-len(unknown), cfg.OverridesPath)
+	for _, code := range unknown {
+		fmt.Printf(tr("⚠️  overrides.yaml: no movo '%s'\n"), code)
+	}
+}
+
+// handleEveryday implements the 'everyday' command
+func handleEveryday(args []string) {
+	status, err := loadEverydayStatus(appConfig, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
 	}
-	for _, entry := range stats.SkippedSnacks {
-		if entry.Subset != "" {
-			subsetMap[entry.Subset] = true
-		}
+
+	if outputJSON {
+		writeJSON(os.Stdout, status)
+		return
 	}
 
-	var subsets []string
-	for subset := range subsetMap {
-		subsets = append(subsets, subset)
+	if len(status.Movos) == 0 && status.Excluded == 0 {
+		fmt.Println(tr("No movos with min_per_day requirement"))
+		return
+	}
+
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println(tr("  EVERY DAY MOVOS"))
+	if status.Subset != "" {
+		fmt.Printf(tr("  (Subset: %s)\n"), status.Subset)
+	}
+	if status.RestDay {
+		fmt.Println(tr("  🛌 Rest day"))
 	}
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+
+	// Display each everyday snack
+	for _, item := range status.Movos {
+		mark := "❌"
+		if item.Complete {
+			mark = "✅"
+		} else if item.Waived {
+			mark = "🛌"
+		}
 
-	fmt.Printf("# Movodoro Report - %s\n\n", stats.Date.Format("Monday, January 2, 2006"))
+		fmt.Printf("%s %s\n", mark, item.Title)
+		fmt.Printf(tr("   Code: %s | RPE: %d | Duration: %d-%d min\n"),
+			item.Code, item.RPE, item.DurationMin, item.DurationMax)
 
-	if len(subsets) > 0 {
-		fmt.Println("**Active subset(s):**")
-		fmt.Println()
-		for _, subset := range subsets {
-			fmt.Printf("- %s\n", subset)
+		if item.Waived && !item.Complete {
+			fmt.Printf(tr("   Waived for today's rest day (%d of %d today)\n"), item.DoneToday, item.MinPerDay)
+		} else if item.DoneToday > 0 {
+			fmt.Printf(tr("   Completed %d of %d today\n"), item.DoneToday, item.MinPerDay)
+		} else {
+			fmt.Printf(tr("   Not yet done (0 of %d today)\n"), item.MinPerDay)
+		}
+
+		if item.Streak > 0 {
+			fmt.Printf(tr("   🔥 Streak: %d day(s)\n"), item.Streak)
 		}
 		fmt.Println()
 	}
 
-	fmt.Println("## Summary")
+	if status.Excluded > 0 {
+		fmt.Printf(tr("⚠️  %d everyday movos excluded by active subset\n"), status.Excluded)
+		fmt.Println()
+	}
+
+	fmt.Printf(tr("Summary: %d/%d everyday movos completed"), status.Completed, status.Total)
+	if status.Subset != "" {
+		fmt.Print(tr(" (in subset)"))
+	}
 	fmt.Println()
-	fmt.Printf("- **Total movos:** %d\n", len(stats.CompletedSnacks))
-	fmt.Printf("- **Total duration:** %d minutes\n", stats.TotalDuration)
-	fmt.Printf("- **Total RPE:** %d / %d\n", stats.TotalRPE, maxDailyRPEDefault)
+}
+
+// handleWeekly implements the 'weekly' command
+func handleWeekly(args []string) {
+	status, err := loadWeeklyStatus(appConfig, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+
+	if outputJSON {
+		writeJSON(os.Stdout, status)
+		return
+	}
+
+	if len(status.Movos) == 0 {
+		fmt.Println(tr("No movos with min_per_week or max_per_week"))
+		return
+	}
+
+	weekStart := appConfig.WeekStartDate(time.Now())
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println(tr("  WEEKLY MOVOS"))
+	fmt.Printf(tr("  (Week of %s)\n"), appConfig.FormatDate(weekStart))
+	fmt.Println("═══════════════════════════════════════")
 	fmt.Println()
 
-	if len(stats.CompletedSnacks) > 0 {
-		fmt.Println("## Completed")
+	for _, item := range status.Movos {
+		mark := "•"
+		switch {
+		case item.Capped:
+			mark = "🔒"
+		case item.Complete:
+			mark = "✅"
+		case item.MinPerWeek > 0:
+			mark = "❌"
+		}
+
+		fmt.Printf("%s %s\n", mark, item.Title)
+		fmt.Printf(tr("   Code: %s | RPE: %d | Duration: %d-%d min\n"),
+			item.Code, item.RPE, item.DurationMin, item.DurationMax)
+		if item.MinPerWeek > 0 {
+			fmt.Printf(tr("   Completed %d of %d this week\n"), item.DoneThisWeek, item.MinPerWeek)
+		}
+		if item.MaxPerWeek > 0 {
+			fmt.Printf(tr("   Weekly limit: %d of %d used\n"), item.DoneThisWeek, item.MaxPerWeek)
+		}
 		fmt.Println()
-		for _, entry := range stats.CompletedSnacks {
-			subsetStr := ""
-			if entry.Subset != "" {
-				subsetStr = ", " + entry.Subset
+	}
+
+	if status.Total > 0 {
+		fmt.Printf(tr("Summary: %d/%d weekly movos completed\n"), status.Completed, status.Total)
+	}
+}
+
+// handleInteractive implements the interactive mode (default when running `movodoro`):
+// full screen on a terminal, line by line otherwise
+func handleInteractive(args []string) {
+	// Parse flags for interactive mode
+	fs := flag.NewFlagSet("interactive", flag.ContinueOnError)
+	var subset string
+	fs.StringVar(&subset, "subset", "", "Use a named subset from subsets.yaml")
+	parseFlags(fs, args)
+
+	// Determine active subset: command flag takes precedence over env var
+	activeSubset := subset
+	if activeSubset == "" {
+		activeSubset = appConfig.ActiveSubset
+	}
+
+	fullScreen := term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+	if !fullScreen && activeSubset != "" {
+		fmt.Printf(tr("🎯 Using subset: %s\n\n"), activeSubset)
+	}
+
+	// The TUI shows session notices in its status line instead
+	var notices bytes.Buffer
+	out, warn := io.Writer(os.Stdout), io.Writer(os.Stderr)
+	if fullScreen {
+		out, warn = &notices, &notices
+	}
+	session, err := newInteractiveSession(activeSubset, out, warn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+
+	if !fullScreen {
+		runInteractiveLines(session)
+		return
+	}
+
+	result, err := runInteractiveTUI(session, activeSubset, notices.String())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+	if result.done == nil {
+		fmt.Println(tr("\n👋 Saved for later. Run 'movodoro' to resume."))
+		return
+	}
+	logDoneInteractive(result.movo, *result.done)
+	os.Remove(appConfig.CurrentPath) // Clear saved snack
+}
+
+// runInteractiveLines is interactive mode without a full-screen terminal: each movo is
+// printed with a menu, read one key (or line) at a time, until one is done or the user quits
+func runInteractiveLines(session *interactiveSession) {
+	for {
+		snack, err := session.next(os.Stdout, os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitCodeOf(err, exitError))
+		}
+
+		// Display the movo
+		displayMovoInteractive(snack)
+
+		// Get user choice; a timer run or the details come back to the menu, with the
+		// timer's minutes kept
+		hasMinimum := snack.MinPerDay > 0
+		hasAlternatives := len(session.alternatives(snack)) > 0
+		choice := getInteractiveChoice(hasMinimum, hasAlternatives, session.canGoBack())
+		timed := 0
+		for choice == "t" || choice == "i" {
+			if choice == "i" {
+				printMovoInfo(snack, session.snacks())
+			} else {
+				timed = timeMovo(snack)
+				fmt.Println()
 			}
+			choice = getInteractiveChoice(hasMinimum, hasAlternatives, session.canGoBack())
+		}
 
-			if verbose {
-				movo := movoMap[entry.Code]
-				if movo != nil {
-					tagsStr := formatMovoTags(movo)
-					fmt.Printf("- **%s** - %s [`%s`] (%d min, RPE %d%s)%s\n",
-						entry.Timestamp.Format("15:04"),
-						movo.Title,
-						entry.Code,
-						entry.Duration,
-						entry.RPE,
-						subsetStr,
-						tagsStr)
-				} else {
-					// Fallback if snack not found
-					fmt.Printf("- **%s** - `%s` (%d min, RPE %d%s)\n",
-						entry.Timestamp.Format("15:04"),
-						entry.Code,
-						entry.Duration,
-						entry.RPE,
-						subsetStr)
-				}
+		switch choice {
+		case "d": // Done
+			handleDoneInteractive(snack, false, timed)
+			os.Remove(appConfig.CurrentPath) // Clear saved snack
+			return                           // Exit after marking done
+
+		case "p": // Partial
+			handleDoneInteractive(snack, true, timed)
+			os.Remove(appConfig.CurrentPath) // Clear saved snack
+			return                           // Exit after logging partial
+
+		case "s": // Skip, then continue the loop to get the next snack
+			session.skipped(snack, handleSkipInteractive(snack), os.Stderr)
+
+		case "a": // Swap in a variation, without logging a skip
+			if hasAlternatives {
+				fmt.Printf(tr("\n🔄 Swapping in %s\n"), session.swapAlternative(snack).Title)
+			}
+
+		case "x": // Skip dailies (only if snack has min_per_day)
+			if hasMinimum {
+				fmt.Print(tr("\n⏭️  Skipping dailies for now...\n"))
+				session.skipDailies(snack)
+			}
+
+		case "b": // Back to the previous movo, taking back its skip
+			if session.canGoBack() {
+				fmt.Printf(tr("\n↩️  Back to %s\n"), session.back(os.Stderr).Title)
+			}
+
+		case "q": // Quit
+			fmt.Println(tr("\n👋 Saved for later. Run 'movodoro' to resume."))
+			return
+
+		default:
+			fmt.Println(tr("Invalid choice, please try again."))
+		}
+	}
+}
+
+// displayMovoInteractive displays a movo in interactive mode
+func displayMovoInteractive(movo *Movo) {
+	printMovoCard(movo)
+	printRPEBudget()
+	fmt.Println()
+}
+
+// printMovoCard prints the title, description and details of a movo,
+// showing the details enabled in the card section of config.yaml
+func printMovoCard(movo *Movo) {
+	writeMovoCard(os.Stdout, movo, terminalWidth(), useColor())
+}
+
+// writeMovoCard is printMovoCard to w, wrapping the description to width
+func writeMovoCard(w io.Writer, movo *Movo, width int, color bool) {
+	card := appConfig.Card
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, "  %s\n", movo.Title)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	if plainOutput {
+		fmt.Fprintln(w, movo.Description)
+	} else {
+		fmt.Fprintln(w, renderDescription(movo.Description, width, color))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, tr("⏱️  Duration: %d-%d minutes\n"), movo.DurationMin, movo.DurationMax)
+	if prescription := movo.prescription(); prescription != "" {
+		fmt.Fprintf(w, tr("📋 Sets: %s\n"), prescription)
+	}
+	if card.RPE && !appConfig.KidMode {
+		fmt.Fprintf(w, "💪 RPE: %d/10\n", movo.EffectiveRPE)
+	}
+	if card.Category && movo.CategoryName != "" {
+		fmt.Fprintf(w, tr("📂 Category: %s\n"), movo.CategoryName)
+	}
+	if card.Code && !appConfig.KidMode {
+		fmt.Fprintf(w, tr("🏷️  Code: %s\n"), movo.FullCode)
+	}
+
+	if card.Tags && len(movo.AllTags) > 0 {
+		fmt.Fprintf(w, tr("🔖 Tags: %s\n"), strings.Join(movo.AllTags, ", "))
+	}
+
+	if summary, ok := loadRatingSummary(movo.FullCode); ok {
+		fmt.Fprintf(w, tr("⭐ Rating: %.1f/5 (%d ratings)\n"), summary.Average, summary.Count)
+	}
+
+	if card.TodayCount {
+		if doneToday, _, err := GetCountTodayDaily(appConfig.LogsDir, movo.FullCode); err == nil {
+			if movo.MinPerDay > 0 {
+				fmt.Fprintf(w, tr("🔁 Today: %d of %d done\n"), doneToday, movo.MinPerDay)
 			} else {
-				fmt.Printf("- **%s** - `%s` (%d min, RPE %d%s)\n",
-					entry.Timestamp.Format("15:04"),
-					entry.Code,
-					entry.Duration,
-					entry.RPE,
-					subsetStr)
+				fmt.Fprintf(w, tr("🔁 Today: %d done\n"), doneToday)
 			}
 		}
-		fmt.Println()
 	}
 
-	if len(stats.SkippedSnacks) > 0 {
-		fmt.Println("## Skipped")
-		fmt.Println()
-		for _, entry := range stats.SkippedSnacks {
-			if verbose {
-				movo := movoMap[entry.Code]
-				if movo != nil {
-					fmt.Printf("- **%s** - %s [`%s`]\n",
-						entry.Timestamp.Format("15:04"),
-						movo.Title,
-						entry.Code)
-				} else {
-					fmt.Printf("- **%s** - `%s`\n",
-						entry.Timestamp.Format("15:04"),
-						entry.Code)
-				}
+	if card.LastDone {
+		if lastDone, err := GetLastDoneDaily(appConfig.LogsDir, movo.FullCode); err == nil {
+			if lastDone == nil {
+				fmt.Fprintln(w, tr("📅 Last done: never"))
 			} else {
-				fmt.Printf("- **%s** - `%s`\n",
-					entry.Timestamp.Format("15:04"),
-					entry.Code)
+				fmt.Fprintf(w, tr("📅 Last done: %s (%s)\n"), appConfig.FormatDate(*lastDone), daysAgo(*lastDone, time.Now()))
 			}
 		}
-		fmt.Println()
 	}
+}
 
-	if stats.TotalRPE >= maxDailyRPEDefault {
-		fmt.Println("*Auto-recovery mode active (RPE limit reached)*")
+// daysAgo describes how many calendar days before now t was
+func daysAgo(t, now time.Time) string {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch days := int(today.Sub(day).Hours() / 24); days {
+	case 0:
+		return tr("today")
+	case 1:
+		return tr("yesterday")
+	default:
+		return trf("%d days ago", days)
 	}
 }
 
-// formatMovoTags formats tags for verbose report output
-func formatMovoTags(movo *Movo) string {
-	if len(movo.AllTags) == 0 && movo.MinPerDay == 0 {
-		return ""
+// getInteractiveChoice prompts user for action choice
+func getInteractiveChoice(hasMinimum, hasAlternatives, canGoBack bool) string {
+	if appConfig.KidMode {
+		fmt.Println(tr("What do you want to do?"))
+		fmt.Println("  [d] " + tr("I did it! 🎉"))
+		fmt.Println("  [s] " + tr("Something else 🔀"))
+		fmt.Println("  [q] " + tr("Later 👋"))
+	} else {
+		fmt.Println(tr("What would you like to do?"))
+		fmt.Println("  [t] " + tr("Start timer"))
+		fmt.Println("  [i] " + tr("Info (equipment, targets, history)"))
+		fmt.Println("  [d] " + tr("Done (log completion)"))
+		fmt.Println("  [p] " + tr("Partial (stopped early)"))
+		fmt.Println("  [s] " + tr("Skip (try another movo)"))
+		if hasAlternatives {
+			fmt.Println("  [a] " + tr("Alternative (swap in a listed variation)"))
+		}
+		if hasMinimum {
+			fmt.Println("  [x] " + tr("Skip dailies (ignore min_per_day > 0 movos)"))
+		}
+		if canGoBack {
+			fmt.Println("  [b] " + tr("Back (return to the previous movo)"))
+		}
+		fmt.Println("  [q] " + tr("Quit (save for later)"))
+		fmt.Println("\n  " + tr("(Press 'h' for help: movodoro --help)"))
 	}
+	fmt.Print("\n" + tr("Choice: "))
 
-	// Format tags with # prefix
-	tagList := make([]string, len(movo.AllTags))
-	for i, tag := range movo.AllTags {
-		tagList[i] = "#" + tag
+	validChars := []string{"t", "i", "d", "p", "s", "q"}
+	if appConfig.KidMode {
+		validChars = []string{"d", "s", "q"}
+	} else {
+		if hasMinimum {
+			validChars = append(validChars, "x")
+		}
+		if hasAlternatives {
+			validChars = append(validChars, "a")
+		}
+		if canGoBack {
+			validChars = append(validChars, "b")
+		}
 	}
 
-	// Add #daily tag if this is an everyday movo
-	if movo.MinPerDay > 0 {
-		tagList = append(tagList, "#daily")
+	choice, ok := readKey(func(key string) bool { return slices.Contains(validChars, key) }, tr("Invalid choice. Choice: "))
+	if !ok {
+		// Fallback to regular input if terminal doesn't support raw mode
+		input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		return strings.TrimSpace(strings.ToLower(input))
+	}
+	return choice
+}
+
+// handleDoneInteractive handles completing (or partially completing) a movo in interactive
+// mode. timed is the minutes a timer ran for, offered as the duration (0 if untimed).
+func handleDoneInteractive(movo *Movo, partial bool, timed int) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Println()
+	in := doneInput{Partial: partial}
+	in.Duration, in.RPE = promptEffort(reader, doneDefaultDuration(movo, partial, timed), movo.EffectiveRPE)
+	in.Details = promptDetails(reader, movo)
+	if appConfig.RateAfterDone {
+		in.Rating = promptRating(reader)
+	}
+	logDoneInteractive(movo, in)
+}
+
+// doneInput is what interactive mode asked for when logging a completion
+type doneInput struct {
+	Partial       bool
+	Duration, RPE int
+	Details       doneDetails
+	Rating        int // 0 if not rated
+}
+
+// doneDefaultDuration is the duration offered for a completion: the timer's minutes if
+// one ran, otherwise the usual duration (half of it for a partial completion)
+func doneDefaultDuration(movo *Movo, partial bool, timed int) int {
+	switch {
+	case timed > 0:
+		return timed
+	case partial:
+		return partialDefaultDuration(movo)
+	default:
+		return usualDuration(movo)
+	}
+}
+
+// logDoneInteractive logs a completion of movo, confirms it and shows today's totals
+func logDoneInteractive(movo *Movo, in doneInput) {
+	status := "done"
+	if in.Partial {
+		status = "partial"
+	}
+
+	// Create history entry
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      movo.FullCode,
+		Status:    status,
+		Duration:  in.Duration,
+		RPE:       in.RPE,
+		Subset:    appConfig.ActiveSubset,
+	}
+	in.Details.record(&entry)
+	recordRating(&entry, in.Rating)
+
+	// Save to history
+	if err := appendLogEntry(entry, movo); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving to history: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitStorage))
+	}
+
+	if appConfig.KidMode {
+		fmt.Printf("\n%s\n", kidCheer(movo.Title))
+	} else if in.Partial {
+		fmt.Printf("\n"+tr("◐ Marked '%s' as partially completed (%d minutes, RPE %d)\n"), movo.Title, in.Duration, in.RPE)
+	} else {
+		fmt.Printf("\n"+tr("✅ Marked '%s' as completed (%d minutes, RPE %d)\n"), movo.Title, in.Duration, in.RPE)
+	}
+
+	// Show updated daily stats
+	stats, _ := GetTodayStatsDaily(appConfig.LogsDir)
+	if appConfig.KidMode {
+		fmt.Printf(tr("⭐ Stickers today: %d\n")+"\n", len(stats.CompletedSnacks)+len(stats.PartialSnacks))
+	} else {
+		fmt.Printf(tr("📊 Today: %d movos, %d minutes, %d RPE\n")+"\n", stats.TotalMovos, stats.TotalDuration, stats.TotalRPE)
+	}
+}
+
+// promptEffort asks for the actual duration and RPE, falling back to the defaults.
+// Kid mode skips both prompts and logs the defaults.
+func promptEffort(reader *bufio.Reader, defaultDuration, defaultRPE int) (duration, rpe int) {
+	if appConfig.KidMode {
+		return defaultDuration, defaultRPE
+	}
+
+	// Prompt for actual duration
+	fmt.Printf(tr("How many minutes did you spend? (default: %d): "), defaultDuration)
+
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+
+	duration = defaultDuration
+	if input != "" {
+		parsed, err := strconv.Atoi(input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Invalid duration, using default: %d\n"), defaultDuration)
+		} else {
+			duration = parsed
+		}
+	}
+
+	// Prompt for RPE
+	fmt.Printf(tr("How hard was it? RPE (default: %d): "), defaultRPE)
+
+	input, _ = reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+
+	rpe = defaultRPE
+	if input != "" {
+		parsed, err := strconv.Atoi(input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Invalid RPE, using default: %d\n"), defaultRPE)
+		} else {
+			rpe = parsed
+		}
 	}
 
-	return fmt.Sprintf(" | %s", strings.Join(tagList, ", "))
+	return duration, rpe
+}
+
+// doneDetails are what done can log beyond minutes and RPE
+type doneDetails struct {
+	Sets, Reps int
+	Load       float64 // kg
+	Distance   float64 // km
+	Steps      int
 }
 
-func displayMovo(movo *Movo) {
-	fmt.Println()
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Printf("  %s\n", movo.Title)
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println()
+// record stores the details given in the entry's extras
+func (d doneDetails) record(entry *HistoryEntry) {
+	recordSetsReps(entry, d.Sets, d.Reps)
+	recordLoad(entry, d.Load)
+	recordDistance(entry, d.Distance, d.Steps)
+}
 
-	fmt.Println(movo.Description)
-	fmt.Println()
+// promptDetails asks for the details that apply to movo: sets×reps when it prescribes
+// them, the load when it uses weights and the distance when it's cardio
+func promptDetails(reader *bufio.Reader, movo *Movo) doneDetails {
+	var d doneDetails
+	d.Sets, d.Reps = promptSetsReps(reader, movo)
+	d.Load = promptLoad(reader, movo)
+	d.Distance, d.Steps = promptDistance(reader, movo)
+	return d
+}
 
-	fmt.Printf("⏱️  Duration: %d-%d minutes\n", movo.DurationMin, movo.DurationMax)
-	fmt.Printf("💪 RPE: %d/10\n", movo.EffectiveRPE)
-	fmt.Printf("🏷️  Code: %s\n", movo.FullCode)
+// promptDistance asks for the distance covered on a cardio movo. It returns zeros
+// if none was given.
+func promptDistance(reader *bufio.Reader, movo *Movo) (km float64, steps int) {
+	if appConfig.KidMode || !movo.tracksDistance() {
+		return 0, 0
+	}
 
-	if len(movo.AllTags) > 0 {
-		fmt.Printf("🔖 Tags: %s\n", strings.Join(movo.AllTags, ", "))
+	fmt.Print(tr("Distance? (e.g. 2.5km, 800m or 4000 steps; Enter to leave out): "))
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0, 0
 	}
 
-	fmt.Println()
-	fmt.Println("When done, run:")
-	fmt.Printf("  movodoro done\n")
-	fmt.Println("Or skip with:")
-	fmt.Printf("  movodoro skip\n")
-	fmt.Println()
+	km, steps, err := parseDistance(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("%v, not saved\n"), err)
+		return 0, 0
+	}
+	return km, steps
 }
 
-// saveCurrentSnack saves the current snack code to a file
-func saveCurrentSnack(code string) error {
-	return os.WriteFile(appConfig.CurrentPath, []byte(code), 0644)
-}
+// promptLoad asks for the load used on a weighted movo, defaulting to the last one
+// logged. It returns 0 if none was given.
+func promptLoad(reader *bufio.Reader, movo *Movo) float64 {
+	if appConfig.KidMode || !movo.tracksLoad() {
+		return 0
+	}
 
-// loadCurrentSnack loads the current snack code from file
-func loadCurrentSnack() (string, error) {
-	data, err := os.ReadFile(appConfig.CurrentPath)
-	if err != nil {
-		return "", err
+	last := 0.0
+	if entries, err := LoadAllHistory(appConfig.LogsDir); err == nil {
+		if history := loadHistory(entries, movo.FullCode); len(history) > 0 {
+			last = entryLoad(history[len(history)-1])
+		}
+	}
+	if last > 0 {
+		fmt.Printf(tr("Load in kg? (default: %s, - to leave out): "), formatLoad(last))
+	} else {
+		fmt.Print(tr("Load in kg? (Enter to leave out): "))
 	}
 
-	return strings.TrimSpace(string(data)), nil
-}
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	switch input {
+	case "-":
+		return 0
+	case "":
+		return last
+	}
 
-// handleClear implements the 'clear' command
-func handleClear(args []string) {
-	// Get today's stats first
-	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	load, err := parseLoad(input)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading stats: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, tr("%v, not saved\n"), err)
+		return 0
 	}
+	return load
+}
 
-	// Show what will be cleared
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println("  CLEAR TODAY'S HISTORY")
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println()
-
-	if stats.TotalMovos == 0 {
-		fmt.Println("No entries for today to clear.")
-		return
+// promptSetsReps asks for the sets×reps done on a movo that prescribes them,
+// defaulting to the prescribed ones. It returns zeros if none were given.
+func promptSetsReps(reader *bufio.Reader, movo *Movo) (sets, reps int) {
+	if appConfig.KidMode || movo.prescription() == "" {
+		return 0, 0
 	}
 
-	fmt.Printf("This will delete today's log file with %d entries:\n", stats.TotalMovos)
-	fmt.Printf("  - %d completed (%d minutes, %d RPE)\n",
-		len(stats.CompletedSnacks), stats.TotalDuration, stats.TotalRPE)
-	fmt.Printf("  - %d skipped\n", len(stats.SkippedSnacks))
-	fmt.Println()
+	if movo.Sets > 0 && movo.Reps > 0 {
+		fmt.Printf(tr("Sets×reps done? (default: %d×%d, - to leave out): "), movo.Sets, movo.Reps)
+	} else {
+		fmt.Printf(tr("Sets×reps done, e.g. 3x10 (%s; Enter to leave out): "), movo.prescription())
+	}
 
-	// Prompt for confirmation
-	fmt.Print("Are you sure you want to clear today's history? (yes/no): ")
-	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(strings.ToLower(input))
-
-	if input != "yes" && input != "y" {
-		fmt.Println("Cancelled.")
-		return
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "-":
+		return 0, 0
+	case input == "":
+		return movo.Sets, movo.Reps
 	}
 
-	// Delete today's log file
-	if err := ClearTodayLog(appConfig.LogsDir); err != nil {
-		fmt.Fprintf(os.Stderr, "Error clearing today's log: %v\n", err)
-		os.Exit(1)
+	sets, reps, err := parseSetsReps(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("%v, not saved\n"), err)
+		return 0, 0
 	}
-
-	fmt.Printf("✅ Cleared %d entries from today's history\n", stats.TotalMovos)
+	return sets, reps
 }
 
-// handleConfig implements the 'config' command
-func handleConfig(args []string) {
-	cfg := appConfig
+// partialDefaultDuration suggests half the usual duration for a partial completion
+func partialDefaultDuration(movo *Movo) int {
+	return (usualDuration(movo) + 1) / 2
+}
 
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println("  MOVODORO CONFIGURATION")
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println()
-	fmt.Printf("Movos directory:  %s\n", cfg.MovosDir)
-	fmt.Printf("Logs directory:   %s\n", cfg.LogsDir)
-	fmt.Printf("Current file:     %s\n", cfg.CurrentPath)
-	fmt.Printf("Max daily RPE:    %d\n", cfg.MaxDailyRPE)
-	if cfg.ActiveSubset != "" {
-		fmt.Printf("Active subset:    %s\n", cfg.ActiveSubset)
+// handleSkipInteractive handles skipping a movo in interactive mode, returning the
+// entry logged
+func handleSkipInteractive(movo *Movo) HistoryEntry {
+	entry := skipEntry(movo)
+	if err := appendLogEntry(entry, movo); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving to history: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitStorage))
 	}
-	fmt.Println()
 
-	// Check if movos directory exists
-	if _, err := os.Stat(cfg.MovosDir); os.IsNotExist(err) {
-		fmt.Printf("⚠️  Movos directory does not exist: %s\n", cfg.MovosDir)
-		fmt.Println()
-		fmt.Println("To set a custom movos directory, use:")
-		fmt.Println("  export MOVODORO_MOVOS_DIR=/path/to/your/movos")
-	} else {
-		// Count snacks
-		snacks, err := LoadSnacks()
-		if err != nil {
-			fmt.Printf("⚠️  Error loading snacks: %v\n", err)
-		} else {
-			fmt.Printf("✅ Found %d movement snacks\n", len(snacks))
-		}
+	fmt.Printf("\n"+tr("⏭️  Skipped '%s'\n"), movo.Title)
+	return entry
+}
+
+// skipEntry is the history entry for skipping movo now, with 0 duration and RPE
+func skipEntry(movo *Movo) HistoryEntry {
+	return HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      movo.FullCode,
+		Status:    "skip",
+		Duration:  0,
+		RPE:       0,
+		Subset:    appConfig.ActiveSubset,
 	}
-	fmt.Println()
 }
 
-// handleEveryday implements the 'everyday' command
-func handleEveryday(args []string) {
+// handleSubsets implements the 'subsets' command
+func handleSubsets(args []string) {
 	cfg := appConfig
 
-	// Load snacks
-	snacks, err := LoadSnacks()
+	// Load subsets configuration
+	subsetsConfig, err := LoadSubsets(cfg.MovosDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Filter to only snacks with min_per_day requirement
-	var everydayMovos []Movo
-	for _, snack := range snacks {
-		if snack.MinPerDay > 0 {
-			everydayMovos = append(everydayMovos, snack)
-		}
+		fmt.Fprintf(os.Stderr, tr("Error loading subsets: %v\n"), err)
+		os.Exit(exitLibrary)
 	}
 
-	if len(everydayMovos) == 0 {
-		fmt.Println("No movos with min_per_day requirement")
+	if outputJSON {
+		writeJSON(os.Stdout, newSubsetsJSON(subsetsConfig))
 		return
 	}
 
-	// Check for active subset
-	activeSubset := cfg.ActiveSubset
-	var subsetCodes map[string]bool
-	if activeSubset != "" {
-		subsetsConfig, err := LoadSubsets(cfg.MovosDir)
-		if err == nil {
-			if subset, exists := subsetsConfig.Subsets[activeSubset]; exists {
-				subsetCodes = make(map[string]bool)
-				for _, code := range subset.Codes {
-					subsetCodes[code] = true
-				}
-			}
-		}
+	if len(subsetsConfig.Subsets) == 0 {
+		fmt.Println(tr("No subsets configured."))
+		fmt.Println()
+		fmt.Print(tr("Create a subsets.yaml file in your movos directory:\n"))
+		fmt.Printf("  %s/subsets.yaml\n", cfg.MovosDir)
+		return
 	}
 
 	fmt.Println("═══════════════════════════════════════")
-	fmt.Println("  EVERY DAY MOVOS")
-	if activeSubset != "" {
-		fmt.Printf("  (Subset: %s)\n", activeSubset)
-	}
+	fmt.Println(tr("  AVAILABLE SUBSETS"))
 	fmt.Println("═══════════════════════════════════════")
 	fmt.Println()
 
-	// Get today's stats
-	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading today's stats: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Create map of completed snacks today
-	completedToday := make(map[string]int)
-	for _, entry := range stats.CompletedSnacks {
-		completedToday[entry.Code]++
+	// Display each subset
+	for name, subset := range subsetsConfig.Subsets {
+		fmt.Printf("📦 %s\n", name)
+		if subset.Description != "" {
+			fmt.Printf("   %s\n", subset.Description)
+		}
+		fmt.Printf(tr("   %d movos\n"), len(subset.Codes))
+		fmt.Println()
 	}
 
-	// Display each everyday snack
-	inSubsetCount := 0
-	excludedCount := 0
-	for _, snack := range everydayMovos {
-		// Check if excluded by subset
-		inSubset := subsetCodes == nil || subsetCodes[snack.FullCode]
-		if !inSubset {
-			excludedCount++
-			continue
-		}
-		inSubsetCount++
+	fmt.Println(tr("Usage:"))
+	fmt.Printf("  movodoro get --subset SUBSET_NAME\n")
+	fmt.Printf("  movodoro --subset SUBSET_NAME          # %s\n", tr("Interactive mode"))
+	fmt.Printf("  export MOVODORO_ACTIVE_SUBSET=SUBSET_NAME\n")
+}
 
-		count := completedToday[snack.FullCode]
-		status := "❌"
-		if count >= snack.MinPerDay {
-			status = "✅"
-		}
+// handleMigrateLogsToCsv implements the 'migrate-logs-to-csv' command
+func handleMigrateLogsToCsv(args []string) {
+	cfg := appConfig
 
-		fmt.Printf("%s %s\n", status, snack.Title)
-		fmt.Printf("   Code: %s | RPE: %d | Duration: %d-%d min\n",
-			snack.FullCode, snack.EffectiveRPE, snack.DurationMin, snack.DurationMax)
+	fs := flag.NewFlagSet("migrate-logs-to-csv", flag.ContinueOnError)
+	var dryRun bool
+	var only string
+	fs.BoolVar(&dryRun, "dry-run", false, "Show a diff of what each file would become without changing anything")
+	fs.StringVar(&only, "only", "", "Migrate a single log file (e.g. 20251012.log)")
+	parseFlags(fs, args)
 
-		if count > 0 {
-			fmt.Printf("   Completed %d of %d today\n", count, snack.MinPerDay)
-		} else {
-			fmt.Printf("   Not yet done (0 of %d today)\n", snack.MinPerDay)
-		}
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println(tr("  MIGRATE LOGS TO CSV FORMAT (v1.0.0)"))
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Println()
+	if dryRun {
+		fmt.Println(tr("Dry run: no files will be changed."))
 		fmt.Println()
 	}
 
-	if excludedCount > 0 {
-		fmt.Printf("⚠️  %d everyday movos excluded by active subset\n", excludedCount)
-		fmt.Println()
+	// Find all .log files
+	pattern := filepath.Join(cfg.LogsDir, "*.log")
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error finding log files: %v\n"), err)
+		os.Exit(exitStorage)
 	}
 
-	completed := 0
-	for _, snack := range everydayMovos {
-		// Only count if in subset
-		inSubset := subsetCodes == nil || subsetCodes[snack.FullCode]
-		if inSubset && completedToday[snack.FullCode] >= snack.MinPerDay {
-			completed++
+	if only != "" {
+		name := filepath.Base(only)
+		if !strings.HasSuffix(name, ".log") {
+			name += ".log"
 		}
+		var matched []string
+		for _, filePath := range files {
+			if filepath.Base(filePath) == name {
+				matched = append(matched, filePath)
+			}
+		}
+		if len(matched) == 0 {
+			fmt.Fprintf(os.Stderr, tr("No log file named %s in %s\n"), name, cfg.LogsDir)
+			os.Exit(exitError)
+		}
+		files = matched
 	}
 
-	fmt.Printf("Summary: %d/%d everyday movos completed", completed, inSubsetCount)
-	if activeSubset != "" {
-		fmt.Printf(" (in subset)")
-	}
-	fmt.Println()
-}
-
-// handleInteractive implements the interactive mode (default when running `movodoro`)
-func handleInteractive(args []string) {
-	// Parse flags for interactive mode
-	fs := flag.NewFlagSet("interactive", flag.ExitOnError)
-	var subset string
-	fs.StringVar(&subset, "subset", "", "Use a named subset from subsets.yaml")
-	fs.Parse(args)
-
-	// Load snacks
-	snacks, err := LoadSnacks()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading snacks: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Determine active subset: command flag takes precedence over env var
-	activeSubset := subset
-	if activeSubset == "" {
-		activeSubset = appConfig.ActiveSubset
+	if len(files) == 0 {
+		fmt.Println(tr("No log files found."))
+		return
 	}
 
-	// Display subset info if active
-	if activeSubset != "" {
-		fmt.Printf("🎯 Using subset: %s\n\n", activeSubset)
-	}
+	fmt.Printf(tr("Found %d log file(s) to check\n\n"), len(files))
 
-	// Start with default filters
-	filters := FilterOptions{
-		Subset: activeSubset,
-	}
+	converted := 0
+	skipped := 0
+	failed := 0
 
-	for {
-		var snack *Movo
+	for _, filePath := range files {
+		filename := filepath.Base(filePath)
 
-		// Try to load saved snack from previous session
-		savedCode, err := loadCurrentSnack()
-		if err == nil && savedCode != "" {
-			// Find the saved snack
-			for i := range snacks {
-				if snacks[i].FullCode == savedCode {
-					snack = &snacks[i]
-					fmt.Println("📥 Resuming saved snack...")
-					fmt.Println()
-					break
-				}
-			}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Printf(tr("⚠️  %s: Could not read (%v)\n"), filename, err)
+			failed++
+			continue
 		}
 
-		// If no saved snack or couldn't find it, select a new one
-		if snack == nil {
-			selected, err := SelectSnack(snacks, filters, maxDailyRPEDefault)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error selecting snack: %v\n", err)
-				os.Exit(1)
-			}
-			snack = selected
+		// Check if already CSV (has header)
+		if isCSVLog(data) {
+			fmt.Printf(tr("✓  %s: Already in CSV format\n"), filename)
+			skipped++
+			continue
 		}
 
-		// Save as current snack (overwrites existing or saves new)
-		if err := saveCurrentSnack(snack.FullCode); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: could not save current snack: %v\n", err)
+		csvData, count, dropped, err := convertLegacyLog(data)
+		if err != nil {
+			fmt.Printf(tr("⚠️  %s: Could not convert (%v)\n"), filename, err)
+			failed++
+			continue
+		}
+		if count == 0 {
+			fmt.Printf(tr("⚠️  %s: No valid entries found\n"), filename)
+			failed++
+			continue
 		}
 
-		// Display the movo
-		displayMovoInteractive(snack)
-
-		// Get user choice
-		hasMinimum := snack.MinPerDay > 0
-		choice := getInteractiveChoice(hasMinimum)
+		// Change from .log to .csv extension
+		newFilePath := strings.TrimSuffix(filePath, ".log") + ".csv"
+		newFilename := strings.TrimSuffix(filename, ".log") + ".csv"
+		droppedStr := ""
+		if dropped > 0 {
+			droppedStr = fmt.Sprintf(", %d unparseable line(s) dropped", dropped)
+		}
 
-		switch choice {
-		case "d": // Done
-			handleDoneInteractive(snack)
-			os.Remove(appConfig.CurrentPath) // Clear saved snack
-			return                           // Exit after marking done
+		if dryRun {
+			fmt.Printf(tr("→  %s → %s: Would convert %d entries%s\n"), filename, newFilename, count, droppedStr)
+			fmt.Print(unifiedDiff(filename, newFilename, string(data), string(csvData)))
+			fmt.Println()
+			converted++
+			continue
+		}
 
-		case "s": // Skip
-			handleSkipInteractive(snack)
-			os.Remove(appConfig.CurrentPath) // Clear saved snack
-			filters.SkipMinimums = false     // Reset skip minimums flag
-			// Continue loop to get next snack
+		fmt.Printf(tr("→  %s: Converting to CSV...\n"), filename)
 
-		case "x": // Skip dailies (only if snack has min_per_day)
-			if snack.MinPerDay > 0 {
-				fmt.Printf("\n⏭️  Skipping dailies for now...\n")
-				os.Remove(appConfig.CurrentPath) // Clear saved snack
-				filters.SkipMinimums = true
-				// Continue loop to get next snack (will reset flag after)
-			}
+		// Create backup
+		backupPath := filePath + ".bak"
+		if err := os.Rename(filePath, backupPath); err != nil {
+			fmt.Printf(tr("⚠️  %s: Could not create backup (%v)\n"), filename, err)
+			failed++
+			continue
+		}
 
-		case "q": // Quit
-			fmt.Println("\n👋 Saved for later. Run 'movodoro' to resume.")
-			return
+		// Write new CSV format (with .csv extension)
+		if err := os.WriteFile(newFilePath, csvData, 0644); err != nil {
+			// Restore backup
+			os.Remove(newFilePath)
+			os.Rename(backupPath, filePath)
+			fmt.Printf(tr("⚠️  %s: Could not write new file (%v)\n"), filename, err)
+			failed++
+			continue
+		}
 
-		default:
-			fmt.Println("Invalid choice, please try again.")
+		if err := recordChecksum(cfg.LogsDir, newFilePath); err != nil {
+			fmt.Printf(tr("⚠️  %s: Could not update checksum (%v)\n"), filename, err)
 		}
+
+		fmt.Printf(tr("✅ %s → %s: Converted %d entries%s (backup: %s.bak)\n"), filename, newFilename, count, droppedStr, filename)
+		converted++
 	}
-}
 
-// displayMovoInteractive displays a movo in interactive mode
-func displayMovoInteractive(movo *Movo) {
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════")
-	fmt.Printf("  %s\n", movo.Title)
+	if dryRun {
+		fmt.Print(tr("Dry run complete:\n"))
+		fmt.Printf(tr("  Would convert: %d\n"), converted)
+	} else {
+		fmt.Print(tr("Migration complete:\n"))
+		fmt.Printf(tr("  Converted: %d\n"), converted)
+	}
+	fmt.Printf(tr("  Skipped:   %d (already CSV)\n"), skipped)
+	fmt.Printf(tr("  Failed:    %d\n"), failed)
 	fmt.Println("═══════════════════════════════════════")
-	fmt.Println()
 
-	fmt.Println(movo.Description)
-	fmt.Println()
+	if converted > 0 && !dryRun {
+		fmt.Println()
+		fmt.Println(tr("Backup files (.bak) have been created."))
+		fmt.Println(tr("After verifying the migration, you can delete them:"))
+		fmt.Printf("  rm %s/*.bak\n", cfg.LogsDir)
+	}
+}
 
-	fmt.Printf("⏱️  Duration: %d-%d minutes\n", movo.DurationMin, movo.DurationMax)
-	fmt.Printf("💪 RPE: %d/10\n", movo.EffectiveRPE)
-	fmt.Printf("🏷️  Code: %s\n", movo.FullCode)
+// handleValidate implements the 'validate' command: check the movos directory for mistakes
+func handleValidate(args []string) {
+	dir := appConfig.MovosDir
+	diagnostics, movos, err := validateMovosDir(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
 
-	if len(movo.AllTags) > 0 {
-		fmt.Printf("🔖 Tags: %s\n", strings.Join(movo.AllTags, ", "))
+	for _, d := range diagnostics {
+		fmt.Println(d)
 	}
-	fmt.Println()
+	if len(diagnostics) > 0 {
+		fmt.Printf(tr("\n❌ %d problem(s) in %s\n"), len(diagnostics), dir)
+		os.Exit(exitLibrary)
+	}
+	fmt.Printf(tr("✅ %d movos in %s look good\n"), movos, dir)
 }
 
-// getInteractiveChoice prompts user for action choice
-func getInteractiveChoice(hasMinimum bool) string {
-	fmt.Println("What would you like to do?")
-	fmt.Println("  [d] Done (log completion)")
-	fmt.Println("  [s] Skip (try another movo)")
-	if hasMinimum {
-		fmt.Println("  [x] Skip dailies (ignore min_per_day > 0 movos)")
+// handleLint implements the 'lint' command: check the library against the style rules in lint.yaml
+func handleLint(args []string) {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro lint"))
+		os.Exit(exitError)
+	}
+	dir := appConfig.MovosDir
+	rules, vocabulary, err := loadLintRules(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+	diagnostics, movos, err := lintMovosDir(dir, rules, vocabulary)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	for _, d := range diagnostics {
+		fmt.Println(d)
+	}
+	if len(diagnostics) > 0 {
+		fmt.Printf(tr("\n❌ %d style problem(s) in %s\n"), len(diagnostics), dir)
+		os.Exit(exitError)
+	}
+	fmt.Printf(tr("✅ %d movos in %s follow the lint rules\n"), movos, dir)
+}
+
+// handleWatch implements the 'watch' command: suggest a movo with a desktop notification
+// every interval, optionally starting interactive mode when acknowledged
+func handleWatch(args []string) {
+	fs, g := newGetFlagSet("watch", flag.ContinueOnError)
+	var every time.Duration
+	var interactive bool
+	fs.DurationVar(&every, "every", 50*time.Minute, "Time between nudges (e.g. 50m, 1h30m)")
+	fs.BoolVar(&interactive, "interactive", false, "After each nudge, wait for Enter and start interactive mode")
+	fs.BoolVar(&interactive, "i", false, "After each nudge, wait for Enter and start interactive mode")
+	parseFlags(fs, args)
+
+	if every < minWatchInterval {
+		fmt.Fprintf(os.Stderr, tr("Error: --every must be at least %s\n"), minWatchInterval)
+		os.Exit(exitError)
 	}
-	fmt.Println("  [q] Quit (save for later)")
-	fmt.Println("\n  (Press 'h' for help: movodoro --help)")
-	fmt.Print("\nChoice: ")
 
-	// Put terminal in raw mode for single-key input
-	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	library, err := loadLibrary()
 	if err != nil {
-		// Fallback to regular input if terminal doesn't support raw mode
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
-		return strings.TrimSpace(strings.ToLower(input))
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
 	}
-	defer term.Restore(int(os.Stdin.Fd()), oldState)
 
-	// Read single character
-	buf := make([]byte, 1)
+	fmt.Printf(tr("👀 Nudging every %s (Ctrl+C to stop)\n"), every)
+	reader := bufio.NewReader(os.Stdin)
 	for {
-		_, err := os.Stdin.Read(buf)
+		// With --interactive the next interval starts once the session is over
+		time.Sleep(every)
+
+		// Library edits are picked up before each nudge; today's history is read fresh anyway
+		if reloaded, err := library.refresh(); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: movo library not reloaded: %v\n"), err)
+		} else if reloaded {
+			fmt.Printf(tr("🔄 Movo library changed; reloaded %d movos\n"), len(library.Movos))
+		}
+		snack, notes, err := pickSnack(library.Movos, g.filterOptions(), appConfig.MaxDailyRPE)
 		if err != nil {
-			fmt.Println()
-			return "q"
+			fmt.Fprintf(os.Stderr, tr("Warning: error selecting snack: %v\n"), err)
+			continue
+		}
+		printSelectionNotes(notes)
+		if err := saveCurrentPick(snack.FullCode, notes.Exploration); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: could not save current snack: %v\n"), err)
 		}
 
-		char := strings.ToLower(string(buf[0]))
-
-		// Validate input
-		validChars := []string{"d", "s", "q"}
-		if hasMinimum {
-			validChars = append(validChars, "x")
+		title, message := nudgeMessage(snack, interactive)
+		fmt.Printf("\a🔔 %s %s (%s)\n", appConfig.FormatClock(time.Now()), snack.Title, snack.FullCode)
+		if err := sendNotification(title, message); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
 		}
 
-		valid := false
-		for _, v := range validChars {
-			if char == v {
-				valid = true
-				break
+		// The daemon's end-of-day summary, once eod_at has passed
+		if eodDue(time.Now(), appConfig.EODAt, appConfig.EODDir) {
+			opts := eodOptions{Dir: appConfig.EODDir, Notify: appConfig.EODNotify, Email: appConfig.EODEmail, Verbose: true}
+			if err := runEOD(opts, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
 			}
 		}
 
-		if valid {
-			fmt.Println(char) // Echo the character
-			return char
+		if interactive {
+			fmt.Print(tr("Press Enter to start it: "))
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+			handleInteractive(nil)
+			fmt.Printf(tr("\n👀 Next nudge in %s\n"), every)
 		}
+	}
+}
 
-		// Handle Ctrl+C (ASCII 3)
-		if buf[0] == 3 {
-			fmt.Println("^C")
-			return "q"
-		}
+// handleServe implements the 'serve' command: an HTTP API for get, done, skip, report
+// and everyday
+func handleServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	var port int
+	var host, token string
+	fs.IntVar(&port, "port", 8080, "Port to listen on")
+	fs.StringVar(&host, "host", "127.0.0.1", "Address to listen on (0.0.0.0 for other devices)")
+	fs.StringVar(&token, "token", os.Getenv("MOVODORO_SERVE_TOKEN"), "Require this token (Bearer header or ?token=)")
+	parseFlags(fs, args)
+
+	if !isLoopback(host) && token == "" {
+		fmt.Fprintln(os.Stderr, tr("Warning: listening beyond this machine without --token; anyone on the network can log movos"))
+	}
 
-		// Invalid key - show error but keep prompt open
-		fmt.Print("\r\033[KInvalid choice. Choice: ")
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	server := &movoServer{token: token}
+	fmt.Printf(tr("🌐 Serving on http://%s (Ctrl+C to stop)\n"), addr)
+	if err := http.ListenAndServe(addr, server.handler()); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
 	}
 }
 
-// handleDoneInteractive handles completing a movo in interactive mode
-func handleDoneInteractive(movo *Movo) {
-	reader := bufio.NewReader(os.Stdin)
+// handleSync implements the 'sync' command: share the logs dir through git, or mirror
+// completed movos to Strava
+func handleSync(args []string) {
+	if len(args) > 0 && args[0] == "strava" {
+		handleSyncStrava(args[1:])
+		return
+	}
 
-	// Prompt for actual duration
-	defaultDuration := movo.GetDefaultDuration()
-	fmt.Printf("\nHow many minutes did you spend? (default: %d): ", defaultDuration)
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	var conflict string
+	fs.StringVar(&conflict, "conflict", appConfig.Sync.Conflict, "How to merge a daily log changed on both sides: append or last-write-wins")
+	parseFlags(fs, args)
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro sync [--conflict append|last-write-wins] | movodoro sync strava [options]"))
+		os.Exit(exitError)
+	}
 
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	cfg := appConfig.Sync
+	cfg.Conflict = conflict
+	result, err := syncLogs(appConfig.LogsDir, cfg, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error syncing logs: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitStorage))
+	}
 
-	duration := defaultDuration
-	if input != "" {
-		parsed, err := strconv.Atoi(input)
+	var done []string
+	if result.Committed {
+		done = append(done, "committed local entries")
+	}
+	if result.Pulled {
+		done = append(done, "pulled remote entries")
+	}
+	if len(result.Resolved) > 0 {
+		done = append(done, fmt.Sprintf("merged %s (%s)", strings.Join(result.Resolved, ", "), cfg.Conflict))
+	}
+	if len(done) == 0 {
+		done = append(done, "already up to date")
+	}
+	fmt.Printf(tr("🔄 Synced %s: %s\n"), appConfig.LogsDir, strings.Join(done, "; "))
+}
+
+// handleSyncStrava implements 'sync strava': upload completed movos as Strava activities
+func handleSyncStrava(args []string) {
+	fs := flag.NewFlagSet("sync strava", flag.ContinueOnError)
+	var since string
+	var dryRun bool
+	fs.StringVar(&since, "since", "", "Upload completions from this date (YYYY-MM-DD, default a week ago)")
+	fs.BoolVar(&dryRun, "dry-run", false, "List what would be uploaded without uploading")
+	parseFlags(fs, args)
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	rng := reportRange{From: today.AddDate(0, 0, -7), To: today}
+	if since != "" {
+		from, err := time.ParseInLocation(dayKeyFormat, since, now.Location())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Invalid duration, using default: %d\n", defaultDuration)
-		} else {
-			duration = parsed
+			fmt.Fprintf(os.Stderr, tr("Error: invalid --since date %q (use YYYY-MM-DD)\n"), since)
+			os.Exit(exitError)
 		}
+		rng.From = from
 	}
+	entries := loadRangeHistory(rng)
 
-	// Prompt for RPE
-	defaultRPE := movo.EffectiveRPE
-	fmt.Printf("How hard was it? RPE (default: %d): ", defaultRPE)
+	synced, err := loadStravaSynced(appConfig.StravaSyncedPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+	pending := stravaCandidates(entries, synced, appConfig.Strava.MinDuration)
+	if len(pending) == 0 {
+		fmt.Printf(tr("Nothing to upload (completions of %d+ minutes since %s are all on Strava)\n"),
+			appConfig.Strava.MinDuration, appConfig.FormatDate(rng.From))
+		return
+	}
 
-	input, _ = reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	movos := reportMovoMap()
+	if dryRun {
+		for _, entry := range pending {
+			a := newStravaActivity(entry, entryMovo(entry, movos), appConfig.Strava.SportType)
+			fmt.Printf(tr("Would upload: %s %s  %s (%d min)\n"),
+				a.Start.Format(periodDayFormat), appConfig.FormatClock(a.Start), a.Name, entry.Duration)
+		}
+		return
+	}
 
-	rpe := defaultRPE
-	if input != "" {
-		parsed, err := strconv.Atoi(input)
+	client, err := newStravaClient(appConfig.Strava, appConfig.StravaTokenPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	uploaded := 0
+	for _, entry := range pending {
+		a := newStravaActivity(entry, entryMovo(entry, movos), appConfig.Strava.SportType)
+		id, err := client.createActivity(a, time.Now())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Invalid RPE, using default: %d\n", defaultRPE)
-		} else {
-			rpe = parsed
+			// The rest are tried again next sync
+			fmt.Fprintf(os.Stderr, tr("Error: %v (uploaded %d of %d)\n"), err, uploaded, len(pending))
+			os.Exit(exitError)
+		}
+		if err := appendStravaSynced(appConfig.StravaSyncedPath, entry, id); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: uploaded %s but couldn't record it, so it may upload again: %v\n"), a.Name, err)
 		}
+		uploaded++
 	}
+	fmt.Printf(tr("⬆️  Uploaded %d movos to Strava\n"), uploaded)
+}
 
-	// Create history entry
-	entry := HistoryEntry{
-		Timestamp: time.Now(),
-		Code:      movo.FullCode,
-		Status:    "done",
-		Duration:  duration,
-		RPE:       rpe,
-		Subset:    appConfig.ActiveSubset,
+// handleEOD implements the 'eod' command (end-of-day summary, intended for cron)
+func handleEOD(args []string) {
+	fs := flag.NewFlagSet("eod", flag.ContinueOnError)
+	opts := eodOptions{}
+	fs.StringVar(&opts.Dir, "dir", appConfig.EODDir, "Directory to write the summary to")
+	fs.BoolVar(&opts.Notify, "notify", appConfig.EODNotify, "Send a desktop notification")
+	fs.StringVar(&opts.Email, "email", appConfig.EODEmail, "Mail the summary to this address (via sendmail)")
+	fs.BoolVar(&opts.Verbose, "verbose", true, "Show titles and tags")
+	fs.BoolVar(&opts.Verbose, "v", true, "Show titles and tags")
+	parseFlags(fs, args)
+
+	if opts.Dir == "" {
+		fmt.Fprintf(os.Stderr, tr("Error: no summary directory. Set eod_dir in %s or pass --dir.\n"), appConfig.ConfigPath)
+		os.Exit(exitError)
 	}
 
-	// Save to history
-	if err := AppendTodayLog(appConfig.LogsDir, entry); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving to history: %v\n", err)
-		os.Exit(1)
+	if err := runEOD(opts, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
 	}
+}
 
-	fmt.Printf("\n✅ Marked '%s' as completed (%d minutes, RPE %d)\n", movo.Title, duration, rpe)
+// handleRate implements the 'rate' command
+func handleRate(args []string) {
+	// Without arguments, list average ratings
+	if len(args) == 0 {
+		showRatings()
+		return
+	}
 
-	// Show updated daily stats
-	stats, _ := GetTodayStatsDaily(appConfig.LogsDir)
-	fmt.Printf("📊 Today: %d movos, %d minutes, %d RPE\n\n", stats.TotalMovos, stats.TotalDuration, stats.TotalRPE)
-}
+	if len(args) != 2 {
+		fmt.Fprint(os.Stderr, tr("Usage: movodoro rate CODE RATING (1-5)\n"))
+		os.Exit(exitError)
+	}
 
-// handleSkipInteractive handles skipping a movo in interactive mode
-func handleSkipInteractive(movo *Movo) {
-	// Create history entry with 0 duration and RPE
-	entry := HistoryEntry{
-		Timestamp: time.Now(),
-		Code:      movo.FullCode,
-		Status:    "skip",
-		Duration:  0,
-		RPE:       0,
-		Subset:    appConfig.ActiveSubset,
+	code := args[0]
+	value, err := strconv.Atoi(args[1])
+	if err != nil || value < minRating || value > maxRating {
+		fmt.Fprintf(os.Stderr, tr("Error: rating must be a number from %d to %d\n"), minRating, maxRating)
+		os.Exit(exitError)
 	}
 
-	// Save to history
-	if err := AppendTodayLog(appConfig.LogsDir, entry); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving to history: %v\n", err)
-		os.Exit(1)
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
 	}
 
-	fmt.Printf("\n⏭️  Skipped '%s'\n", movo.Title)
-}
+	snack := findMovo(snacks, code)
+	if snack == nil {
+		fmt.Fprintf(os.Stderr, tr("Error: snack code '%s' not found\n"), code)
+		os.Exit(exitError)
+	}
 
-// handleSubsets implements the 'subsets' command
-func handleSubsets(args []string) {
-	cfg := appConfig
+	rating := Rating{Timestamp: time.Now(), Code: code, Value: value}
+	if err := AppendRating(appConfig.RatingsPath, rating); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error saving rating: %v\n"), err)
+		os.Exit(exitStorage)
+	}
 
-	// Load subsets configuration
-	subsetsConfig, err := LoadSubsets(cfg.MovosDir)
+	fmt.Printf(tr("⭐ Rated '%s' %d/5\n"), snack.Title, value)
+}
+
+// showRatings prints the average rating of every rated movo, best first
+func showRatings() {
+	ratings, err := loadAllRatings(appConfig)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading subsets: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, tr("Error loading ratings: %v\n"), err)
+		os.Exit(exitStorage)
 	}
 
-	if len(subsetsConfig.Subsets) == 0 {
-		fmt.Println("No subsets configured.")
-		fmt.Println()
-		fmt.Printf("Create a subsets.yaml file in your movos directory:\n")
-		fmt.Printf("  %s/subsets.yaml\n", cfg.MovosDir)
+	if len(ratings) == 0 {
+		fmt.Println(tr("No ratings yet. Use 'movodoro rate CODE 1-5' to rate a movo."))
 		return
 	}
 
+	summaries := SummarizeRatings(ratings)
+	codes := make([]string, 0, len(summaries))
+	for code := range summaries {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		a, b := summaries[codes[i]], summaries[codes[j]]
+		if a.Average != b.Average {
+			return a.Average > b.Average
+		}
+		return codes[i] < codes[j]
+	})
+
 	fmt.Println("═══════════════════════════════════════")
-	fmt.Println("  AVAILABLE SUBSETS")
+	fmt.Println(tr("  MOVO RATINGS"))
 	fmt.Println("═══════════════════════════════════════")
 	fmt.Println()
 
-	// Display each subset
-	for name, subset := range subsetsConfig.Subsets {
-		fmt.Printf("📦 %s\n", name)
-		if subset.Description != "" {
-			fmt.Printf("   %s\n", subset.Description)
-		}
-		fmt.Printf("   %d movos\n", len(subset.Codes))
-		fmt.Println()
+	for _, code := range codes {
+		s := summaries[code]
+		fmt.Printf(tr("⭐ %.1f  %s (%d ratings)\n"), s.Average, code, s.Count)
 	}
-
-	fmt.Println("Usage:")
-	fmt.Printf("  movodoro get --subset SUBSET_NAME\n")
-	fmt.Printf("  movodoro --subset SUBSET_NAME          # Interactive mode\n")
-	fmt.Printf("  export MOVODORO_ACTIVE_SUBSET=SUBSET_NAME\n")
 }
 
-// handleMigrateLogsToCsv implements the 'migrate-logs-to-csv' command
-func handleMigrateLogsToCsv(args []string) {
-	cfg := appConfig
-
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println("  MIGRATE LOGS TO CSV FORMAT (v1.0.0)")
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Println()
+// promptRating asks for an optional 1-5 rating, returning 0 if none was given
+func promptRating(reader *bufio.Reader) int {
+	fmt.Print(tr("Enjoyment rating 1-5 (Enter to skip): "))
 
-	// Find all .log files
-	pattern := filepath.Join(cfg.LogsDir, "*.log")
-	files, err := filepath.Glob(pattern)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error finding log files: %v\n", err)
-		os.Exit(1)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return 0
 	}
 
-	if len(files) == 0 {
-		fmt.Println("No log files found.")
-		return
+	value, err := strconv.Atoi(input)
+	if err != nil || value < minRating || value > maxRating {
+		fmt.Fprint(os.Stderr, tr("Invalid rating, not saved\n"))
+		return 0
 	}
+	return value
+}
 
-	fmt.Printf("Found %d log file(s) to check\n\n", len(files))
+// loadRatingSummary returns the rating summary for a movo, if it has been rated
+func loadRatingSummary(code string) (RatingSummary, bool) {
+	ratings, err := loadAllRatings(appConfig)
+	if err != nil {
+		return RatingSummary{}, false
+	}
+	summary, ok := SummarizeRatings(ratings)[code]
+	return summary, ok
+}
 
-	converted := 0
-	skipped := 0
-	failed := 0
+// handleBatch implements the 'batch' command: get/done/skip lines read from stdin or a file
+func handleBatch(args []string) {
+	if len(args) != 1 {
+		fmt.Fprint(os.Stderr, tr("Usage: movodoro batch - | FILE\n"))
+		os.Exit(exitError)
+	}
 
-	for _, filePath := range files {
-		filename := filepath.Base(filePath)
-		
-		// Try to detect if it's already CSV format
-		file, err := os.Open(filePath)
+	input := os.Stdin
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
 		if err != nil {
-			fmt.Printf("⚠️  %s: Could not open (%v)\n", filename, err)
-			failed++
-			continue
+			fmt.Fprintf(os.Stderr, tr("Error opening batch file: %v\n"), err)
+			os.Exit(exitError)
 		}
+		defer file.Close()
+		input = file
+	}
 
-		scanner := bufio.NewScanner(file)
-		var firstLine string
-		if scanner.Scan() {
-			firstLine = scanner.Text()
-		}
-		file.Close()
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
 
-		// Check if already CSV (has header)
-		if strings.HasPrefix(firstLine, "timestamp,") {
-			fmt.Printf("✓  %s: Already in CSV format\n", filename)
-			skipped++
-			continue
-		}
+	// Start from the saved current snack so 'done' works without a prior 'get'
+	current, _ := loadCurrentSnack()
+	session := &batchSession{movos: snacks, current: current, out: os.Stdout}
 
-		// Old format detected - convert it
-		fmt.Printf("→  %s: Converting to CSV...\n", filename)
+	failed, err := runBatch(input, session, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, tr("%d batch command(s) failed\n"), failed)
+		os.Exit(exitError)
+	}
+}
 
-		// Read all old format lines
-		file, err = os.Open(filePath)
-		if err != nil {
-			fmt.Printf("⚠️  %s: Could not read (%v)\n", filename, err)
-			failed++
-			continue
+// findMovo returns the movo with the given full code, or nil if not found
+func findMovo(movos []Movo, code string) *Movo {
+	for i := range movos {
+		if movos[i].FullCode == code {
+			return &movos[i]
 		}
+	}
+	return nil
+}
 
-		var entries []HistoryEntry
-		scanner = bufio.NewScanner(file)
-		for scanner.Scan() {
-			line := scanner.Text()
-			if line == "" {
-				continue
-			}
+// parseInterspersed parses flags that may appear before or after positional arguments
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
 
-			// Parse old space-separated format: TIMESTAMP CODE STATUS DURATION RPE
-			parts := strings.Fields(line)
-			if len(parts) != 5 {
-				continue
-			}
+// stringList is a flag.Value collecting repeated string flags
+type stringList []string
 
-			timestamp, err := time.Parse(time.RFC3339, parts[0])
-			if err != nil {
-				continue
-			}
+func (s *stringList) String() string     { return strings.Join(*s, ",") }
+func (s *stringList) Set(v string) error { *s = append(*s, v); return nil }
 
-			duration, err := strconv.Atoi(parts[3])
-			if err != nil {
-				continue
-			}
+// handleMovos implements the 'movos' command (library maintenance subcommands)
+func handleMovos(args []string) {
+	if len(args) == 0 || args[0] != "set-field" {
+		fmt.Fprint(os.Stderr, tr("Usage: movodoro movos set-field --filter tag=kbx [--dry-run] FIELD=VALUE...\n"))
+		os.Exit(exitError)
+	}
 
-			rpe, err := strconv.Atoi(parts[4])
-			if err != nil {
-				continue
-			}
+	fs := flag.NewFlagSet("movos set-field", flag.ContinueOnError)
+	var filters stringList
+	var dryRun bool
+	fs.Var(&filters, "filter", "Select movos by tag=, category= or code= (repeatable)")
+	fs.BoolVar(&dryRun, "dry-run", false, "Show a diff without writing files")
+	positional := parseFlagsInterspersed(fs, args[1:])
+
+	if len(positional) == 0 {
+		fmt.Fprint(os.Stderr, tr("Error: no FIELD=VALUE assignments given\n"))
+		os.Exit(exitError)
+	}
+	if len(filters) == 0 {
+		fmt.Fprint(os.Stderr, tr("Error: at least one --filter is required (use --filter tag=... to edit a subset)\n"))
+		os.Exit(exitError)
+	}
+
+	sel, err := parseMovoSelector(filters)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
 
-			entries = append(entries, HistoryEntry{
-				Timestamp: timestamp,
-				Code:      parts[1],
-				Status:    parts[2],
-				Duration:  duration,
-				RPE:       rpe,
-				Subset:    "", // Old logs don't have subset info
-			})
+	var assignments []fieldAssignment
+	for _, arg := range positional {
+		a, err := parseFieldAssignment(arg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitError)
 		}
-		file.Close()
+		assignments = append(assignments, a)
+	}
+	if err := checkAssignments(assignments); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
 
-		if len(entries) == 0 {
-			fmt.Printf("⚠️  %s: No valid entries found\n", filename)
-			failed++
+	files, err := filepath.Glob(filepath.Join(appConfig.MovosDir, "*.yaml"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error finding YAML files: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	totalChanged := 0
+	filesChanged := 0
+	for _, file := range files {
+		if name := filepath.Base(file); name == "subsets.yaml" || name == "programs.yaml" {
 			continue
 		}
 
-		// Create backup
-		backupPath := filePath + ".bak"
-		if err := os.Rename(filePath, backupPath); err != nil {
-			fmt.Printf("⚠️  %s: Could not create backup (%v)\n", filename, err)
-			failed++
-			continue
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error reading %s: %v\n"), file, err)
+			os.Exit(exitStorage)
 		}
 
-		// Write new CSV format (with .csv extension)
-		// Change from .log to .csv extension
-		newFilePath := strings.TrimSuffix(filePath, ".log") + ".csv"
-		newFile, err := os.Create(newFilePath)
+		updated, changed, err := editCategoryFile(data, sel, assignments)
 		if err != nil {
-			// Restore backup
-			os.Rename(backupPath, filePath)
-			fmt.Printf("⚠️  %s: Could not create new file (%v)\n", filename, err)
-			failed++
+			fmt.Fprintf(os.Stderr, tr("Error editing %s: %v\n"), file, err)
+			os.Exit(exitLibrary)
+		}
+		if len(changed) == 0 {
 			continue
 		}
 
-		writer := csv.NewWriter(newFile)
-		
-		// Write header
-		if err := writer.Write([]string{"timestamp", "code", "status", "duration", "rpe", "subset"}); err != nil {
-			newFile.Close()
-			os.Rename(backupPath, filePath)
-			fmt.Printf("⚠️  %s: Could not write header (%v)\n", filename, err)
-			failed++
+		totalChanged += len(changed)
+		filesChanged++
+
+		if dryRun {
+			fmt.Print(unifiedDiff(file, file, string(data), string(updated)))
 			continue
 		}
 
-		// Write entries
-		for _, entry := range entries {
-			record := []string{
-				entry.Timestamp.Format(time.RFC3339),
-				entry.Code,
-				entry.Status,
-				strconv.Itoa(entry.Duration),
-				strconv.Itoa(entry.RPE),
-				entry.Subset,
-			}
-			if err := writer.Write(record); err != nil {
-				newFile.Close()
-				os.Rename(backupPath, filePath)
-				fmt.Printf("⚠️  %s: Could not write entries (%v)\n", filename, err)
-				failed++
-				continue
-			}
+		if err := os.WriteFile(file, updated, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error writing %s: %v\n"), file, err)
+			os.Exit(exitStorage)
+		}
+		fmt.Printf("✏️  %s: %s\n", filepath.Base(file), strings.Join(changed, ", "))
+	}
+
+	if totalChanged == 0 {
+		fmt.Println(tr("No movos matched (or all already had these values)."))
+		return
+	}
+
+	if dryRun {
+		fmt.Printf(tr("\nDry run: %d movo(s) in %d file(s) would change\n"), totalChanged, filesChanged)
+	} else {
+		fmt.Printf(tr("\n✅ Updated %d movo(s) in %d file(s)\n"), totalChanged, filesChanged)
+	}
+}
+
+// handleVerifyHistory implements the 'verify-history' command
+func handleVerifyHistory(args []string) {
+	fs := flag.NewFlagSet("verify-history", flag.ContinueOnError)
+	var initSums bool
+	fs.BoolVar(&initSums, "init", false, "Record checksums for all daily logs (accepting their current contents)")
+	parseFlags(fs, args)
+
+	if initSums {
+		count, err := initChecksums(appConfig.LogsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+			os.Exit(exitStorage)
 		}
+		fmt.Printf(tr("✅ Recorded checksums for %d daily log(s)\n"), count)
+		fmt.Println(tr("New entries will keep them up to date."))
+		return
+	}
 
-		writer.Flush()
-		newFile.Close()
+	checked, problems, err := verifyHistory(appConfig.LogsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitStorage)
+	}
 
-		newFilename := strings.TrimSuffix(filename, ".log") + ".csv"
-		fmt.Printf("✅ %s → %s: Converted %d entries (backup: %s.bak)\n", filename, newFilename, len(entries), filename)
-		converted++
+	if len(problems) == 0 {
+		fmt.Printf(tr("✅ %d daily log(s) verified\n"), checked)
+		return
 	}
 
+	fmt.Printf(tr("⚠️  %d of %d daily log(s) look suspect:\n"), len(problems), checked)
+	for _, p := range problems {
+		day := ""
+		if date, ok := logFileDate(p.File); ok {
+			day = " (" + appConfig.FormatDate(date) + ")"
+		}
+		fmt.Printf("   %s%s: %s\n", p.File, day, p.Problem)
+	}
 	fmt.Println()
-	fmt.Println("═══════════════════════════════════════")
-	fmt.Printf("Migration complete:\n")
-	fmt.Printf("  Converted: %d\n", converted)
-	fmt.Printf("  Skipped:   %d (already CSV)\n", skipped)
-	fmt.Printf("  Failed:    %d\n", failed)
-	fmt.Println("═══════════════════════════════════════")
-	
-	if converted > 0 {
+	fmt.Println(tr("Once you've checked them, run 'movodoro verify-history --init' to accept the current contents."))
+	os.Exit(exitError)
+}
+
+// handleArchive implements the 'archive' command: move old daily logs into
+// monthly archive files
+func handleArchive(args []string) {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	var before string
+	var dryRun bool
+	fs.StringVar(&before, "before", "", "Archive daily logs of days before this date (YYYY-MM-DD)")
+	fs.BoolVar(&dryRun, "dry-run", false, "Show what would be archived without changing anything")
+	parseFlags(fs, args)
+
+	if before == "" {
+		fmt.Fprintln(os.Stderr, tr("Usage: movodoro archive --before YYYY-MM-DD [--dry-run]"))
+		os.Exit(exitError)
+	}
+	cutoff, err := time.ParseInLocation(dayKeyFormat, before, time.Local)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: invalid date %q (use YYYY-MM-DD)\n"), before)
+		os.Exit(exitError)
+	}
+	if today := time.Now(); cutoff.After(today) {
+		fmt.Fprintln(os.Stderr, tr("Error: --before can't be in the future (today's log stays a daily log)"))
+		os.Exit(exitError)
+	}
+
+	result, err := archiveLogs(appConfig.LogsDir, cutoff, dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error archiving logs: %v\n"), err)
+		os.Exit(exitStorage)
+	}
+
+	if result.Files == 0 {
+		fmt.Printf(tr("No daily logs before %s to archive\n"), appConfig.FormatDate(cutoff))
+		return
+	}
+	verb := tr("Archived")
+	if dryRun {
+		verb = tr("Would archive")
+	}
+	fmt.Printf(tr("📦 %s %d daily log(s), %d entries, into %d monthly file(s) in %s\n"),
+		verb, result.Files, result.Entries, len(result.Months), filepath.Join(appConfig.LogsDir, archiveDirName))
+}
+
+// handleAnalyzeWeights implements the 'analyze-weights' command
+func handleAnalyzeWeights(args []string) {
+	fs, g := newGetFlagSet("analyze-weights", flag.ContinueOnError)
+	var iterations int
+	var jsonOutput bool
+	fs.IntVar(&iterations, "iterations", 5000, "Number of simulated selections")
+	fs.IntVar(&iterations, "n", 5000, "Number of simulated selections")
+	fs.BoolVar(&jsonOutput, "json", false, "Output JSON")
+	parseFlags(fs, args)
+
+	if iterations < 0 {
+		fmt.Fprint(os.Stderr, tr("Error: --iterations must not be negative\n"))
+		os.Exit(exitError)
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error loading snacks: %v\n"), err)
+		os.Exit(exitLibrary)
+	}
+
+	weighted, inRecoveryMode, err := weighCandidates(snacks, g.filterOptions(), appConfig.MaxDailyRPE)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitCodeOf(err, exitError))
+	}
+
+	analysis := weightAnalysis{
+		Iterations:      iterations,
+		ExplorationRate: appConfig.ExplorationRate,
+		RecoveryMode:    inRecoveryMode,
+		Excluded:        len(snacks) - len(weighted),
+		Movos:           analyzeWeights(selectorRand, weighted, iterations, appConfig.ExplorationRate),
+	}
+
+	collapsed := 0
+	for _, share := range analysis.Movos {
+		if share.Collapsed {
+			collapsed++
+		}
+	}
+
+	if jsonOutput || outputJSON {
+		writeJSON(os.Stdout, analysis)
+	} else {
+		fmt.Println("═══════════════════════════════════════")
+		fmt.Println(tr("  SELECTION WEIGHT ANALYSIS"))
+		fmt.Println("═══════════════════════════════════════")
 		fmt.Println()
-		fmt.Println("Backup files (.bak) have been created.")
-		fmt.Println("After verifying the migration, you can delete them:")
-		fmt.Printf("  rm %s/*.bak\n", cfg.LogsDir)
+		fmt.Printf(tr("Candidates:  %d (%d excluded by filters, limits or daily priority)\n"), len(analysis.Movos), analysis.Excluded)
+		fmt.Printf(tr("Iterations:  %d\n"), iterations)
+		if analysis.ExplorationRate > 0 {
+			fmt.Printf(tr("Exploration: %.0f%%\n"), analysis.ExplorationRate*100)
+		}
+		if inRecoveryMode {
+			fmt.Println(tr("🔋 Auto-recovery mode is active (RPE ≤ 2)"))
+		}
+		fmt.Println()
+
+		fmt.Printf("   %-32s %8s %9s %9s\n", tr("Code"), tr("Weight"), tr("Expected"), tr("Observed"))
+		for _, share := range analysis.Movos {
+			marker := "  "
+			if share.Collapsed {
+				marker = "⚠️"
+			}
+			fmt.Printf("%s %-32s %8.2f %8.2f%% %8.2f%%\n",
+				marker, share.Code, share.Weight, share.Expected*100, share.Observed*100)
+		}
+		fmt.Println()
+
+		if collapsed > 0 {
+			fmt.Printf(tr("⚠️  %d movo(s) have collapsed to under %.0f%% of an even share\n"), collapsed, collapseFactor*100)
+		} else {
+			fmt.Println(tr("✅ No movo's probability has collapsed"))
+		}
+	}
+
+	if collapsed > 0 {
+		os.Exit(exitError)
 	}
 }
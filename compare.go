@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rampWarnPercent is the rise in minutes or RPE over the previous period that gets
+// flagged (the usual 10% rule for increasing training load)
+const rampWarnPercent = 10
+
+// reportSpan is one side of a comparison
+type reportSpan struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Movos   int    `json:"movos"`
+	Minutes int    `json:"minutes"`
+	RPE     int    `json:"rpe"`
+	Skipped int    `json:"skipped"`
+}
+
+// categoryChange is one category's minutes and movos in both periods
+type categoryChange struct {
+	Category        string `json:"category"`
+	Movos           int    `json:"movos"`
+	PreviousMovos   int    `json:"previous_movos"`
+	Minutes         int    `json:"minutes"`
+	PreviousMinutes int    `json:"previous_minutes"`
+}
+
+// periodComparison is 'report compare': the current period so far against the
+// same days of the previous one
+type periodComparison struct {
+	Period     string           `json:"period"`
+	Current    reportSpan       `json:"current"`
+	Previous   reportSpan       `json:"previous"`
+	Categories []categoryChange `json:"categories"`
+}
+
+// compareRanges returns the day, week or month containing now up to today, and the
+// same run of days at the start of the period before (clamped to a shorter month)
+func compareRanges(period string, now time.Time) (current, previous reportRange, err error) {
+	if current, err = periodRange(period, now); err != nil {
+		return current, previous, fmt.Errorf("compare works with day, week and month periods, not %s", period)
+	}
+	current.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	elapsed := current.days()
+
+	switch period {
+	case "month":
+		previous.From = current.From.AddDate(0, -1, 0)
+		monthEnd := current.From.AddDate(0, 0, -1)
+		previous.To = previous.From.AddDate(0, 0, elapsed-1)
+		if previous.To.After(monthEnd) {
+			previous.To = monthEnd
+		}
+	case "week":
+		previous = reportRange{From: current.From.AddDate(0, 0, -7), To: current.To.AddDate(0, 0, -7)}
+	default:
+		previous = reportRange{From: current.From.AddDate(0, 0, -1), To: current.To.AddDate(0, 0, -1)}
+	}
+	return current, previous, nil
+}
+
+// buildComparison totals both periods and lines up their categories, biggest
+// current minutes first
+func buildComparison(period string, entries []HistoryEntry, current, previous reportRange, movos map[string]*Movo) periodComparison {
+	span := func(rng reportRange) (reportSpan, []categoryTotals) {
+		report := buildMonthReport(entries, rng.From, rng.days(), movos)
+		t := report.Total
+		return reportSpan{From: dayKey(rng.From), To: dayKey(rng.To), Movos: t.Movos, Minutes: t.Minutes, RPE: t.RPE, Skipped: t.Skipped}, report.Categories
+	}
+
+	c := periodComparison{Period: period, Categories: []categoryChange{}}
+	var currentCats, previousCats []categoryTotals
+	c.Current, currentCats = span(current)
+	c.Previous, previousCats = span(previous)
+
+	byName := make(map[string]*categoryChange)
+	change := func(name string) *categoryChange {
+		if byName[name] == nil {
+			byName[name] = &categoryChange{Category: name}
+		}
+		return byName[name]
+	}
+	for _, cat := range currentCats {
+		ch := change(cat.Category)
+		ch.Movos, ch.Minutes = cat.Movos, cat.Minutes
+	}
+	for _, cat := range previousCats {
+		ch := change(cat.Category)
+		ch.PreviousMovos, ch.PreviousMinutes = cat.Movos, cat.Minutes
+	}
+	for _, ch := range byName {
+		c.Categories = append(c.Categories, *ch)
+	}
+	sort.Slice(c.Categories, func(i, j int) bool {
+		if c.Categories[i].Minutes != c.Categories[j].Minutes {
+			return c.Categories[i].Minutes > c.Categories[j].Minutes
+		}
+		return c.Categories[i].Category < c.Categories[j].Category
+	})
+	return c
+}
+
+// formatDelta describes the change from previous to current, e.g. "▲ 20 (+25%)"
+func formatDelta(current, previous int) string {
+	diff := current - previous
+	switch {
+	case diff == 0:
+		return "="
+	case previous == 0:
+		return trf("▲ %d (new)", diff)
+	case diff > 0:
+		return fmt.Sprintf("▲ %d (+%d%%)", diff, diff*100/previous)
+	default:
+		return fmt.Sprintf("▼ %d (%d%%)", -diff, diff*100/previous)
+	}
+}
+
+// rampedUp reports whether current is more than rampWarnPercent above previous
+func rampedUp(current, previous int) bool {
+	return previous > 0 && (current-previous)*100 > previous*rampWarnPercent
+}
+
+// writeComparison prints both periods side by side with the change in each total and
+// category, and flags minutes or RPE rising faster than the 10% rule
+func writeComparison(w io.Writer, c periodComparison) {
+	this, last := tr("Today"), tr("Yesterday")
+	switch c.Period {
+	case "week":
+		this, last = tr("This week"), tr("Last week")
+	case "month":
+		this, last = tr("This month"), tr("Last month")
+	}
+
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, tr("  %s VS %s\n"), strings.ToUpper(this), strings.ToUpper(last))
+	fmt.Fprintf(w, tr("  %s to %s vs %s to %s\n"), c.Current.From, c.Current.To, c.Previous.From, c.Previous.To)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "  %-10s %6s %6s   %s\n", "", tr("This"), tr("Last"), tr("Change"))
+	rows := []struct {
+		label          string
+		current, prior int
+	}{
+		{tr("Movos"), c.Current.Movos, c.Previous.Movos},
+		{tr("Minutes"), c.Current.Minutes, c.Previous.Minutes},
+		{tr("RPE"), c.Current.RPE, c.Previous.RPE},
+		{tr("Skipped"), c.Current.Skipped, c.Previous.Skipped},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "  %-10s %6d %6d   %s\n", row.label, row.current, row.prior, formatDelta(row.current, row.prior))
+	}
+
+	if len(c.Categories) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, tr("📂 Category mix (minutes):"))
+		for _, cat := range c.Categories {
+			fmt.Fprintf(w, "  %-22s %4d %4d   %s\n", cat.Category, cat.Minutes, cat.PreviousMinutes, formatDelta(cat.Minutes, cat.PreviousMinutes))
+		}
+	}
+
+	// Minutes and RPE are the load measures
+	var ramped []string
+	for _, row := range rows[1:3] {
+		if rampedUp(row.current, row.prior) {
+			ramped = append(ramped, row.label)
+		}
+	}
+	if len(ramped) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, tr("⚠️  %s up more than %d%% on %s\n"), strings.Join(ramped, tr(" and ")), rampWarnPercent, strings.ToLower(last))
+	}
+}
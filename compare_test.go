@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareRanges(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	tests := []struct {
+		period    string
+		now       time.Time
+		cur, prev [2]string
+	}{
+		{"week", time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local), [2]string{"2025-03-03", "2025-03-05"}, [2]string{"2025-02-24", "2025-02-26"}},
+		{"month", time.Date(2025, 3, 30, 9, 0, 0, 0, time.Local), [2]string{"2025-03-01", "2025-03-30"}, [2]string{"2025-02-01", "2025-02-28"}},
+		{"day", time.Date(2025, 3, 1, 9, 0, 0, 0, time.Local), [2]string{"2025-03-01", "2025-03-01"}, [2]string{"2025-02-28", "2025-02-28"}},
+	}
+	for _, tt := range tests {
+		cur, prev, err := compareRanges(tt.period, tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := [4]string{dayKey(cur.From), dayKey(cur.To), dayKey(prev.From), dayKey(prev.To)}
+		want := [4]string{tt.cur[0], tt.cur[1], tt.prev[0], tt.prev[1]}
+		if got != want {
+			t.Errorf("%s: got %v, want %v", tt.period, got, want)
+		}
+	}
+}
+
+func TestBuildComparison(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	now := time.Date(2025, 3, 5, 18, 0, 0, 0, time.Local)
+	cur, prev, _ := compareRanges("week", now)
+	entry := func(code string, day, minutes, rpe int) HistoryEntry {
+		return HistoryEntry{Timestamp: time.Date(2025, 2, day, 9, 0, 0, 0, time.Local), Code: code, Status: "done", Duration: minutes, RPE: rpe}
+	}
+	entries := []HistoryEntry{
+		entry("KB-swings", 24, 10, 6),
+		entry("MOB-hips", 25, 10, 2),
+		entry("KB-swings", 28, 5, 7), // Last Friday: after the days compared
+		{Timestamp: time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local), Code: "KB-swings", Status: "done", Duration: 20, RPE: 7},
+		{Timestamp: time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local), Code: "KB-swings", Status: "done", Duration: 10, RPE: 7},
+	}
+
+	c := buildComparison("week", entries, cur, prev, nil)
+	if c.Current.Minutes != 30 || c.Previous.Minutes != 20 || c.Current.RPE != 14 || c.Previous.RPE != 8 {
+		t.Fatalf("unexpected totals: %+v vs %+v", c.Current, c.Previous)
+	}
+	if len(c.Categories) != 2 || c.Categories[0].Category != "KB" || c.Categories[0].PreviousMinutes != 10 || c.Categories[1].Minutes != 0 {
+		t.Errorf("unexpected categories: %+v", c.Categories)
+	}
+
+	var b strings.Builder
+	writeComparison(&b, c)
+	out := b.String()
+	if !strings.Contains(out, "▲ 10 (+50%)") || !strings.Contains(out, "⚠️  Minutes and RPE up more than 10% on last week") {
+		t.Errorf("expected deltas and a ramp warning, got:\n%s", out)
+	}
+}
+
+func TestFormatDelta(t *testing.T) {
+	tests := []struct {
+		current, previous int
+		want              string
+	}{
+		{5, 5, "="},
+		{5, 0, "▲ 5 (new)"},
+		{12, 10, "▲ 2 (+20%)"},
+		{5, 10, "▼ 5 (-50%)"},
+	}
+	for _, tt := range tests {
+		if got := formatDelta(tt.current, tt.previous); got != tt.want {
+			t.Errorf("formatDelta(%d, %d) = %q, want %q", tt.current, tt.previous, got, tt.want)
+		}
+	}
+}
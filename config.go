@@ -1,8 +1,15 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds configuration for the application
@@ -11,11 +18,179 @@ type Config struct {
 	CurrentPath   string
 	MovosDir      string
 	MaxDailyRPE   int
-	ActiveSubset  string // From MOVODORO_ACTIVE_SUBSET env var
+	ActiveSubset  string // From MOVODORO_ACTIVE_SUBSET env var, or the program's current block
+	ConfigPath    string // Optional config.yaml in the movodoro home directory
+	ConfigErr     error  // Set if config.yaml exists but could not be loaded
+	EODDir        string // Directory for end-of-day markdown summaries
+	EODNotify     bool   // Send a desktop notification when the summary is written
+	EODEmail      string // Mail the summary to this address via sendmail
+	RatingsPath   string // CSV file storing 1-5 enjoyment ratings
+	RateAfterDone bool   // Prompt for a rating after marking a movo done
+	RatingWeight  bool   // Fold average ratings into selection weight
+	// Chance (0-1) of ignoring weights and picking uniformly among eligible movos
+	ExplorationRate float64
+	WeekStart       time.Weekday // First day of the week for weekly reports and limits
+	Clock12h        bool         // Show times as 3:04pm instead of 15:04
+	DateFormat      string       // Go time layout for report dates
+	// Completions before this time of day may count toward yesterday's streak
+	GraceUntil time.Duration
+	// Time of day watch writes the end-of-day summary at (0 for never)
+	EODAt time.Duration
+	// Queue incomplete everyday movos (lowest RPE first) at the first interactive session of the day
+	EverydayQueue     bool
+	EverydayQueuePath string
+	SnoozePath        string   // Movos snoozed with 'snooze' and until when
+	QueuePath         string   // Movos lined up for later today with 'queue add'
+	RecentPath        string   // The last movos shown, for the anti-repeat window
+	FavoritesPath     string   // Movos marked with 'fav add'
+	BansPath          string   // Movos kept out of selection with 'ban'
+	OverridesPath     string   // Local weight, RPE and duration overrides for library movos
+	SpoolPath         string   // Entries queued while the logs dir is unavailable
+	PackRegistry      string   // URL or file listing the packs 'packs install NAME' can fetch
+	Profile           string   // Active config profile (MOVODORO_PROFILE / --config-profile)
+	ProfileNames      []string // Every profile defined in config.yaml, sorted
+	// Selection boosts (a profile may override them)
+	NeverDoneBoost  float64
+	RecencyBoost    float64
+	RecencyDays     int
+	FavoriteBoost   float64
+	CategoryWeights map[string]float64 // Extra weight multipliers by category code
+	// Anti-repeat: movos among the last RepeatWindow shown, or shown or done in the
+	// last RepeatHours, are heavily penalized (0 turns either off)
+	RepeatWindow int
+	RepeatHours  int
+	Variety      string // Selection variety: low, normal or high
+	// Weighted random (default) or rotation through categories, in Rotation's order first
+	SelectionMode string
+	Rotation      []string
+	// Boost categories or tags under-represented in the last week ("" off, category or tag)
+	BalanceBy string
+	Card      CardDisplay // What the movo card shows
+	// Kid mode: no RPE prompts, fixed durations, cheers and a sticker chart
+	KidMode bool
+	Seed    uint64 // Fixed selection seed for reproducible runs (0 seeds randomly)
+	// Ask before logging more than this many entries in a day (0 disables the guard)
+	MaxEntriesPerDay int
+	// Completions before a movo's median logged duration becomes its default (0 disables)
+	HistoryDurationSamples int
+	SkipPolicy             string // What skips mean for streaks and activity days: neutral, excuse or break
+	DuplicateCodes         string // What loading does when a full code is defined twice: error or warn
+	Language               string // Locale for CLI output, e.g. es (MOVODORO_LANG beats config.yaml)
+	// Rest days: weekdays from rest_days plus dates taken off with 'rest'. The selector
+	// only offers RPE ≤ 2 and everyday minimums above RestEverydayRPE are waived.
+	RestDays        []time.Weekday
+	RestDatesPath   string
+	RestEverydayRPE int
+	// Strava upload for 'sync strava'; the token and what's been uploaded are kept alongside
+	Strava           StravaConfig
+	StravaTokenPath  string
+	StravaSyncedPath string
+	Hooks            HooksConfig // URLs to POST each logged entry to
+	Post             PostConfig  // Chat webhooks for 'report --post'
+	Sync             SyncConfig  // Git remote the logs dir is synced with
+	// Where the active program in programs.yaml is today (nil without one)
+	Program *programPosition
+	// Contraindications to keep out of selection, e.g. during a flare-up
+	Avoid []string
+	// Equipment on hand: selection leaves out movos needing anything else (if LimitEquipment)
+	Equipment      []string
+	LimitEquipment bool
+}
+
+// CardDisplay controls which details appear on the movo card
+type CardDisplay struct {
+	RPE        bool
+	Code       bool
+	Tags       bool
+	Category   bool // Category name
+	LastDone   bool // Date the movo was last completed
+	TodayCount bool // Times completed today
+}
+
+const defaultDateFormat = "Monday, January 2, 2006"
+
+// defaultMaxEntriesPerDay is far more than a real day of movos, so hitting it
+// usually means a runaway script or a stuck key
+const defaultMaxEntriesPerDay = 40
+
+// fileConfig mirrors the optional config.yaml file
+type fileConfig struct {
+	EODDir        string `yaml:"eod_dir"`
+	PackRegistry  string `yaml:"pack_registry"`
+	EODNotify     bool   `yaml:"eod_notify"`
+	EODEmail      string `yaml:"eod_email"`
+	EODAt         string `yaml:"eod_at"` // e.g. "21:55"
+	RateAfterDone bool   `yaml:"rate_after_done"`
+	RatingWeight  bool   `yaml:"rating_weight"`
+
+	ExplorationRate float64 `yaml:"exploration_rate"`
+	WeekStart       string  `yaml:"week_start"`  // e.g. monday, sunday
+	Clock           string  `yaml:"clock"`       // 24h or 12h
+	DateFormat      string  `yaml:"date_format"` // Go layout, e.g. "Mon 02 Jan 2006"
+	GraceUntil      string  `yaml:"grace_until"` // e.g. "10:00"
+	EverydayQueue   bool    `yaml:"everyday_queue"`
+	KidMode         bool    `yaml:"kid_mode"`
+	Seed            uint64  `yaml:"seed"`
+	SkipPolicy      string  `yaml:"skip_policy"`
+	DuplicateCodes  string  `yaml:"duplicate_codes"`
+	Language        string  `yaml:"language"`
+	Variety         string  `yaml:"variety"`
+	SelectionMode   string  `yaml:"selection_mode"`
+	FavoriteBoost   float64 `yaml:"favorite_boost"`
+	BalanceBy       string  `yaml:"balance_by"`
+	// Pointers so an explicit 0 can turn these off
+	MaxEntriesPerDay       *int `yaml:"max_entries_per_day"`
+	HistoryDurationSamples *int `yaml:"history_duration_samples"`
+	RestEverydayRPE        *int `yaml:"rest_everyday_rpe"`
+	RepeatWindow           *int `yaml:"repeat_window"`
+	RepeatHours            *int `yaml:"repeat_hours"`
+	// Unset allows any equipment; [] means none
+	Equipment *[]string `yaml:"equipment"`
+
+	Profiles map[string]profileConfig `yaml:"profiles"`
+	Card     cardFileConfig           `yaml:"card"`
+	Strava   stravaFileConfig         `yaml:"strava"`
+	Hooks    HooksConfig              `yaml:"hooks"`
+	Post     PostConfig               `yaml:"post"`
+	Sync     SyncConfig               `yaml:"sync"`
+	RestDays []string                 `yaml:"rest_days"` // e.g. [sunday]
+	Rotation []string                 `yaml:"rotation"`  // Category codes, e.g. [RB, TS, CF, MOB]
+	Avoid    []string                 `yaml:"avoid"`     // e.g. [spinal-flexion, overhead]
+}
+
+// cardFileConfig mirrors the card section of config.yaml (unset keeps the default)
+type cardFileConfig struct {
+	ShowRPE        *bool `yaml:"show_rpe"`
+	ShowCode       *bool `yaml:"show_code"`
+	ShowTags       *bool `yaml:"show_tags"`
+	ShowCategory   *bool `yaml:"show_category"`
+	ShowLastDone   *bool `yaml:"show_last_done"`
+	ShowTodayCount *bool `yaml:"show_today_count"`
+}
+
+// profileConfig holds the settings a named profile may override
+type profileConfig struct {
+	MaxDailyRPE     *int               `yaml:"max_daily_rpe"`
+	ExplorationRate *float64           `yaml:"exploration_rate"`
+	RatingWeight    *bool              `yaml:"rating_weight"`
+	NeverDoneBoost  *float64           `yaml:"never_done_boost"`
+	RecencyBoost    *float64           `yaml:"recency_boost"`
+	RecencyDays     *int               `yaml:"recency_days"`
+	CategoryWeights map[string]float64 `yaml:"category_weights"`
+	KidMode         *bool              `yaml:"kid_mode"`
+	LogsDir         *string            `yaml:"logs_dir"` // Separate history, e.g. per household member
+	Equipment       *[]string          `yaml:"equipment"`
+	Avoid           []string           `yaml:"avoid"` // Replaces the top-level list
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
+	// Named profile from config.yaml (set by --config-profile)
+	return loadConfig(os.Getenv("MOVODORO_PROFILE"))
+}
+
+// loadConfig returns the configuration with the named profile applied ("" for none)
+func loadConfig(profile string) *Config {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		home = "."
@@ -31,21 +206,409 @@ func DefaultConfig() *Config {
 	// Check for MOVODORO_ACTIVE_SUBSET environment variable
 	activeSubset := os.Getenv("MOVODORO_ACTIVE_SUBSET")
 
-	return &Config{
-		LogsDir:      filepath.Join(home, ".movodoro", "logs"),
-		CurrentPath:  filepath.Join(home, ".movodoro", "current"),
-		MovosDir:     movosDir,
-		MaxDailyRPE:  30,
-		ActiveSubset: activeSubset,
+	cfg := &Config{
+		LogsDir:                filepath.Join(home, ".movodoro", "logs"),
+		CurrentPath:            filepath.Join(home, ".movodoro", "current"),
+		MovosDir:               movosDir,
+		MaxDailyRPE:            30,
+		ActiveSubset:           activeSubset,
+		ConfigPath:             filepath.Join(home, ".movodoro", "config.yaml"),
+		RatingsPath:            filepath.Join(home, ".movodoro", "ratings.csv"),
+		EverydayQueuePath:      filepath.Join(home, ".movodoro", "everyday-queue"),
+		SnoozePath:             filepath.Join(home, ".movodoro", "snoozed"),
+		QueuePath:              filepath.Join(home, ".movodoro", "queue"),
+		RecentPath:             filepath.Join(home, ".movodoro", "recent"),
+		FavoritesPath:          filepath.Join(home, ".movodoro", "favorites"),
+		BansPath:               filepath.Join(home, ".movodoro", "banned"),
+		OverridesPath:          filepath.Join(home, ".movodoro", "overrides.yaml"),
+		RestDatesPath:          filepath.Join(home, ".movodoro", "rest-days"),
+		RestEverydayRPE:        defaultRestEverydayRPE,
+		SpoolPath:              filepath.Join(home, ".movodoro", "spool.csv"),
+		WeekStart:              time.Monday,
+		DateFormat:             defaultDateFormat,
+		Profile:                profile,
+		NeverDoneBoost:         neverDoneBoost,
+		RecencyBoost:           recencyBoost,
+		RecencyDays:            recencyDays,
+		FavoriteBoost:          defaultFavoriteBoost,
+		RepeatWindow:           defaultRepeatWindow,
+		Card:                   defaultCardDisplay(),
+		MaxEntriesPerDay:       defaultMaxEntriesPerDay,
+		HistoryDurationSamples: defaultHistoryDurationSamples,
+		SkipPolicy:             skipNeutral,
+		DuplicateCodes:         duplicatesError,
+		Language:               defaultLocale,
+		Variety:                varietyNormal,
+		SelectionMode:          selectionWeighted,
+		Strava:                 StravaConfig{MinDuration: defaultStravaMinDuration, SportType: defaultStravaSportType},
+		StravaTokenPath:        filepath.Join(home, ".movodoro", "strava-token.json"),
+		StravaSyncedPath:       filepath.Join(home, ".movodoro", "strava-synced.csv"),
+	}
+
+	// Apply settings from config.yaml (missing file is not an error)
+	fc, err := loadFileConfig(cfg.ConfigPath)
+	if err != nil {
+		cfg.ConfigErr = err
+		return cfg
+	}
+	cfg.EODDir = expandHome(fc.EODDir, home)
+	cfg.PackRegistry = expandHome(fc.PackRegistry, home)
+	cfg.EODNotify = fc.EODNotify
+	cfg.EODEmail = fc.EODEmail
+	cfg.RateAfterDone = fc.RateAfterDone
+	cfg.RatingWeight = fc.RatingWeight
+	cfg.EverydayQueue = fc.EverydayQueue
+	cfg.KidMode = fc.KidMode
+	cfg.Seed = fc.Seed
+	cfg.Hooks = fc.Hooks
+	cfg.Post = fc.Post
+	cfg.Sync = fc.Sync
+	if policy, err := parseConflictPolicy(fc.Sync.Conflict); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.Sync.Conflict = policy
+	}
+	if policy, err := parseSkipPolicy(fc.SkipPolicy); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.SkipPolicy = policy
+	}
+	language := fc.Language
+	if env := os.Getenv("MOVODORO_LANG"); env != "" {
+		language = env
+	}
+	if locale, err := parseLocale(language); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.Language = locale
+	}
+	if policy, err := parseDuplicatePolicy(fc.DuplicateCodes); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.DuplicateCodes = policy
+	}
+	if variety, err := parseVariety(fc.Variety); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.Variety = variety
+	}
+	if fc.FavoriteBoost < 0 {
+		cfg.ConfigErr = fmt.Errorf("favorite_boost must not be negative, got %g", fc.FavoriteBoost)
+	} else if fc.FavoriteBoost > 0 {
+		cfg.FavoriteBoost = fc.FavoriteBoost
+	}
+	if mode, err := parseSelectionMode(fc.SelectionMode); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.SelectionMode = mode
+	}
+	cfg.Rotation = fc.Rotation
+	if by, err := parseBalanceBy(fc.BalanceBy); err != nil {
+		cfg.ConfigErr = err
+	} else {
+		cfg.BalanceBy = by
+	}
+	if fc.MaxEntriesPerDay != nil {
+		if *fc.MaxEntriesPerDay < 0 {
+			cfg.ConfigErr = fmt.Errorf("max_entries_per_day must not be negative, got %d", *fc.MaxEntriesPerDay)
+		} else {
+			cfg.MaxEntriesPerDay = *fc.MaxEntriesPerDay
+		}
+	}
+	if fc.HistoryDurationSamples != nil {
+		if *fc.HistoryDurationSamples < 0 {
+			cfg.ConfigErr = fmt.Errorf("history_duration_samples must not be negative, got %d", *fc.HistoryDurationSamples)
+		} else {
+			cfg.HistoryDurationSamples = *fc.HistoryDurationSamples
+		}
+	}
+	if fc.RepeatWindow != nil {
+		if *fc.RepeatWindow < 0 {
+			cfg.ConfigErr = fmt.Errorf("repeat_window must not be negative, got %d", *fc.RepeatWindow)
+		} else {
+			cfg.RepeatWindow = *fc.RepeatWindow
+		}
+	}
+	if fc.RepeatHours != nil {
+		if *fc.RepeatHours < 0 {
+			cfg.ConfigErr = fmt.Errorf("repeat_hours must not be negative, got %d", *fc.RepeatHours)
+		} else {
+			cfg.RepeatHours = *fc.RepeatHours
+		}
+	}
+	if fc.RestEverydayRPE != nil {
+		if *fc.RestEverydayRPE < 0 {
+			cfg.ConfigErr = fmt.Errorf("rest_everyday_rpe must not be negative, got %d", *fc.RestEverydayRPE)
+		} else {
+			cfg.RestEverydayRPE = *fc.RestEverydayRPE
+		}
+	}
+	for _, name := range fc.RestDays {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			cfg.ConfigErr = fmt.Errorf("rest_days: %w", err)
+			break
+		}
+		if !slices.Contains(cfg.RestDays, weekday) {
+			cfg.RestDays = append(cfg.RestDays, weekday)
+		}
+	}
+	if len(cfg.RestDays) == 7 {
+		cfg.ConfigErr = fmt.Errorf("rest_days can't cover the whole week")
+		cfg.RestDays = nil
+	}
+	cfg.setEquipment(fc.Equipment)
+	cfg.Avoid = fc.Avoid
+	fc.Card.apply(&cfg.Card)
+	if err := fc.Strava.apply(&cfg.Strava); err != nil {
+		cfg.ConfigErr = err
+	}
+	if fc.ExplorationRate < 0 || fc.ExplorationRate > 1 {
+		cfg.ConfigErr = fmt.Errorf("exploration_rate must be between 0 and 1, got %g", fc.ExplorationRate)
+	} else {
+		cfg.ExplorationRate = fc.ExplorationRate
+	}
+	if fc.WeekStart != "" {
+		weekday, err := parseWeekday(fc.WeekStart)
+		if err != nil {
+			cfg.ConfigErr = err
+		} else {
+			cfg.WeekStart = weekday
+		}
+	}
+	switch strings.ToLower(fc.Clock) {
+	case "", "24h":
+	case "12h":
+		cfg.Clock12h = true
+	default:
+		cfg.ConfigErr = fmt.Errorf("clock must be 12h or 24h, got %q", fc.Clock)
+	}
+	if fc.DateFormat != "" {
+		cfg.DateFormat = fc.DateFormat
+	}
+	if fc.EODAt != "" {
+		at, err := parseTimeOfDay(fc.EODAt)
+		if err != nil {
+			cfg.ConfigErr = fmt.Errorf("eod_at: %w", err)
+		} else {
+			cfg.EODAt = at
+		}
+	}
+	if fc.GraceUntil != "" {
+		grace, err := parseTimeOfDay(fc.GraceUntil)
+		if err != nil {
+			cfg.ConfigErr = fmt.Errorf("grace_until: %w", err)
+		} else {
+			cfg.GraceUntil = grace
+		}
+	}
+
+	for name := range fc.Profiles {
+		cfg.ProfileNames = append(cfg.ProfileNames, name)
+	}
+	sort.Strings(cfg.ProfileNames)
+
+	if profile != "" {
+		p, ok := fc.Profiles[profile]
+		if !ok {
+			cfg.ConfigErr = fmt.Errorf("unknown config profile %q", profile)
+		} else if err := cfg.applyProfile(p, home); err != nil {
+			cfg.ConfigErr = fmt.Errorf("profile %s: %w", profile, err)
+		} else if p.LogsDir != nil {
+			// Separate history needs its own spool
+			cfg.SpoolPath = filepath.Join(home, ".movodoro", "spool-"+profile+".csv")
+		}
+	}
+
+	// The active program's current block adjusts settings on top of any profile
+	if err := cfg.applyProgram(time.Now()); err != nil {
+		cfg.ConfigErr = err
 	}
+
+	return cfg
+}
+
+// defaultCardDisplay shows RPE, code and tags, as the card always has
+func defaultCardDisplay() CardDisplay {
+	return CardDisplay{RPE: true, Code: true, Tags: true}
+}
+
+// apply overrides card defaults with any options set in config.yaml
+func (fc cardFileConfig) apply(card *CardDisplay) {
+	set := func(dst *bool, src *bool) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	set(&card.RPE, fc.ShowRPE)
+	set(&card.Code, fc.ShowCode)
+	set(&card.Tags, fc.ShowTags)
+	set(&card.Category, fc.ShowCategory)
+	set(&card.LastDone, fc.ShowLastDone)
+	set(&card.TodayCount, fc.ShowTodayCount)
+}
+
+// applyProfile overrides settings with those set in a profile
+func (c *Config) applyProfile(p profileConfig, home string) error {
+	if p.MaxDailyRPE != nil {
+		if *p.MaxDailyRPE <= 0 {
+			return fmt.Errorf("max_daily_rpe must be positive, got %d", *p.MaxDailyRPE)
+		}
+		c.MaxDailyRPE = *p.MaxDailyRPE
+	}
+	if p.ExplorationRate != nil {
+		if *p.ExplorationRate < 0 || *p.ExplorationRate > 1 {
+			return fmt.Errorf("exploration_rate must be between 0 and 1, got %g", *p.ExplorationRate)
+		}
+		c.ExplorationRate = *p.ExplorationRate
+	}
+	if p.RatingWeight != nil {
+		c.RatingWeight = *p.RatingWeight
+	}
+	if p.NeverDoneBoost != nil {
+		c.NeverDoneBoost = *p.NeverDoneBoost
+	}
+	if p.RecencyBoost != nil {
+		c.RecencyBoost = *p.RecencyBoost
+	}
+	if p.RecencyDays != nil {
+		c.RecencyDays = *p.RecencyDays
+	}
+	if p.KidMode != nil {
+		c.KidMode = *p.KidMode
+	}
+	if p.LogsDir != nil {
+		if *p.LogsDir == "" {
+			return fmt.Errorf("logs_dir must not be empty")
+		}
+		c.LogsDir = expandHome(*p.LogsDir, home)
+	}
+	c.setEquipment(p.Equipment)
+	if p.Avoid != nil {
+		c.Avoid = p.Avoid
+	}
+	if len(p.CategoryWeights) > 0 {
+		c.CategoryWeights = make(map[string]float64, len(p.CategoryWeights))
+		for code, weight := range p.CategoryWeights {
+			c.CategoryWeights[strings.ToUpper(code)] = weight
+		}
+	}
+	return nil
+}
+
+// setEquipment limits selection to the equipment listed, if there's a list
+func (c *Config) setEquipment(equipment *[]string) {
+	if equipment == nil {
+		return
+	}
+	c.LimitEquipment = true
+	c.Equipment = nil
+	for _, item := range *equipment {
+		if item = normalizeEquipment(item); item != "" {
+			c.Equipment = append(c.Equipment, item)
+		}
+	}
+}
+
+// loadFileConfig reads config.yaml, returning an empty config if it doesn't exist
+func loadFileConfig(path string) (*fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &fileConfig{}, nil
+		}
+		return nil, fmt.Errorf("error reading config.yaml: %w", err)
+	}
+
+	var fc fileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return nil, fmt.Errorf("error parsing config.yaml: %w", err)
+	}
+
+	return &fc, nil
+}
+
+// expandHome replaces a leading ~ in path with the home directory
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(home, path[2:])
+	}
+	return path
+}
+
+// parseWeekday parses a weekday name such as "monday" or "Sun"
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("unknown weekday %q", name)
+}
+
+// parseTimeOfDay parses "HH:MM" into a duration since midnight
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q (use HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// FormatDate formats a date using the configured date format
+func (c *Config) FormatDate(t time.Time) string {
+	return localizeDate(t.Format(c.DateFormat))
+}
+
+// FormatClock formats a time of day using the configured 12h/24h clock
+func (c *Config) FormatClock(t time.Time) string {
+	if c.Clock12h {
+		return t.Format("3:04pm")
+	}
+	return t.Format("15:04")
+}
+
+// WeekStartDate returns midnight on the first day of the week containing t
+func (c *Config) WeekStartDate(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) - int(c.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
 }
 
 // TestConfig returns a configuration for testing
 func TestConfig(testDir string) *Config {
 	return &Config{
-		LogsDir:     filepath.Join(testDir, "logs"),
-		CurrentPath: filepath.Join(testDir, "current"),
-		MovosDir:    filepath.Join(testDir, "test-movos"),
-		MaxDailyRPE: 30,
+		LogsDir:           filepath.Join(testDir, "logs"),
+		CurrentPath:       filepath.Join(testDir, "current"),
+		RatingsPath:       filepath.Join(testDir, "ratings.csv"),
+		EverydayQueuePath: filepath.Join(testDir, "everyday-queue"),
+		SnoozePath:        filepath.Join(testDir, "snoozed"),
+		QueuePath:         filepath.Join(testDir, "queue"),
+		RecentPath:        filepath.Join(testDir, "recent"),
+		FavoritesPath:     filepath.Join(testDir, "favorites"),
+		BansPath:          filepath.Join(testDir, "banned"),
+		OverridesPath:     filepath.Join(testDir, "overrides.yaml"),
+		RestDatesPath:     filepath.Join(testDir, "rest-days"),
+		RestEverydayRPE:   defaultRestEverydayRPE,
+		SpoolPath:         filepath.Join(testDir, "spool.csv"),
+		MovosDir:          filepath.Join(testDir, "test-movos"),
+		MaxDailyRPE:       30,
+		WeekStart:         time.Monday,
+		DateFormat:        defaultDateFormat,
+		NeverDoneBoost:    neverDoneBoost,
+		RecencyBoost:      recencyBoost,
+		RecencyDays:       recencyDays,
+		FavoriteBoost:     defaultFavoriteBoost,
+		Card:              defaultCardDisplay(),
+		SkipPolicy:        skipNeutral,
+		DuplicateCodes:    duplicatesError,
+		Language:          defaultLocale,
+		Variety:           varietyNormal,
+		SelectionMode:     selectionWeighted,
 	}
 }
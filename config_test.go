@@ -0,0 +1,246 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected time.Weekday
+		wantErr  bool
+	}{
+		{"monday", time.Monday, false},
+		{"Sunday", time.Sunday, false},
+		{"sat", time.Saturday, false},
+		{" Wed ", time.Wednesday, false},
+		{"someday", time.Sunday, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseWeekday(tt.name)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseWeekday(%q): expected error", tt.name)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("parseWeekday(%q) = %v, %v; want %v", tt.name, got, err, tt.expected)
+		}
+	}
+}
+
+func TestWeekStartDate(t *testing.T) {
+	// Thursday, October 16, 2025 at 14:30
+	thursday := time.Date(2025, 10, 16, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		weekStart time.Weekday
+		expected  time.Time
+	}{
+		{time.Monday, time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)},
+		{time.Sunday, time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{time.Thursday, time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{time.Friday, time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{WeekStart: tt.weekStart}
+		got := cfg.WeekStartDate(thursday)
+		if !got.Equal(tt.expected) {
+			t.Errorf("WeekStartDate with %v start = %v, want %v", tt.weekStart, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	ts := time.Date(2025, 10, 16, 14, 5, 0, 0, time.UTC)
+
+	cfg := &Config{}
+	if got := cfg.FormatClock(ts); got != "14:05" {
+		t.Errorf("24h clock: got %s, want 14:05", got)
+	}
+
+	cfg.Clock12h = true
+	if got := cfg.FormatClock(ts); got != "2:05pm" {
+		t.Errorf("12h clock: got %s, want 2:05pm", got)
+	}
+}
+
+func TestDefaultConfigReadsConfigFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "week_start: sunday\nclock: 12h\ndate_format: \"2006-01-02\"\neod_dir: ~/journal\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	if cfg.ConfigErr != nil {
+		t.Fatalf("unexpected config error: %v", cfg.ConfigErr)
+	}
+	if cfg.WeekStart != time.Sunday {
+		t.Errorf("expected week start Sunday, got %v", cfg.WeekStart)
+	}
+	if !cfg.Clock12h {
+		t.Error("expected 12h clock")
+	}
+	if got := cfg.FormatDate(time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)); got != "2025-10-16" {
+		t.Errorf("expected custom date format, got %s", got)
+	}
+	if cfg.EODDir != filepath.Join(home, "journal") {
+		t.Errorf("expected ~ to expand in eod_dir, got %s", cfg.EODDir)
+	}
+}
+
+func TestDefaultConfigProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `exploration_rate: 0.1
+profiles:
+  experiment:
+    max_daily_rpe: 40
+    exploration_rate: 0.3
+    recency_boost: 4
+    category_weights:
+      ts: 1.5
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	if cfg.MaxDailyRPE != 30 || cfg.ExplorationRate != 0.1 || cfg.RecencyBoost != recencyBoost {
+		t.Errorf("profile settings applied without a profile: %+v", cfg)
+	}
+
+	t.Setenv("MOVODORO_PROFILE", "experiment")
+	cfg = DefaultConfig()
+	if cfg.ConfigErr != nil {
+		t.Fatalf("unexpected config error: %v", cfg.ConfigErr)
+	}
+	if cfg.MaxDailyRPE != 40 || cfg.ExplorationRate != 0.3 || cfg.RecencyBoost != 4 {
+		t.Errorf("profile overrides not applied: %+v", cfg)
+	}
+	if cfg.NeverDoneBoost != neverDoneBoost {
+		t.Errorf("unset profile fields should keep defaults, got never_done_boost %g", cfg.NeverDoneBoost)
+	}
+	if cfg.CategoryWeights["TS"] != 1.5 {
+		t.Errorf("expected category weight for TS, got %v", cfg.CategoryWeights)
+	}
+
+	t.Setenv("MOVODORO_PROFILE", "missing")
+	if cfg := DefaultConfig(); cfg.ConfigErr == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
+
+func TestExtractProfileFlag(t *testing.T) {
+	args, profile, err := extractProfileFlag([]string{"--config-profile", "experiment", "get", "-t", "kbx"})
+	if err != nil || profile != "experiment" || len(args) != 3 || args[0] != "get" {
+		t.Errorf("got %v, %q, %v", args, profile, err)
+	}
+
+	args, profile, _ = extractProfileFlag([]string{"report", "--config-profile=b"})
+	if profile != "b" || len(args) != 1 {
+		t.Errorf("got %v, %q", args, profile)
+	}
+
+	if _, _, err := extractProfileFlag([]string{"--config-profile"}); err == nil {
+		t.Error("expected an error for a missing profile name")
+	}
+}
+
+func TestDefaultConfigCardDisplay(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg := DefaultConfig()
+	if cfg.Card != defaultCardDisplay() {
+		t.Errorf("expected default card display without config.yaml, got %+v", cfg.Card)
+	}
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "card:\n  show_rpe: false\n  show_code: false\n  show_category: true\n  show_last_done: true\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg = DefaultConfig()
+	want := CardDisplay{RPE: false, Code: false, Tags: true, Category: true, LastDone: true}
+	if cfg.Card != want {
+		t.Errorf("card display = %+v, want %+v", cfg.Card, want)
+	}
+}
+
+func TestDaysAgo(t *testing.T) {
+	now := time.Date(2025, 10, 16, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{time.Date(2025, 10, 16, 7, 0, 0, 0, time.UTC), "today"},
+		{time.Date(2025, 10, 15, 23, 0, 0, 0, time.UTC), "yesterday"},
+		{time.Date(2025, 10, 6, 12, 0, 0, 0, time.UTC), "10 days ago"},
+	}
+	for _, tt := range tests {
+		if got := daysAgo(tt.t, now); got != tt.expected {
+			t.Errorf("daysAgo(%v) = %q, want %q", tt.t, got, tt.expected)
+		}
+	}
+}
+
+func TestDefaultConfigMaxEntriesPerDay(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if cfg := DefaultConfig(); cfg.MaxEntriesPerDay != defaultMaxEntriesPerDay {
+		t.Errorf("expected default cap %d, got %d", defaultMaxEntriesPerDay, cfg.MaxEntriesPerDay)
+	}
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("max_entries_per_day: 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	if cfg.MaxEntriesPerDay != 0 || overEntryCap(1000, cfg.MaxEntriesPerDay) {
+		t.Errorf("expected an explicit 0 to disable the cap, got %d", cfg.MaxEntriesPerDay)
+	}
+}
+
+func TestOverEntryCap(t *testing.T) {
+	tests := []struct {
+		count, limit int
+		want         bool
+	}{
+		{39, 40, false},
+		{40, 40, true},
+		{41, 40, true},
+		{500, 0, false},
+	}
+	for _, tt := range tests {
+		if got := overEntryCap(tt.count, tt.limit); got != tt.want {
+			t.Errorf("overEntryCap(%d, %d) = %v, want %v", tt.count, tt.limit, got, tt.want)
+		}
+	}
+}
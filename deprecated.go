@@ -0,0 +1,26 @@
+package main
+
+import "fmt"
+
+// A movo marked deprecated stays in the library so old logs keep their titles, but is
+// never selected; superseded_by names the full code that replaces it.
+
+// deprecationNote is appended to a movo's details in reports: ", deprecated → CODE"
+// (or just ", deprecated"), empty for a current movo
+func deprecationNote(movo *Movo) string {
+	if !movo.Deprecated {
+		return ""
+	}
+	if movo.SupersededBy != "" {
+		return ", deprecated → " + movo.SupersededBy
+	}
+	return ", deprecated"
+}
+
+// deprecationMessage tells someone logging a deprecated movo what to do instead
+func deprecationMessage(snacks []Movo, movo *Movo) string {
+	if replacement := findMovo(snacks, movo.SupersededBy); movo.SupersededBy != "" && replacement != nil {
+		return fmt.Sprintf("'%s' is deprecated; next time try '%s' (%s)", movo.Title, replacement.Title, replacement.FullCode)
+	}
+	return fmt.Sprintf("'%s' is deprecated and won't be suggested again", movo.Title)
+}
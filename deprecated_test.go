@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeprecatedMovosAreNotSelected(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "BR-box", Title: "Box breathing", Deprecated: true, SupersededBy: "BR-square"},
+		{FullCode: "BR-square", Title: "Square breathing"},
+	}
+	got := filterSnacks(snacks, FilterOptions{})
+	if len(got) != 1 || got[0].FullCode != "BR-square" {
+		t.Errorf("filterSnacks() = %v, want only BR-square", got)
+	}
+
+	if note := deprecationNote(&snacks[0]); note != ", deprecated → BR-square" {
+		t.Errorf("deprecationNote() = %q", note)
+	}
+	if note := deprecationNote(&snacks[1]); note != "" {
+		t.Errorf("deprecationNote() for a current movo = %q", note)
+	}
+	if msg := deprecationMessage(snacks, &snacks[0]); !strings.Contains(msg, "'Square breathing' (BR-square)") {
+		t.Errorf("deprecationMessage() = %q", msg)
+	}
+}
+
+func TestDeprecatedEntryJSON(t *testing.T) {
+	movo := &Movo{FullCode: "BR-box", Title: "Box breathing", Deprecated: true, SupersededBy: "BR-square"}
+	entries := newEntriesJSON([]HistoryEntry{{Code: "BR-box", Status: "done"}}, map[string]*Movo{"BR-box": movo})
+	if entries[0].Title != "Box breathing" || !entries[0].Deprecated || entries[0].SupersededBy != "BR-square" {
+		t.Errorf("entry = %+v", entries[0])
+	}
+}
+
+func TestValidateSupersededBy(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{
+		"breathing.yaml": `category: Breathing
+code: BR
+movos:
+  - code: box
+    title: Box
+    deprecated: true
+    superseded_by: BR-square
+  - code: square
+    title: Square
+  - code: old
+    title: Old
+    deprecated: true
+    superseded_by: BR-gone
+  - code: loop
+    title: Loop
+    superseded_by: BR-loop
+`,
+	})
+	diagnostics, _, err := validateMovosDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"breathing.yaml:13: BR-old: superseded_by BR-gone doesn't match any movo",
+		"breathing.yaml:16: BR-loop: superseded_by without deprecated: true",
+		"breathing.yaml:16: BR-loop: superseded_by names the movo itself",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Extras keys holding the distance covered (km) or steps taken
+const (
+	extraDistance = "distance_km"
+	extraSteps    = "steps"
+)
+
+// distanceTag marks cardio movos, which done asks for a distance on
+const distanceTag = "cardiox"
+
+// tracksDistance reports whether the movo is cardio worth recording the distance of
+func (s *Movo) tracksDistance() bool {
+	for _, tag := range s.AllTags {
+		if strings.EqualFold(tag, distanceTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDistance reads a distance such as 2.5km, 800m or 4000 steps. A bare
+// number is km.
+func parseDistance(s string) (km float64, steps int, err error) {
+	value := strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	switch {
+	case strings.HasSuffix(value, "steps"):
+		steps, err = strconv.Atoi(strings.TrimSuffix(value, "steps"))
+		if err != nil || steps <= 0 {
+			return 0, 0, fmt.Errorf("invalid steps %q", s)
+		}
+		return 0, steps, nil
+	case strings.HasSuffix(value, "km"):
+		km, err = strconv.ParseFloat(strings.TrimSuffix(value, "km"), 64)
+	case strings.HasSuffix(value, "m"):
+		km, err = strconv.ParseFloat(strings.TrimSuffix(value, "m"), 64)
+		km /= 1000
+	default:
+		km, err = strconv.ParseFloat(value, 64)
+	}
+	if err != nil || km <= 0 {
+		return 0, 0, fmt.Errorf("invalid distance %q (e.g. 2.5km, 800m or 4000 steps)", s)
+	}
+	return km, 0, nil
+}
+
+// recordDistance stores the distance or steps in the entry's extras (0 for none)
+func recordDistance(entry *HistoryEntry, km float64, steps int) {
+	if km <= 0 && steps <= 0 {
+		return
+	}
+	if entry.Extras == nil {
+		entry.Extras = make(map[string]string)
+	}
+	if km > 0 {
+		entry.Extras[extraDistance] = strconv.FormatFloat(km, 'f', -1, 64)
+	}
+	if steps > 0 {
+		entry.Extras[extraSteps] = strconv.Itoa(steps)
+	}
+}
+
+// entryDistance returns the distance (km) and steps logged with an entry (0 if none)
+func entryDistance(entry HistoryEntry) (km float64, steps int) {
+	if parsed, err := strconv.ParseFloat(entry.Extras[extraDistance], 64); err == nil && parsed > 0 {
+		km = parsed
+	}
+	if parsed, err := strconv.Atoi(entry.Extras[extraSteps]); err == nil && parsed > 0 {
+		steps = parsed
+	}
+	return km, steps
+}
+
+// formatDistance prints a distance to at most two decimals, e.g. "2.5 km"
+func formatDistance(km float64) string {
+	return strconv.FormatFloat(math.Round(km*100)/100, 'f', -1, 64) + " km"
+}
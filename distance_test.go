@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDistance(t *testing.T) {
+	tests := []struct {
+		input string
+		km    float64
+		steps int
+	}{
+		{"2.5km", 2.5, 0},
+		{"2.5 KM", 2.5, 0},
+		{"800m", 0.8, 0},
+		{"3", 3, 0},
+		{"4000 steps", 0, 4000},
+	}
+	for _, tt := range tests {
+		km, steps, err := parseDistance(tt.input)
+		if err != nil || km != tt.km || steps != tt.steps {
+			t.Errorf("parseDistance(%q) = %g km, %d steps, %v", tt.input, km, steps, err)
+		}
+	}
+	for _, input := range []string{"", "far", "0km", "-1", "lots steps"} {
+		if _, _, err := parseDistance(input); err == nil {
+			t.Errorf("parseDistance(%q): expected an error", input)
+		}
+	}
+}
+
+func TestPeriodReportDistance(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	newEntry := func(day int, status string, km float64, steps int) HistoryEntry {
+		entry := HistoryEntry{Timestamp: start.AddDate(0, 0, day).Add(9 * time.Hour), Code: "CAR-ruck", Status: status, Duration: 20, RPE: 5}
+		recordDistance(&entry, km, steps)
+		return entry
+	}
+	entries := []HistoryEntry{
+		newEntry(0, "done", 2.1, 0),
+		newEntry(0, "partial", 1.2, 0),
+		newEntry(1, "done", 0, 3500),
+		newEntry(2, "skip", 5, 0),
+	}
+
+	report := buildPeriodReport(entries, start, 7)
+	if got := report.Days[0].distance(); got != "3.3 km" {
+		t.Errorf("day 0 distance = %q, want 3.3 km", got)
+	}
+	if got := report.Total.distance(); got != "3.3 km, 3500 steps" {
+		t.Errorf("total distance = %q", got)
+	}
+
+	var out strings.Builder
+	writePeriodReport(&out, "WEEKLY MOVODORO REPORT", "heading", report)
+	if !strings.Contains(out.String(), "Total distance:  3.3 km, 3500 steps") {
+		t.Errorf("report is missing the total distance:\n%s", out.String())
+	}
+}
+
+func TestTracksDistance(t *testing.T) {
+	if !(&Movo{AllTags: []string{"CardioX"}}).tracksDistance() {
+		t.Error("a cardiox movo should track distance")
+	}
+	if (&Movo{AllTags: []string{"mobilityx"}}).tracksDistance() {
+		t.Error("a mobility movo shouldn't track distance")
+	}
+}
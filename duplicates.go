@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Policies for a full code defined more than once (duplicate_codes in config.yaml).
+// A duplicate would double the movo's selection probability and mix two movos' stats.
+const (
+	duplicatesError = "error" // Refuse to load the library
+	duplicatesWarn  = "warn"  // Warn and keep the first definition
+)
+
+// parseDuplicatePolicy validates a duplicate_codes value ("" means error)
+func parseDuplicatePolicy(s string) (string, error) {
+	switch policy := strings.ToLower(strings.TrimSpace(s)); policy {
+	case "":
+		return duplicatesError, nil
+	case duplicatesError, duplicatesWarn:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("duplicate_codes must be error or warn, got %q", s)
+	}
+}
+
+// duplicateCode is a full code with every file defining it, in load order
+// (a file appears twice when one category repeats a code)
+type duplicateCode struct {
+	Code  string
+	Files []string
+}
+
+func (d duplicateCode) String() string {
+	names := make([]string, len(d.Files))
+	for i, file := range d.Files {
+		names[i] = filepath.Base(file)
+	}
+	return fmt.Sprintf("%s is defined %d times: %s", d.Code, len(d.Files), strings.Join(names, ", "))
+}
+
+// findDuplicateCodes lists the full codes defined more than once, by code
+func findDuplicateCodes(movos []Movo) []duplicateCode {
+	files := make(map[string][]string)
+	for _, movo := range movos {
+		files[movo.FullCode] = append(files[movo.FullCode], movo.SourceFile)
+	}
+	var dups []duplicateCode
+	for code, defined := range files {
+		if len(defined) > 1 {
+			dups = append(dups, duplicateCode{Code: code, Files: defined})
+		}
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i].Code < dups[j].Code })
+	return dups
+}
+
+// checkDuplicateCodes applies policy to duplicate full codes: an error listing them,
+// or a warning on w with only the first definition of each kept
+func checkDuplicateCodes(movos []Movo, policy string, w io.Writer) ([]Movo, error) {
+	dups := findDuplicateCodes(movos)
+	if len(dups) == 0 {
+		return movos, nil
+	}
+
+	lines := make([]string, len(dups))
+	for i, dup := range dups {
+		lines[i] = "  " + dup.String()
+	}
+	if policy != duplicatesWarn {
+		return nil, fmt.Errorf("duplicate movo codes (rename one, or set duplicate_codes: warn to keep the first):\n%s",
+			strings.Join(lines, "\n"))
+	}
+
+	fmt.Fprintf(w, tr("Warning: duplicate movo codes, keeping the first definition:\n%s\n"), strings.Join(lines, "\n"))
+	seen := make(map[string]bool)
+	var kept []Movo
+	for _, movo := range movos {
+		if seen[movo.FullCode] {
+			continue
+		}
+		seen[movo.FullCode] = true
+		kept = append(kept, movo)
+	}
+	return kept, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckDuplicateCodes(t *testing.T) {
+	movos := []Movo{
+		{FullCode: "BR-box", Title: "Box", SourceFile: "/movos/breathing.yaml"},
+		{FullCode: "MOB-hips", SourceFile: "/movos/mobility.yaml"},
+		{FullCode: "BR-box", Title: "Box again", SourceFile: "/movos/calm.yaml"},
+	}
+
+	_, err := checkDuplicateCodes(movos, duplicatesError, nil)
+	if err == nil || !strings.Contains(err.Error(), "BR-box is defined 2 times: breathing.yaml, calm.yaml") {
+		t.Errorf("expected an error naming both files, got %v", err)
+	}
+
+	var warning bytes.Buffer
+	kept, err := checkDuplicateCodes(movos, duplicatesWarn, &warning)
+	if err != nil {
+		t.Fatalf("checkDuplicateCodes() error = %v", err)
+	}
+	if len(kept) != 2 || kept[0].Title != "Box" {
+		t.Errorf("expected the first BR-box kept, got %v", kept)
+	}
+	if !strings.Contains(warning.String(), "BR-box") {
+		t.Errorf("expected a warning, got %q", warning.String())
+	}
+
+	if got, err := checkDuplicateCodes(movos[:2], duplicatesError, nil); err != nil || len(got) != 2 {
+		t.Errorf("no duplicates: got %v, %v", got, err)
+	}
+}
+
+func TestLoadSnacksDuplicateCodes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	movosDir := filepath.Join(home, ".movodoro", "movos")
+	if err := os.MkdirAll(movosDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	category := "category: Breathing\ncode: BR\nmovos:\n  - code: box\n    title: Box\n    duration_min: 3\n    duration_max: 5\n"
+	for _, name := range []string{"breathing.yaml", "calm.yaml"} {
+		if err := os.WriteFile(filepath.Join(movosDir, name), []byte(category), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := LoadSnacks(); err == nil || !strings.Contains(err.Error(), "BR-box") {
+		t.Errorf("expected a duplicate code error, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(home, ".movodoro", "config.yaml"), []byte("duplicate_codes: warn\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	movos, err := LoadSnacks()
+	os.Stderr = stderr
+	if err != nil || len(movos) != 1 {
+		t.Errorf("with duplicate_codes: warn got %d movos, %v", len(movos), err)
+	}
+}
+
+func TestParseDuplicatePolicy(t *testing.T) {
+	if got, err := parseDuplicatePolicy(""); got != duplicatesError || err != nil {
+		t.Errorf("parseDuplicatePolicy(\"\") = %q, %v", got, err)
+	}
+	if got, err := parseDuplicatePolicy("Warn"); got != duplicatesWarn || err != nil {
+		t.Errorf("parseDuplicatePolicy(\"Warn\") = %q, %v", got, err)
+	}
+	if _, err := parseDuplicatePolicy("ignore"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
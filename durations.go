@@ -0,0 +1,47 @@
+package main
+
+import "sort"
+
+// defaultHistoryDurationSamples is how many completions a movo needs before its
+// median logged duration replaces the YAML midpoint as the default
+const defaultHistoryDurationSamples = 5
+
+// medianDurations returns the median logged duration of each movo with at least
+// minSamples full completions (partials and skips don't count)
+func medianDurations(entries []HistoryEntry, minSamples int) map[string]int {
+	byCode := make(map[string][]int)
+	for _, entry := range entries {
+		if entry.Status == "done" && entry.Duration > 0 {
+			byCode[entry.Code] = append(byCode[entry.Code], entry.Duration)
+		}
+	}
+
+	medians := make(map[string]int)
+	for code, durations := range byCode {
+		if len(durations) < minSamples {
+			continue
+		}
+		sort.Ints(durations)
+		mid := len(durations) / 2
+		if len(durations)%2 == 1 {
+			medians[code] = durations[mid]
+		} else {
+			// Round up, like GetDefaultDuration
+			medians[code] = (durations[mid-1] + durations[mid] + 1) / 2
+		}
+	}
+	return medians
+}
+
+// usualDuration is the default for logging a movo: the median of how long it has
+// actually taken once there's enough history, otherwise the YAML midpoint
+func usualDuration(movo *Movo) int {
+	if appConfig.HistoryDurationSamples > 0 {
+		if history, err := LoadAllHistory(appConfig.LogsDir); err == nil {
+			if median, ok := medianDurations(history, appConfig.HistoryDurationSamples)[movo.FullCode]; ok {
+				return median
+			}
+		}
+	}
+	return movo.GetDefaultDuration()
+}
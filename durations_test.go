@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMedianDurations(t *testing.T) {
+	now := time.Now()
+	done := func(code string, minutes int) HistoryEntry {
+		return HistoryEntry{Timestamp: now, Code: code, Status: "done", Duration: minutes}
+	}
+
+	entries := []HistoryEntry{
+		done("TS-pushups", 4), done("TS-pushups", 9), done("TS-pushups", 5),
+		{Timestamp: now, Code: "TS-pushups", Status: "partial", Duration: 1},
+		{Timestamp: now, Code: "TS-pushups", Status: "skip"},
+		done("TB-box-breath", 3), done("TB-box-breath", 4), done("TB-box-breath", 6), done("TB-box-breath", 7),
+		done("KB-swings", 10),
+	}
+
+	medians := medianDurations(entries, 3)
+	if medians["TS-pushups"] != 5 {
+		t.Errorf("odd count: expected median 5 (partials and skips ignored), got %d", medians["TS-pushups"])
+	}
+	if medians["TB-box-breath"] != 5 {
+		t.Errorf("even count: expected 4.5 rounded up to 5, got %d", medians["TB-box-breath"])
+	}
+	if _, ok := medians["KB-swings"]; ok {
+		t.Error("expected no median with too few completions")
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// sendmailPath is the sendmail-compatible program eod_email hands the summary to
+var sendmailPath = "sendmail"
+
+// eodOptions is where and how the end-of-day summary goes
+type eodOptions struct {
+	Dir     string
+	Notify  bool
+	Email   string // Address to mail the summary to (empty for none)
+	Verbose bool
+}
+
+// eodFileName is the summary file for the day of now; one per day
+func eodFileName(now time.Time) string {
+	return "movodoro-" + now.Format("2006-01-02") + ".md"
+}
+
+// eodDue reports whether the summary for today should be written now: eod_at has
+// passed and today's file isn't there yet
+func eodDue(now time.Time, at time.Duration, dir string) bool {
+	if at <= 0 || dir == "" {
+		return false
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if now.Before(midnight.Add(at)) {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, eodFileName(now)))
+	return os.IsNotExist(err)
+}
+
+// writeEODSummary writes today's markdown report into dir, overwriting any earlier
+// summary for the day, and returns the file's path and content
+func writeEODSummary(dir string, verbose bool, now time.Time) (string, []byte, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, withExitCode(exitStorage, fmt.Errorf("error creating summary directory: %w", err))
+	}
+
+	var buf bytes.Buffer
+	if err := writeDayReportMarkdown(&buf, reportOptions{Verbose: verbose}); err != nil {
+		return "", nil, err
+	}
+
+	path := filepath.Join(dir, eodFileName(now))
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return "", nil, withExitCode(exitStorage, fmt.Errorf("error writing summary: %w", err))
+	}
+	return path, buf.Bytes(), nil
+}
+
+// runEOD writes the summary, then sends the notification and email if asked.
+// Only a summary that can't be written is an error; delivery problems are warnings.
+func runEOD(opts eodOptions, now time.Time) error {
+	path, summary, err := writeEODSummary(opts.Dir, opts.Verbose, now)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(tr("📝 Wrote end-of-day summary to %s\n"), path)
+
+	if opts.Notify {
+		stats, _ := GetTodayStatsDaily(appConfig.LogsDir)
+		message := fmt.Sprintf("%d movos, %d minutes, %d RPE today",
+			len(stats.CompletedSnacks), stats.TotalDuration, stats.TotalRPE)
+		if err := sendNotification("Movodoro daily summary", message); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
+		}
+	}
+
+	if opts.Email != "" {
+		subject := "Movodoro summary for " + now.Format("2006-01-02")
+		if err := sendEmail(opts.Email, subject, summary); err != nil {
+			fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), err)
+		} else {
+			fmt.Printf(tr("📧 Mailed it to %s\n"), opts.Email)
+		}
+	}
+	return nil
+}
+
+// sendEmail hands a plain text message to sendmail, which reads the recipient
+// from the headers
+func sendEmail(to, subject string, body []byte) error {
+	if strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("invalid email address %q", to)
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.Write(body)
+
+	cmd := exec.Command(sendmailPath, "-t")
+	cmd.Stdin = &msg
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("email failed: %v %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
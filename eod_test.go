@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteEODSummary(t *testing.T) {
+	dir := t.TempDir()
+	originalConfig := appConfig
+	appConfig = TestConfig(dir)
+	defer func() { appConfig = originalConfig }()
+
+	now := time.Now()
+	entry := HistoryEntry{Timestamp: now, Code: "KB-swings", Status: "done", Duration: 7, RPE: 6}
+	if err := AppendDailyLog(appConfig.LogsDir, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	eodDir := filepath.Join(dir, "journal", "movodoro")
+	path, summary, err := writeEODSummary(eodDir, false, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(eodDir, "movodoro-"+now.Format("2006-01-02")+".md"); path != want {
+		t.Errorf("expected the summary at %s, got %s", want, path)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != string(summary) {
+		t.Error("the returned summary should be what was written")
+	}
+	for _, want := range []string{"# Movodoro Report", "- **Total movos:** 1", "- **Total duration:** 7 minutes", "KB-swings"} {
+		if !strings.Contains(string(written), want) {
+			t.Errorf("summary is missing %q:\n%s", want, written)
+		}
+	}
+}
+
+func TestEODDue(t *testing.T) {
+	dir := t.TempDir()
+	at := 21*time.Hour + 55*time.Minute
+	before := time.Date(2025, 3, 10, 21, 54, 0, 0, time.Local)
+	after := time.Date(2025, 3, 10, 22, 30, 0, 0, time.Local)
+
+	if eodDue(before, at, dir) {
+		t.Error("the summary isn't due before eod_at")
+	}
+	if !eodDue(after, at, dir) {
+		t.Error("the summary is due once eod_at has passed")
+	}
+	if eodDue(after, 0, dir) || eodDue(after, at, "") {
+		t.Error("without eod_at or eod_dir the summary is never due")
+	}
+
+	// Once today's file exists it's not due again until tomorrow
+	if err := os.WriteFile(filepath.Join(dir, eodFileName(after)), []byte("# done\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if eodDue(after, at, dir) {
+		t.Error("the summary shouldn't be written twice in a day")
+	}
+	if !eodDue(after.AddDate(0, 0, 1), at, dir) {
+		t.Error("the next day's summary should be due after its eod_at")
+	}
+}
+
+func TestSendEmail(t *testing.T) {
+	dir := t.TempDir()
+	captured := filepath.Join(dir, "message")
+	fake := filepath.Join(dir, "sendmail")
+	script := "#!/bin/sh\n[ \"$1\" = -t ] || exit 1\ncat > '" + captured + "'\n"
+	if err := os.WriteFile(fake, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	original := sendmailPath
+	sendmailPath = fake
+	defer func() { sendmailPath = original }()
+
+	if err := sendEmail("me@example.com", "Movodoro summary for 2025-03-10", []byte("# Movodoro Report\n")); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := os.ReadFile(captured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"To: me@example.com\r\n", "Subject: Movodoro summary for 2025-03-10\r\n", "\r\n\r\n# Movodoro Report\n"} {
+		if !strings.Contains(string(msg), want) {
+			t.Errorf("message is missing %q:\n%s", want, msg)
+		}
+	}
+
+	if err := sendEmail("me@example.com\r\nBcc: spam@example.com", "x", nil); err == nil {
+		t.Error("an address with a line break should be refused")
+	}
+}
+
+func TestEODConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "eod_dir: ~/journal\neod_at: \"21:55\"\neod_email: me@example.com\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	if cfg.ConfigErr != nil {
+		t.Fatalf("unexpected config error: %v", cfg.ConfigErr)
+	}
+	if cfg.EODAt != 21*time.Hour+55*time.Minute || cfg.EODEmail != "me@example.com" {
+		t.Errorf("expected eod_at 21:55 and eod_email, got %v %q", cfg.EODAt, cfg.EODEmail)
+	}
+
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("eod_at: late\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := DefaultConfig(); cfg.ConfigErr == nil {
+		t.Error("expected an error for an invalid eod_at")
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "strings"
+
+// noEquipment in a movo's equipment list means it needs nothing
+const noEquipment = "none"
+
+// Short names accepted for common equipment
+var equipmentAliases = map[string]string{
+	"kb": "kettlebell",
+	"db": "dumbbell",
+}
+
+// normalizeEquipment lowercases an equipment name and expands aliases
+func normalizeEquipment(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if full, ok := equipmentAliases[name]; ok {
+		return full
+	}
+	return name
+}
+
+// parseEquipmentList splits a comma-separated equipment list
+func parseEquipmentList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = normalizeEquipment(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// HasEquipment reports whether everything the snack needs is in available
+func (s *Movo) HasEquipment(available []string) bool {
+	for _, needed := range s.Equipment {
+		needed = normalizeEquipment(needed)
+		if needed == noEquipment || needed == "" {
+			continue
+		}
+		found := false
+		for _, have := range available {
+			if normalizeEquipment(have) == needed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHasEquipment(t *testing.T) {
+	tests := []struct {
+		needs     []string
+		available []string
+		want      bool
+	}{
+		{nil, nil, true},
+		{[]string{"none"}, nil, true},
+		{[]string{"kettlebell"}, nil, false},
+		{[]string{"Kettlebell"}, []string{"kb", "band"}, true},
+		{[]string{"kettlebell", "pullup-bar"}, []string{"kettlebell"}, false},
+	}
+	for _, tt := range tests {
+		snack := Movo{Equipment: tt.needs}
+		if got := snack.HasEquipment(tt.available); got != tt.want {
+			t.Errorf("HasEquipment(%v) with %v = %v, want %v", tt.available, tt.needs, got, tt.want)
+		}
+	}
+}
+
+func TestEquipmentFlags(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+	appConfig.LimitEquipment, appConfig.Equipment = true, []string{"kettlebell"}
+
+	snacks := []Movo{
+		{FullCode: "KB-swings", Equipment: []string{"kettlebell"}, DurationMin: 2, DurationMax: 3},
+		{FullCode: "PU-pullups", Equipment: []string{"pullup-bar"}, DurationMin: 2, DurationMax: 3},
+		{FullCode: "BW-squats", DurationMin: 2, DurationMax: 3},
+	}
+	matching := func(args ...string) []string {
+		fs, g := newGetFlagSet("get", flag.ContinueOnError)
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		var codes []string
+		for _, s := range filterSnacks(snacks, g.filterOptions()) {
+			codes = append(codes, s.FullCode)
+		}
+		return codes
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{nil, []string{"KB-swings", "BW-squats"}}, // config.yaml default
+		{[]string{"--equipment", "pullup-bar, kb"}, []string{"KB-swings", "PU-pullups", "BW-squats"}},
+		{[]string{"--no-equipment"}, []string{"BW-squats"}},
+		{[]string{"--equipment", "any"}, []string{"KB-swings", "PU-pullups", "BW-squats"}},
+	}
+	for _, tt := range tests {
+		if got := matching(tt.args...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultConfigEquipment(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if cfg := DefaultConfig(); cfg.LimitEquipment {
+		t.Error("expected no equipment limit without config")
+	}
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "equipment: [KB, band]\nprofiles:\n  travel:\n    equipment: []\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultConfig()
+	if !cfg.LimitEquipment || !reflect.DeepEqual(cfg.Equipment, []string{"kettlebell", "band"}) {
+		t.Errorf("expected kettlebell and band, got %v %v", cfg.LimitEquipment, cfg.Equipment)
+	}
+	t.Setenv("MOVODORO_PROFILE", "travel")
+	if cfg := DefaultConfig(); !cfg.LimitEquipment || len(cfg.Equipment) != 0 {
+		t.Errorf("expected the travel profile to allow no equipment, got %v %v", cfg.LimitEquipment, cfg.Equipment)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// everydayStatus is today's progress on the movos with a min_per_day. Movos
+// waived for a rest day aren't counted in Completed or Total.
+type everydayStatus struct {
+	Subset    string         `json:"subset,omitempty"`
+	RestDay   bool           `json:"rest_day"`
+	Excluded  int            `json:"excluded_by_subset"`
+	Completed int            `json:"completed"`
+	Total     int            `json:"total"`
+	Movos     []everydayItem `json:"movos"`
+}
+
+// everydayItem is one everyday movo's progress and streak
+type everydayItem struct {
+	movoJSON
+	DoneToday int  `json:"done_today"`
+	Complete  bool `json:"complete"`
+	Streak    int  `json:"streak"`
+	Waived    bool `json:"waived"` // Minimum waived for today's rest day
+}
+
+// loadEverydayStatus checks each everyday movo (in the active subset, if any) against
+// today's completions and its streak
+func loadEverydayStatus(cfg *Config, now time.Time) (everydayStatus, error) {
+	status := everydayStatus{Subset: cfg.ActiveSubset, Movos: []everydayItem{}}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		return status, withExitCode(exitLibrary, fmt.Errorf("error loading snacks: %w", err))
+	}
+
+	// An unknown subset or unreadable subsets.yaml leaves every movo in
+	var subsetCodes map[string]bool
+	if cfg.ActiveSubset != "" {
+		if subsetsConfig, err := LoadSubsets(cfg.MovosDir); err == nil {
+			if subset, exists := subsetsConfig.Subsets[cfg.ActiveSubset]; exists {
+				subsetCodes = make(map[string]bool)
+				for _, code := range subset.Codes {
+					subsetCodes[code] = true
+				}
+			}
+		}
+	}
+
+	stats, err := GetTodayStatsDaily(cfg.LogsDir)
+	if err != nil {
+		return status, withExitCode(exitStorage, fmt.Errorf("error loading today's stats: %w", err))
+	}
+	completedToday := make(map[string]int)
+	for _, entry := range stats.CompletedSnacks {
+		completedToday[entry.Code]++
+	}
+
+	rest, err := loadRestSchedule(cfg)
+	if err != nil {
+		return status, withExitCode(exitStorage, err)
+	}
+	status.RestDay = rest.isRest(now)
+
+	// Full history is needed for streaks
+	history, err := LoadAllHistory(cfg.LogsDir)
+	if err != nil {
+		return status, withExitCode(exitStorage, fmt.Errorf("error loading history: %w", err))
+	}
+
+	for i := range snacks {
+		snack := &snacks[i]
+		if snack.MinPerDay == 0 || snack.Deprecated {
+			continue
+		}
+		if subsetCodes != nil && !subsetCodes[snack.FullCode] {
+			status.Excluded++
+			continue
+		}
+
+		counts := attributeCompletions(history, snack.FullCode, snack.MinPerDay, cfg.GraceUntil)
+		skipped := skippedDays(history, snack.FullCode)
+		// Only movos waived on rest days get the rest days bridged in their streak
+		waived := waivedOnRestDay(snack, cfg)
+		bridged := restSchedule{}
+		if waived {
+			bridged = rest
+		}
+		item := everydayItem{
+			movoJSON:  newMovoJSON(snack),
+			DoneToday: completedToday[snack.FullCode],
+			Complete:  completedToday[snack.FullCode] >= snack.MinPerDay,
+			Streak:    streakWithSkips(counts, skipped, bridged, snack.MinPerDay, now, cfg.SkipPolicy),
+			Waived:    waived && status.RestDay,
+		}
+		status.Movos = append(status.Movos, item)
+		if item.Waived {
+			continue
+		}
+		if item.Complete {
+			status.Completed++
+		}
+		status.Total++
+	}
+	return status, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// everydayQueue is the ordered list of everyday movos queued for a given day
+type everydayQueue struct {
+	Day   string // YYYY-MM-DD
+	Codes []string
+}
+
+// buildEverydayQueue lists incomplete everyday movos, lowest RPE first
+func buildEverydayQueue(snacks []Movo, logsDir string) ([]string, error) {
+	incomplete, err := filterToIncompleteMinimums(snacks, logsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(incomplete, func(i, j int) bool {
+		if incomplete[i].EffectiveRPE != incomplete[j].EffectiveRPE {
+			return incomplete[i].EffectiveRPE < incomplete[j].EffectiveRPE
+		}
+		return incomplete[i].FullCode < incomplete[j].FullCode
+	})
+
+	codes := make([]string, len(incomplete))
+	for i, snack := range incomplete {
+		codes[i] = snack.FullCode
+	}
+	return codes, nil
+}
+
+// loadEverydayQueue reads the queue file: the day on the first line, then one code per line.
+// A missing file returns an empty queue.
+func loadEverydayQueue(path string) (everydayQueue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return everydayQueue{}, nil
+		}
+		return everydayQueue{}, err
+	}
+
+	lines := strings.Fields(string(data))
+	if len(lines) == 0 {
+		return everydayQueue{}, nil
+	}
+	return everydayQueue{Day: lines[0], Codes: lines[1:]}, nil
+}
+
+// saveEverydayQueue writes the queue file
+func saveEverydayQueue(path string, q everydayQueue) error {
+	content := strings.Join(append([]string{q.Day}, q.Codes...), "\n") + "\n"
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// startEverydayQueue builds today's queue if this is the first interactive session of the day.
+// Returns the queue and whether it was just created.
+func startEverydayQueue(snacks []Movo, now time.Time) (everydayQueue, bool, error) {
+	q, err := loadEverydayQueue(appConfig.EverydayQueuePath)
+	if err != nil {
+		return everydayQueue{}, false, fmt.Errorf("error reading everyday queue: %w", err)
+	}
+
+	today := now.Format("2006-01-02")
+	if q.Day == today {
+		return q, false, nil
+	}
+
+	snacks = filterContraindicated(dropWaivedMinimums(snacks, appConfig, now), appConfig.Avoid)
+	codes, err := buildEverydayQueue(snacks, appConfig.LogsDir)
+	if err != nil {
+		return everydayQueue{}, false, err
+	}
+	q = everydayQueue{Day: today, Codes: codes}
+	if err := saveEverydayQueue(appConfig.EverydayQueuePath, q); err != nil {
+		return everydayQueue{}, false, fmt.Errorf("error saving everyday queue: %w", err)
+	}
+	return q, true, nil
+}
+
+// nextQueued drops queued movos that are already complete (or no longer exist)
+// and returns the head of the queue, or nil once it's empty
+func (q *everydayQueue) nextQueued(snacks []Movo, logsDir string) (*Movo, error) {
+	for len(q.Codes) > 0 {
+		movo := findMovo(snacks, q.Codes[0])
+		if movo != nil {
+			doneToday, _, err := GetCountTodayDaily(logsDir, movo.FullCode)
+			if err != nil {
+				return nil, err
+			}
+			if doneToday < movo.MinPerDay {
+				return movo, nil
+			}
+		}
+		q.Codes = q.Codes[1:]
+	}
+	return nil, nil
+}
+
+// drop removes a code from the queue (e.g. after a skip)
+func (q *everydayQueue) drop(code string) {
+	for i, c := range q.Codes {
+		if c == code {
+			q.Codes = append(q.Codes[:i], q.Codes[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEverydayQueue(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := TestConfig(tmpDir)
+	if err := os.MkdirAll(cfg.LogsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	snacks := []Movo{
+		{FullCode: "TS-pushups", MinPerDay: 1, EffectiveRPE: 5},
+		{FullCode: "TB-box-breath", MinPerDay: 1, EffectiveRPE: 1},
+		{FullCode: "TM-hips", MinPerDay: 2, EffectiveRPE: 3},
+		{FullCode: "TS-squats", EffectiveRPE: 2},
+	}
+
+	// Hips done once already: still incomplete (min 2)
+	if err := AppendTodayLog(cfg.LogsDir, doneAt("TM-hips", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	codes, err := buildEverydayQueue(snacks, cfg.LogsDir)
+	if err != nil {
+		t.Fatalf("buildEverydayQueue: %v", err)
+	}
+	want := []string{"TB-box-breath", "TM-hips", "TS-pushups"}
+	if !reflect.DeepEqual(codes, want) {
+		t.Fatalf("queue = %v, want %v (lowest RPE first)", codes, want)
+	}
+
+	path := filepath.Join(tmpDir, "everyday-queue")
+	if err := saveEverydayQueue(path, everydayQueue{Day: "2025-10-12", Codes: codes}); err != nil {
+		t.Fatal(err)
+	}
+	q, err := loadEverydayQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Day != "2025-10-12" || !reflect.DeepEqual(q.Codes, want) {
+		t.Fatalf("round trip = %+v", q)
+	}
+
+	// Completed heads are dropped
+	if err := AppendTodayLog(cfg.LogsDir, doneAt("TB-box-breath", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	next, err := q.nextQueued(snacks, cfg.LogsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == nil || next.FullCode != "TM-hips" {
+		t.Fatalf("expected TM-hips next, got %v", next)
+	}
+
+	q.drop("TM-hips")
+	next, _ = q.nextQueued(snacks, cfg.LogsDir)
+	if next == nil || next.FullCode != "TS-pushups" {
+		t.Fatalf("expected TS-pushups after dropping TM-hips, got %v", next)
+	}
+}
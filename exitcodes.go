@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+)
+
+// Exit codes, so shell integrations can tell "nothing matched my filters" from "the
+// YAML is broken". Anything not covered, bad usage included, exits with exitError.
+const (
+	exitOK        = 0
+	exitError     = 1
+	exitNoMatch   = 2 // No movo fits the filters and today's limits
+	exitLibrary   = 3 // The movo library or a subset doesn't load (a bad config.yaml only warns)
+	exitStorage   = 4 // History or other state can't be read or written
+	exitCancelled = 5 // The user said no at a prompt
+)
+
+// exitCodeError is an error that says which exit code it should end the program with
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// withExitCode marks err with the exit code it calls for
+func withExitCode(code int, err error) error {
+	return &exitCodeError{code: code, err: err}
+}
+
+// exitCodeOf is the exit code err was marked with, or fallback if it wasn't
+func exitCodeOf(err error, fallback int) int {
+	var coded *exitCodeError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return fallback
+}
+
+// parseFlags parses a command's flags (from a flag.ContinueOnError set): -h prints
+// the usage and exits cleanly, a bad flag exits with exitError. flag's own
+// ExitOnError would use 2, which means no match here.
+func parseFlags(fs *flag.FlagSet, args []string) {
+	exitOnFlagError(fs.Parse(args))
+}
+
+// parseFlagsInterspersed is parseFlags for commands taking flags among their
+// arguments; it returns the positional ones
+func parseFlagsInterspersed(fs *flag.FlagSet, args []string) []string {
+	positional, err := parseInterspersed(fs, args)
+	exitOnFlagError(err)
+	return positional
+}
+
+func exitOnFlagError(err error) {
+	if err == flag.ErrHelp {
+		os.Exit(exitOK)
+	} else if err != nil {
+		os.Exit(exitError)
+	}
+}
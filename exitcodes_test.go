@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestExitCodeOf(t *testing.T) {
+	err := fmt.Errorf("saving: %w", withExitCode(exitCancelled, errors.New("not logged")))
+	if got := exitCodeOf(err, exitStorage); got != exitCancelled {
+		t.Errorf("exitCodeOf(wrapped) = %d, want %d", got, exitCancelled)
+	}
+	if got := exitCodeOf(errors.New("disk full"), exitStorage); got != exitStorage {
+		t.Errorf("exitCodeOf(unmarked) = %d, want the fallback %d", got, exitStorage)
+	}
+	if err.Error() != "saving: not logged" {
+		t.Errorf("a marked error's message changed: %q", err)
+	}
+}
+
+func TestNoMatchExitCode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	movos := []Movo{{FullCode: "MOB-hips", CategoryCode: "MOB", Weight: 1}}
+
+	_, err := SelectSnack(movos, FilterOptions{Categories: []string{"KB"}}, 30)
+	if err == nil {
+		t.Fatal("expected no match for a category with no movos")
+	}
+	if got := exitCodeOf(err, exitError); got != exitNoMatch {
+		t.Errorf("exit code = %d, want %d", got, exitNoMatch)
+	}
+}
+
+func TestStatusExitCodes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MOVODORO_MOVOS_DIR", t.TempDir()+"/missing")
+	cfg := DefaultConfig()
+
+	// A library that doesn't load is told apart from unreadable history
+	if _, err := loadWeeklyStatus(cfg, time.Now()); exitCodeOf(err, exitError) != exitLibrary {
+		t.Errorf("weekly: expected exit code %d, got %v", exitLibrary, err)
+	}
+	if _, err := loadEverydayStatus(cfg, time.Now()); exitCodeOf(err, exitError) != exitLibrary {
+		t.Errorf("everyday: expected exit code %d, got %v", exitLibrary, err)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// candidateExplanation is why one candidate has the chance of selection it does
+type candidateExplanation struct {
+	Code        string         `json:"code"`
+	Title       string         `json:"title"`
+	BaseWeight  float64        `json:"base_weight"`
+	Factors     []weightFactor `json:"factors"`
+	Weight      float64        `json:"weight"`
+	Probability float64        `json:"probability"`
+}
+
+// heldBackMovo is a movo left out until its requires_done_today movos are done
+type heldBackMovo struct {
+	Code    string   `json:"code"`
+	Title   string   `json:"title"`
+	Missing []string `json:"missing"`
+}
+
+// selectionExplanation is the candidate pool get would pick from right now
+type selectionExplanation struct {
+	RecoveryMode    bool                   `json:"recovery_mode"`
+	RestDay         bool                   `json:"rest_day"`
+	DailyPriority   bool                   `json:"daily_priority"` // Only unfinished min_per_day movos are eligible
+	Variety         string                 `json:"variety"`
+	Rotation        string                 `json:"rotation,omitempty"` // Category picked from in rotation mode
+	ExplorationRate float64                `json:"exploration_rate"`
+	Excluded        int                    `json:"excluded"`
+	Candidates      []candidateExplanation `json:"candidates"`
+	HeldBack        []heldBackMovo         `json:"held_back,omitempty"` // Waiting on requires_done_today
+}
+
+// explainSelection works out the candidate pool, each candidate's boosts and
+// its final selection probability, without picking anything
+func explainSelection(snacks []Movo, filters FilterOptions, maxDailyRPE int) (selectionExplanation, error) {
+	weighted, inRecoveryMode, err := weighCandidates(snacks, filters, maxDailyRPE)
+	if err != nil {
+		return selectionExplanation{}, err
+	}
+
+	balance, err := loadBalance(DefaultConfig(), snacks, time.Now())
+	if err != nil {
+		return selectionExplanation{}, err
+	}
+	candidates := explainCandidates(weighted, appConfig.ExplorationRate, filters.Fit, balance)
+	variety, _ := parseVariety(filters.Variety) // weighCandidates has already rejected a bad one
+	rotation := ""
+	if filters.Rotate {
+		rotation = weighted[0].snack.CategoryCode
+	}
+	heldBack, err := heldBackByPrerequisites(snacks, filters)
+	if err != nil {
+		return selectionExplanation{}, err
+	}
+
+	return selectionExplanation{
+		RecoveryMode:    inRecoveryMode,
+		RestDay:         isRestDay(appConfig, time.Now()),
+		DailyPriority:   !filters.SkipMinimums && allDailyPriority(candidates),
+		Variety:         variety,
+		Rotation:        rotation,
+		ExplorationRate: appConfig.ExplorationRate,
+		Excluded:        len(snacks) - len(weighted),
+		Candidates:      candidates,
+		HeldBack:        heldBack,
+	}, nil
+}
+
+// heldBackByPrerequisites lists the movos matching the filters that requires_done_today
+// keeps out for now, with the codes each is still waiting on
+func heldBackByPrerequisites(snacks []Movo, filters FilterOptions) ([]heldBackMovo, error) {
+	todayStats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		return nil, err
+	}
+	matching := filterSnacks(snacks, filters)
+	if filters.Subset != "" {
+		if matching, err = filterBySubset(matching, filters.Subset, appConfig.MovosDir); err != nil {
+			return nil, err
+		}
+	}
+
+	doneToday := completedToday(todayStats)
+	var held []heldBackMovo
+	for _, snack := range matching {
+		if missing := missingPrerequisites(snack, doneToday); len(missing) > 0 {
+			held = append(held, heldBackMovo{Code: snack.FullCode, Title: snack.Title, Missing: missing})
+		}
+	}
+	return held, nil
+}
+
+// explainCandidates lists the factors behind each weighted candidate, most likely first
+func explainCandidates(weighted []weightedSnack, explorationRate float64, fit int, balance map[string]float64) []candidateExplanation {
+	total := 0.0
+	for _, w := range weighted {
+		total += w.weight
+	}
+
+	n := float64(len(weighted))
+	explained := make([]candidateExplanation, len(weighted))
+	for i, w := range weighted {
+		factors := slices.Clone(w.factors)
+		if fit > 0 {
+			name := fmt.Sprintf("fills %d of %d min", fitMinutes(w.snack, fit), fit)
+			factors = append(factors, weightFactor{Name: name, Multiplier: fitMultiplier(w.snack, fit)})
+		}
+		if multiplier, ok := balance[w.snack.FullCode]; ok {
+			factors = append(factors, weightFactor{Name: "weekly balance", Multiplier: multiplier})
+		}
+		explained[i] = candidateExplanation{
+			Code:        w.snack.FullCode,
+			Title:       w.snack.Title,
+			BaseWeight:  w.snack.Weight,
+			Factors:     factors,
+			Weight:      w.weight,
+			Probability: expectedShare(w.weight, total, n, explorationRate),
+		}
+	}
+
+	sort.SliceStable(explained, func(i, j int) bool { return explained[i].Probability > explained[j].Probability })
+	return explained
+}
+
+// allDailyPriority reports whether every candidate is an unfinished daily,
+// meaning min_per_day priority narrowed the pool
+func allDailyPriority(candidates []candidateExplanation) bool {
+	if len(candidates) == 0 {
+		return false
+	}
+	for _, c := range candidates {
+		found := false
+		for _, f := range c.Factors {
+			if f.Name == minPerDayFactor {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// writeExplanation prints the candidate pool and the working behind each weight
+func writeExplanation(w io.Writer, e selectionExplanation) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w, tr("  WHY THIS MOVO?"))
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, tr("Candidates:  %d (%d excluded by filters, limits or daily priority)\n"), len(e.Candidates), e.Excluded)
+	if e.ExplorationRate > 0 {
+		fmt.Fprintf(w, tr("Exploration: %.0f%% of picks ignore weights\n"), e.ExplorationRate*100)
+	}
+	if e.Variety != varietyNormal {
+		fmt.Fprintf(w, tr("Variety:     %s (weights raised to the power %g)\n"), e.Variety, varietyExponents[e.Variety])
+	}
+	if e.Rotation != "" {
+		fmt.Fprintf(w, tr("🔄 Rotation: only %s is up next\n"), e.Rotation)
+	}
+	if e.RecoveryMode {
+		fmt.Fprintln(w, tr("🔋 Auto-recovery mode is active (RPE ≤ 2)"))
+	}
+	if e.RestDay {
+		fmt.Fprintln(w, tr("😴 Rest day: only the lightest movos are offered"))
+	}
+	if e.DailyPriority {
+		fmt.Fprintln(w, tr("📅 Only today's unfinished dailies are eligible (--skip-minimums to widen)"))
+	}
+	fmt.Fprintln(w)
+
+	for _, c := range e.Candidates {
+		fmt.Fprintf(w, tr("%6.2f%%  %-32s weight %.2f\n"), c.Probability*100, c.Code, c.Weight)
+		working := []string{fmt.Sprintf("base %.2f", c.BaseWeight)}
+		for _, f := range c.Factors {
+			working = append(working, fmt.Sprintf("× %.2f %s", f.Multiplier, f.Name))
+		}
+		if e.Variety != varietyNormal {
+			working = append(working, fmt.Sprintf("^ %g variety", varietyExponents[e.Variety]))
+		}
+		fmt.Fprintf(w, "         %s\n", strings.Join(working, " "))
+	}
+
+	if len(e.HeldBack) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, tr("⏳ Waiting on requires_done_today:"))
+		for _, h := range e.HeldBack {
+			fmt.Fprintf(w, tr("         %-32s needs %s\n"), h.Code, strings.Join(h.Missing, ", "))
+		}
+	}
+}
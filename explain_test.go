@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplainSelection(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	originalConfig := appConfig
+	appConfig = DefaultConfig()
+	defer func() { appConfig = originalConfig }()
+
+	movos := []Movo{
+		{FullCode: "KB-swings", CategoryCode: "KB", Weight: 1, EffectiveRPE: 6},
+		{FullCode: "TB-box-breath", CategoryCode: "TB", Weight: 1, EffectiveRPE: 1, MinPerDay: 1},
+	}
+
+	// An unfinished daily narrows the pool to itself
+	explanation, err := explainSelection(movos, FilterOptions{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(explanation.Candidates) != 1 || !explanation.DailyPriority || explanation.Excluded != 1 {
+		t.Fatalf("expected only the daily to be eligible, got %+v", explanation)
+	}
+	if got := explanation.Candidates[0]; got.Probability != 1 || got.Factors[0].Name != minPerDayFactor {
+		t.Errorf("expected the daily to be certain with its min_per_day boost, got %+v", got)
+	}
+
+	explanation, err = explainSelection(movos, FilterOptions{SkipMinimums: true}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanation.DailyPriority || len(explanation.Candidates) != 2 {
+		t.Fatalf("expected both candidates with --skip-minimums, got %+v", explanation)
+	}
+
+	// --skip-minimums widens the pool but the daily keeps its boost
+	daily, swings := explanation.Candidates[0], explanation.Candidates[1]
+	if daily.Code != "TB-box-breath" || swings.Code != "KB-swings" {
+		t.Fatalf("expected the boosted daily first, got %+v", explanation.Candidates)
+	}
+	if len(daily.Factors) != 2 || daily.Factors[0].Name != minPerDayFactor || daily.Factors[1].Name != "never done" {
+		t.Errorf("expected min_per_day and never-done factors, got %+v", daily.Factors)
+	}
+	if want := appConfig.NeverDoneBoost; math.Abs(swings.Weight-want) > 1e-9 {
+		t.Errorf("expected weight %f, got %f", want, swings.Weight)
+	}
+
+	total := 0.0
+	for _, c := range explanation.Candidates {
+		total += c.Probability
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("probabilities should sum to 1, got %f", total)
+	}
+}
+
+func TestExplainSelectionHeldBack(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	originalConfig := appConfig
+	appConfig = DefaultConfig()
+	defer func() { appConfig = originalConfig }()
+	// AppendDailyLog needs the local state dir the logs dir lives in
+	if err := ensureStateDir(); err != nil {
+		t.Fatal(err)
+	}
+
+	movos := []Movo{
+		{FullCode: "KB-carries", CategoryCode: "KB", Weight: 1, EffectiveRPE: 6, RequiresDoneToday: []string{"MOB-hips"}},
+		{FullCode: "MOB-hips", CategoryCode: "MOB", Weight: 1, EffectiveRPE: 2},
+	}
+
+	explanation, err := explainSelection(movos, FilterOptions{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(explanation.HeldBack) != 1 {
+		t.Fatalf("expected KB-carries to be held back, got %+v", explanation.HeldBack)
+	}
+	if held := explanation.HeldBack[0]; held.Code != "KB-carries" || !slices.Equal(held.Missing, []string{"MOB-hips"}) {
+		t.Errorf("unexpected held back movo %+v", held)
+	}
+
+	var text bytes.Buffer
+	writeExplanation(&text, explanation)
+	if !strings.Contains(text.String(), "KB-carries") || !strings.Contains(text.String(), "needs MOB-hips") {
+		t.Errorf("text explanation should list KB-carries waiting on MOB-hips:\n%s", text.String())
+	}
+	data, err := json.Marshal(explanation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"held_back":[{"code":"KB-carries","title":"","missing":["MOB-hips"]}]`) {
+		t.Errorf("JSON explanation should list held_back movos: %s", data)
+	}
+
+	// Once the prerequisite is done, nothing is held back
+	if err := AppendDailyLog(appConfig.LogsDir, HistoryEntry{Timestamp: time.Now(), Code: "MOB-hips", Status: "done", Duration: 3, RPE: 2}); err != nil {
+		t.Fatal(err)
+	}
+	explanation, err = explainSelection(movos, FilterOptions{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(explanation.HeldBack) != 0 {
+		t.Errorf("expected nothing held back, got %+v", explanation.HeldBack)
+	}
+}
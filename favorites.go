@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const defaultFavoriteBoost = 2.0 // Weight multiplier for movos marked with 'fav add'
+
+// loadFavorites reads the favorites file (one full code per line). A missing file
+// means there are none. Favorites are personal, so they live outside the movo YAML.
+func loadFavorites(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading favorites: %w", err)
+	}
+
+	var codes []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if code := strings.TrimSpace(line); code != "" && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// saveFavorites writes the favorites one per line
+func saveFavorites(path string, codes []string) error {
+	var b strings.Builder
+	for _, code := range codes {
+		fmt.Fprintln(&b, code)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// updateFavorites adds (or with remove, takes out) codes and saves the result, sorted
+func updateFavorites(path string, codes []string, remove bool) ([]string, error) {
+	favorites, err := loadFavorites(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range codes {
+		if remove {
+			favorites = slices.DeleteFunc(favorites, func(f string) bool { return f == code })
+		} else if !slices.Contains(favorites, code) {
+			favorites = append(favorites, code)
+		}
+	}
+	slices.Sort(favorites)
+	if err := saveFavorites(path, favorites); err != nil {
+		return nil, fmt.Errorf("error saving favorites: %w", err)
+	}
+	return favorites, nil
+}
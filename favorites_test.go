@@ -0,0 +1,37 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestUpdateFavorites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites")
+
+	if favorites, err := loadFavorites(path); err != nil || favorites != nil {
+		t.Fatalf("expected no favorites without a file, got %v, %v", favorites, err)
+	}
+
+	if _, err := updateFavorites(path, []string{"TS-pushups", "KB-swings", "TS-pushups"}, false); err != nil {
+		t.Fatal(err)
+	}
+	favorites, err := updateFavorites(path, []string{"TB-box-breath"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"KB-swings", "TB-box-breath", "TS-pushups"}; !slices.Equal(favorites, want) {
+		t.Errorf("got %v, want %v", favorites, want)
+	}
+
+	if _, err := updateFavorites(path, []string{"KB-swings", "CF-unknown"}, true); err != nil {
+		t.Fatal(err)
+	}
+	favorites, err = loadFavorites(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"TB-box-breath", "TS-pushups"}; !slices.Equal(favorites, want) {
+		t.Errorf("after removing, got %v, want %v", favorites, want)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+// fitsMinutes reports whether a movo can be done within fit minutes (its shortest duration does)
+func fitsMinutes(movo Movo, fit int) bool {
+	return movo.DurationMin <= fit
+}
+
+// fitMinutes is how much of fit minutes a movo can fill
+func fitMinutes(movo Movo, fit int) int {
+	return min(max(movo.DurationMin, movo.DurationMax), fit)
+}
+
+// fitMultiplier scales a movo's weight by the share of fit minutes it can fill, so
+// with --fit 7 a 5-8 minute movo is preferred over a 2 minute one
+func fitMultiplier(movo Movo, fit int) float64 {
+	if fit <= 0 || fitMinutes(movo, fit) <= 0 {
+		return 1
+	}
+	return float64(fitMinutes(movo, fit)) / float64(fit)
+}
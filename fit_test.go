@@ -0,0 +1,36 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitMultiplier(t *testing.T) {
+	tests := []struct {
+		name string
+		movo Movo
+		fit  int
+		want float64
+	}{
+		{"range covers the time", Movo{DurationMin: 5, DurationMax: 8}, 7, 1},
+		{"short movo", Movo{DurationMin: 1, DurationMax: 2}, 8, 0.25},
+		{"fixed duration", Movo{DurationMin: 4}, 8, 0.5},
+		{"no fit", Movo{DurationMin: 1, DurationMax: 2}, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := fitMultiplier(tt.movo, tt.fit); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: got %f, want %f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFilterSnacksFit(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "TS-pushups", DurationMin: 2, DurationMax: 5},
+		{FullCode: "KB-complex", DurationMin: 10, DurationMax: 15},
+	}
+	filtered := filterSnacks(snacks, FilterOptions{Fit: 7})
+	if len(filtered) != 1 || filtered[0].FullCode != "TS-pushups" {
+		t.Errorf("expected only movos doable in 7 minutes, got %+v", filtered)
+	}
+}
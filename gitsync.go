@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How 'sync' settles a daily log changed on both machines
+const (
+	conflictAppend        = "append"          // Keep every entry from both sides
+	conflictLastWriteWins = "last-write-wins" // Keep the side committed most recently
+)
+
+const defaultSyncBranch = "main"
+
+// SyncConfig holds the git remote from the sync section of config.yaml
+type SyncConfig struct {
+	Remote   string `yaml:"remote"`
+	Branch   string `yaml:"branch"`
+	Conflict string `yaml:"conflict"`
+}
+
+// parseConflictPolicy validates a conflict policy, defaulting to append
+func parseConflictPolicy(s string) (string, error) {
+	switch strings.ToLower(s) {
+	case "", conflictAppend:
+		return conflictAppend, nil
+	case conflictLastWriteWins:
+		return conflictLastWriteWins, nil
+	}
+	return "", fmt.Errorf("sync conflict must be append or last-write-wins, got %q", s)
+}
+
+// gitRepo runs git commands in a directory
+type gitRepo struct {
+	dir string
+}
+
+// run returns git's trimmed output, with its stderr in the error if it fails
+func (g gitRepo) run(args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", g.dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %v %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// commit commits what's staged
+func (g gitRepo) commit(args ...string) error {
+	_, err := g.run(append([]string{"commit", "--quiet"}, args...)...)
+	return err
+}
+
+// syncResult is what a sync did
+type syncResult struct {
+	Committed bool     // Local changes were committed
+	Pulled    bool     // The remote had changes
+	Resolved  []string // Daily logs changed on both sides and settled by the conflict policy
+}
+
+// syncLogs commits the logs dir, merges the remote branch into it and pushes the result
+func syncLogs(logsDir string, cfg SyncConfig, now time.Time) (syncResult, error) {
+	var result syncResult
+	if _, err := exec.LookPath("git"); err != nil {
+		return result, withExitCode(exitError, fmt.Errorf("sync needs git installed"))
+	}
+	policy, err := parseConflictPolicy(cfg.Conflict)
+	if err != nil {
+		return result, withExitCode(exitError, err)
+	}
+	branch := cfg.Branch
+	if branch == "" {
+		branch = defaultSyncBranch
+	}
+
+	if err := ensureReachableLogsDir(logsDir); err != nil {
+		return result, err
+	}
+	repo := gitRepo{dir: logsDir}
+	if err := repo.setup(branch, cfg.Remote); err != nil {
+		return result, err
+	}
+
+	if _, err := repo.run("add", "--all"); err != nil {
+		return result, err
+	}
+	if status, err := repo.run("status", "--porcelain"); err != nil {
+		return result, err
+	} else if status != "" {
+		host, _ := os.Hostname()
+		message := fmt.Sprintf("movodoro sync from %s at %s", host, now.Format(time.RFC3339))
+		if err := repo.commit("-m", message); err != nil {
+			return result, err
+		}
+		result.Committed = true
+	}
+
+	if _, err := repo.run("fetch", "--quiet", "origin"); err != nil {
+		return result, err
+	}
+	remoteRef := "origin/" + branch
+	if _, err := repo.run("rev-parse", "--verify", "--quiet", remoteRef); err == nil {
+		if result.Pulled, result.Resolved, err = repo.merge(logsDir, remoteRef, policy); err != nil {
+			return result, err
+		}
+	}
+
+	// Nothing to push from a logs dir that has never had an entry
+	if _, err := repo.run("rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return result, nil
+	}
+	if _, err := repo.run("push", "--quiet", "--set-upstream", "origin", branch); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// setup makes the logs dir a repository on branch with origin pointing at remote
+func (g gitRepo) setup(branch, remote string) error {
+	if _, err := os.Stat(filepath.Join(g.dir, ".git")); os.IsNotExist(err) {
+		if _, err := g.run("init", "--quiet"); err != nil {
+			return err
+		}
+		if _, err := g.run("symbolic-ref", "HEAD", "refs/heads/"+branch); err != nil {
+			return err
+		}
+	}
+
+	// Commits need an author; without a git identity, name the machine
+	if email, _ := g.run("config", "user.email"); email == "" {
+		host, _ := os.Hostname()
+		if _, err := g.run("config", "user.name", "movodoro"); err != nil {
+			return err
+		}
+		if _, err := g.run("config", "user.email", "movodoro@"+host); err != nil {
+			return err
+		}
+	}
+
+	current, err := g.run("remote", "get-url", "origin")
+	switch {
+	case err != nil && remote == "":
+		return fmt.Errorf("no git remote for %s: set sync: remote: in config.yaml", g.dir)
+	case err != nil:
+		_, err = g.run("remote", "add", "origin", remote)
+		return err
+	case remote != "" && remote != current:
+		_, err = g.run("remote", "set-url", "origin", remote)
+		return err
+	}
+	return nil
+}
+
+// merge brings in remoteRef, settling files changed on both sides by policy. Each
+// machine starts its own history, so unrelated histories are expected.
+func (g gitRepo) merge(logsDir, remoteRef, policy string) (pulled bool, resolved []string, err error) {
+	before, _ := g.run("rev-parse", "--verify", "--quiet", "HEAD")
+	if before == "" {
+		// Nothing local yet: just take the remote's history
+		_, err := g.run("reset", "--quiet", "--hard", remoteRef)
+		return err == nil, nil, err
+	}
+	if base, _ := g.run("merge-base", "HEAD", remoteRef); base != "" {
+		if remote, _ := g.run("rev-parse", remoteRef); base == remote {
+			return false, nil, nil // Already up to date
+		}
+	}
+
+	// A failed merge with conflicts still leaves MERGE_HEAD to finish from
+	_, mergeErr := g.run("merge", "--quiet", "--no-commit", "--allow-unrelated-histories", remoteRef)
+	if _, err := g.run("rev-parse", "--verify", "--quiet", "MERGE_HEAD"); err != nil {
+		// Fast-forwarded (or failed outright)
+		return mergeErr == nil, nil, mergeErr
+	}
+
+	conflicted, err := g.run("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return true, nil, err
+	}
+	for _, name := range strings.Fields(conflicted) {
+		if err := g.resolve(name, policy); err != nil {
+			g.run("merge", "--abort")
+			return true, nil, fmt.Errorf("error merging %s: %w", name, err)
+		}
+		if filepath.Ext(name) == ".csv" {
+			resolved = append(resolved, name)
+		}
+	}
+
+	// Merged logs no longer match either side's checksums
+	if err := g.refreshChecksums(logsDir, before); err != nil {
+		g.run("merge", "--abort")
+		return true, nil, err
+	}
+	if err := g.commit("--no-edit"); err != nil {
+		return true, nil, err
+	}
+	return true, resolved, nil
+}
+
+// resolve settles one conflicted file: daily logs by policy, anything else by last write
+func (g gitRepo) resolve(name, policy string) error {
+	ours, _ := g.run("show", ":2:"+name)
+	theirs, _ := g.run("show", ":3:"+name)
+
+	var merged []byte
+	if filepath.Ext(name) == ".csv" && policy == conflictAppend {
+		var err error
+		if merged, err = mergeDailyLogs([]byte(ours), []byte(theirs)); err != nil {
+			return err
+		}
+	} else {
+		ourTime, _ := g.run("log", "-1", "--format=%ct", "HEAD")
+		theirTime, _ := g.run("log", "-1", "--format=%ct", "MERGE_HEAD")
+		ourSecs, _ := strconv.ParseInt(ourTime, 10, 64)
+		theirSecs, _ := strconv.ParseInt(theirTime, 10, 64)
+		merged = []byte(ours + "\n")
+		if theirSecs > ourSecs {
+			merged = []byte(theirs + "\n")
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(g.dir, name), merged, 0644); err != nil {
+		return err
+	}
+	_, err := g.run("add", "--", name)
+	return err
+}
+
+// refreshChecksums re-records the checksums of daily logs the merge changed
+func (g gitRepo) refreshChecksums(logsDir, before string) error {
+	if _, enabled, _ := loadChecksums(logsDir); !enabled {
+		return nil
+	}
+	changed, err := g.run("diff", "--cached", "--name-only", before)
+	if err != nil {
+		return err
+	}
+	for _, name := range strings.Fields(changed) {
+		if filepath.Ext(name) != ".csv" {
+			continue
+		}
+		if err := recordChecksum(logsDir, filepath.Join(logsDir, name)); err != nil {
+			return err
+		}
+	}
+	_, err = g.run("add", "--", checksumIndex)
+	return err
+}
+
+// mergeDailyLogs combines two versions of a daily log: every entry from either side
+// once, in timestamp order
+func mergeDailyLogs(ours, theirs []byte) ([]byte, error) {
+	seen := make(map[string]bool)
+	var records [][]string
+	for _, data := range [][]byte{ours, theirs} {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1 // Older rows have no extras column
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range rows {
+			if record[0] == "timestamp" {
+				continue
+			}
+			key := strings.Join(record, "\x00")
+			if !seen[key] {
+				seen[key] = true
+				records = append(records, record)
+			}
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, errA := time.Parse(time.RFC3339, records[i][0])
+		b, errB := time.Parse(time.RFC3339, records[j][0])
+		if errA != nil || errB != nil {
+			return records[i][0] < records[j][0]
+		}
+		return a.Before(b)
+	})
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(logHeader)
+	writer.WriteAll(records)
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
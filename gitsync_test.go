@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeDailyLogs(t *testing.T) {
+	ours := "timestamp,code,status,duration,rpe,subset,extras\n" +
+		"2025-03-10T09:00:00Z,BR-box,done,3,1,,\n" +
+		"2025-03-10T12:00:00Z,STR-squats,done,5,6,,\n"
+	theirs := "timestamp,code,status,duration,rpe,subset,extras\n" +
+		"2025-03-10T09:00:00Z,BR-box,done,3,1,,\n" +
+		"2025-03-10T10:30:00+01:00,MOB-hips,skip,0,0,\n"
+
+	merged, err := mergeDailyLogs([]byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "timestamp,code,status,duration,rpe,subset,extras\n" +
+		"2025-03-10T09:00:00Z,BR-box,done,3,1,,\n" +
+		"2025-03-10T10:30:00+01:00,MOB-hips,skip,0,0,\n" +
+		"2025-03-10T12:00:00Z,STR-squats,done,5,6,,\n"
+	if string(merged) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, merged)
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	for in, want := range map[string]string{"": conflictAppend, "append": conflictAppend, "Last-Write-Wins": conflictLastWriteWins} {
+		if got, err := parseConflictPolicy(in); err != nil || got != want {
+			t.Errorf("parseConflictPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseConflictPolicy("theirs"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+// TestSyncLogsBetweenMachines syncs two logs dirs that both logged the same day
+func TestSyncLogsBetweenMachines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir()) // No user git config
+
+	remote := filepath.Join(t.TempDir(), "logs.git")
+	if out, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("%v %s", err, out)
+	}
+	laptop, desktop := t.TempDir(), t.TempDir()
+	cfg := SyncConfig{Remote: remote}
+
+	day := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	entry := func(hour int, code string) HistoryEntry {
+		return HistoryEntry{Timestamp: day.Add(time.Duration(hour) * time.Hour), Code: code, Status: "done", Duration: 5, RPE: 3}
+	}
+	if err := AppendDailyLog(laptop, entry(0, "BR-box")); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendDailyLog(desktop, entry(2, "STR-squats")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := syncLogs(laptop, cfg, day); err != nil {
+		t.Fatalf("laptop sync: %v", err)
+	}
+	result, err := syncLogs(desktop, cfg, day)
+	if err != nil {
+		t.Fatalf("desktop sync: %v", err)
+	}
+	if !result.Committed || !result.Pulled || len(result.Resolved) != 1 {
+		t.Errorf("expected the desktop to commit, pull and merge one log, got %+v", result)
+	}
+	if _, err := syncLogs(laptop, cfg, day); err != nil {
+		t.Fatalf("second laptop sync: %v", err)
+	}
+
+	for name, dir := range map[string]string{"laptop": laptop, "desktop": desktop} {
+		entries, err := LoadDailyLog(dir, day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 2 || entries[0].Code != "BR-box" || entries[1].Code != "STR-squats" {
+			t.Errorf("expected both machines' entries on the %s, got %+v", name, entries)
+		}
+	}
+
+	result, err = syncLogs(laptop, cfg, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Committed || result.Pulled {
+		t.Errorf("expected nothing to do, got %+v", result)
+	}
+}
+
+func TestSyncLogsNeedsRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	_, err := syncLogs(dir, SyncConfig{}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "remote") {
+		t.Errorf("expected a missing remote error, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, ".git")); statErr != nil {
+		t.Errorf("expected the logs dir to be initialised anyway: %v", statErr)
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// heatmapCells shade a day from nothing logged to the busiest day's level
+var heatmapCells = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapMetrics lists what 'heatmap --by' can shade days by
+var heatmapMetrics = []string{"minutes", "movos"}
+
+// heatmapLevel places value on a 0-4 scale: 0 for nothing, else quarters of peak
+func heatmapLevel(value, peak int) int {
+	if value <= 0 || peak <= 0 {
+		return 0
+	}
+	level := (value*4 + peak - 1) / peak // Rounded up so any activity shows
+	return min(level, 4)
+}
+
+// heatmapValue is the day's minutes or movo count
+func heatmapValue(day dayTotals, metric string) int {
+	if metric == "movos" {
+		return day.Movos
+	}
+	return day.Minutes
+}
+
+// writeHeatmap draws the report's days as a calendar: a row per weekday starting
+// on weekStart, a column per week, and month names over the week each month starts
+func writeHeatmap(w io.Writer, heading string, report periodReport, metric string, weekStart time.Weekday, color bool) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w, tr("  MOVODORO HEATMAP"))
+	fmt.Fprintf(w, "  %s\n", heading)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+	if len(report.Days) == 0 {
+		return
+	}
+
+	values := make(map[string]int, len(report.Days))
+	peak, active := 0, 0
+	for _, day := range report.Days {
+		v := heatmapValue(day, metric)
+		values[dayKey(day.Date)] = v
+		peak = max(peak, v)
+		if v > 0 {
+			active++
+		}
+	}
+
+	first, last := report.Days[0].Date, report.Days[len(report.Days)-1].Date
+	gridStart := first.AddDate(0, 0, -((int(first.Weekday()) - int(weekStart) + 7) % 7))
+	weeks := 0
+	for day := gridStart; !day.After(last); day = day.AddDate(0, 0, 7) {
+		weeks++
+	}
+
+	// Month names over the column holding each month's 1st, where there's room
+	header := []rune(strings.Repeat(" ", weeks+3))
+	free := 0
+	for c := 0; c < weeks; c++ {
+		for i := 0; i < 7; i++ {
+			day := gridStart.AddDate(0, 0, 7*c+i)
+			if day.Day() != 1 || day.Before(first) || day.After(last) || c < free {
+				continue
+			}
+			copy(header[c:], []rune(day.Format("Jan")))
+			free = c + 4
+		}
+	}
+	fmt.Fprintf(w, "     %s\n", strings.TrimRight(string(header), " "))
+
+	for r := 0; r < 7; r++ {
+		var row strings.Builder
+		for c := 0; c < weeks; c++ {
+			day := gridStart.AddDate(0, 0, 7*c+r)
+			if day.Before(first) || day.After(last) {
+				row.WriteString(" ")
+				continue
+			}
+			level := heatmapLevel(values[dayKey(day)], peak)
+			cell := heatmapCells[level]
+			if color && level > 0 {
+				cell = ansiGreen + cell + ansiReset
+			}
+			row.WriteString(cell)
+		}
+		weekday := time.Weekday((int(weekStart) + r) % 7)
+		fmt.Fprintf(w, " %s %s\n", weekday.String()[:3], strings.TrimRight(row.String(), " "))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, tr("     Less %s More (%s; busiest day %d)\n"), strings.Join(heatmapCells, " "), metric, peak)
+	fmt.Fprintf(w, tr("     %d active days of %d, %d movos, %d minutes\n"), active, len(report.Days), report.Total.Movos, report.Total.Minutes)
+}
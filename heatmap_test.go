@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeatmapLevel(t *testing.T) {
+	tests := []struct{ value, peak, want int }{
+		{0, 40, 0},
+		{1, 40, 1},
+		{10, 40, 1},
+		{11, 40, 2},
+		{30, 40, 3},
+		{40, 40, 4},
+	}
+	for _, tt := range tests {
+		if got := heatmapLevel(tt.value, tt.peak); got != tt.want {
+			t.Errorf("heatmapLevel(%d, %d) = %d, want %d", tt.value, tt.peak, got, tt.want)
+		}
+	}
+}
+
+func TestWriteHeatmap(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local) // A Wednesday
+	entries := []HistoryEntry{
+		doneAt("KB-swings", time.Date(2025, 1, 1, 9, 0, 0, 0, time.Local)),
+		doneAt("KB-swings", time.Date(2025, 2, 3, 9, 0, 0, 0, time.Local)),
+	}
+	report := buildPeriodReport(entries, start, 59) // Through Feb 28
+
+	var b strings.Builder
+	writeHeatmap(&b, "2025", report, "minutes", time.Monday, false)
+	lines := strings.Split(b.String(), "\n")
+
+	var rows []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, " Mon") || strings.HasPrefix(line, " Wed") || strings.HasPrefix(line, " Sun") {
+			rows = append(rows, line)
+		}
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected rows starting on Monday, got:\n%s", b.String())
+	}
+	// Jan 1 is in the first column; Mon Dec 30 before it is left blank
+	if rows[0] != " Mon  ····█···" || !strings.HasPrefix(rows[1], " Wed █") {
+		t.Errorf("unexpected rows:\n%s", strings.Join(rows, "\n"))
+	}
+	if !strings.Contains(b.String(), "Jan") || !strings.Contains(b.String(), "Feb") {
+		t.Errorf("expected month labels, got:\n%s", b.String())
+	}
+	if !strings.Contains(b.String(), "2 active days of 59") {
+		t.Errorf("expected the active day count, got:\n%s", b.String())
+	}
+}
@@ -3,13 +3,18 @@ package main
 import (
 	"encoding/csv"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"time"
 )
 
+// logHeader is the CSV header written to new daily log files
+var logHeader = []string{"timestamp", "code", "status", "duration", "rpe", "subset", "extras"}
+
 // GetDailyLogPath returns the path for a specific date's log file
 func GetDailyLogPath(logsDir string, date time.Time) string {
 	filename := date.Format("20060102") + ".csv"
@@ -21,33 +26,67 @@ func GetTodayLogPath(logsDir string) string {
 	return GetDailyLogPath(logsDir, time.Now())
 }
 
-// ensureLogsDir creates the logs directory if it doesn't exist
-func ensureLogsDir(logsDir string) error {
-	return os.MkdirAll(logsDir, 0755)
+// ensureReachableLogsDir creates the logs directory for writing only when its parent
+// exists. A missing parent usually means an offline mount; creating the tree locally
+// would strand entries there instead of queueing them in the spool.
+func ensureReachableLogsDir(logsDir string) error {
+	if info, err := os.Stat(logsDir); err == nil && info.IsDir() {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Dir(logsDir)); err != nil {
+		return fmt.Errorf("logs dir %s is unavailable: %w", logsDir, err)
+	}
+	return os.Mkdir(logsDir, 0755)
 }
 
-// LoadDailyLog loads entries from a specific daily log file (CSV format)
+// LoadDailyLog loads a day's movo entries from its daily log file (CSV format) and
+// from the month's archive, if 'archive' has moved the day there. Check-ins are left
+// out; see loadDayEntries.
 func LoadDailyLog(logsDir string, date time.Time) ([]HistoryEntry, error) {
+	entries, err := loadDayEntries(logsDir, date)
+	if err != nil {
+		return nil, err
+	}
+	return movoEntries(entries), nil
+}
+
+// movoEntries drops check-ins, keeping the entries for movos
+func movoEntries(entries []HistoryEntry) []HistoryEntry {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.Status != statusCheckin {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// loadDayEntries loads every entry logged on a day, check-ins included
+func loadDayEntries(logsDir string, date time.Time) ([]HistoryEntry, error) {
+	entries, err := loadArchivedDay(logsDir, date)
+	if err != nil {
+		return nil, err
+	}
+
 	logPath := GetDailyLogPath(logsDir, date)
 
 	file, err := os.Open(logPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []HistoryEntry{}, nil
+			return entries, nil
 		}
 		return nil, fmt.Errorf("error opening log file: %w", err)
 	}
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Older rows have no extras column
 	records, err := reader.ReadAll()
 	if err != nil {
 		// If CSV parsing fails, check if it's old format and provide helpful error
 		return nil, fmt.Errorf("⚠️  Error reading log file. If this is an old format log, run 'movodoro migrate' to convert to v1.0.0 CSV format: %w", err)
 	}
 
-	var entries []HistoryEntry
-
 	for i, record := range records {
 		// Skip header row
 		if i == 0 && record[0] == "timestamp" {
@@ -86,26 +125,29 @@ func LoadHistoryRange(logsDir string, startDate, endDate time.Time) ([]HistoryEn
 	return allEntries, nil
 }
 
-// LoadAllHistory loads all history entries from all log files
+// LoadAllHistory loads all movo entries (not check-ins) from all log files. A missing
+// logs dir is empty history; reads never create it (see ensureReachableLogsDir).
 func LoadAllHistory(logsDir string) ([]HistoryEntry, error) {
-	// Ensure logs directory exists
-	if err := ensureLogsDir(logsDir); err != nil {
-		return nil, err
+	// Find all .csv files: monthly archives (YYYYMM.csv), then daily logs
+	archived, err := filepath.Glob(filepath.Join(logsDir, archiveDirName, "*.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("error finding archived logs: %w", err)
 	}
-
-	// Find all .csv files
 	pattern := filepath.Join(logsDir, "*.csv")
 	files, err := filepath.Glob(pattern)
 	if err != nil {
 		return nil, fmt.Errorf("error finding log files: %w", err)
 	}
 
-	if len(files) == 0 {
+	if len(archived)+len(files) == 0 {
 		return []HistoryEntry{}, nil
 	}
 
-	// Sort files (they're named YYYYMMDD.csv so alphabetical = chronological)
+	// Sort files (they're named YYYYMMDD.csv so alphabetical = chronological).
+	// Archived months are older than any daily log left.
+	sort.Strings(archived)
 	sort.Strings(files)
+	files = append(archived, files...)
 
 	var allEntries []HistoryEntry
 
@@ -116,6 +158,7 @@ func LoadAllHistory(logsDir string) ([]HistoryEntry, error) {
 		}
 
 		reader := csv.NewReader(f)
+		reader.FieldsPerRecord = -1 // Older rows have no extras column
 		records, err := reader.ReadAll()
 		f.Close()
 
@@ -131,7 +174,7 @@ func LoadAllHistory(logsDir string) ([]HistoryEntry, error) {
 			}
 
 			entry, err := parseCSVRecord(record)
-			if err != nil {
+			if err != nil || entry.Status == statusCheckin {
 				continue
 			}
 
@@ -144,13 +187,21 @@ func LoadAllHistory(logsDir string) ([]HistoryEntry, error) {
 
 // AppendTodayLog appends an entry to today's log file in CSV format
 func AppendTodayLog(logsDir string, entry HistoryEntry) error {
-	// Ensure logs directory exists
-	if err := ensureLogsDir(logsDir); err != nil {
+	return appendLog(logsDir, GetTodayLogPath(logsDir), entry)
+}
+
+// AppendDailyLog appends an entry to the log file for the day it was logged
+func AppendDailyLog(logsDir string, entry HistoryEntry) error {
+	return appendLog(logsDir, GetDailyLogPath(logsDir, entry.Timestamp), entry)
+}
+
+// appendLog appends an entry to a daily log file, writing the header to new files
+func appendLog(logsDir, logPath string, entry HistoryEntry) error {
+	// Ensure logs directory exists, without creating it on a missing mount
+	if err := ensureReachableLogsDir(logsDir); err != nil {
 		return err
 	}
 
-	logPath := GetTodayLogPath(logsDir)
-
 	// Check if file exists and is empty (need to write header)
 	fileInfo, err := os.Stat(logPath)
 	writeHeader := err != nil || fileInfo.Size() == 0
@@ -162,16 +213,51 @@ func AppendTodayLog(logsDir string, entry HistoryEntry) error {
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
-	defer writer.Flush()
 
 	// Write header if this is a new/empty file
 	if writeHeader {
-		if err := writer.Write([]string{"timestamp", "code", "status", "duration", "rpe", "subset"}); err != nil {
+		if err := writer.Write(logHeader); err != nil {
 			return fmt.Errorf("error writing CSV header: %w", err)
 		}
 	}
 
 	// Write the entry
+	if err := writer.Write(entryRecord(entry)); err != nil {
+		return fmt.Errorf("error writing CSV record: %w", err)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing CSV record: %w", err)
+	}
+
+	// Keep the checksum index current (if enabled)
+	return recordChecksum(logsDir, logPath)
+}
+
+// removeLogEntry deletes the most recent entry in its day's log with entry's timestamp,
+// code and status, e.g. a skip taken back in interactive mode
+func removeLogEntry(logsDir string, entry HistoryEntry) error {
+	path := GetDailyLogPath(logsDir, entry.Timestamp)
+	records, err := readLogRecords(path)
+	if err != nil {
+		return err
+	}
+	timestamp := entry.Timestamp.Format(time.RFC3339)
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		if len(record) >= 3 && record[0] == timestamp && record[1] == entry.Code && record[2] == entry.Status {
+			if err := writeLogRecords(path, slices.Delete(records, i, i+1)); err != nil {
+				return err
+			}
+			return recordChecksum(logsDir, path)
+		}
+	}
+	return fmt.Errorf("no %s entry for %s in %s", entry.Status, entry.Code, filepath.Base(path))
+}
+
+// entryRecord converts an entry to its CSV record (the extras column only when needed)
+func entryRecord(entry HistoryEntry) []string {
 	record := []string{
 		entry.Timestamp.Format(time.RFC3339),
 		entry.Code,
@@ -180,26 +266,28 @@ func AppendTodayLog(logsDir string, entry HistoryEntry) error {
 		strconv.Itoa(entry.RPE),
 		entry.Subset,
 	}
-
-	if err := writer.Write(record); err != nil {
-		return fmt.Errorf("error writing CSV record: %w", err)
+	if len(entry.Extras) > 0 {
+		record = append(record, encodeExtras(entry.Extras))
 	}
-
-	return nil
+	return record
 }
 
 // GetTodayStatsDaily returns today's stats (optimized for daily files)
 func GetTodayStatsDaily(logsDir string) (DailyStats, error) {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return GetDayStatsDaily(logsDir, time.Now())
+}
+
+// GetDayStatsDaily returns the stats for the day containing t
+func GetDayStatsDaily(logsDir string, t time.Time) (DailyStats, error) {
+	day := dayStart(t)
 
-	entries, err := LoadDailyLog(logsDir, today)
+	entries, err := LoadDailyLog(logsDir, day)
 	if err != nil {
 		return DailyStats{}, err
 	}
 
 	stats := DailyStats{
-		Date: today,
+		Date: day,
 	}
 
 	for _, entry := range entries {
@@ -209,6 +297,10 @@ func GetTodayStatsDaily(logsDir string) (DailyStats, error) {
 			stats.TotalDuration += entry.Duration
 			stats.TotalRPE += entry.RPE
 			stats.CompletedSnacks = append(stats.CompletedSnacks, entry)
+		} else if entry.Status == "partial" {
+			stats.TotalDuration += entry.Duration
+			stats.TotalRPE += entry.RPE
+			stats.PartialSnacks = append(stats.PartialSnacks, entry)
 		} else if entry.Status == "skip" {
 			stats.SkippedSnacks = append(stats.SkippedSnacks, entry)
 		}
@@ -237,8 +329,30 @@ func GetCountTodayDaily(logsDir string, code string) (done int, skipped int, err
 	return done, skipped, nil
 }
 
+// GetCountWeekDaily returns how many times a snack was completed in the week starting at weekStart
+func GetCountWeekDaily(logsDir string, code string, weekStart time.Time) (int, error) {
+	entries, err := LoadHistoryRange(logsDir, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, entry := range entries {
+		if entry.Code == code && entry.Status == "done" {
+			done++
+		}
+	}
+
+	return done, nil
+}
+
 // GetLastDoneDaily returns when a snack was last completed
 func GetLastDoneDaily(logsDir string, code string) (*time.Time, error) {
+	return GetLastStatusDaily(logsDir, code, "done")
+}
+
+// GetLastStatusDaily returns when a snack was last logged with the given status
+func GetLastStatusDaily(logsDir string, code string, status string) (*time.Time, error) {
 	// Load all history (we need to scan everything for this)
 	entries, err := LoadAllHistory(logsDir)
 	if err != nil {
@@ -248,7 +362,7 @@ func GetLastDoneDaily(logsDir string, code string) (*time.Time, error) {
 	// Iterate backwards to find most recent
 	for i := len(entries) - 1; i >= 0; i-- {
 		entry := entries[i]
-		if entry.Code == code && entry.Status == "done" {
+		if entry.Code == code && entry.Status == status {
 			return &entry.Timestamp, nil
 		}
 	}
@@ -277,13 +391,13 @@ func ClearTodayLog(logsDir string) error {
 		return fmt.Errorf("error removing log file: %w", err)
 	}
 
-	return nil
+	return forgetChecksum(logsDir, logPath)
 }
 
-// parseCSVRecord parses a CSV record: timestamp,code,status,duration,rpe,subset
+// parseCSVRecord parses a CSV record: timestamp,code,status,duration,rpe,subset[,extras]
 func parseCSVRecord(record []string) (HistoryEntry, error) {
-	if len(record) != 6 {
-		return HistoryEntry{}, fmt.Errorf("expected 6 fields, got %d", len(record))
+	if len(record) != 6 && len(record) != 7 {
+		return HistoryEntry{}, fmt.Errorf("expected 6 or 7 fields, got %d", len(record))
 	}
 
 	// Parse timestamp
@@ -304,6 +418,14 @@ func parseCSVRecord(record []string) (HistoryEntry, error) {
 		return HistoryEntry{}, fmt.Errorf("invalid RPE: %w", err)
 	}
 
+	var extras map[string]string
+	if len(record) == 7 && record[6] != "" {
+		extras, err = parseExtras(record[6])
+		if err != nil {
+			return HistoryEntry{}, fmt.Errorf("invalid extras: %w", err)
+		}
+	}
+
 	return HistoryEntry{
 		Timestamp: timestamp,
 		Code:      record[1],
@@ -311,5 +433,28 @@ func parseCSVRecord(record []string) (HistoryEntry, error) {
 		Duration:  duration,
 		RPE:       rpe,
 		Subset:    record[5],
+		Extras:    extras,
 	}, nil
 }
+
+// encodeExtras encodes extra fields as a URL query string (keys sorted)
+func encodeExtras(extras map[string]string) string {
+	values := url.Values{}
+	for k, v := range extras {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
+
+// parseExtras decodes the extras column
+func parseExtras(s string) (map[string]string, error) {
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	extras := make(map[string]string, len(values))
+	for k := range values {
+		extras[k] = values.Get(k)
+	}
+	return extras, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemoveLogEntry(t *testing.T) {
+	logsDir := t.TempDir()
+	at := func(h int) time.Time { return time.Date(2026, 5, 4, h, 0, 0, 0, time.Local) }
+	skip := HistoryEntry{Timestamp: at(10), Code: "MOB-hips", Status: "skip"}
+	for _, entry := range []HistoryEntry{doneAt("KB-swings", at(9)), skip, doneAt("MOB-hips", at(11))} {
+		if err := AppendDailyLog(logsDir, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := removeLogEntry(logsDir, skip); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadDailyLog(logsDir, at(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Code != "KB-swings" || entries[1].Status != "done" {
+		t.Errorf("entries after removing the skip = %+v", entries)
+	}
+
+	if err := removeLogEntry(logsDir, skip); err == nil {
+		t.Error("removing an entry that isn't logged should fail")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// hookTimeout keeps a slow endpoint from holding up logging for long
+const hookTimeout = 5 * time.Second
+
+// HooksConfig holds the URLs from the hooks section of config.yaml
+type HooksConfig struct {
+	OnDone string `yaml:"on_done"` // Also called for partial completions
+	OnSkip string `yaml:"on_skip"`
+}
+
+// url returns the hook URL for an entry's status ("" if none is set)
+func (h HooksConfig) url(status string) string {
+	if status == "skip" {
+		return h.OnSkip
+	}
+	return h.OnDone
+}
+
+// hookPayload is the JSON posted to a hook
+type hookPayload struct {
+	Event     string    `json:"event"` // done or skip
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Code      string    `json:"code"`
+	Title     string    `json:"title,omitempty"`
+	Duration  int       `json:"duration"`
+	RPE       int       `json:"rpe"`
+	Tags      []string  `json:"tags"`
+	Subset    string    `json:"subset,omitempty"`
+	Profile   string    `json:"profile,omitempty"`
+}
+
+func newHookPayload(entry HistoryEntry, movo *Movo) hookPayload {
+	p := hookPayload{
+		Event:     "done",
+		Status:    entry.Status,
+		Timestamp: entry.Timestamp,
+		Code:      entry.Code,
+		Duration:  entry.Duration,
+		RPE:       entry.RPE,
+		Tags:      []string{},
+		Subset:    entry.Subset,
+		Profile:   entry.Extras["profile"],
+	}
+	if entry.Status == "skip" {
+		p.Event = "skip"
+	}
+	if movo != nil {
+		p.Title = movo.Title
+		if movo.AllTags != nil {
+			p.Tags = movo.AllTags
+		}
+	}
+	return p
+}
+
+// postJSON posts v as JSON to url, failing on anything but a 2xx reply
+func postJSON(client *http.Client, url string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
+
+// runHooks calls the configured hook for a logged entry. The entry is already in
+// history, so a failing hook is only a warning, written to warn.
+func runHooks(hooks HooksConfig, entry HistoryEntry, movo *Movo, warn io.Writer) {
+	url := hooks.url(entry.Status)
+	if url == "" {
+		return
+	}
+	p := newHookPayload(entry, movo)
+	if err := postJSON(&http.Client{Timeout: hookTimeout}, url, p); err != nil {
+		fmt.Fprintf(warn, tr("Warning: %s hook failed: %v\n"), p.Event, err)
+	}
+}
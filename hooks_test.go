@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewHookPayload(t *testing.T) {
+	at := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+	movo := &Movo{FullCode: "MOB-hips", Title: "Hip circles", AllTags: []string{"mobilityx", "hipsx"}}
+
+	done := newHookPayload(HistoryEntry{Timestamp: at, Code: "MOB-hips", Status: "partial", Duration: 3, RPE: 2, Subset: "desk",
+		Extras: map[string]string{"profile": "travel"}}, movo)
+	if done.Event != "done" || done.Status != "partial" || done.Title != "Hip circles" || len(done.Tags) != 2 ||
+		done.Subset != "desk" || done.Profile != "travel" {
+		t.Errorf("unexpected payload %+v", done)
+	}
+
+	skip := newHookPayload(HistoryEntry{Timestamp: at, Code: "MOB-hips", Status: "skip"}, &Movo{Title: "Hip circles"})
+	if skip.Event != "skip" || skip.Tags == nil {
+		t.Errorf("expected a skip event with empty (not null) tags, got %+v", skip)
+	}
+}
+
+func TestHooksURL(t *testing.T) {
+	hooks := HooksConfig{OnDone: "https://example.com/done", OnSkip: "https://example.com/skip"}
+	for status, want := range map[string]string{
+		"done":    hooks.OnDone,
+		"partial": hooks.OnDone,
+		"skip":    hooks.OnSkip,
+	} {
+		if got := hooks.url(status); got != want {
+			t.Errorf("url(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
+
+func TestAppendLogEntryCallsHook(t *testing.T) {
+	var received []hookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p hookPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("bad hook body: %v", err)
+		}
+		received = append(received, p)
+	}))
+	defer server.Close()
+
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+	appConfig.Hooks = HooksConfig{OnSkip: server.URL}
+
+	movo := &Movo{FullCode: "MOB-hips", Title: "Hip circles"}
+	if err := appendLogEntry(HistoryEntry{Timestamp: time.Now(), Code: "MOB-hips", Status: "done", Duration: 5, RPE: 3}, movo); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendLogEntry(HistoryEntry{Timestamp: time.Now(), Code: "MOB-hips", Status: "skip"}, movo); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only on_skip is set, so only the skip is posted
+	if len(received) != 1 || received[0].Event != "skip" || received[0].Title != "Hip circles" {
+		t.Errorf("expected one skip hook call, got %+v", received)
+	}
+}
+
+func TestPostJSONFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := postJSON(http.DefaultClient, server.URL, hookPayload{}); err == nil {
+		t.Error("expected an error for a 500 reply")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// householdMember is one config profile in a combined household report
+type householdMember struct {
+	Profile string // "" for the default profile
+	LogsDir string
+	KidMode bool
+}
+
+// name labels the member in reports
+func (m householdMember) name() string {
+	if m.Profile == "" {
+		return defaultProfileName
+	}
+	return m.Profile
+}
+
+// memberDay is a member's entries for one day and their totals
+type memberDay struct {
+	Member  householdMember
+	Entries []HistoryEntry
+	Totals  profileOutcome
+}
+
+// householdMembers lists the default profile followed by every profile in config.yaml,
+// each with the logs dir it records to
+func householdMembers() ([]householdMember, error) {
+	base := loadConfig("")
+	if base.ConfigErr != nil {
+		return nil, base.ConfigErr
+	}
+
+	members := []householdMember{{LogsDir: base.LogsDir, KidMode: base.KidMode}}
+	for _, name := range base.ProfileNames {
+		cfg := loadConfig(name)
+		if cfg.ConfigErr != nil {
+			return nil, cfg.ConfigErr
+		}
+		members = append(members, householdMember{Profile: name, LogsDir: cfg.LogsDir, KidMode: cfg.KidMode})
+	}
+	return members, nil
+}
+
+// memberEntries picks a member's entries from their logs dir. A logs dir used by a
+// single member is all theirs; a shared one is split by the profile each entry records.
+func memberEntries(m householdMember, entries []HistoryEntry, shared bool) []HistoryEntry {
+	if !shared {
+		return entries
+	}
+
+	var mine []HistoryEntry
+	for _, entry := range entries {
+		if entry.Extras["profile"] == m.Profile {
+			mine = append(mine, entry)
+		}
+	}
+	return mine
+}
+
+// householdDay loads each member's entries for date, reading each logs dir once
+func householdDay(members []householdMember, date time.Time) ([]memberDay, error) {
+	users := make(map[string]int)
+	for _, m := range members {
+		users[m.LogsDir]++
+	}
+
+	logs := make(map[string][]HistoryEntry)
+	days := make([]memberDay, 0, len(members))
+	for _, m := range members {
+		entries, ok := logs[m.LogsDir]
+		if !ok {
+			var err error
+			entries, err = LoadDailyLog(m.LogsDir, date)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", m.name(), err)
+			}
+			logs[m.LogsDir] = entries
+		}
+
+		day := memberDay{Member: m, Totals: profileOutcome{Profile: m.name()}}
+		day.Entries = memberEntries(m, entries, users[m.LogsDir] > 1)
+		for _, entry := range day.Entries {
+			day.Totals.add(entry)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHouseholdDay(t *testing.T) {
+	shared := t.TempDir()
+	separate := t.TempDir()
+
+	now := time.Now()
+	tagged := func(e HistoryEntry, profile string) HistoryEntry {
+		e.Extras = map[string]string{"profile": profile}
+		return e
+	}
+	for _, entry := range []HistoryEntry{
+		doneAt("TS-pushups", now),
+		tagged(doneAt("TS-squats", now), "partner"),
+		tagged(doneAt("TS-lunges", now), "partner"),
+	} {
+		if err := AppendTodayLog(shared, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Untagged entries in a logs dir of their own still belong to its member
+	if err := AppendTodayLog(separate, doneAt("TB-box-breath", now)); err != nil {
+		t.Fatal(err)
+	}
+
+	members := []householdMember{
+		{LogsDir: shared},
+		{Profile: "partner", LogsDir: shared},
+		{Profile: "kids", LogsDir: separate, KidMode: true},
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days, err := householdDay(members, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]int{defaultProfileName: 1, "partner": 2, "kids": 1}
+	if len(days) != len(want) {
+		t.Fatalf("expected %d members, got %d", len(want), len(days))
+	}
+	for _, day := range days {
+		if day.Totals.Done != want[day.Member.name()] {
+			t.Errorf("%s: expected %d done, got %d", day.Member.name(), want[day.Member.name()], day.Totals.Done)
+		}
+	}
+}
+
+func TestHouseholdMembers(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `profiles:
+  kids:
+    kid_mode: true
+    logs_dir: ~/.movodoro/kids-logs
+  experiment:
+    exploration_rate: 0.3
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := householdMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 3 || members[0].Profile != "" || members[1].Profile != "experiment" || members[2].Profile != "kids" {
+		t.Fatalf("expected default, experiment, kids; got %+v", members)
+	}
+	defaultLogs := filepath.Join(configDir, "logs")
+	if members[0].LogsDir != defaultLogs || members[1].LogsDir != defaultLogs {
+		t.Errorf("expected shared logs dir %s, got %+v", defaultLogs, members)
+	}
+	if members[2].LogsDir != filepath.Join(configDir, "kids-logs") || !members[2].KidMode {
+		t.Errorf("expected kids to have their own logs dir and kid mode, got %+v", members[2])
+	}
+}
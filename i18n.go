@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// User-facing strings are written in English in the code and looked up in the active
+// locale's catalog, gettext style: tr("Choice: "). A string missing from a catalog
+// falls back to English, so partial translations are safe.
+
+const defaultLocale = "en"
+
+// catalogs holds the translations for each supported locale; English needs none
+var catalogs = map[string]map[string]string{
+	defaultLocale: nil,
+	"es":          catalogES,
+}
+
+// activeCatalog is the catalog tr looks strings up in (nil for English)
+var activeCatalog map[string]string
+
+// parseLocale validates a language setting such as "es", "es_ES.UTF-8" or "es-MX"
+// ("" means English)
+func parseLocale(s string) (string, error) {
+	lang := strings.ToLower(strings.TrimSpace(s))
+	if lang == "" || lang == "c" || lang == "posix" {
+		return defaultLocale, nil
+	}
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	if _, ok := catalogs[lang]; !ok {
+		return "", fmt.Errorf("unsupported language %q (use: %s)", s, strings.Join(supportedLocales(), ", "))
+	}
+	return lang, nil
+}
+
+// supportedLocales lists the locale codes, sorted
+func supportedLocales() []string {
+	var locales []string
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// setLocale switches output to locale (one parseLocale returned)
+func setLocale(locale string) {
+	activeCatalog = catalogs[locale]
+}
+
+// tr translates msg into the active locale
+func tr(msg string) string {
+	if translated, ok := activeCatalog[msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// trf translates format and fills it in
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// usageLine splits an indented usage line into what stays as written (the indent and
+// any command, option or example) and the description or comment after it
+var usageLine = regexp.MustCompile(`^(\s+(?:\S+(?: \S+)*(?:\s+# |\s{2,}))?)(.*)$`)
+
+// localizeUsage translates the usage text line by line: headings whole, and the
+// descriptions and comments beside commands, options and examples
+func localizeUsage(usage string) string {
+	if activeCatalog == nil {
+		return usage
+	}
+	lines := strings.Split(usage, "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			lines[i] = tr(line)
+			continue
+		}
+		m := usageLine.FindStringSubmatch(line)
+		lines[i] = m[1] + tr(m[2])
+	}
+	return strings.Join(lines, "\n")
+}
+
+var dateWord = regexp.MustCompile(`[A-Z][a-z]+`)
+
+// localizeDate translates the English weekday and month names in a formatted date
+func localizeDate(s string) string {
+	if activeCatalog == nil {
+		return s
+	}
+	return dateWord.ReplaceAllStringFunc(s, tr)
+}
@@ -0,0 +1,701 @@
+package main
+
+// catalogES is the Spanish translation of the CLI output
+var catalogES = map[string]string{
+	// Interactive mode
+	"🎯 Using subset: %s\n\n":                                "🎯 Usando el subconjunto: %s\n\n",
+	"📋 Queued %d everyday movo(s), lowest RPE first\n\n":    "📋 %d movo(s) diarios en cola, de menor a mayor RPE\n\n",
+	"🔄 Movo library changed; reloaded %d movos\n\n":         "🔄 La biblioteca de movos cambió; %d movos recargados\n\n",
+	"📥 Resuming saved snack...":                             "📥 Retomando el movo guardado...",
+	"📋 Everyday queue: %d left\n":                           "📋 Cola diaria: quedan %d\n",
+	"🔁 Every matching movo has been offered; starting over": "🔁 Ya se ofrecieron todos los movos posibles; empezando de nuevo",
+	"\n🔄 Swapping in %s\n":                                  "\n🔄 Cambiando a %s\n",
+	"\n⏭️  Skipping dailies for now...\n":                   "\n⏭️  Saltando los diarios por ahora...\n",
+	"\n👋 Saved for later. Run 'movodoro' to resume.":        "\n👋 Guardado para después. Ejecuta 'movodoro' para retomarlo.",
+	"Invalid choice, please try again.":                     "Opción no válida, inténtalo de nuevo.",
+	"What would you like to do?":                            "¿Qué quieres hacer?",
+	"What do you want to do?":                               "¿Qué quieres hacer?",
+	"Start timer":                                           "Iniciar temporizador",
+	"Info (equipment, targets, history)":                    "Info (equipo, zonas, historial)",
+	"Done (log completion)":                                 "Hecho (registrar)",
+	"Partial (stopped early)":                               "Parcial (paré antes)",
+	"Skip (try another movo)":                               "Saltar (probar otro movo)",
+	"Alternative (swap in a listed variation)":              "Alternativa (cambiar a una variante)",
+	"Skip dailies (ignore min_per_day > 0 movos)":           "Saltar diarios (ignorar movos con min_per_day > 0)",
+	"Back (return to the previous movo)":                    "Volver (al movo anterior)",
+	"\n↩️  Back to %s\n":                                    "\n↩️  De vuelta a %s\n",
+	"Quit (save for later)":                                 "Salir (guardar para después)",
+	"(Press 'h' for help: movodoro --help)":                 "(Pulsa 'h' para ver la ayuda: movodoro --help)",
+	"Invalid choice. Choice: ":                              "Opción no válida. Opción: ",
+	"Choice: ":                                              "Opción: ",
+	"I did it! 🎉":                                           "¡Lo hice! 🎉",
+	"Something else 🔀":                                      "Otra cosa 🔀",
+	"Later 👋":                                               "Más tarde 👋",
+
+	// Full-screen interactive mode
+	"📋 Every day (%d/%d)":            "📋 Cada día (%d/%d)",
+	"⏳ %s remaining":                 "⏳ quedan %s",
+	"🔔 Time's up! %s":                "🔔 ¡Se acabó el tiempo! %s",
+	"⏹️  Stopped after %s":           "⏹️  Parado tras %s",
+	"⏭️  Skip '%s'?":                 "⏭️  ¿Saltar '%s'?",
+	"timer":                          "temporizador",
+	"info":                           "info",
+	"card":                           "ficha",
+	"done":                           "hecho",
+	"partial":                        "parcial",
+	"skip":                           "saltar",
+	"alternative":                    "alternativa",
+	"skip dailies":                   "saltar diarios",
+	"back":                           "volver",
+	"scroll":                         "desplazar",
+	"quit":                           "salir",
+	"stop":                           "parar",
+	"cancel":                         "cancelar",
+	"✅ Log completion":               "✅ Registrar como hecho",
+	"◐ Log partial completion":       "◐ Registrar como parcial",
+	"Sets×reps":                      "Series×reps",
+	"Load (kg)":                      "Carga (kg)",
+	"Distance":                       "Distancia",
+	"e.g. 2.5km, 800m or 4000 steps": "p. ej. 2.5km, 800m o 4000 steps",
+	"Rating":                         "Valoración",
+	"enter next/save · tab move · esc cancel": "enter siguiente/guardar · tab mover · esc cancelar",
+
+	// Daily entry cap
+	"⚠️  Already %d entries %s (max_entries_per_day: %d). Log anyway? [y/N]: ": "⚠️  Ya hay %d entradas %s (max_entries_per_day: %d). ¿Registrar de todos modos? [y/N]: ",
+	"on %s":                               "el %s",
+	"not logged: daily entry cap reached": "no registrado: se alcanzó el máximo de entradas del día",
+
+	// Movo card
+	"⏱️  Duration: %d-%d minutes\n":   "⏱️  Duración: %d-%d minutos\n",
+	"📋 Sets: %s\n":                    "📋 Series: %s\n",
+	"📂 Category: %s\n":                "📂 Categoría: %s\n",
+	"🏷️  Code: %s\n":                  "🏷️  Código: %s\n",
+	"🔖 Tags: %s\n":                    "🔖 Etiquetas: %s\n",
+	"⭐ Rating: %.1f/5 (%d ratings)\n": "⭐ Valoración: %.1f/5 (%d valoraciones)\n",
+	"🔁 Today: %d of %d done\n":        "🔁 Hoy: %d de %d hechos\n",
+	"🔁 Today: %d done\n":              "🔁 Hoy: %d hechos\n",
+	"📅 Last done: never":              "📅 Última vez: nunca",
+	"📅 Last done: %s (%s)\n":          "📅 Última vez: %s (%s)\n",
+	"🔋 RPE budget: %s\n":              "🔋 Presupuesto de RPE: %s\n",
+	"today":                           "hoy",
+	"yesterday":                       "ayer",
+	"%d days ago":                     "hace %d días",
+	"When done, run:":                 "Cuando termines, ejecuta:",
+	"Or skip with:":                   "O sáltalo con:",
+
+	// Movo details ([i])
+	"ℹ️  %s [%s]\n":                       "ℹ️  %s [%s]\n",
+	"none":                                "ninguno",
+	"after %s":                            "desde las %s",
+	"before %s":                           "antes de las %s",
+	"   Equipment:    %s\n":               "   Equipo:       %s\n",
+	"   Targets:      %s\n":               "   Zonas:        %s\n",
+	"   Avoid with:   %s\n":               "   Evitar con:   %s\n",
+	"   Time window:  %s\n":               "   Horario:      %s\n",
+	"   Alternatives: %s\n":               "   Variantes:    %s\n",
+	"   Pairs with:   %s\n":               "   Combina con:  %s\n",
+	"   History:      unavailable (%v)\n": "   Historial:    no disponible (%v)\n",
+	"   Last done:    never":              "   Última vez:   nunca",
+	"   Last done:    %s (%s)\n":          "   Última vez:   %s (%s)\n",
+	"   First done:   %s\n":               "   Primera vez:  %s\n",
+	"   Completed:    %d times (%d partial, %d skipped)\n": "   Hecho:        %d veces (%d parciales, %d saltados)\n",
+
+	// Logging
+	"How many minutes did you spend? (default: %d): ":                  "¿Cuántos minutos le dedicaste? (por defecto: %d): ",
+	"Invalid duration, using default: %d\n":                            "Duración no válida, se usa la de por defecto: %d\n",
+	"How hard was it? RPE (default: %d): ":                             "¿Qué tan duro fue? RPE (por defecto: %d): ",
+	"Invalid RPE, using default: %d\n":                                 "RPE no válido, se usa el de por defecto: %d\n",
+	"Distance? (e.g. 2.5km, 800m or 4000 steps; Enter to leave out): ": "¿Distancia? (p. ej. 2.5km, 800m o 4000 steps; Intro para omitir): ",
+	"Load in kg? (default: %s, - to leave out): ":                      "¿Carga en kg? (por defecto: %s, - para omitir): ",
+	"Load in kg? (Enter to leave out): ":                               "¿Carga en kg? (Intro para omitir): ",
+	"Sets×reps done? (default: %d×%d, - to leave out): ":               "¿Series×repeticiones hechas? (por defecto: %d×%d, - para omitir): ",
+	"Sets×reps done, e.g. 3x10 (%s; Enter to leave out): ":             "Series×repeticiones hechas, p. ej. 3x10 (%s; Intro para omitir): ",
+	"%v, not saved\n":                                             "%v, no se guardó\n",
+	"Enjoyment rating 1-5 (Enter to skip): ":                      "¿Cuánto lo disfrutaste? 1-5 (Intro para omitir): ",
+	"Invalid rating, not saved\n":                                 "Valoración no válida, no se guardó\n",
+	"✅ Marked '%s' as completed (%d minutes, RPE %d)\n":           "✅ '%s' registrado como hecho (%d minutos, RPE %d)\n",
+	"◐ Marked '%s' as partially completed (%d minutes, RPE %d)\n": "◐ '%s' registrado como parcial (%d minutos, RPE %d)\n",
+	"%s %s (%d minutes, RPE %d)\n":                                "%s %s (%d minutos, RPE %d)\n",
+	"⏭️  Skipped '%s'\n":                                          "⏭️  Saltado '%s'\n",
+	"📊 Today: %d movos, %d minutes, %d RPE\n":                     "📊 Hoy: %d movos, %d minutos, %d RPE\n",
+	"⭐ Stickers today: %d\n":                                      "⭐ Pegatinas de hoy: %d\n",
+	"📊 %s: %d movos, %d minutes, %d RPE\n":                        "📊 %s: %d movos, %d minutos, %d RPE\n",
+	"⭐ Stickers on %s: %d\n":                                      "⭐ Pegatinas del %s: %d\n",
+
+	// Day report
+	"TODAY'S MOVODORO REPORT":                         "INFORME MOVODORO DE HOY",
+	"🛌 Rest day":                                      "🛌 Día de descanso",
+	"Active subset(s):":                               "Subconjunto(s) activo(s):",
+	"📊 Summary:":                                      "📊 Resumen:",
+	"   Total movos:     %d\n":                        "   Movos:           %d\n",
+	"   Total duration:  %d minutes\n":                "   Duración total:  %d minutos\n",
+	"   Total RPE:       %d / %d\n":                   "   RPE total:       %d / %d\n",
+	"   RPE budget:      %s\n":                        "   Presupuesto RPE: %s\n",
+	"✅ Completed:":                                    "✅ Hechos:",
+	"  ▸ %s: %d movos, %dm, RPE %d\n":                 "  ▸ %s: %d movos, %dm, RPE %d\n",
+	"◐ Partial:":                                      "◐ Parciales:",
+	"⏭️  Skipped:":                                    "⏭️  Saltados:",
+	"🔋 Auto-recovery mode active (RPE limit reached)": "🔋 Modo de recuperación activo (límite de RPE alcanzado)",
+
+	// Kid mode
+	"🎉 Woohoo! You did %s!":         "🎉 ¡Yupi! ¡Hiciste %s!",
+	"🌟 Superstar! %s - done!":       "🌟 ¡Superestrella! %s: ¡hecho!",
+	"🚀 Blast off! You finished %s!": "🚀 ¡Despegue! ¡Terminaste %s!",
+	"🦖 Roar! %s is all done!":       "🦖 ¡Grrr! ¡%s está hecho!",
+	"🏆 Champion move! %s complete!": "🏆 ¡Movimiento de campeón! ¡%s completado!",
+	"🐯 So strong! You did %s!":      "🐯 ¡Qué fuerza! ¡Hiciste %s!",
+	"\n  Stickers this week: %d\n":  "\n  Pegatinas esta semana: %d\n",
+
+	// Week, month and other reports
+	"WEEKLY MOVODORO REPORT":                   "INFORME SEMANAL DE MOVODORO",
+	"MONTHLY MOVODORO REPORT":                  "INFORME MENSUAL DE MOVODORO",
+	"MOVODORO REPORT":                          "INFORME DE MOVODORO",
+	"MOVODORO REPORT BY TAG":                   "INFORME DE MOVODORO POR ETIQUETA",
+	"HOUSEHOLD MOVODORO REPORT":                "INFORME DE MOVODORO DEL HOGAR",
+	"MOVODORO TREND":                           "TENDENCIA DE MOVODORO",
+	"Week of %s":                               "Semana del %s",
+	"Week %d (%s-%s)":                          "Semana %d (%s-%s)",
+	"%s to %s":                                 "%s a %s",
+	"🛌 rest day":                               "🛌 día de descanso",
+	"  %-10s %3d movos %5dm  RPE %3d":          "  %-10s %3d movos %5dm  RPE %3d",
+	"  (%d skipped)":                           "  (%d saltados)",
+	" (%d skipped)":                            " (%d saltados)",
+	"%d steps":                                 "%d pasos",
+	"   Total distance:  %s\n":                 "   Distancia total: %s\n",
+	"   Total RPE:       %d\n":                 "   RPE total:       %d\n",
+	"   Skipped:         %d\n":                 "   Saltados:        %d\n",
+	"📅 By week:":                               "📅 Por semana:",
+	"   %-22s %3d movos %5dm  RPE %4d":         "   %-22s %3d movos %5dm  RPE %4d",
+	"🗂️  By category:":                         "🗂️  Por categoría:",
+	"   %-10s %3d movos %5dm  RPE %4d\n":       "   %-10s %3d movos %5dm  RPE %4d\n",
+	"🏆 Most frequent:":                         "🏆 Los más frecuentes:",
+	"🎯 Targets:":                               "🎯 Zonas:",
+	"   %-14s ⚠️  not worked\n":                "   %-14s ⚠️  sin trabajar\n",
+	"   %-14s %3d movos %5dm\n":                "   %-14s %3d movos %5dm\n",
+	"No tagged movos done in this period":      "No se hicieron movos con etiquetas en este periodo",
+	"🔖 %-14s %3d movos %5dm  RPE %d\n":         "🔖 %-14s %3d movos %5dm  RPE %d\n",
+	"⏱️  Minutes  %s  avg %.0f/day, peak %d\n": "⏱️  Minutos  %s  media %.0f/día, máximo %d\n",
+	"💪 RPE      %s  avg %.0f/day, peak %d\n":   "💪 RPE      %s  media %.0f/día, máximo %d\n",
+	"Today":                            "Hoy",
+	"Yesterday":                        "Ayer",
+	"This week":                        "Esta semana",
+	"Last week":                        "La semana pasada",
+	"This month":                       "Este mes",
+	"Last month":                       "El mes pasado",
+	"  %s VS %s\n":                     "  %s VS %s\n",
+	"  %s to %s vs %s to %s\n":         "  %s a %s vs %s a %s\n",
+	"This":                             "Ahora",
+	"Last":                             "Antes",
+	"Change":                           "Cambio",
+	"Minutes":                          "Minutos",
+	"Skipped":                          "Saltados",
+	"📂 Category mix (minutes):":        "📂 Reparto por categoría (minutos):",
+	"⚠️  %s up more than %d%% on %s\n": "⚠️  %s suben más de un %d%% respecto a %s\n",
+	" and ":                            " y ",
+	"▲ %d (new)":                       "▲ %d (nuevo)",
+	"  SKIPPED IN THE LAST %d DAYS\n":  "  SALTADOS EN LOS ÚLTIMOS %d DÍAS\n",
+	"Nothing skipped. 🎉":               "No se saltó nada. 🎉",
+	"Code":                             "Código",
+	"Skips":                            "Saltos",
+	"Last skip":                        "Último",
+	"Weight":                           "Peso",
+	"Weight is how much of its usual weight each movo keeps; it recovers as skips age.": "El peso es cuánto conserva cada movo de su peso habitual; se recupera a medida que los saltos envejecen.",
+	"👪 Together: %d movos, %d minutes\n\n":                                              "👪 En total: %d movos, %d minutos\n\n",
+	"  %-12s %d movos, %dm":                                                             "  %-12s %d movos, %dm",
+	"  %-12s %d movos, %dm, RPE %d":                                                     "  %-12s %d movos, %dm, RPE %d",
+	"🌟 STICKER CHART - week of %s\n\n":                                                  "🌟 TABLA DE PEGATINAS - semana del %s\n\n",
+
+	// Stats
+	"Completed:  %d times\n": "Hecho:      %d veces\n",
+	"No loads logged yet (done asks for one, or use done --load KG)": "Aún no hay cargas registradas (done la pregunta, o usa done --load KG)",
+	"Latest:     %s (%s)\n": "Última:     %s (%s)\n",
+	"Heaviest:   %s (%s)\n": "Máxima:     %s (%s)\n",
+	"🏋️  Load history:":     "🏋️  Historial de cargas:",
+
+	// Errors and warnings
+	"Error: %v\n":                                     "Error: %v\n",
+	"Warning: %v\n":                                   "Aviso: %v\n",
+	"Sorry, %v\n":                                     "Lo siento, %v\n",
+	"line %d: %v\n":                                   "línea %d: %v\n",
+	"Unknown command: %s\n\n":                         "Comando desconocido: %s\n\n",
+	"Error loading snacks: %v\n":                      "Error al cargar los movos: %v\n",
+	"Error loading history: %v\n":                     "Error al cargar el historial: %v\n",
+	"Error loading stats: %v\n":                       "Error al cargar las estadísticas: %v\n",
+	"Error loading config: %v\n":                      "Error al cargar la configuración: %v\n",
+	"Error loading subset: %v\n":                      "Error al cargar el subconjunto: %v\n",
+	"Error loading subsets: %v\n":                     "Error al cargar los subconjuntos: %v\n",
+	"Error loading ratings: %v\n":                     "Error al cargar las valoraciones: %v\n",
+	"Error selecting snack: %v\n":                     "Error al elegir un movo: %v\n",
+	"Error planning session: %v\n":                    "Error al planificar la sesión: %v\n",
+	"Error applying subset filter: %v\n":              "Error al aplicar el subconjunto: %v\n",
+	"Error creating sandbox: %v\n":                    "Error al crear el entorno de prueba: %v\n",
+	"Error saving to history: %v\n":                   "Error al guardar en el historial: %v\n",
+	"Error saving to history: %v (logged %d of %d)\n": "Error al guardar en el historial: %v (registrados %d de %d)\n",
+	"Error saving snooze: %v\n":                       "Error al guardar el aplazamiento: %v\n",
+	"Error saving bans: %v\n":                         "Error al guardar los vetos: %v\n",
+	"Error saving check-in: %v\n":                     "Error al guardar el registro de energía: %v\n",
+	"Error saving rest day: %v\n":                     "Error al guardar el día de descanso: %v\n",
+	"Error saving rating: %v\n":                       "Error al guardar la valoración: %v\n",
+	"Error fetching pack: %v\n":                       "Error al descargar el paquete: %v\n",
+	"Error copying report: %v\n":                      "Error al copiar el informe: %v\n",
+	"Error posting report: %v\n":                      "Error al publicar el informe: %v\n",
+	"Error clearing today's log: %v\n":                "Error al borrar el registro de hoy: %v\n",
+	"Error reading today's history: %v\n":             "Error al leer el historial de hoy: %v\n",
+	"Error finding log files: %v\n":                   "Error al buscar los registros: %v\n",
+	"Error finding YAML files: %v\n":                  "Error al buscar los archivos YAML: %v\n",
+	"Error reading %s: %v\n":                          "Error al leer %s: %v\n",
+	"Error editing %s: %v\n":                          "Error al editar %s: %v\n",
+	"Error writing %s: %v\n":                          "Error al escribir %s: %v\n",
+	"Error syncing logs: %v\n":                        "Error al sincronizar los registros: %v\n",
+	"Error opening batch file: %v\n":                  "Error al abrir el archivo de lotes: %v\n",
+	"Error archiving logs: %v\n":                      "Error al archivar los registros: %v\n",
+	"Error encoding JSON: %v\n":                       "Error al generar el JSON: %v\n",
+	"Error: %v (uploaded %d of %d)\n":                 "Error: %v (subidos %d de %d)\n",
+	"Error: snack code '%s' not found\n":              "Error: no se encontró el código de movo '%s'\n",
+	"Error: '%s' is not banned\n":                     "Error: '%s' no está vetado\n",
+	"Error: no codes on stdin":                        "Error: no hay códigos en la entrada estándar",
+	"Error: no current snack. Use 'movodoro get' first or specify a code.\n":                         "Error: no hay movo actual. Usa 'movodoro get' primero o indica un código.\n",
+	"Error: no current snack. Use 'movodoro get' first.\n":                                           "Error: no hay movo actual. Usa 'movodoro get' primero.\n",
+	"Error: --pair can't be combined with --timer":                                                   "Error: --pair no se puede combinar con --timer",
+	"Error: --quiet can't be combined with --timer":                                                  "Error: --quiet no se puede combinar con --timer",
+	"Error: -n must be at least 1":                                                                   "Error: -n debe ser al menos 1",
+	"Error: --pick must be between 1 and -n":                                                         "Error: --pick debe estar entre 1 y -n",
+	"Error: -n can't be combined with --pair":                                                        "Error: -n no se puede combinar con --pair",
+	"Error: only %d movo(s) match, can't pick %d\n":                                                  "Error: solo coinciden %d movo(s), no se puede elegir el %d\n",
+	"Error: --minutes must be positive":                                                              "Error: --minutes debe ser positivo",
+	"Error: --days must be positive":                                                                 "Error: --days debe ser positivo",
+	"Error: --iterations must not be negative\n":                                                     "Error: --iterations no puede ser negativo\n",
+	"Error: --every must be at least %s\n":                                                           "Error: --every debe ser al menos %s\n",
+	"Error: invalid --seed %q\n":                                                                     "Error: --seed no válido %q\n",
+	"Error: invalid date %q (use YYYY-MM-DD)\n":                                                      "Error: fecha no válida %q (usa AAAA-MM-DD)\n",
+	"Error: invalid --since date %q (use YYYY-MM-DD)\n":                                              "Error: fecha de --since no válida %q (usa AAAA-MM-DD)\n",
+	"Error: --before can't be in the future (today's log stays a daily log)":                         "Error: --before no puede ser una fecha futura (el registro de hoy sigue siendo diario)",
+	"Error: %d hasn't started yet\n":                                                                 "Error: %d aún no ha empezado\n",
+	"Error: rating must be a number from %d to %d\n":                                                 "Error: la valoración debe ser un número del %d al %d\n",
+	"Error: no summary directory. Set eod_dir in %s or pass --dir.\n":                                "Error: no hay directorio para el resumen. Define eod_dir en %s o pasa --dir.\n",
+	"Error: no FIELD=VALUE assignments given\n":                                                      "Error: no se indicó ninguna asignación CAMPO=VALOR\n",
+	"Error: at least one --filter is required (use --filter tag=... to edit a subset)\n":             "Error: hace falta al menos un --filter (usa --filter tag=... para editar una parte)\n",
+	"Error: 'report compare' takes only --period, --against and --json":                              "Error: 'report compare' solo admite --period, --against y --json",
+	"Error: the trend report is a terminal chart (use --json for its data)":                          "Error: el informe de tendencia es un gráfico de terminal (usa --json para sus datos)",
+	"Error: 'report skips' always covers the last %d days and takes only --json\n":                   "Error: 'report skips' siempre cubre los últimos %d días y solo admite --json\n",
+	"Error: --by-tag can't be combined with --all-profiles, --copy or --post":                        "Error: --by-tag no se puede combinar con --all-profiles, --copy ni --post",
+	"Error: --from/--to work with the day, week, month and profiles reports":                         "Error: --from/--to funcionan con los informes day, week, month y profiles",
+	"Error: JSON output isn't available for markdown, --copy, --post or sticker reports":             "Error: la salida JSON no está disponible para markdown, --copy, --post ni el informe de pegatinas",
+	"Error: --post only supports the day report":                                                     "Error: --post solo admite el informe del día",
+	"Error: --all-profiles only supports the plain day report":                                       "Error: --all-profiles solo admite el informe del día en texto",
+	"Error: unknown --against %q (use: previous)\n":                                                  "Error: --against desconocido %q (usa: previous)\n",
+	"Error: unknown --by %q (use: %s)\n":                                                             "Error: --by desconocido %q (usa: %s)\n",
+	"Unknown report period: %s (use: day, week, month, trend, compare, skips, profiles, stickers)\n": "Periodo de informe desconocido: %s (usa: day, week, month, trend, compare, skips, profiles, stickers)\n",
+	"Unknown report period for a date range: %s (use: day, week, month, trend, profiles)\n":          "Periodo de informe desconocido para un rango de fechas: %s (usa: day, week, month, trend, profiles)\n",
+	"Unknown program command: %s (use: status)\n":                                                    "Comando de programa desconocido: %s (usa: status)\n",
+	"No log file named %s in %s\n":                                                                   "No hay ningún registro llamado %s en %s\n",
+	"%d batch command(s) failed\n":                                                                   "Fallaron %d comando(s) del lote\n",
+	"Warning: could not save current snack: %v\n":                                                    "Aviso: no se pudo guardar el movo actual: %v\n",
+	"Warning: movo library not reloaded: %v\n":                                                       "Aviso: no se recargó la biblioteca de movos: %v\n",
+	"Warning: could not save everyday queue: %v\n":                                                   "Aviso: no se pudo guardar la cola diaria: %v\n",
+	"Warning: could not remove the skip: %v\n":                                                       "Aviso: no se pudo quitar el salto: %v\n",
+	"Warning: error selecting snack: %v\n":                                                           "Aviso: error al elegir un movo: %v\n",
+	"Warning: %s hook failed: %v\n":                                                                  "Aviso: falló el hook %s: %v\n",
+	"Warning: uploaded %s but couldn't record it, so it may upload again: %v\n":                      "Aviso: se subió %s pero no se pudo anotar, así que puede volver a subirse: %v\n",
+	"Warning: listening beyond this machine without --token; anyone on the network can log movos":    "Aviso: escuchando más allá de este equipo sin --token; cualquiera en la red puede registrar movos",
+	"Warning: duplicate movo codes, keeping the first definition:\n%s\n":                             "Aviso: códigos de movo duplicados, se conserva la primera definición:\n%s\n",
+	"📥 Logs unavailable (%v); queued locally, %d pending\n":                                          "📥 Registros no disponibles (%v); guardado en local, %d pendientes\n",
+	"📤 Delivered %d queued entries to %s\n":                                                          "📤 Entregadas %d entradas pendientes a %s\n",
+	"⏳ %d entries queued locally until the logs dir is reachable (%v)\n":                             "⏳ %d entradas guardadas en local hasta que se pueda acceder a los registros (%v)\n",
+
+	// Command usage errors
+	"Usage: movodoro search QUERY":                                                                                       "Uso: movodoro search CONSULTA",
+	`Usage: movodoro say "did box breathing five minutes easy"`:                                                          `Uso: movodoro say "did box breathing five minutes easy"`,
+	"Usage: movodoro qr CODE [--url http://HOST:PORT] [--token SECRET] [--png FILE] [--command] [--invert]":              "Uso: movodoro qr CÓDIGO [--url http://HOST:PUERTO] [--token SECRETO] [--png ARCHIVO] [--command] [--invert]",
+	"Note: without --token, serve only logs the link with a POST (a phone shortcut); opening it in a browser is refused": "Nota: sin --token, serve solo registra el enlace con un POST (un atajo del teléfono); abrirlo en un navegador se rechaza",
+	"Usage: movodoro snooze [DURATION]   (default 30m)":                                                                  "Uso: movodoro snooze [DURACIÓN]   (por defecto 30m)",
+	"Usage: movodoro queue add CODE...":                                                                                  "Uso: movodoro queue add CÓDIGO...",
+	"Usage: movodoro queue add CODE... | list | next | clear":                                                            "Uso: movodoro queue add CÓDIGO... | list | next | clear",
+	"Usage: movodoro fav %s CODE...\n":                                                                                   "Uso: movodoro fav %s CÓDIGO...\n",
+	"Usage: movodoro fav add CODE... | remove CODE... | list":                                                            "Uso: movodoro fav add CÓDIGO... | remove CÓDIGO... | list",
+	"Usage: movodoro stats CODE":                                                                                         "Uso: movodoro stats CÓDIGO",
+	"Usage: movodoro ban CODE [--until YYYY-MM-DD] | ban list":                                                           "Uso: movodoro ban CÓDIGO [--until AAAA-MM-DD] | ban list",
+	"Usage: movodoro unban CODE...":                                                                                      "Uso: movodoro unban CÓDIGO...",
+	"Usage: movodoro packs [list] | packs available | packs install NAME|URL|FILE | packs remove NAME":                   "Uso: movodoro packs [list] | packs available | packs install NOMBRE|URL|ARCHIVO | packs remove NOMBRE",
+	"Usage: movodoro checkin [--energy 1-5]":                                                                             "Uso: movodoro checkin [--energy 1-5]",
+	"Usage: movodoro rest [--cancel] [today|tomorrow|YYYY-MM-DD]":                                                        "Uso: movodoro rest [--cancel] [today|tomorrow|AAAA-MM-DD]",
+	"Usage: movodoro lint":                                                                                               "Uso: movodoro lint",
+	"Usage: movodoro sync [--conflict append|last-write-wins] | movodoro sync strava [options]":                          "Uso: movodoro sync [--conflict append|last-write-wins] | movodoro sync strava [opciones]",
+	"Usage: movodoro rate CODE RATING (1-5)\n":                                                                           "Uso: movodoro rate CÓDIGO VALORACIÓN (1-5)\n",
+	"Usage: movodoro batch - | FILE\n":                                                                                   "Uso: movodoro batch - | ARCHIVO\n",
+	"Usage: movodoro movos set-field --filter tag=kbx [--dry-run] FIELD=VALUE...\n":                                      "Uso: movodoro movos set-field --filter tag=kbx [--dry-run] CAMPO=VALOR...\n",
+	"Usage: movodoro archive --before YYYY-MM-DD [--dry-run]":                                                            "Uso: movodoro archive --before AAAA-MM-DD [--dry-run]",
+
+	// Usage headings
+	"movodoro - Movement snack generator": "movodoro - Generador de pausas activas",
+	"USAGE:":                              "USO:",
+	"COMMANDS:":                           "COMANDOS:",
+	"GLOBAL OPTIONS:":                     "OPCIONES GLOBALES:",
+	"INTERACTIVE MODE OPTIONS:":           "OPCIONES DEL MODO INTERACTIVO:",
+	"REPORT OPTIONS:":                     "OPCIONES DE INFORMES:",
+	"BATCH COMMANDS (one per line, # for comments):": "COMANDOS POR LOTES (uno por línea, # para comentarios):",
+	"MOVOS OPTIONS:": "OPCIONES DE MOVOS:",
+	"EOD OPTIONS:":   "OPCIONES DE EOD:",
+	"GET OPTIONS:":   "OPCIONES DE GET:",
+	"SUBSETS:":       "SUBCONJUNTOS:",
+	"EXAMPLES:":      "EJEMPLOS:",
+
+	// Usage descriptions
+	"Interactive mode (default)":                                                "Modo interactivo (por defecto)",
+	"movodoro <command> [options]":                                              "movodoro <comando> [opciones]",
+	"Get a random movement snack":                                               "Obtener una pausa activa al azar",
+	"(--timer counts down its duration, then logs it;":                          "(--timer cuenta atrás su duración y luego lo registra;",
+	"--explain shows the candidates and their weights instead;":                 "--explain muestra en su lugar los candidatos y sus pesos;",
+	"--pair adds a complementary movo and queues it;":                           "--pair añade un movo complementario y lo pone en cola;",
+	"-n 3 offers three to choose from, --pick K takes one)":                     "-n 3 ofrece tres para elegir, --pick K se queda con uno)",
+	"Same as get --explain":                                                     "Igual que get --explain",
+	"List every movo matching the get filters":                                  "Listar todos los movos que cumplen los filtros de get",
+	"Plan movos for --minutes (default 30), then do them one by one":            "Planificar movos para --minutes (por defecto 30) y hacerlos uno a uno",
+	"Find movos by title, tags, code or description":                            "Buscar movos por título, etiquetas, código o descripción",
+	"Mark the current/specified snack as completed":                             "Marcar como hecho el movo actual o indicado",
+	"(--partial logs a partial completion; several codes, --batch":              "(--partial lo registra como parcial; varios códigos, --batch",
+	"reading codes from stdin, or -d/-r log without prompts;":                   "leyendo códigos de la entrada estándar, o -d/-r registran sin preguntas;",
+	"--sets 3x10 records sets×reps, --load 24 the kg used,":                     "--sets 3x10 anota series×repeticiones, --load 24 los kg usados,",
+	"--distance 2.5km|800m|4000steps the distance covered;":                     "--distance 2.5km|800m|4000steps la distancia recorrida;",
+	"--date yesterday|YYYY-MM-DD and --time HH:MM backdate it)":                 "--date yesterday|AAAA-MM-DD y --time HH:MM le ponen otra fecha)",
+	"Skip the current/specified snack":                                          "Saltar el movo actual o indicado",
+	"Put off the current snack without logging it (default 30m)":                "Aplazar el movo actual sin registrarlo (por defecto 30m)",
+	"Line up movos for later today; get takes them first":                       "Poner movos en cola para más tarde; get los toma primero",
+	"(queue list, queue next, queue clear)":                                     "(queue list, queue next, queue clear)",
+	"Mark favorites locally; they're weighted favorite_boost (default 2x)":      "Marcar favoritos en local; pesan favorite_boost (por defecto 2x)",
+	"Keep a movo out of selection locally; --until YYYY-MM-DD ends it":          "Dejar un movo fuera de la selección en local; --until AAAA-MM-DD lo termina",
+	"Add a themed movo pack from the registry, a URL or a .yaml file":           "Añadir un paquete temático de movos del registro, una URL o un archivo .yaml",
+	"Show where you are in the active program (programs.yaml)":                  "Mostrar por dónde vas en el programa activo (programs.yaml)",
+	"Make today (or tomorrow, YYYY-MM-DD) a rest day; --cancel undoes it":       "Hacer de hoy (o mañana, AAAA-MM-DD) un día de descanso; --cancel lo deshace",
+	"Log your energy 1-5; for 4 hours selection leans easier or harder":         "Registrar tu energía 1-5; durante 4 horas la selección tiende a más fácil o más duro",
+	"Show how often a movo was done and the loads used over time":               "Mostrar cuántas veces se hizo un movo y las cargas usadas con el tiempo",
+	"Show report (day, week, month, trend, compare, skips, profiles, stickers)": "Mostrar un informe (day, week, month, trend, compare, skips, profiles, stickers)",
+	"(--by-tag totals movos, minutes and RPE per tag instead)":                  "(--by-tag suma en su lugar movos, minutos y RPE por etiqueta)",
+	"Calendar of the year's activity (--year YYYY, --by minutes|movos)":         "Calendario de la actividad del año (--year AAAA, --by minutes|movos)",
+	"Clear today's history (requires confirmation)":                             "Borrar el historial de hoy (pide confirmación)",
+	"Show current configuration":                                                "Mostrar la configuración actual",
+	"Show \"every day\" snacks and completion status":                           "Mostrar los movos \"diarios\" y si están hechos",
+	"Show min/max_per_week snacks and this week's progress":                     "Mostrar los movos con min/max_per_week y el progreso de la semana",
+	"List available subsets from subsets.yaml":                                  "Listar los subconjuntos de subsets.yaml",
+	"Check movo YAML files, subsets.yaml and programs.yaml for mistakes":        "Buscar errores en los YAML de movos, subsets.yaml y programs.yaml",
+	"Check movos against the style rules in lint.yaml (tags, weights, ...)":     "Comprobar los movos con las reglas de estilo de lint.yaml (etiquetas, pesos, ...)",
+	"Bulk edit movo YAML fields (see MOVOS OPTIONS)":                            "Editar en bloque campos de los YAML de movos (ver OPCIONES DE MOVOS)",
+	"Run get/done/skip commands from stdin or a file":                           "Ejecutar comandos get/done/skip desde la entrada estándar o un archivo",
+	"Rate a movo 1-5 (no args: list average ratings)":                           "Valorar un movo 1-5 (sin argumentos: listar las valoraciones medias)",
+	"Write today's markdown summary to eod_dir (for cron)":                      "Escribir el resumen markdown de hoy en eod_dir (para cron)",
+	"Send a movo suggestion notification every interval":                        "Enviar una notificación con un movo sugerido cada intervalo",
+	"(--every 50m; --interactive starts a session on Enter)":                    "(--every 50m; --interactive empieza una sesión al pulsar Intro)",
+	"Serve get/done/skip/report/everyday over HTTP as JSON":                     "Servir get/done/skip/report/everyday por HTTP como JSON",
+	"(--port 8080, --host, --token; see README)":                                "(--port 8080, --host, --token; ver README)",
+	"Commit, pull and push the logs dir with a git remote (see README)":         "Hacer commit, pull y push del directorio de registros con un remoto git (ver README)",
+	"(--conflict append|last-write-wins for daily logs changed on both)":        "(--conflict append|last-write-wins para registros diarios cambiados en ambos lados)",
+	"Upload completed movos to Strava (see README)":                             "Subir los movos hechos a Strava (ver README)",
+	"(--since YYYY-MM-DD, default a week ago; --dry-run)":                       "(--since AAAA-MM-DD, por defecto hace una semana; --dry-run)",
+	"Show the current movo and today's totals":                                  "Mostrar el movo actual y los totales de hoy",
+	"(--xbar for an xbar/SwiftBar menu bar plugin)":                             "(--xbar para un plugin de barra de menús de xbar/SwiftBar)",
+	"Log a movo from a spoken sentence (for Siri/Assistant)":                    "Registrar un movo a partir de una frase hablada (para Siri/Assistant)",
+	`e.g. say "did box breathing five minutes easy"`:                            `p. ej. say "did box breathing five minutes easy"`,
+	"Show a QR code that logs CODE when scanned":                                "Mostrar un código QR que registra CODE al escanearlo",
+	"(--png FILE saves it, --command encodes the CLI command)":                  "(--png FILE lo guarda, --command codifica el comando de la CLI)",
+	"Simulate selections to check no movo's probability collapsed":              "Simular selecciones para comprobar que ningún movo tiene una probabilidad ínfima",
+	"Check daily logs against recorded checksums (--init to enable)":            "Comprobar los registros diarios con sus sumas de verificación (--init para activarlo)",
+	"Move daily logs before --before YYYY-MM-DD into monthly files":             "Mover los registros diarios anteriores a --before AAAA-MM-DD a archivos mensuales",
+	"migrate-logs-to-csv Migrate old log files to v1.0.0 CSV format":            "migrate-logs-to-csv Migrar los registros antiguos al formato CSV v1.0.0",
+	"(--dry-run shows a diff, --only FILE migrates one file)":                   "(--dry-run muestra un diff, --only FILE migra un solo archivo)",
+	"Try movodoro in a throwaway home with demo movos and history":              "Probar movodoro en un directorio desechable con movos e historial de ejemplo",
+	"(--keep leaves it in place, --days N sets the history length)":             "(--keep lo conserva, --days N fija la longitud del historial)",
+	"Show version information":                                                  "Mostrar la versión",
+	"Show this help message":                                                    "Mostrar esta ayuda",
+	"Use a named profile from config.yaml (shares history)":                     "Usar un perfil de config.yaml (comparte el historial)",
+	"Seed selection for reproducible picks (also: seed in config.yaml)":         "Fijar la semilla para selecciones reproducibles (también: seed en config.yaml)",
+	"JSON output from get, list, search, session, queue, fav, ban list, stats,": "Salida JSON de get, list, search, session, queue, fav, ban list, stats,",
+	"status, program, packs list, report, heatmap, everyday, weekly, subsets,":  "status, program, packs list, report, heatmap, everyday, weekly, subsets,",
+	"config and analyze-weights":                                                "config y analyze-weights",
+	"Print descriptions as written, without formatting or colour":               "Mostrar las descripciones tal cual, sin formato ni color",
+	"Script-friendly output: get prints the full code, done and skip nothing":   "Salida para scripts: get muestra el código completo, done y skip nada",
+	"Use a named subset from subsets.yaml":                                      "Usar un subconjunto de subsets.yaml",
+	"Output report in markdown format":                                          "Mostrar el informe en formato markdown",
+	"Show titles and tags":                                                      "Mostrar títulos y etiquetas",
+	"Copy the markdown report to the clipboard":                                 "Copiar el informe markdown al portapapeles",
+	"Post today's report to a slack or discord webhook from config.yaml":        "Publicar el informe de hoy en un webhook de slack o discord de config.yaml",
+	"Group completed movos by category, session or hour":                        "Agrupar los movos hechos por categoría, sesión u hora",
+	"Show times in ZONE (e.g. local, Asia/Tokyo) instead of as logged":          "Mostrar las horas en ZONE (p. ej. local, Asia/Tokyo) en vez de como se registraron",
+	"Combine today's report for every config profile (household)":               "Combinar el informe de hoy de todos los perfiles (hogar)",
+	"Select a movo (becomes current)":                                           "Elegir un movo (pasa a ser el actual)",
+	"Log completion without prompts":                                            "Registrar como hecho sin preguntas",
+	"Log a skip":                                                                "Registrar un salto",
+	"Select movos by tag=, category= or code= (repeatable)":                     "Seleccionar movos por tag=, category= o code= (se puede repetir)",
+	"Print a unified diff instead of writing files":                             "Mostrar un diff unificado en vez de escribir los archivos",
+	"Directory for the summary (default: eod_dir from config)":                  "Directorio del resumen (por defecto: eod_dir de la configuración)",
+	"Send a desktop notification (default: eod_notify)":                         "Enviar una notificación de escritorio (por defecto: eod_notify)",
+	"Also mail the summary via sendmail (default: eod_email)":                   "Enviar también el resumen por sendmail (por defecto: eod_email)",
+	"Filter by category codes (e.g., RB or RB,CF)":                              "Filtrar por códigos de categoría (p. ej., RB o RB,CF)",
+	"Leave out category codes (e.g., TS)":                                       "Excluir códigos de categoría (p. ej., TS)",
+	"Filter by body regions worked (e.g., hips,t-spine)":                        "Filtrar por zonas del cuerpo trabajadas (p. ej., hips,t-spine)",
+	"Filter by tags (comma-separated; 'a|b' matches either)":                    "Filtrar por etiquetas (separadas por comas; 'a|b' acepta cualquiera)",
+	"Filter by any of these tags (comma-separated)":                             "Filtrar por cualquiera de estas etiquetas (separadas por comas)",
+	"Exact duration in minutes":                                                 "Duración exacta en minutos",
+	"Minimum duration":                                                          "Duración mínima",
+	"Maximum duration":                                                          "Duración máxima",
+	"Minimum RPE (for intense work)":                                            "RPE mínimo (para trabajo intenso)",
+	"Maximum RPE (for recovery)":                                                "RPE máximo (para recuperar)",
+	"Only movos needing no more than this (e.g., kb,band; 'any')":               "Solo movos que no necesiten más que esto (p. ej., kb,band; 'any')",
+	"Only movos that need no equipment":                                         "Solo movos que no necesitan equipo",
+	"Also select movos outside their time_window":                               "Elegir también movos fuera de su time_window",
+	"Pick from the next category in rotation":                                   "Elegir de la siguiente categoría de la rotación",
+	"Prefer movos that fill these minutes (session: pack several)":              "Preferir movos que llenen estos minutos (session: encaja varios)",
+	"low favors high-weight movos, high spreads picks evenly":                   "low favorece los movos de más peso, high reparte por igual",
+	"Subsets allow you to restrict movement selection to a specific collection": "Los subconjuntos limitan la selección de movimientos a una colección",
+	"of movos. Perfect for injury recovery, travel, or equipment constraints.":  "concreta de movos. Ideal para lesiones, viajes o falta de equipo.",
+	"Define subsets in: $MOVODORO_MOVOS_DIR/subsets.yaml":                       "Define los subconjuntos en: $MOVODORO_MOVOS_DIR/subsets.yaml",
+	"Activation:":                         "Activación:",
+	"One-time use":                        "Una sola vez",
+	"Interactive mode":                    "Modo interactivo",
+	"Persistent (env var)":                "Persistente (variable de entorno)",
+	"Interactive with subset":             "Interactivo con subconjunto",
+	"Get any snack":                       "Cualquier movo",
+	"Get from subset":                     "Un movo del subconjunto",
+	"Get from Reset & Breath category":    "Un movo de la categoría Reset & Breath",
+	"Kettlebell swings":                   "Swings con kettlebell",
+	"Very light recovery snacks":          "Movos de recuperación muy suaves",
+	"Bodyweight only (e.g. travelling)":   "Solo peso corporal (p. ej. de viaje)",
+	"Mark current snack completed":        "Marcar como hecho el movo actual",
+	"Verbose markdown report":             "Informe markdown detallado",
+	"Copy verbose markdown to clipboard":  "Copiar el markdown detallado al portapapeles",
+	"Completed movos grouped by category": "Movos hechos agrupados por categoría",
+	"List available subsets":              "Listar los subconjuntos disponibles",
+
+	// Command output
+	"\nPick 1-%d (q to quit): ":                                          "\nElige 1-%d (q para salir): ",
+	"📋 Next in your queue":                                               "📋 Siguiente en tu cola",
+	"⏱  Do it for %d minutes to fit your time\n":                         "⏱  Hazlo durante %d minutos para que encaje en tu tiempo\n",
+	"🎲 Pick one:":                                                        "🎲 Elige uno:",
+	"👋 Nothing picked":                                                   "👋 No elegiste ninguno",
+	"\nNo other movo matches to pair with it.":                           "\nNingún otro movo encaja para combinarlo.",
+	"\n🤝 Then pair it with:":                                             "\n🤝 Luego combínalo con:",
+	"📋 Queued %s to come up on your next get\n":                          "📋 %s en cola para tu próximo get\n",
+	"📋 Session plan: %d movos, %d of %d minutes, RPE %d\n\n":             "📋 Plan de la sesión: %d movos, %d de %d minutos, RPE %d\n\n",
+	"\n▶️  %d of %d\n":                                                   "\n▶️  %d de %d\n",
+	"\n👋 Session stopped: %d done, %d skipped, %d not started\n":         "\n👋 Sesión detenida: %d hechos, %d saltados, %d sin empezar\n",
+	"\n🏁 Session complete: %d done, %d skipped\n":                        "\n🏁 Sesión completa: %d hechos, %d saltados\n",
+	"No movos match these filters.":                                      "Ningún movo coincide con estos filtros.",
+	"\n%d of %d movos match\n":                                           "\n%d de %d movos coinciden\n",
+	"No movos match %q\n":                                                "Ningún movo coincide con %q\n",
+	"Current: %s (%s)\n":                                                 "Actual: %s (%s)\n",
+	"Current: none (run 'movodoro get')":                                 "Actual: ninguno (ejecuta 'movodoro get')",
+	"Today:   %d movos, %d minutes, RPE %d/%d\n":                         "Hoy:    %d movos, %d minutos, RPE %d/%d\n",
+	"✅ Logged %s, %d minutes, RPE %d\n":                                  "✅ Registrado %s, %d minutos, RPE %d\n",
+	"Saved QR code for %s to %s\n":                                       "Código QR de %s guardado en %s\n",
+	"📅 Logging for %s %s\n":                                              "📅 Registrando para %s %s\n",
+	"💤 Snoozed '%s' until %s\n":                                          "💤 '%s' pospuesto hasta %s\n",
+	"📋 Queued '%s'\n":                                                    "📋 '%s' en cola\n",
+	"%d in today's queue\n":                                              "%d en la cola de hoy\n",
+	"The queue is empty; 'get' picks at random":                          "La cola está vacía; 'get' elige al azar",
+	"The queue is empty":                                                 "La cola está vacía",
+	"📋 Today's queue:":                                                   "📋 Cola de hoy:",
+	"  %d. %s [%s] %d-%d min, RPE %d\n":                                  "  %d. %s [%s] %d-%d min, RPE %d\n",
+	"Cleared the queue":                                                  "Cola vaciada",
+	"Removed '%s' from favorites\n":                                      "'%s' quitado de favoritos\n",
+	"⭐ Favorited '%s'\n":                                                 "⭐ '%s' añadido a favoritos\n",
+	"%d favorite(s), weighted %gx\n":                                     "%d favorito(s), con peso %gx\n",
+	"No favorites yet (movodoro fav add CODE)":                           "Aún no hay favoritos (movodoro fav add CODE)",
+	"⭐ Favorites (weighted %gx):\n":                                      "⭐ Favoritos (con peso %gx):\n",
+	"  %s [%s] %d-%d min, RPE %d\n":                                      "  %s [%s] %d-%d min, RPE %d\n",
+	"  %s (no longer in the library)\n":                                  "  %s (ya no está en la biblioteca)\n",
+	"🚫 Banned '%s' until you unban it\n":                                 "🚫 '%s' vetado hasta que lo desvetes\n",
+	"🚫 Banned '%s' through %s\n":                                         "🚫 '%s' vetado hasta el %s\n",
+	"Unbanned '%s'\n":                                                    "'%s' desvetado\n",
+	"No banned movos (movodoro ban CODE)":                                "No hay movos vetados (movodoro ban CODE)",
+	"🚫 Banned:":                                                          "🚫 Vetados:",
+	"No packs installed (see 'movodoro packs available')":                "No hay paquetes instalados (ver 'movodoro packs available')",
+	"📦 Installed packs:":                                                 "📦 Paquetes instalados:",
+	"  %-20s %-5s %2d movos  %s\n":                                       "  %-20s %-5s %2d movos  %s\n",
+	"📦 Available packs:":                                                 "📦 Paquetes disponibles:",
+	"📦 Installed pack '%s': %d movos under %s (%s)\n":                    "📦 Paquete '%s' instalado: %d movos en %s (%s)\n",
+	"🗑️  Removed pack '%s'\n":                                            "🗑️  Paquete '%s' eliminado\n",
+	"No check-in in the last %d hours (movodoro checkin --energy 1-5)\n": "No hay check-in en las últimas %d horas (movodoro checkin --energy 1-5)\n",
+	"⚡ Energy %d/5, checked in at %s\n":                                  "⚡ Energía %d/5, check-in a las %s\n",
+	"⚡ Energy %d/5: leaning toward easier movos for the next %d hours\n": "⚡ Energía %d/5: tirando a movos más suaves durante las próximas %d horas\n",
+	"⚡ Energy %d/5: leaning toward harder movos for the next %d hours\n": "⚡ Energía %d/5: tirando a movos más duros durante las próximas %d horas\n",
+	"⚡ Energy %d/5: selection unchanged\n":                               "⚡ Energía %d/5: la selección no cambia\n",
+	"🛌 %s is a rest day: only RPE ≤ %d movos, and everyday minimums above RPE %d are waived\n": "🛌 %s es día de descanso: solo movos con RPE ≤ %d, y se perdonan los mínimos diarios por encima de RPE %d\n",
+	"%s is still a rest day (rest_days in config.yaml)\n":                                      "%s sigue siendo día de descanso (rest_days en config.yaml)\n",
+	"%s is no longer a rest day\n":                                                             "%s ya no es día de descanso\n",
+	"No active program (set active: in programs.yaml in your movos directory)":                 "No hay programa activo (pon active: en programs.yaml en tu directorio de movos)",
+	"  PROGRAM: %s\n": "  PROGRAMA: %s\n",
+	"  Started %s\n":  "  Empezó el %s\n",
+	"Finished all %d weeks; settings are back to config.yaml's\n": "Terminadas las %d semanas; vuelven los ajustes de config.yaml\n",
+	"Starts %s with %s\n":                             "Empieza el %s con %s\n",
+	"Block:            %s (block %d of %d)\n":         "Bloque:           %s (bloque %d de %d)\n",
+	"Program week:     %d of %d":                      "Semana:           %d de %d",
+	" (cycle %d)":                                     " (ciclo %d)",
+	"Next:             %s from %s\n":                  "Siguiente:        %s desde el %s\n",
+	"Next:             program ends after this block": "Siguiente:        el programa termina tras este bloque",
+	"Settings in effect:":                             "Ajustes en vigor:",
+	"   Max daily RPE:    %d\n":                       "   RPE diario máx.:  %d\n",
+	"   Category weights: %s\n":                       "   Pesos por categoría: %s\n",
+	"   Subset:           %s\n":                       "   Subconjunto:      %s\n",
+	"  CONFIG PROFILE COMPARISON":                     "  COMPARACIÓN DE PERFILES DE CONFIGURACIÓN",
+	"No history yet.":                                 "Aún no hay historial.",
+	"Profile":                                         "Perfil",
+	"Days":                                            "Días",
+	"Done/day":                                        "Hechos/día",
+	"Min/day":                                         "Min/día",
+	"RPE/day":                                         "RPE/día",
+	"Days count any day with an entry logged under the profile (skip_policy: excuse).": "Los días cuentan cualquier día con una entrada registrada con el perfil (skip_policy: excuse).",
+	"Days count days with a done or partial movo logged under the profile.":            "Los días cuentan los días con un movo hecho o parcial registrado con el perfil.",
+	"📋 Copied markdown report to clipboard":                                            "📋 Informe markdown copiado al portapapeles",
+	"📣 Posted today's report to %s\n":                                                  "📣 Informe de hoy publicado en %s\n",
+	"  CLEAR TODAY'S HISTORY":                                                          "  BORRAR EL HISTORIAL DE HOY",
+	"No entries for today to clear.":                                                   "No hay entradas de hoy que borrar.",
+	"This will delete today's log file with %d entries:\n":                             "Se borrará el registro de hoy con %d entradas:\n",
+	"  - %d completed (%d minutes, %d RPE)\n":                                          "  - %d hechos (%d minutos, %d RPE)\n",
+	"  - %d partial\n": "  - %d parciales\n",
+	"  - %d skipped\n": "  - %d saltados\n",
+	"Are you sure you want to clear today's history? (yes/no): ": "¿Seguro que quieres borrar el historial de hoy? (yes/no): ",
+	"Cancelled.": "Cancelado.",
+	"✅ Cleared %d entries from today's history\n":              "✅ Borradas %d entradas del historial de hoy\n",
+	"  MOVODORO CONFIGURATION":                                 "  CONFIGURACIÓN DE MOVODORO",
+	"Movos directory:  %s\n":                                   "Directorio movos: %s\n",
+	"Logs directory:   %s\n":                                   "Directorio logs:  %s\n",
+	"Current file:     %s\n":                                   "Archivo actual:   %s\n",
+	"Config profile:   %s\n":                                   "Perfil config.:   %s\n",
+	"Program:          %s (%s)\n":                              "Programa:         %s (%s)\n",
+	"Max daily RPE:    %d\n":                                   "RPE diario máx.:  %d\n",
+	"Active subset:    %s\n":                                   "Subconjunto:      %s\n",
+	"Week starts on:   %s\n":                                   "Semana empieza:   %s\n",
+	"Avoiding:         %s\n":                                   "Evitando:         %s\n",
+	"Equipment:        %s\n":                                   "Equipo:           %s\n",
+	"Streak grace:     until %02d:%02d\n":                      "Gracia de racha:  hasta las %02d:%02d\n",
+	"Everyday queue:   on\n":                                   "Cola diaria:      activada\n",
+	"Skip policy:      %s\n":                                   "Política saltos:  %s\n",
+	"Config file:      %s\n":                                   "Archivo config.:  %s\n",
+	"EOD summaries:    %s\n":                                   "Resúmenes EOD:    %s\n",
+	"EOD in watch:     at %02d:%02d\n":                         "EOD en watch:     a las %02d:%02d\n",
+	"EOD email:        %s\n":                                   "Correo EOD:       %s\n",
+	"Queued entries:   %d (waiting for the logs dir, in %s)\n": "En cola:          %d (esperando al directorio de logs, en %s)\n",
+	"⚠️  Movos directory does not exist: %s\n":                 "⚠️  El directorio de movos no existe: %s\n",
+	"To set a custom movos directory, use:":                    "Para usar otro directorio de movos:",
+	"⚠️  Error loading snacks: %v\n":                           "⚠️  Error al cargar los movos: %v\n",
+	"✅ Found %d movement snacks\n":                             "✅ %d movos encontrados\n",
+	"✏️  %d movo(s) overridden in %s\n":                        "✏️  %d movo(s) modificados en %s\n",
+	"⚠️  overrides.yaml: no movo '%s'\n":                       "⚠️  overrides.yaml: no existe el movo '%s'\n",
+	"No movos with min_per_day requirement":                    "No hay movos con min_per_day",
+	"  EVERY DAY MOVOS":                                        "  MOVOS DE CADA DÍA",
+	"  (Subset: %s)\n":                                         "  (Subconjunto: %s)\n",
+	"  🛌 Rest day":                                             "  🛌 Día de descanso",
+	"   Code: %s | RPE: %d | Duration: %d-%d min\n":            "   Código: %s | RPE: %d | Duración: %d-%d min\n",
+	"   Waived for today's rest day (%d of %d today)\n":        "   Perdonado por el descanso de hoy (%d de %d hoy)\n",
+	"   Completed %d of %d today\n":                            "   Hechos %d de %d hoy\n",
+	"   Not yet done (0 of %d today)\n":                        "   Aún sin hacer (0 de %d hoy)\n",
+	"   🔥 Streak: %d day(s)\n":                                 "   🔥 Racha: %d día(s)\n",
+	"⚠️  %d everyday movos excluded by active subset\n":        "⚠️  %d movos diarios excluidos por el subconjunto activo\n",
+	"Summary: %d/%d everyday movos completed":                  "Resumen: %d/%d movos diarios hechos",
+	" (in subset)":                                             " (en el subconjunto)",
+	"No movos with min_per_week or max_per_week":               "No hay movos con min_per_week o max_per_week",
+	"  WEEKLY MOVOS":                                           "  MOVOS SEMANALES",
+	"  (Week of %s)\n":                                         "  (Semana del %s)\n",
+	"   Completed %d of %d this week\n":                        "   Hechos %d de %d esta semana\n",
+	"   Weekly limit: %d of %d used\n":                         "   Límite semanal: %d de %d usados\n",
+	"Summary: %d/%d weekly movos completed\n":                  "Resumen: %d/%d movos semanales hechos\n",
+	"No subsets configured.":                                   "No hay subconjuntos configurados.",
+	"Create a subsets.yaml file in your movos directory:\n":    "Crea un archivo subsets.yaml en tu directorio de movos:\n",
+	"  AVAILABLE SUBSETS":                                      "  SUBCONJUNTOS DISPONIBLES",
+	"   %d movos\n":                                            "   %d movos\n",
+	"Usage:":                                                   "Uso:",
+	"  MIGRATE LOGS TO CSV FORMAT (v1.0.0)":                    "  MIGRAR LOS REGISTROS A CSV (v1.0.0)",
+	"Dry run: no files will be changed.":                       "Simulación: no se cambiará ningún archivo.",
+	"No log files found.":                                      "No se encontraron archivos de registro.",
+	"Found %d log file(s) to check\n\n":                        "Encontrados %d archivo(s) de registro que revisar\n\n",
+	"⚠️  %s: Could not read (%v)\n":                            "⚠️  %s: no se pudo leer (%v)\n",
+	"✓  %s: Already in CSV format\n":                           "✓  %s: ya está en CSV\n",
+	"⚠️  %s: Could not convert (%v)\n":                         "⚠️  %s: no se pudo convertir (%v)\n",
+	"⚠️  %s: No valid entries found\n":                         "⚠️  %s: no hay entradas válidas\n",
+	"→  %s → %s: Would convert %d entries%s\n":                 "→  %s → %s: se convertirían %d entradas%s\n",
+	"→  %s: Converting to CSV...\n":                            "→  %s: convirtiendo a CSV...\n",
+	"⚠️  %s: Could not create backup (%v)\n":                   "⚠️  %s: no se pudo crear la copia (%v)\n",
+	"⚠️  %s: Could not write new file (%v)\n":                  "⚠️  %s: no se pudo escribir el archivo nuevo (%v)\n",
+	"⚠️  %s: Could not update checksum (%v)\n":                 "⚠️  %s: no se pudo actualizar la suma de control (%v)\n",
+	"✅ %s → %s: Converted %d entries%s (backup: %s.bak)\n":     "✅ %s → %s: convertidas %d entradas%s (copia: %s.bak)\n",
+	"Dry run complete:\n":                                      "Simulación terminada:\n",
+	"  Would convert: %d\n":                                    "  Se convertirían: %d\n",
+	"Migration complete:\n":                                    "Migración terminada:\n",
+	"  Converted: %d\n":                                        "  Convertidos: %d\n",
+	"  Skipped:   %d (already CSV)\n":                          "  Omitidos:    %d (ya en CSV)\n",
+	"  Failed:    %d\n":                                        "  Fallidos:    %d\n",
+	"Backup files (.bak) have been created.":                   "Se han creado copias de seguridad (.bak).",
+	"After verifying the migration, you can delete them:":      "Cuando hayas comprobado la migración, puedes borrarlas:",
+	"\n❌ %d problem(s) in %s\n":                                "\n❌ %d problema(s) en %s\n",
+	"✅ %d movos in %s look good\n":                             "✅ %d movos en %s están bien\n",
+	"\n❌ %d style problem(s) in %s\n":                          "\n❌ %d problema(s) de estilo en %s\n",
+	"✅ %d movos in %s follow the lint rules\n":                 "✅ %d movos en %s cumplen las reglas de estilo\n",
+	"👀 Nudging every %s (Ctrl+C to stop)\n":                    "👀 Avisando cada %s (Ctrl+C para parar)\n",
+	"🔄 Movo library changed; reloaded %d movos\n":              "🔄 La biblioteca de movos cambió; %d movos recargados\n",
+	"Press Enter to start it: ":                                "Pulsa Enter para empezarlo: ",
+	"\n👀 Next nudge in %s\n":                                   "\n👀 Próximo aviso en %s\n",
+	"🌐 Serving on http://%s (Ctrl+C to stop)\n":                "🌐 Sirviendo en http://%s (Ctrl+C para parar)\n",
+	"🔄 Synced %s: %s\n":                                        "🔄 %s sincronizado: %s\n",
+	"Nothing to upload (completions of %d+ minutes since %s are all on Strava)\n": "Nada que subir (los movos de %d+ minutos desde el %s ya están en Strava)\n",
+	"Would upload: %s %s  %s (%d min)\n":                                          "Se subiría: %s %s  %s (%d min)\n",
+	"⬆️  Uploaded %d movos to Strava\n":                                           "⬆️  %d movos subidos a Strava\n",
+	"⭐ Rated '%s' %d/5\n":                                                         "⭐ '%s' valorado con %d/5\n",
+	"No ratings yet. Use 'movodoro rate CODE 1-5' to rate a movo.":                "Aún no hay valoraciones. Usa 'movodoro rate CODE 1-5' para valorar un movo.",
+	"  MOVO RATINGS":                                      "  VALORACIONES DE MOVOS",
+	"⭐ %.1f  %s (%d ratings)\n":                           "⭐ %.1f  %s (%d valoraciones)\n",
+	"No movos matched (or all already had these values).": "Ningún movo coincide (o todos tenían ya estos valores).",
+	"\nDry run: %d movo(s) in %d file(s) would change\n":  "\nSimulación: cambiarían %d movo(s) en %d archivo(s)\n",
+	"\n✅ Updated %d movo(s) in %d file(s)\n":              "\n✅ Actualizados %d movo(s) en %d archivo(s)\n",
+	"✅ Recorded checksums for %d daily log(s)\n":          "✅ Sumas de control guardadas para %d registro(s) diario(s)\n",
+	"New entries will keep them up to date.":              "Las entradas nuevas las mantendrán al día.",
+	"✅ %d daily log(s) verified\n":                        "✅ %d registro(s) diario(s) verificados\n",
+	"⚠️  %d of %d daily log(s) look suspect:\n":           "⚠️  %d de %d registro(s) diario(s) parecen sospechosos:\n",
+	"Once you've checked them, run 'movodoro verify-history --init' to accept the current contents.": "Cuando los hayas revisado, ejecuta 'movodoro verify-history --init' para aceptar su contenido actual.",
+	"No daily logs before %s to archive\n":                                 "No hay registros diarios anteriores al %s que archivar\n",
+	"📦 %s %d daily log(s), %d entries, into %d monthly file(s) in %s\n":    "📦 %s %d registro(s) diario(s), %d entradas, en %d archivo(s) mensual(es) en %s\n",
+	"  SELECTION WEIGHT ANALYSIS":                                          "  ANÁLISIS DE PESOS DE SELECCIÓN",
+	"Candidates:  %d (%d excluded by filters, limits or daily priority)\n": "Candidatos:  %d (%d excluidos por filtros, límites o prioridad diaria)\n",
+	"Iterations:  %d\n":                        "Iteraciones: %d\n",
+	"Exploration: %.0f%%\n":                    "Exploración: %.0f%%\n",
+	"🔋 Auto-recovery mode is active (RPE ≤ 2)": "🔋 El modo de recuperación automática está activo (RPE ≤ 2)",
+	"Expected": "Esperado",
+	"Observed": "Observado",
+	"⚠️  %d movo(s) have collapsed to under %.0f%% of an even share\n": "⚠️  %d movo(s) han caído por debajo del %.0f%% de un reparto igual\n",
+	"✅ No movo's probability has collapsed":                            "✅ La probabilidad de ningún movo ha caído",
+	"Movos":                              "Movos",
+	"RPE":                                "RPE",
+	"📝 Wrote end-of-day summary to %s\n": "📝 Resumen del día escrito en %s\n",
+	"📧 Mailed it to %s\n":                "📧 Enviado por correo a %s\n",
+	"  WHY THIS MOVO?":                   "  ¿POR QUÉ ESTE MOVO?",
+	"Exploration: %.0f%% of picks ignore weights\n":                             "Exploración: el %.0f%% de las elecciones ignoran los pesos\n",
+	"Variety:     %s (weights raised to the power %g)\n":                        "Variedad:    %s (pesos elevados a %g)\n",
+	"🔄 Rotation: only %s is up next\n":                                          "🔄 Rotación: solo toca %s\n",
+	"😴 Rest day: only the lightest movos are offered":                           "😴 Día de descanso: solo se ofrecen los movos más suaves",
+	"📅 Only today's unfinished dailies are eligible (--skip-minimums to widen)": "📅 Solo cuentan los diarios sin terminar de hoy (--skip-minimums para ampliar)",
+	"%6.2f%%  %-32s weight %.2f\n":                                              "%6.2f%%  %-32s peso %.2f\n",
+	"⏳ Waiting on requires_done_today:":                                         "⏳ Esperando a requires_done_today:",
+	"         %-32s needs %s\n":                                                 "         %-32s necesita %s\n",
+	"  MOVODORO HEATMAP":                                                        "  MAPA DE CALOR DE MOVODORO",
+	"     Less %s More (%s; busiest day %d)\n":                                  "     Menos %s Más (%s; día con más: %d)\n",
+	"     %d active days of %d, %d movos, %d minutes\n":                         "     %d días activos de %d, %d movos, %d minutos\n",
+	"CODE":    "CÓDIGO",
+	"MINUTES": "MINUTOS",
+	"TAGS":    "ETIQUETAS",
+	"🔋 Auto-recovery mode: limiting to RPE ≤ 2":      "🔋 Modo de recuperación automática: limitado a RPE ≤ 2",
+	"🛌 Rest day: limiting to RPE ≤ 2":                "🛌 Día de descanso: limitado a RPE ≤ 2",
+	"🎲 Exploration pick: ignoring weights this time": "🎲 Elección de exploración: ignorando los pesos esta vez",
+	"No current movo":                        "No hay movo actual",
+	"Today: %d movos, %d minutes\n":          "Hoy: %d movos, %d minutos\n",
+	"⏳ %s remaining  (Ctrl+C to stop early)": "⏳ quedan %s  (Ctrl+C para parar antes)",
+	"Archived":      "Archivados",
+	"Would archive": "Se archivarían",
+
+	// Dates
+	"Monday": "lunes", "Tuesday": "martes", "Wednesday": "miércoles", "Thursday": "jueves",
+	"Friday": "viernes", "Saturday": "sábado", "Sunday": "domingo",
+	"Mon": "lun", "Tue": "mar", "Wed": "mié", "Thu": "jue", "Fri": "vie", "Sat": "sáb", "Sun": "dom",
+	"January": "enero", "February": "febrero", "March": "marzo", "April": "abril", "May": "mayo",
+	"June": "junio", "July": "julio", "August": "agosto", "September": "septiembre",
+	"October": "octubre", "November": "noviembre", "December": "diciembre",
+	"Jan": "ene", "Feb": "feb", "Mar": "mar", "Apr": "abr", "Jun": "jun", "Jul": "jul",
+	"Aug": "ago", "Sep": "sep", "Oct": "oct", "Nov": "nov", "Dec": "dic",
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", "en"},
+		{"en", "en"},
+		{"C", "en"},
+		{"es", "es"},
+		{"ES", "es"},
+		{"es_ES.UTF-8", "es"},
+		{"es-MX", "es"},
+		{"en_GB.UTF-8", "en"},
+	}
+	for _, tt := range tests {
+		got, err := parseLocale(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseLocale(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseLocale("klingon"); err == nil {
+		t.Error("parseLocale(\"klingon\") should fail")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer setLocale(defaultLocale)
+
+	if got := trf("%d days ago", 3); got != "3 days ago" {
+		t.Errorf("English trf() = %q", got)
+	}
+	setLocale("es")
+	if got := trf("%d days ago", 3); got != "hace 3 días" {
+		t.Errorf("Spanish trf() = %q", got)
+	}
+	if got := tr("not in any catalog"); got != "not in any catalog" {
+		t.Errorf("missing translation should fall back to English, got %q", got)
+	}
+	if got := localizeDate("Monday, January 2, 2006"); got != "lunes, enero 2, 2006" {
+		t.Errorf("localizeDate() = %q", got)
+	}
+	usage := "USAGE:\n    movodoro    # Interactive mode\n    version     Show version information\n" +
+		"                (--keep leaves it in place, --days N sets the history length)\n    done [CODE] [-d MINS]\n"
+	want := "USO:\n    movodoro    # Modo interactivo\n    version     Mostrar la versión\n" +
+		"                (--keep lo conserva, --days N fija la longitud del historial)\n    done [CODE] [-d MINS]\n"
+	if got := localizeUsage(usage); got != want {
+		t.Errorf("localizeUsage() = %q", got)
+	}
+}
+
+func TestPeriodReportSpanish(t *testing.T) {
+	defer setLocale(defaultLocale)
+	setLocale("es")
+
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	report := buildPeriodReport([]HistoryEntry{doneAt("TS-pushups", monday.Add(9*time.Hour))}, monday, 7)
+	var b strings.Builder
+	writePeriodReport(&b, tr("WEEKLY MOVODORO REPORT"), trf("Week of %s", "10/03"), report)
+	out := b.String()
+	for _, want := range []string{"INFORME SEMANAL DE MOVODORO", "Semana del 10/03", "lun mar 10", "📊 Resumen:", "Duración total:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the Spanish week report, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	for locale, catalog := range catalogs {
+		for msg, translated := range catalog {
+			if !slices.Equal(verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1)) {
+				t.Errorf("%s: %q changes the format verbs of %q", locale, translated, msg)
+			}
+		}
+	}
+}
+
+// printedLiteral is a string literal printed by fmt.Print* or fmt.Fprint*, or passed to tr
+type printedLiteral struct {
+	pos     token.Position
+	fn      string // Enclosing function
+	value   string
+	wrapped bool // Passed through tr or trf
+	printed bool // An argument of fmt.Print* or fmt.Fprint*
+}
+
+// sourceLiterals collects the printed and translated string literals of the package
+func sourceLiterals(t *testing.T) []printedLiteral {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	var literals []printedLiteral
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			ast.Inspect(fn, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				add := func(arg ast.Expr, wrapped, printed bool) {
+					if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						value, _ := strconv.Unquote(lit.Value)
+						literals = append(literals, printedLiteral{fset.Position(lit.Pos()), fn.Name.Name, value, wrapped, printed})
+					}
+				}
+				switch fun := call.Fun.(type) {
+				case *ast.Ident:
+					if (fun.Name == "tr" || fun.Name == "trf") && len(call.Args) > 0 {
+						add(call.Args[0], true, false)
+					}
+				case *ast.SelectorExpr:
+					if pkg, ok := fun.X.(*ast.Ident); !ok || pkg.Name != "fmt" {
+						return true
+					}
+					args := call.Args
+					switch fun.Sel.Name {
+					case "Print", "Println", "Printf":
+					case "Fprint", "Fprintln", "Fprintf":
+						args = args[1:]
+					default:
+						return true
+					}
+					for _, arg := range args {
+						add(arg, false, true)
+					}
+				}
+				return true
+			})
+		}
+	}
+	return literals
+}
+
+var (
+	literalWords = regexp.MustCompile(`[A-Za-z]{2,}`)
+	literalVerbs = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	// Command lines to type, shown as written
+	literalCommand = regexp.MustCompile(`^\s*(movodoro|export|rm) |^\s*%s/`)
+)
+
+// keepsEnglish reports whether a literal's output stays in English on purpose:
+// markdown reports, email headers and the batch lines scripts read
+func keepsEnglish(lit printedLiteral) bool {
+	return strings.Contains(lit.fn, "Markdown") || lit.fn == "sendEmail" || lit.pos.Filename == "batch.go"
+}
+
+func TestPrintedStringsAreTranslated(t *testing.T) {
+	for _, lit := range sourceLiterals(t) {
+		if !lit.printed || keepsEnglish(lit) || literalCommand.MatchString(lit.value) {
+			continue
+		}
+		for _, word := range literalWords.FindAllString(literalVerbs.ReplaceAllString(lit.value, ""), -1) {
+			if word != "RPE" {
+				t.Errorf("%s: %s prints %q without tr()", lit.pos, lit.fn, lit.value)
+				break
+			}
+		}
+	}
+}
+
+func TestCatalogsCoverTranslatedStrings(t *testing.T) {
+	for _, lit := range sourceLiterals(t) {
+		if !lit.wrapped {
+			continue
+		}
+		for locale, catalog := range catalogs {
+			if _, ok := catalog[lit.value]; !ok && catalog != nil {
+				t.Errorf("%s: %q has no %s translation", lit.pos, lit.value, locale)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// movoRecord is a movo's whole logged history, summed up for the [i] details
+type movoRecord struct {
+	Done, Partial, Skipped int
+	FirstDone, LastDone    *time.Time // Completions, partial ones included
+}
+
+// recordOf sums up the entries logged for code
+func recordOf(entries []HistoryEntry, code string) movoRecord {
+	var record movoRecord
+	for _, entry := range entries {
+		if entry.Code != code {
+			continue
+		}
+		switch entry.Status {
+		case "done":
+			record.Done++
+		case "partial":
+			record.Partial++
+		case "skip":
+			record.Skipped++
+			continue
+		default:
+			continue
+		}
+		timestamp := entry.Timestamp
+		if record.FirstDone == nil || timestamp.Before(*record.FirstDone) {
+			record.FirstDone = &timestamp
+		}
+		if record.LastDone == nil || timestamp.After(*record.LastDone) {
+			record.LastDone = &timestamp
+		}
+	}
+	return record
+}
+
+// timeWindowText describes a time window, e.g. "after 06:00, before 11:00"
+func timeWindowText(w TimeWindow) string {
+	var parts []string
+	if w.After != "" {
+		parts = append(parts, trf("after %s", w.After))
+	}
+	if w.Before != "" {
+		parts = append(parts, trf("before %s", w.Before))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printMovoInfo prints the details the compact card leaves out: what the movo needs
+// and works, and its history across every log
+func printMovoInfo(movo *Movo, snacks []Movo) {
+	writeMovoInfo(os.Stdout, movo, snacks)
+}
+
+// writeMovoInfo is printMovoInfo to w
+func writeMovoInfo(w io.Writer, movo *Movo, snacks []Movo) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "───────────────────────────────────────")
+	fmt.Fprintf(w, tr("ℹ️  %s [%s]\n"), movo.Title, movo.FullCode)
+	fmt.Fprintln(w, "───────────────────────────────────────")
+
+	equipment := tr("none")
+	if len(movo.Equipment) > 0 {
+		equipment = strings.Join(movo.Equipment, ", ")
+	}
+	fmt.Fprintf(w, tr("   Equipment:    %s\n"), equipment)
+	if len(movo.Targets) > 0 {
+		fmt.Fprintf(w, tr("   Targets:      %s\n"), strings.Join(movo.Targets, ", "))
+	}
+	if len(movo.Contraindications) > 0 {
+		fmt.Fprintf(w, tr("   Avoid with:   %s\n"), strings.Join(movo.Contraindications, ", "))
+	}
+	if movo.TimeWindow != nil {
+		fmt.Fprintf(w, tr("   Time window:  %s\n"), timeWindowText(*movo.TimeWindow))
+	}
+	if alternatives := alternativesOf(snacks, movo); len(alternatives) > 0 {
+		var codes []string
+		for _, alternative := range alternatives {
+			codes = append(codes, alternative.FullCode)
+		}
+		fmt.Fprintf(w, tr("   Alternatives: %s\n"), strings.Join(codes, ", "))
+	}
+	if len(movo.PairsWellWith) > 0 {
+		fmt.Fprintf(w, tr("   Pairs with:   %s\n"), strings.Join(movo.PairsWellWith, ", "))
+	}
+
+	entries, err := LoadAllHistory(appConfig.LogsDir)
+	if err != nil {
+		fmt.Fprintf(w, tr("   History:      unavailable (%v)\n"), err)
+		return
+	}
+	record := recordOf(entries, movo.FullCode)
+	if record.LastDone == nil {
+		fmt.Fprintln(w, tr("   Last done:    never"))
+	} else {
+		fmt.Fprintf(w, tr("   Last done:    %s (%s)\n"), appConfig.FormatDate(*record.LastDone), daysAgo(*record.LastDone, time.Now()))
+		fmt.Fprintf(w, tr("   First done:   %s\n"), appConfig.FormatDate(*record.FirstDone))
+	}
+	fmt.Fprintf(w, tr("   Completed:    %d times (%d partial, %d skipped)\n"), record.Done, record.Partial, record.Skipped)
+	fmt.Fprintln(w)
+}
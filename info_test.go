@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	entries := []HistoryEntry{
+		{Timestamp: day(4), Code: "MOB-hips", Status: "done"},
+		{Timestamp: day(2), Code: "MOB-hips", Status: "partial"},
+		{Timestamp: day(9), Code: "MOB-hips", Status: "skip"},
+		{Timestamp: day(7), Code: "MOB-hips", Status: "done"},
+		{Timestamp: day(1), Code: "MOB-neck", Status: "done"},
+	}
+	record := recordOf(entries, "MOB-hips")
+	if record.Done != 2 || record.Partial != 1 || record.Skipped != 1 {
+		t.Errorf("record = %+v, want 2 done, 1 partial, 1 skipped", record)
+	}
+	if !record.FirstDone.Equal(day(2)) || !record.LastDone.Equal(day(7)) {
+		t.Errorf("first/last done = %v/%v, want the partial on the 2nd and the completion on the 7th (skips don't count)",
+			record.FirstDone, record.LastDone)
+	}
+
+	if never := recordOf(entries, "BR-box"); never.LastDone != nil || never.Done != 0 {
+		t.Errorf("record for a movo never logged = %+v", never)
+	}
+}
+
+func TestTimeWindowText(t *testing.T) {
+	if got := timeWindowText(TimeWindow{After: "06:00", Before: "11:00"}); got != "after 06:00, before 11:00" {
+		t.Errorf("timeWindowText() = %q", got)
+	}
+	if got := timeWindowText(TimeWindow{Before: "20:00"}); got != "before 20:00" {
+		t.Errorf("timeWindowText() = %q", got)
+	}
+}
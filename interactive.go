@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// interactiveSession is what interactive mode carries from one movo to the next, shared
+// by the full-screen TUI and the line-based prompts used without a terminal
+type interactiveSession struct {
+	library *movoLibrary
+	filters FilterOptions
+	queue   *everydayQueue // Incomplete everyday movos, stepped through first (nil if off)
+
+	// Movos offered this session, kept out of rerolls so two don't bounce back and forth
+	offered []string
+	// Variation chosen with [a] or movo returned to with [b], shown next
+	swapIn *Movo
+	// Movos moved on from this session, most recent last, for [b] to return to
+	previous []previousSuggestion
+}
+
+// previousSuggestion is a movo interactive mode moved on from, with the skip logged
+// for it (nil if none) so [b] can take that back
+type previousSuggestion struct {
+	movo *Movo
+	skip *HistoryEntry
+}
+
+// newInteractiveSession loads the movos and, if everyday_queue is on, queues today's
+// incomplete everyday movos. Notices go to out and warnings to warn.
+func newInteractiveSession(subset string, out, warn io.Writer) (*interactiveSession, error) {
+	library, err := loadLibrary()
+	if err != nil {
+		return nil, withExitCode(exitLibrary, fmt.Errorf("loading snacks: %w", err))
+	}
+
+	s := &interactiveSession{
+		library: library,
+		filters: FilterOptions{
+			Subset:         subset,
+			LimitEquipment: appConfig.LimitEquipment,
+			Equipment:      appConfig.Equipment,
+		},
+	}
+	if !appConfig.EverydayQueue {
+		return s, nil
+	}
+
+	queueSnacks := library.Movos
+	if subset != "" {
+		if queueSnacks, err = filterBySubset(library.Movos, subset, appConfig.MovosDir); err != nil {
+			return nil, withExitCode(exitLibrary, fmt.Errorf("loading subset: %w", err))
+		}
+	}
+	q, created, err := startEverydayQueue(queueSnacks, time.Now())
+	if err != nil {
+		fmt.Fprintf(warn, tr("Warning: %v\n"), err)
+		return s, nil
+	}
+	s.queue = &q
+	if created && len(q.Codes) > 0 {
+		fmt.Fprintf(out, tr("📋 Queued %d everyday movo(s), lowest RPE first\n\n"), len(q.Codes))
+	}
+	return s, nil
+}
+
+// snacks returns the movos in the library as last loaded
+func (s *interactiveSession) snacks() []Movo {
+	return s.library.Movos
+}
+
+// next picks the movo to show: a swapped-in one, the saved current snack, the next in
+// the everyday queue, or a weighted random pick. It becomes the current snack.
+func (s *interactiveSession) next(out, warn io.Writer) (*Movo, error) {
+	if reloaded, err := s.library.refresh(); err != nil {
+		fmt.Fprintf(warn, tr("Warning: movo library not reloaded: %v\n"), err)
+	} else if reloaded {
+		fmt.Fprintf(out, tr("🔄 Movo library changed; reloaded %d movos\n\n"), len(s.snacks()))
+		if s.swapIn != nil {
+			s.swapIn = findMovo(s.snacks(), s.swapIn.FullCode)
+		}
+	}
+
+	snacks := s.snacks()
+	snack := s.swapIn
+	s.swapIn = nil
+
+	// Resume the snack saved by a previous session
+	if savedCode, err := loadCurrentSnack(); snack == nil && err == nil && savedCode != "" {
+		if snack = findMovo(snacks, savedCode); snack != nil {
+			fmt.Fprintln(out, tr("📥 Resuming saved snack..."))
+			fmt.Fprintln(out)
+		}
+	}
+
+	// Next from the everyday queue, unless dailies are being skipped
+	if snack == nil && s.queue != nil && !s.filters.SkipMinimums {
+		queued, err := s.queue.nextQueued(snacks, appConfig.LogsDir)
+		if err != nil {
+			return nil, withExitCode(exitStorage, fmt.Errorf("reading today's history: %w", err))
+		}
+		if queued != nil {
+			snack = queued
+			fmt.Fprintf(out, tr("📋 Everyday queue: %d left\n"), len(s.queue.Codes))
+		}
+	}
+
+	var notes selectionNotes
+	if snack == nil {
+		s.filters.Exclude = s.offered
+		selected, picked, err := pickSnack(snacks, s.filters, appConfig.MaxDailyRPE)
+		if err != nil && len(s.offered) > 0 {
+			fmt.Fprintln(out, tr("🔁 Every matching movo has been offered; starting over"))
+			s.offered, s.filters.Exclude = nil, nil
+			selected, picked, err = pickSnack(snacks, s.filters, appConfig.MaxDailyRPE)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("selecting snack: %w", err)
+		}
+		writeSelectionNotes(out, picked)
+		snack, notes = selected, picked
+	}
+
+	// Save as current snack (overwrites existing or saves new); a resumed
+	// exploration pick stays marked
+	explored := notes.Exploration || currentIsExploration(snack.FullCode)
+	if err := saveCurrentPick(snack.FullCode, explored); err != nil {
+		fmt.Fprintf(warn, tr("Warning: could not save current snack: %v\n"), err)
+	}
+
+	if !slices.Contains(s.offered, snack.FullCode) {
+		s.offered = append(s.offered, snack.FullCode)
+	}
+	return snack, nil
+}
+
+// alternatives lists the variations of movo that [a] can swap in
+func (s *interactiveSession) alternatives(movo *Movo) []*Movo {
+	return alternativesOf(s.snacks(), movo)
+}
+
+// canGoBack reports whether [b] has a movo to return to
+func (s *interactiveSession) canGoBack() bool {
+	return len(s.previous) > 0
+}
+
+// skipped moves on from movo once skip is logged for it, dropping it from the everyday queue
+func (s *interactiveSession) skipped(movo *Movo, skip HistoryEntry, warn io.Writer) {
+	s.previous = append(s.previous, previousSuggestion{movo: movo, skip: &skip})
+	os.Remove(appConfig.CurrentPath) // Clear saved snack
+	if s.queue != nil {
+		s.queue.drop(movo.FullCode)
+		if err := saveEverydayQueue(appConfig.EverydayQueuePath, *s.queue); err != nil {
+			fmt.Fprintf(warn, tr("Warning: could not save everyday queue: %v\n"), err)
+		}
+	}
+	s.filters.SkipMinimums = false
+}
+
+// swapAlternative moves on from movo to its next variation, without logging a skip,
+// and returns the variation
+func (s *interactiveSession) swapAlternative(movo *Movo) *Movo {
+	s.previous = append(s.previous, previousSuggestion{movo: movo})
+	s.swapIn = nextAlternative(s.alternatives(movo), s.offered)
+	return s.swapIn
+}
+
+// skipDailies moves on from movo and leaves min_per_day movos out of the next pick
+func (s *interactiveSession) skipDailies(movo *Movo) {
+	s.previous = append(s.previous, previousSuggestion{movo: movo})
+	os.Remove(appConfig.CurrentPath) // Clear saved snack
+	s.filters.SkipMinimums = true
+}
+
+// back returns to the movo moved on from last, taking back its skip
+func (s *interactiveSession) back(warn io.Writer) *Movo {
+	last := s.previous[len(s.previous)-1]
+	s.previous = s.previous[:len(s.previous)-1]
+	if last.skip != nil {
+		if err := removeLogEntry(appConfig.LogsDir, *last.skip); err != nil {
+			fmt.Fprintf(warn, tr("Warning: could not remove the skip: %v\n"), err)
+		}
+	}
+	s.swapIn = last.movo
+	return s.swapIn
+}
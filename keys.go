@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// readKey reads one key press from the terminal in raw mode and returns it, lowercased
+// and echoed, once accept takes it; any other key reprints retry on the same line.
+// Ctrl+C and end of input count as "q". ok is false when stdin isn't a terminal, and
+// the caller should read a line instead.
+func readKey(accept func(key string) bool, retry string) (key string, ok bool) {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", false
+	}
+	defer term.Restore(fd, oldState)
+
+	buf := make([]byte, 1)
+	for {
+		if _, err := os.Stdin.Read(buf); err != nil {
+			fmt.Print("\r\n")
+			return "q", true
+		}
+		if buf[0] == 3 {
+			fmt.Print("^C\r\n")
+			return "q", true
+		}
+		key := strings.ToLower(string(buf[0]))
+		if accept(key) {
+			fmt.Printf("%s\r\n", key)
+			return key, true
+		}
+		fmt.Print("\r\033[K" + retry)
+	}
+}
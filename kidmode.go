@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// kidCheers are the playful messages shown after a movo in kid mode
+var kidCheers = []string{
+	"🎉 Woohoo! You did %s!",
+	"🌟 Superstar! %s - done!",
+	"🚀 Blast off! You finished %s!",
+	"🦖 Roar! %s is all done!",
+	"🏆 Champion move! %s complete!",
+	"🐯 So strong! You did %s!",
+}
+
+// stickers are handed out on the sticker chart, one per completed movo
+var stickers = []string{"⭐", "🌈", "🦄", "🐸", "🚀", "🍓", "🐝", "🌻", "🦋", "🐢", "🍉", "🎈"}
+
+// kidCheer picks a random cheer for a finished movo
+func kidCheer(title string) string {
+	return trf(kidCheers[selectorRand.IntN(len(kidCheers))], title)
+}
+
+// stickerFor returns the sticker for a movo code, so each movo always gets the same one
+func stickerFor(code string) string {
+	h := fnv.New32a()
+	h.Write([]byte(code))
+	return stickers[h.Sum32()%uint32(len(stickers))]
+}
+
+// stickerChart renders one row per day of the week starting at weekStart,
+// with a sticker for every done or partial entry, followed by the week's total
+func stickerChart(entries []HistoryEntry, weekStart time.Time) string {
+	byDay := make(map[string][]string)
+	total := 0
+	for _, entry := range entries {
+		if entry.Status != "done" && entry.Status != "partial" {
+			continue
+		}
+		key := dayKey(entry.Timestamp)
+		byDay[key] = append(byDay[key], stickerFor(entry.Code))
+		total++
+	}
+
+	var b strings.Builder
+	for i := 0; i < 7; i++ {
+		day := weekStart.AddDate(0, 0, i)
+		row := strings.Join(byDay[dayKey(day)], " ")
+		if row == "" {
+			row = "·"
+		}
+		fmt.Fprintf(&b, "  %-3s  %s\n", localizeDate(day.Format("Mon")), row)
+	}
+	fmt.Fprintf(&b, tr("\n  Stickers this week: %d\n"), total)
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStickerFor(t *testing.T) {
+	if stickerFor("TS-pushups") != stickerFor("TS-pushups") {
+		t.Error("expected the same sticker for the same code")
+	}
+	for _, code := range []string{"TS-pushups", "TB-box-breath", "MO-hip-circles"} {
+		found := false
+		for _, s := range stickers {
+			if stickerFor(code) == s {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("sticker for %s not in sticker list: %q", code, stickerFor(code))
+		}
+	}
+}
+
+func TestStickerChart(t *testing.T) {
+	weekStart := time.Date(2025, 10, 13, 0, 0, 0, 0, time.Local) // Monday
+	at := func(d int) time.Time { return time.Date(2025, 10, d, 12, 0, 0, 0, time.Local) }
+
+	entries := []HistoryEntry{
+		doneAt("TS-pushups", at(13)),
+		doneAt("TB-box-breath", at(13)),
+		{Timestamp: at(13), Code: "TS-squats", Status: "skip"},
+		{Timestamp: at(15), Code: "TS-squats", Status: "partial"},
+	}
+
+	chart := stickerChart(entries, weekStart)
+	lines := strings.Split(chart, "\n")
+	if len(lines) < 7 {
+		t.Fatalf("expected a row per day, got:\n%s", chart)
+	}
+
+	monday := stickerFor("TS-pushups") + " " + stickerFor("TB-box-breath")
+	if !strings.HasPrefix(lines[0], "  Mon") || !strings.HasSuffix(lines[0], monday) {
+		t.Errorf("Monday row: expected stickers %q, got %q", monday, lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "·") {
+		t.Errorf("Tuesday row: expected an empty day, got %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], stickerFor("TS-squats")) {
+		t.Errorf("Wednesday row: expected a sticker for the partial, got %q", lines[2])
+	}
+	if !strings.Contains(chart, "Stickers this week: 3") {
+		t.Errorf("expected 3 stickers (skips don't count), got:\n%s", chart)
+	}
+}
+
+func TestDefaultConfigKidModeProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `profiles:
+  kids:
+    kid_mode: true
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if DefaultConfig().KidMode {
+		t.Error("kid mode should be off without the profile")
+	}
+
+	t.Setenv("MOVODORO_PROFILE", "kids")
+	if cfg := DefaultConfig(); !cfg.KidMode {
+		t.Errorf("expected kid mode from profile, got %+v", cfg)
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lintRulesFile holds the style rules 'movodoro lint' enforces. It lives in the movos
+// directory, so a team sharing a library through git shares its rules too.
+const lintRulesFile = "lint.yaml"
+
+// lintRules are the conventions checked by 'lint'. Unlike 'validate', which finds
+// mistakes the loader would trip over, these are matters of house style.
+type lintRules struct {
+	MaxDescription    int     `yaml:"max_description"` // Characters, 0 for no limit
+	MinWeight         float64 `yaml:"min_weight"`
+	MaxWeight         float64 `yaml:"max_weight"`
+	RequireTags       bool    `yaml:"require_tags"`        // Every movo has a tag, its own or its category's
+	RequireDefaultRPE bool    `yaml:"require_default_rpe"` // Every category sets default_rpe
+	// File of allowed tags, one per line (# comments), relative to the movos directory.
+	// Without one any tag is allowed.
+	TagVocabulary string `yaml:"tag_vocabulary"`
+}
+
+// defaultLintRules apply when there's no lint.yaml, and to any rule it leaves out
+func defaultLintRules() lintRules {
+	return lintRules{
+		MaxDescription:    600,
+		MinWeight:         0.1,
+		MaxWeight:         5,
+		RequireTags:       true,
+		RequireDefaultRPE: true,
+	}
+}
+
+// parseLintRules reads lint.yaml over the default rules
+func parseLintRules(data []byte) (lintRules, error) {
+	rules := defaultLintRules()
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rules); err != nil && err != io.EOF {
+		return rules, err
+	}
+	if rules.MaxDescription < 0 {
+		return rules, fmt.Errorf("max_description must not be negative, got %d", rules.MaxDescription)
+	}
+	if rules.MinWeight < 0 || rules.MinWeight > rules.MaxWeight {
+		return rules, fmt.Errorf("min_weight %g and max_weight %g don't make a range", rules.MinWeight, rules.MaxWeight)
+	}
+	return rules, nil
+}
+
+// loadLintRules reads dir's lint.yaml (the defaults if there isn't one) and its tag vocabulary
+func loadLintRules(dir string) (lintRules, map[string]bool, error) {
+	rules := defaultLintRules()
+	data, err := os.ReadFile(filepath.Join(dir, lintRulesFile))
+	if err == nil {
+		if rules, err = parseLintRules(data); err != nil {
+			return rules, nil, fmt.Errorf("invalid %s: %w", lintRulesFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return rules, nil, err
+	}
+
+	if rules.TagVocabulary == "" {
+		return rules, nil, nil
+	}
+	path := rules.TagVocabulary
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	vocabulary, err := loadTagVocabulary(path)
+	if err != nil {
+		return rules, nil, fmt.Errorf("error reading tag vocabulary: %w", err)
+	}
+	return rules, vocabulary, nil
+}
+
+// loadTagVocabulary reads a file of allowed tags, one per line
+func loadTagVocabulary(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tags := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if tag := strings.TrimSpace(line); tag != "" {
+			tags[tag] = true
+		}
+	}
+	return tags, scanner.Err()
+}
+
+// movoLinter collects style diagnostics across the category files in a movos directory
+type movoLinter struct {
+	rules       lintRules
+	vocabulary  map[string]bool // nil allows any tag
+	diagnostics []diagnostic
+	movos       int
+}
+
+// lintMovosDir checks every category file in dir against the rules. Files that don't
+// parse are left to 'validate'. It returns the diagnostics (sorted by file and line)
+// and how many movos were checked.
+func lintMovosDir(dir string, rules lintRules, vocabulary map[string]bool) ([]diagnostic, int, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error finding YAML files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, 0, fmt.Errorf("no YAML files found in %s", dir)
+	}
+
+	l := &movoLinter{rules: rules, vocabulary: vocabulary}
+	for _, file := range files {
+		if !isCategoryFile(file) {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, 0, err
+		}
+		l.lintCategoryFile(filepath.Base(file), data)
+	}
+	sortDiagnostics(l.diagnostics)
+	return l.diagnostics, l.movos, nil
+}
+
+func (l *movoLinter) report(file string, line int, format string, args ...any) {
+	l.diagnostics = append(l.diagnostics, diagnostic{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *movoLinter) lintCategoryFile(file string, data []byte) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return
+	}
+	root := doc.Content[0]
+	var category Category
+	if err := root.Decode(&category); err != nil {
+		return
+	}
+
+	if l.rules.RequireDefaultRPE && mappingValue(root, "default_rpe") == nil {
+		l.report(file, root.Line, "category %s has no default_rpe", category.Code)
+	}
+	l.checkWeight(file, root, "category "+category.Code+": ", category.Weight)
+	l.checkTags(file, root, "category "+category.Code+": ", category.Tags)
+
+	movosNode := mappingValue(root, "movos")
+	if movosNode == nil {
+		return
+	}
+	for _, item := range movosNode.Content {
+		var movo Movo
+		if item.Kind != yaml.MappingNode || item.Decode(&movo) != nil {
+			continue
+		}
+		l.movos++
+		name := category.Code + "-" + movo.Code + ": "
+
+		if length := utf8.RuneCountInString(strings.TrimSpace(movo.Description)); l.rules.MaxDescription > 0 && length > l.rules.MaxDescription {
+			l.report(file, mappingValue(item, "description").Line, "%sdescription is %d characters (max %d)",
+				name, length, l.rules.MaxDescription)
+		}
+		l.checkWeight(file, item, name, movo.Weight)
+		l.checkTags(file, item, name, movo.Tags)
+		if l.rules.RequireTags && len(movo.Tags) == 0 && len(category.Tags) == 0 {
+			l.report(file, item.Line, "%sno tags (on the movo or its category)", name)
+		}
+	}
+}
+
+// checkWeight reports a weight set in mapping outside the allowed range
+func (l *movoLinter) checkWeight(file string, mapping *yaml.Node, name string, weight float64) {
+	node := mappingValue(mapping, "weight")
+	if node == nil {
+		return
+	}
+	if weight < l.rules.MinWeight || weight > l.rules.MaxWeight {
+		l.report(file, node.Line, "%sweight %g is outside %g-%g", name, weight, l.rules.MinWeight, l.rules.MaxWeight)
+	}
+}
+
+// checkTags reports tags in mapping missing from the vocabulary
+func (l *movoLinter) checkTags(file string, mapping *yaml.Node, name string, tags []string) {
+	if l.vocabulary == nil {
+		return
+	}
+	node := mappingValue(mapping, "tags")
+	for _, tag := range tags {
+		if !l.vocabulary[tag] {
+			l.report(file, node.Line, "%stag %q is not in %s", name, tag, l.rules.TagVocabulary)
+		}
+	}
+}
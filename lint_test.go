@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLintMovosDir(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{
+		"breathing.yaml": `category: Breathing
+code: BR
+movos:
+  - code: box
+    title: Box breathing
+    description: Breathe in a square, four counts a side.
+    duration_min: 3
+    duration_max: 5
+    weight: 12
+  - code: sigh
+    title: Physiological sigh
+    description: Two inhales, one long exhale.
+    duration_min: 1
+    duration_max: 2
+    tags: [breathx, calmx]
+`,
+		"lint.yaml": "max_description: 30\ntag_vocabulary: tags.txt\n",
+		"tags.txt":  "# Allowed tags\nbreathx\nkbx\n",
+	})
+
+	rules, vocabulary, err := loadLintRules(dir)
+	if err != nil {
+		t.Fatalf("loadLintRules() error = %v", err)
+	}
+	if rules.MaxDescription != 30 || rules.MaxWeight != 5 || !rules.RequireTags {
+		t.Errorf("rules = %+v, want lint.yaml over the defaults", rules)
+	}
+
+	diagnostics, movos, err := lintMovosDir(dir, rules, vocabulary)
+	if err != nil {
+		t.Fatalf("lintMovosDir() error = %v", err)
+	}
+	if movos != 2 {
+		t.Errorf("checked %d movos, want 2", movos)
+	}
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"breathing.yaml:1: category BR has no default_rpe",
+		"breathing.yaml:4: BR-box: no tags (on the movo or its category)",
+		"breathing.yaml:6: BR-box: description is 40 characters (max 30)",
+		"breathing.yaml:9: BR-box: weight 12 is outside 0.1-5",
+		`breathing.yaml:15: BR-sigh: tag "calmx" is not in tags.txt`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// lint.yaml and tags.txt aren't mistaken for category files
+	if _, err := loadMovosDir(dir); err != nil {
+		t.Errorf("loadMovosDir() error = %v", err)
+	}
+}
+
+func TestParseLintRules(t *testing.T) {
+	if _, err := parseLintRules([]byte("max_desc: 100\n")); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+	if _, err := parseLintRules([]byte("min_weight: 3\nmax_weight: 2\n")); err == nil {
+		t.Error("expected an error for an empty weight range")
+	}
+	rules, err := parseLintRules(nil)
+	if err != nil || rules != defaultLintRules() {
+		t.Errorf("empty lint.yaml = %+v, %v; want the defaults", rules, err)
+	}
+}
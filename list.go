@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// matchingMovos applies the static selection filters (category, tags, RPE, duration
+// and subset) without today's limits or priorities, sorted by code
+func matchingMovos(snacks []Movo, filters FilterOptions, movosDir string) ([]Movo, error) {
+	matches := filterSnacks(snacks, filters)
+	if filters.Subset != "" && len(matches) > 0 {
+		var err error
+		matches, err = filterBySubset(matches, filters.Subset, movosDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].FullCode < matches[j].FullCode })
+	return matches, nil
+}
+
+// writeMovoList prints one row per movo with its code, RPE, duration range and tags
+func writeMovoList(w io.Writer, movos []Movo) {
+	width := len("CODE")
+	for _, movo := range movos {
+		width = max(width, len(movo.FullCode))
+	}
+
+	fmt.Fprintf(w, "%-*s  %3s  %7s  %s\n", width, tr("CODE"), "RPE", tr("MINUTES"), tr("TAGS"))
+	for _, movo := range movos {
+		fmt.Fprintf(w, "%-*s  %3d  %7s  %s\n", width, movo.FullCode, movo.EffectiveRPE,
+			fmt.Sprintf("%d-%d", movo.DurationMin, movo.DurationMax), strings.Join(movo.AllTags, ", "))
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchingMovos(t *testing.T) {
+	dir := t.TempDir()
+	subsets := "subsets:\n  desk:\n    codes:\n      - TB-box\n      - TS-pushups\n"
+	if err := os.WriteFile(filepath.Join(dir, "subsets.yaml"), []byte(subsets), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	movos := []Movo{
+		{FullCode: "TS-pushups", CategoryCode: "TS", EffectiveRPE: 7, DurationMin: 3, DurationMax: 5, AllTags: []string{"strengthx"}},
+		{FullCode: "TB-box", CategoryCode: "TB", EffectiveRPE: 1, DurationMin: 3, DurationMax: 5, AllTags: []string{"breathx"}},
+		{FullCode: "TB-deep", CategoryCode: "TB", EffectiveRPE: 1, DurationMin: 2, DurationMax: 4, AllTags: []string{"breathx"}},
+		{FullCode: "TS-lunges", CategoryCode: "TS", EffectiveRPE: 6, DurationMin: 8, DurationMax: 10, AllTags: []string{"strengthx"}},
+	}
+
+	codes := func(filters FilterOptions) string {
+		matches, err := matchingMovos(movos, filters, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, m := range matches {
+			out = append(out, m.FullCode)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := codes(FilterOptions{}); got != "TB-box,TB-deep,TS-lunges,TS-pushups" {
+		t.Errorf("no filters: got %s", got)
+	}
+	if got := codes(FilterOptions{MaxRPE: 2, Tags: []string{"breathx"}}); got != "TB-box,TB-deep" {
+		t.Errorf("RPE and tags: got %s", got)
+	}
+	if got := codes(FilterOptions{Categories: []string{"TS"}, MaxDuration: 5}); got != "TS-pushups" {
+		t.Errorf("category and duration: got %s", got)
+	}
+	if got := codes(FilterOptions{Subset: "desk"}); got != "TB-box,TS-pushups" {
+		t.Errorf("subset: got %s", got)
+	}
+}
+
+func TestWriteMovoList(t *testing.T) {
+	var buf bytes.Buffer
+	writeMovoList(&buf, []Movo{
+		{FullCode: "TB-box-breathing", EffectiveRPE: 1, DurationMin: 3, DurationMax: 5, AllTags: []string{"breathx", "recovx"}},
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and one row, got %q", buf.String())
+	}
+	if lines[1] != "TB-box-breathing    1      3-5  breathx, recovx" {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+}
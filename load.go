@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// extraLoad is the log extras key holding the load used, in kg
+const extraLoad = "load_kg"
+
+// Equipment and tags that mark a movo as weighted, so done asks for the load
+var (
+	loadEquipment = map[string]bool{"kettlebell": true, "dumbbell": true, "barbell": true, "clubs": true, "mace": true, "sandbag": true}
+	loadTags      = map[string]bool{"kbx": true, "clubsx": true}
+)
+
+// tracksLoad reports whether the movo uses weights worth recording the load of
+func (s *Movo) tracksLoad() bool {
+	for _, item := range s.Equipment {
+		if loadEquipment[normalizeEquipment(item)] {
+			return true
+		}
+	}
+	for _, tag := range s.AllTags {
+		if loadTags[strings.ToLower(tag)] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLoad reads a load in kg, e.g. 24 or 12.5
+func parseLoad(s string) (float64, error) {
+	load, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "kg"), 64)
+	if err != nil || load <= 0 {
+		return 0, fmt.Errorf("invalid load %q (kg, e.g. 24)", s)
+	}
+	return load, nil
+}
+
+// recordLoad stores the load used in the entry's extras (0 for none)
+func recordLoad(entry *HistoryEntry, load float64) {
+	if load <= 0 {
+		return
+	}
+	if entry.Extras == nil {
+		entry.Extras = make(map[string]string)
+	}
+	entry.Extras[extraLoad] = strconv.FormatFloat(load, 'f', -1, 64)
+}
+
+// entryLoad returns the load logged with an entry (0 if none)
+func entryLoad(entry HistoryEntry) float64 {
+	load, err := strconv.ParseFloat(entry.Extras[extraLoad], 64)
+	if err != nil || load <= 0 {
+		return 0
+	}
+	return load
+}
+
+// loadHistory returns a movo's completions that logged a load, oldest first
+func loadHistory(entries []HistoryEntry, code string) []HistoryEntry {
+	var history []HistoryEntry
+	for _, entry := range entries {
+		if entry.Code == code && entry.Status != "skip" && entryLoad(entry) > 0 {
+			history = append(history, entry)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Timestamp.Before(history[j].Timestamp) })
+	return history
+}
+
+// formatLoad prints a load without trailing zeros, e.g. "24 kg" or "12.5 kg"
+func formatLoad(load float64) string {
+	return strconv.FormatFloat(load, 'f', -1, 64) + " kg"
+}
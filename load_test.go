@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracksLoad(t *testing.T) {
+	tests := []struct {
+		movo Movo
+		want bool
+	}{
+		{Movo{Equipment: []string{"kb"}}, true},
+		{Movo{Equipment: []string{"band"}}, false},
+		{Movo{AllTags: []string{"strengthx", "kbx"}}, true},
+		{Movo{AllTags: []string{"bodyx"}}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.movo.tracksLoad(); got != tt.want {
+			t.Errorf("tracksLoad(%+v) = %v, want %v", tt.movo, got, tt.want)
+		}
+	}
+}
+
+func TestParseLoad(t *testing.T) {
+	for input, want := range map[string]float64{"24": 24, "12.5": 12.5, "16kg": 16, " 32 ": 32} {
+		if got, err := parseLoad(input); err != nil || got != want {
+			t.Errorf("parseLoad(%q) = %g, %v; want %g", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "heavy", "0", "-8"} {
+		if _, err := parseLoad(input); err == nil {
+			t.Errorf("parseLoad(%q): expected an error", input)
+		}
+	}
+}
+
+func TestLoadHistory(t *testing.T) {
+	day := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	newEntry := func(days int, code, status string, load float64) HistoryEntry {
+		entry := HistoryEntry{Timestamp: day.AddDate(0, 0, days), Code: code, Status: status, Duration: 5, RPE: 6}
+		recordLoad(&entry, load)
+		return entry
+	}
+	entries := []HistoryEntry{
+		newEntry(2, "KB-swings", "done", 24),
+		newEntry(0, "KB-swings", "done", 16),
+		newEntry(1, "KB-swings", "done", 0),
+		newEntry(1, "KB-press", "done", 12),
+		newEntry(3, "KB-swings", "skip", 32),
+	}
+
+	history := loadHistory(entries, "KB-swings")
+	if len(history) != 2 || entryLoad(history[0]) != 16 || entryLoad(history[1]) != 24 {
+		t.Fatalf("loadHistory = %+v, want the 16 and 24 kg completions in order", history)
+	}
+	if details := entryDetails(history[1]); details != ", 24 kg" {
+		t.Errorf("entryDetails = %q", details)
+	}
+}
@@ -1,19 +1,42 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// LoadSnacks loads all snack definitions from YAML files in the movos directory
+// LoadSnacks loads all snack definitions from YAML files in the movos directory,
+// with the local overrides.yaml merged over them
 func LoadSnacks() ([]Movo, error) {
 	cfg := DefaultConfig()
-	movosDir := cfg.MovosDir
+	movos, err := loadMovosDir(cfg.MovosDir)
+	if err != nil {
+		return nil, err
+	}
+	if movos, err = checkDuplicateCodes(movos, cfg.DuplicateCodes, os.Stderr); err != nil {
+		return nil, err
+	}
+	overrides, err := loadOverrides(cfg.OverridesPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyOverrides(movos, overrides); err != nil {
+		return nil, err
+	}
+	translateMovos(movos, cfg.Language)
+	return movos, nil
+}
 
+// loadMovosDir loads all movo definitions from the YAML files in movosDir
+func loadMovosDir(movosDir string) ([]Movo, error) {
 	// Check if movos directory exists
 	if _, err := os.Stat(movosDir); os.IsNotExist(err) {
 		return nil, fmt.Errorf("movos directory not found: %s", movosDir)
@@ -31,22 +54,27 @@ func LoadSnacks() ([]Movo, error) {
 
 	var allMovos []Movo
 
-	// Load each file
+	// Load each category file (subsets, programs and lint rules live alongside them)
 	for _, file := range files {
+		if !isCategoryFile(file) {
+			continue
+		}
 		category, err := loadCategory(file)
 		if err != nil {
-			return nil, fmt.Errorf("error loading %s: %w", file, err)
+			return nil, err
 		}
 
 		// Process snacks in this category
 		for i := range category.Movos {
 			snack := &category.Movos[i]
 
-			// Set category code
+			// Set category code and name
 			snack.CategoryCode = category.Code
+			snack.CategoryName = category.Category
 
 			// Set full code
 			snack.FullCode = fmt.Sprintf("%s-%s", category.Code, snack.Code)
+			snack.SourceFile = file
 
 			// Combine tags (category tags + snack tags)
 			snack.AllTags = append([]string{}, category.Tags...)
@@ -59,6 +87,11 @@ func LoadSnacks() ([]Movo, error) {
 				snack.EffectiveRPE = category.DefaultRPE
 			}
 
+			// Category-level recovery_safe protects every snack in it
+			if category.RecoverySafe {
+				snack.RecoverySafe = true
+			}
+
 			// Apply category weight if snack weight is 1.0 (i.e., not customized)
 			if snack.Weight == 1.0 && category.Weight != 1.0 {
 				snack.Weight = category.Weight
@@ -71,33 +104,133 @@ func LoadSnacks() ([]Movo, error) {
 	return allMovos, nil
 }
 
-func loadCategory(filepath string) (*Category, error) {
-	data, err := os.ReadFile(filepath)
+// isCategoryFile reports whether a YAML file in the movos directory defines a category
+func isCategoryFile(path string) bool {
+	switch filepath.Base(path) {
+	case "subsets.yaml", "programs.yaml", lintRulesFile:
+		return false
+	}
+	return true
+}
+
+// loadCategory reads a category file strictly: a misspelt or mistyped field is an
+// error giving the file, line and movo instead of being silently ignored
+func loadCategory(path string) (*Category, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return decodeCategory(path, data)
+}
+
+// unknownFieldError matches yaml.v3's message for a key the target struct doesn't have
+var unknownFieldError = regexp.MustCompile(`^line (\d+): field (\S+) not found in type main\.(\w+)$`)
 
+// decodeCategory decodes category YAML read from name, rejecting unknown fields.
+// Each problem becomes a "name:line: CODE: message" line.
+func decodeCategory(name string, data []byte) (*Category, error) {
 	var category Category
-	if err := yaml.Unmarshal(data, &category); err != nil {
-		return nil, err
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	err := dec.Decode(&category)
+	if err == nil || err == io.EOF {
+		return &category, nil
+	}
+
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		line := yamlErrorLine(err)
+		msg := strings.TrimPrefix(strings.TrimPrefix(err.Error(), "yaml: "), fmt.Sprintf("line %d: ", line))
+		return nil, fmt.Errorf("%s:%d: %s", name, line, msg)
+	}
+
+	// The document parsed, so its nodes say which movo each line belongs to
+	var doc yaml.Node
+	yaml.Unmarshal(data, &doc)
+	var problems []string
+	for _, msg := range typeErr.Errors {
+		line := yamlErrorLine(errors.New(msg))
+		problem := strings.TrimPrefix(msg, fmt.Sprintf("line %d: ", line))
+		if m := unknownFieldError.FindStringSubmatch(msg); m != nil {
+			problem = fmt.Sprintf("unknown field %q", m[2])
+			known := map[string]map[string]bool{"Category": categoryKeys, "Movo": movoKeys}[m[3]]
+			if suggestion := closestKey(m[2], known); suggestion != "" {
+				problem += fmt.Sprintf(" (did you mean %s?)", suggestion)
+			}
+		}
+		problems = append(problems, fmt.Sprintf("%s:%d: %s%s", name, line, movoAtLine(&doc, line), problem))
 	}
+	return nil, errors.New(strings.Join(problems, "\n"))
+}
+
+// movoAtLine names the movo whose definition contains line as "CODE: " ("" outside the movos list)
+func movoAtLine(doc *yaml.Node, line int) string {
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return ""
+	}
+	root := doc.Content[0]
+	movos := mappingValue(root, "movos")
+	if movos == nil || movos.Kind != yaml.SequenceNode {
+		return ""
+	}
+	for i, item := range movos.Content {
+		if line < item.Line || (i+1 < len(movos.Content) && line >= movos.Content[i+1].Line) {
+			continue
+		}
+		code := "movo"
+		if node := mappingValue(item, "code"); node != nil && node.Value != "" {
+			code = node.Value
+			if cat := mappingValue(root, "code"); cat != nil && cat.Value != "" {
+				code = cat.Value + "-" + code
+			}
+		}
+		return code + ": "
+	}
+	return ""
+}
 
-	return &category, nil
+// closestKey suggests the known key a misspelt one was probably meant to be:
+// the nearest within two edits, or "" if none is that close
+func closestKey(key string, known map[string]bool) string {
+	best, bestDist := "", 3
+	for candidate := range known {
+		if d := editDistance(key, candidate); d < bestDist || (d == bestDist && candidate < best) {
+			best, bestDist = candidate, d
+		}
+	}
+	return best
 }
 
-// HasAllTags checks if snack has all specified tags
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// HasAllTags checks if snack has all specified tags. A tag written "a|b" is satisfied
+// by either alternative.
 func (s *Movo) HasAllTags(tags []string) bool {
 	if len(tags) == 0 {
 		return true
 	}
 
-	snackTagSet := make(map[string]bool)
-	for _, tag := range s.AllTags {
-		snackTagSet[strings.ToLower(tag)] = true
-	}
-
 	for _, requiredTag := range tags {
-		if !snackTagSet[strings.ToLower(requiredTag)] {
+		if !s.HasAnyTag(strings.Split(requiredTag, "|")) {
 			return false
 		}
 	}
@@ -105,6 +238,19 @@ func (s *Movo) HasAllTags(tags []string) bool {
 	return true
 }
 
+// HasAnyTag checks if snack has at least one of the specified tags
+func (s *Movo) HasAnyTag(tags []string) bool {
+	for _, tag := range s.AllTags {
+		for _, wanted := range tags {
+			if strings.EqualFold(tag, strings.TrimSpace(wanted)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // MatchesDuration checks if snack duration overlaps with filter
 func (s *Movo) MatchesDuration(minDur, maxDur int) bool {
 	if minDur == 0 && maxDur == 0 {
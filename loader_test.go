@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadCategoryRejectsUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "breathing.yaml")
+	yaml := `category: Breathing
+code: BR
+movos:
+  - code: box
+    title: Box breathing
+    duration_min: 3
+    duration_max: 5
+  - code: sigh
+    title: Physiological sigh
+    duration_mins: 1
+    duration_max: three
+`
+	if err := os.WriteFile(file, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := loadCategory(file)
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	want := []string{
+		file + `:10: BR-sigh: unknown field "duration_mins" (did you mean duration_min?)`,
+		file + ":11: BR-sigh: cannot unmarshal !!str `three` into int",
+	}
+	if got := strings.Split(err.Error(), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("error =\n%s\nwant\n%s", err, strings.Join(want, "\n"))
+	}
+}
+
+func TestLoadCategorySyntaxError(t *testing.T) {
+	_, err := decodeCategory("broken.yaml", []byte("category: Breathing\nmovos: [\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "broken.yaml:") {
+		t.Errorf("expected an error starting with the file name, got %v", err)
+	}
+}
+
+func TestLoadMovosDirSkipsSubsetsAndPrograms(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"breathing.yaml": "category: Breathing\ncode: BR\nmovos:\n  - code: box\n    title: Box\n    duration_min: 3\n    duration_max: 5\n",
+		"subsets.yaml":   "subsets:\n  calm:\n    description: Calm\n    include_tags: [breathx]\n",
+		"programs.yaml":  "programs: {}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	movos, err := loadMovosDir(dir)
+	if err != nil {
+		t.Fatalf("loadMovosDir() error = %v", err)
+	}
+	if len(movos) != 1 {
+		t.Errorf("expected 1 movo, got %d", len(movos))
+	}
+}
+
+func TestClosestKey(t *testing.T) {
+	tests := map[string]string{
+		"duration_mins": "duration_min",
+		"titel":         "title",
+		"rpee":          "rpe",
+		"colour":        "",
+	}
+	for key, want := range tests {
+		if got := closestKey(key, movoKeys); got != want {
+			t.Errorf("closestKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
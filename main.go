@@ -3,11 +3,57 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 )
 
 const version = "1.0.0"
 
 func main() {
+	args, profile, err := extractProfileFlag(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	args, seedArg, err := extractGlobalFlag(args, "seed")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	args, outputJSON, err = extractOutputFormat(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error: %v\n"), err)
+		os.Exit(exitError)
+	}
+	args, plainOutput = extractPlainFlag(args)
+	args, quietOutput = extractQuietFlag(args)
+	if profile != "" {
+		os.Setenv("MOVODORO_PROFILE", profile)
+		appConfig = DefaultConfig()
+	}
+	os.Args = append(os.Args[:1], args...)
+
+	if appConfig.ConfigErr != nil {
+		fmt.Fprintf(os.Stderr, tr("Warning: %v\n"), appConfig.ConfigErr)
+	}
+	setLocale(appConfig.Language)
+
+	// A fixed seed (--seed beats config.yaml) makes selection reproducible
+	seed := appConfig.Seed
+	if seedArg != "" {
+		seed, err = strconv.ParseUint(seedArg, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, tr("Error: invalid --seed %q\n"), seedArg)
+			os.Exit(exitError)
+		}
+	}
+	if seed != 0 {
+		seedSelector(seed)
+	}
+
+	// Deliver entries queued while the logs dir was unavailable
+	deliverSpool()
+
 	// If no command provided (or starts with --), enter interactive mode
 	if len(os.Args) < 2 || (len(os.Args) >= 2 && os.Args[1][:1] == "-") {
 		handleInteractive(os.Args[1:])
@@ -19,10 +65,26 @@ func main() {
 	switch command {
 	case "get":
 		handleGet(os.Args[2:])
+	case "why":
+		handleGet(append([]string{"--explain"}, os.Args[2:]...))
+	case "list":
+		handleList(os.Args[2:])
+	case "search":
+		handleSearch(os.Args[2:])
 	case "done":
 		handleDone(os.Args[2:])
 	case "skip":
 		handleSkip(os.Args[2:])
+	case "program":
+		handleProgram(os.Args[2:])
+	case "rest":
+		handleRest(os.Args[2:])
+	case "checkin":
+		handleCheckin(os.Args[2:])
+	case "stats":
+		handleStats(os.Args[2:])
+	case "snooze":
+		handleSnooze(os.Args[2:])
 	case "report":
 		handleReport(os.Args[2:])
 	case "clear":
@@ -31,8 +93,56 @@ func main() {
 		handleConfig(os.Args[2:])
 	case "everyday":
 		handleEveryday(os.Args[2:])
+	case "weekly":
+		handleWeekly(os.Args[2:])
+	case "queue":
+		handleQueue(os.Args[2:])
+	case "fav":
+		handleFav(os.Args[2:])
+	case "ban":
+		handleBan(os.Args[2:])
+	case "unban":
+		handleUnban(os.Args[2:])
+	case "packs":
+		handlePacks(os.Args[2:])
+	case "session":
+		handleSession(os.Args[2:])
 	case "subsets":
 		handleSubsets(os.Args[2:])
+	case "movos":
+		handleMovos(os.Args[2:])
+	case "batch":
+		handleBatch(os.Args[2:])
+	case "rate":
+		handleRate(os.Args[2:])
+	case "eod":
+		handleEOD(os.Args[2:])
+	case "watch":
+		handleWatch(os.Args[2:])
+	case "serve":
+		handleServe(os.Args[2:])
+	case "sync":
+		handleSync(os.Args[2:])
+	case "validate":
+		handleValidate(os.Args[2:])
+	case "lint":
+		handleLint(os.Args[2:])
+	case "status":
+		handleStatus(os.Args[2:])
+	case "qr":
+		handleQR(os.Args[2:])
+	case "say":
+		handleSay(os.Args[2:])
+	case "sandbox":
+		handleSandbox(os.Args[2:])
+	case "analyze-weights":
+		handleAnalyzeWeights(os.Args[2:])
+	case "heatmap":
+		handleHeatmap(os.Args[2:])
+	case "archive":
+		handleArchive(os.Args[2:])
+	case "verify-history":
+		handleVerifyHistory(os.Args[2:])
 	case "migrate-logs-to-csv":
 		handleMigrateLogsToCsv(os.Args[2:])
 	case "version", "--version", "-v":
@@ -40,14 +150,41 @@ func main() {
 	case "help", "--help", "-h":
 		printUsage()
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
+		fmt.Fprintf(os.Stderr, tr("Unknown command: %s\n\n"), command)
 		printUsage()
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+}
+
+// extractProfileFlag removes a global --config-profile NAME (or --config-profile=NAME) from args
+func extractProfileFlag(args []string) ([]string, string, error) {
+	return extractGlobalFlag(args, "config-profile")
+}
+
+// extractGlobalFlag removes --name VALUE (or --name=VALUE) from anywhere in args
+func extractGlobalFlag(args []string, name string) ([]string, string, error) {
+	var rest []string
+	value := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--"+name || arg == "-"+name:
+			if i+1 >= len(args) {
+				return nil, "", fmt.Errorf("--%s needs a value", name)
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--"+name+"="):
+			value = strings.TrimPrefix(arg, "--"+name+"=")
+		default:
+			rest = append(rest, arg)
+		}
 	}
+	return rest, value, nil
 }
 
 func printUsage() {
-	fmt.Print(`movodoro - Movement snack generator
+	fmt.Print(localizeUsage(`movodoro - Movement snack generator
 
 USAGE:
     movodoro [options]         # Interactive mode (default)
@@ -55,33 +192,127 @@ USAGE:
 
 COMMANDS:
     get                 Get a random movement snack
-    done [CODE]         Mark the current/specified snack as completed
+                        (--timer counts down its duration, then logs it;
+                        --explain shows the candidates and their weights instead;
+                        --pair adds a complementary movo and queues it;
+                        -n 3 offers three to choose from, --pick K takes one)
+    why                 Same as get --explain
+    list                List every movo matching the get filters
+    session             Plan movos for --minutes (default 30), then do them one by one
+    search QUERY        Find movos by title, tags, code or description
+    done [CODE...]      Mark the current/specified snack as completed
+                        (--partial logs a partial completion; several codes, --batch
+                        reading codes from stdin, or -d/-r log without prompts;
+                        --sets 3x10 records sets×reps, --load 24 the kg used,
+                        --distance 2.5km|800m|4000steps the distance covered;
+                        --date yesterday|YYYY-MM-DD and --time HH:MM backdate it)
     skip [CODE]         Skip the current/specified snack
-    report [period]     Show report (day, week, month)
+    snooze [DURATION]   Put off the current snack without logging it (default 30m)
+    queue add CODE...   Line up movos for later today; get takes them first
+                        (queue list, queue next, queue clear)
+    fav add CODE...     Mark favorites locally; they're weighted favorite_boost (default 2x)
+                        (fav list, fav remove CODE...)
+    ban CODE            Keep a movo out of selection locally; --until YYYY-MM-DD ends it
+                        (ban list, unban CODE...)
+    packs install NAME  Add a themed movo pack from the registry, a URL or a .yaml file
+                        (packs list, packs available, packs remove NAME)
+    program [status]    Show where you are in the active program (programs.yaml)
+    rest [DAY]          Make today (or tomorrow, YYYY-MM-DD) a rest day; --cancel undoes it
+    checkin --energy N  Log your energy 1-5; for 4 hours selection leans easier or harder
+    stats CODE          Show how often a movo was done and the loads used over time
+    report [period]     Show report (day, week, month, trend, compare, skips, profiles, stickers)
+                        (--by-tag totals movos, minutes and RPE per tag instead)
+    heatmap             Calendar of the year's activity (--year YYYY, --by minutes|movos)
     clear               Clear today's history (requires confirmation)
     config              Show current configuration
     everyday            Show "every day" snacks and completion status
+    weekly              Show min/max_per_week snacks and this week's progress
     subsets             List available subsets from subsets.yaml
+    validate            Check movo YAML files, subsets.yaml and programs.yaml for mistakes
+    lint                Check movos against the style rules in lint.yaml (tags, weights, ...)
+    movos set-field     Bulk edit movo YAML fields (see MOVOS OPTIONS)
+    batch - | FILE      Run get/done/skip commands from stdin or a file
+    rate [CODE RATING]  Rate a movo 1-5 (no args: list average ratings)
+    eod                 Write today's markdown summary to eod_dir (for cron)
+    watch               Send a movo suggestion notification every interval
+                        (--every 50m; --interactive starts a session on Enter)
+    serve               Serve get/done/skip/report/everyday over HTTP as JSON
+                        (--port 8080, --host, --token; see README)
+    sync                Commit, pull and push the logs dir with a git remote (see README)
+                        (--conflict append|last-write-wins for daily logs changed on both)
+    sync strava         Upload completed movos to Strava (see README)
+                        (--since YYYY-MM-DD, default a week ago; --dry-run)
+    status              Show the current movo and today's totals
+                        (--xbar for an xbar/SwiftBar menu bar plugin)
+    say "SENTENCE"      Log a movo from a spoken sentence (for Siri/Assistant)
+                        e.g. say "did box breathing five minutes easy"
+    qr CODE             Show a QR code that logs CODE when scanned
+                        (--png FILE saves it, --command encodes the CLI command)
+    analyze-weights     Simulate selections to check no movo's probability collapsed
+    verify-history      Check daily logs against recorded checksums (--init to enable)
+    archive             Move daily logs before --before YYYY-MM-DD into monthly files
     migrate-logs-to-csv Migrate old log files to v1.0.0 CSV format
+                        (--dry-run shows a diff, --only FILE migrates one file)
+    sandbox             Try movodoro in a throwaway home with demo movos and history
+                        (--keep leaves it in place, --days N sets the history length)
     version             Show version information
     help                Show this help message
 
+GLOBAL OPTIONS:
+    --config-profile NAME  Use a named profile from config.yaml (shares history)
+    --seed N               Seed selection for reproducible picks (also: seed in config.yaml)
+    --format json, --json  JSON output from get, list, search, session, queue, fav, ban list, stats,
+                           status, program, packs list, report, heatmap, everyday, weekly, subsets,
+                           config and analyze-weights
+    --plain                Print descriptions as written, without formatting or colour
+    --quiet, --porcelain   Script-friendly output: get prints the full code, done and skip nothing
+
 INTERACTIVE MODE OPTIONS:
     --subset NAME       Use a named subset from subsets.yaml
 
 REPORT OPTIONS:
     --markdown, --md    Output report in markdown format
     -v, --verbose       Show titles and tags
+    --copy              Copy the markdown report to the clipboard
+    --post SERVICE      Post today's report to a slack or discord webhook from config.yaml
+    --group-by KEY      Group completed movos by category, session or hour
+    --tz ZONE           Show times in ZONE (e.g. local, Asia/Tokyo) instead of as logged
+    --all-profiles      Combine today's report for every config profile (household)
+
+BATCH COMMANDS (one per line, # for comments):
+    get [GET OPTIONS]                 Select a movo (becomes current)
+    done [CODE] [-d MINS] [-r RPE] [--partial]
+                                      Log completion without prompts
+    skip [CODE]                       Log a skip
+
+MOVOS OPTIONS:
+    movos set-field --filter tag=kbx [--dry-run] weight=1.3 ...
+    --filter F          Select movos by tag=, category= or code= (repeatable)
+    --dry-run           Print a unified diff instead of writing files
+
+EOD OPTIONS:
+    --dir DIR           Directory for the summary (default: eod_dir from config)
+    --notify            Send a desktop notification (default: eod_notify)
+    --email ADDRESS     Also mail the summary via sendmail (default: eod_email)
 
 GET OPTIONS:
-    -c, --category CODE       Filter by category code (e.g., RB, CF, TS)
-    -t, --tags TAGS           Filter by tags (comma-separated)
+    -c, --category CODES      Filter by category codes (e.g., RB or RB,CF)
+    --exclude-category CODES  Leave out category codes (e.g., TS)
+    --target REGIONS          Filter by body regions worked (e.g., hips,t-spine)
+    -t, --tags TAGS           Filter by tags (comma-separated; 'a|b' matches either)
+    --any-tags TAGS           Filter by any of these tags (comma-separated)
     -d, --duration MINS       Exact duration in minutes
     -m, --min-duration MINS   Minimum duration
     -M, --max-duration MINS   Maximum duration
     -r, --min-rpe RPE         Minimum RPE (for intense work)
     -R, --max-rpe RPE         Maximum RPE (for recovery)
     --subset NAME             Use a named subset from subsets.yaml
+    --equipment LIST          Only movos needing no more than this (e.g., kb,band; 'any')
+    --no-equipment            Only movos that need no equipment
+    --ignore-time-windows     Also select movos outside their time_window
+    --rotate                  Pick from the next category in rotation
+    --fit MINS                Prefer movos that fill these minutes (session: pack several)
+    --variety LEVEL           low favors high-weight movos, high spreads picks evenly
 
 SUBSETS:
     Subsets allow you to restrict movement selection to a specific collection
@@ -102,10 +333,13 @@ EXAMPLES:
     movodoro get -c RB                    # Get from Reset & Breath category
     movodoro get -t kbx,swingx            # Kettlebell swings
     movodoro get -R 2                     # Very light recovery snacks
+    movodoro get --no-equipment           # Bodyweight only (e.g. travelling)
     movodoro done                         # Mark current snack completed
     movodoro report --md -v               # Verbose markdown report
+    movodoro report --copy -v             # Copy verbose markdown to clipboard
+    movodoro report --group-by category   # Completed movos grouped by category
     movodoro subsets                      # List available subsets
-`)
+`))
 }
 
 // Command handlers are implemented in commands.go
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isCSVLog reports whether a log file already uses the v1.0.0 CSV format (has the header)
+func isCSVLog(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("timestamp,"))
+}
+
+// convertLegacyLog converts an old space-separated log (TIMESTAMP CODE STATUS DURATION RPE)
+// to CSV. Returns the CSV content, the number of entries converted and the number of
+// non-empty lines that couldn't be parsed (and are dropped).
+func convertLegacyLog(data []byte) (out []byte, converted int, dropped int, err error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	// Migrated files keep the six-column v1.0.0 header
+	if err := writer.Write([]string{"timestamp", "code", "status", "duration", "rpe", "subset"}); err != nil {
+		return nil, 0, 0, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		entry, ok := parseLegacyLine(line)
+		if !ok {
+			dropped++
+			continue
+		}
+
+		record := []string{
+			entry.Timestamp.Format(time.RFC3339),
+			entry.Code,
+			entry.Status,
+			strconv.Itoa(entry.Duration),
+			strconv.Itoa(entry.RPE),
+			entry.Subset,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, 0, 0, err
+		}
+		converted++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, 0, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), converted, dropped, nil
+}
+
+// parseLegacyLine parses one line of the old space-separated format
+func parseLegacyLine(line string) (HistoryEntry, bool) {
+	parts := strings.Fields(line)
+	if len(parts) != 5 {
+		return HistoryEntry{}, false
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, parts[0])
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+
+	duration, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+
+	rpe, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+
+	return HistoryEntry{
+		Timestamp: timestamp,
+		Code:      parts[1],
+		Status:    parts[2],
+		Duration:  duration,
+		RPE:       rpe,
+		Subset:    "", // Old logs don't have subset info
+	}, true
+}
@@ -276,6 +276,39 @@ another bad line
 	}
 }
 
+func TestConvertLegacyLog(t *testing.T) {
+	content := `2025-10-15T10:00:00Z TB-box-breath done 4 1
+malformed line without enough fields
+
+2025-10-15T12:00:00Z TS-heavy-lift skip 0 0
+`
+
+	out, converted, dropped, err := convertLegacyLog([]byte(content))
+	if err != nil {
+		t.Fatalf("convertLegacyLog: %v", err)
+	}
+	if converted != 2 || dropped != 1 {
+		t.Errorf("expected 2 converted and 1 dropped, got %d and %d", converted, dropped)
+	}
+
+	want := `timestamp,code,status,duration,rpe,subset
+2025-10-15T10:00:00Z,TB-box-breath,done,4,1,
+2025-10-15T12:00:00Z,TS-heavy-lift,skip,0,0,
+`
+	if string(out) != want {
+		t.Errorf("unexpected CSV:\n%s", out)
+	}
+	if !isCSVLog(out) || isCSVLog([]byte(content)) {
+		t.Error("isCSVLog should detect the header")
+	}
+
+	// The dry-run preview is a diff of the two
+	diff := unifiedDiff("20251015.log", "20251015.csv", content, string(out))
+	if !strings.Contains(diff, "-malformed line without enough fields") || !strings.Contains(diff, "+timestamp,code,status") {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+}
+
 // Helper function to load CSV log for testing
 func loadCSVLog(path string) ([]HistoryEntry, error) {
 	file, err := os.Open(path)
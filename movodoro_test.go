@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -30,6 +31,11 @@ func TestParseCSVRecord(t *testing.T) {
 			record:  []string{"bad-timestamp", "GUP-naked-getups", "done", "4", "3", ""},
 			wantErr: true,
 		},
+		{
+			name:    "valid record with extras",
+			record:  []string{"2025-10-12T14:09:37+01:00", "GUP-naked-getups", "done", "4", "3", "", "profile=experiment"},
+			wantErr: false,
+		},
 		{
 			name:    "wrong number of fields",
 			record:  []string{"2025-10-12T14:09:37+01:00", "GUP-naked-getups", "done"},
@@ -92,6 +98,9 @@ func TestSnackHasAllTags(t *testing.T) {
 		{"non-matching tag", []string{"strengthx"}, false},
 		{"mixed matching and non-matching", []string{"breathx", "strengthx"}, false},
 		{"case insensitive", []string{"BREATHX"}, true},
+		{"either alternative", []string{"strengthx|mobilityx"}, true},
+		{"neither alternative", []string{"strengthx|kbx"}, false},
+		{"alternative and tag", []string{"strengthx|breathx", "kbx"}, false},
 	}
 
 	for _, tt := range tests {
@@ -275,6 +284,47 @@ func TestHistoryReadWrite(t *testing.T) {
 	}
 }
 
+func TestHistoryExtrasMixedColumns(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := TestConfig(tmpDir)
+	if err := os.MkdirAll(cfg.LogsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// An existing file with the older six-column header
+	content := "timestamp,code,status,duration,rpe,subset\n" +
+		time.Now().Format(time.RFC3339) + ",TS-pushups,done,5,4,\n"
+	if err := os.WriteFile(GetTodayLogPath(cfg.LogsDir), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      "TB-box-breath",
+		Status:    "done",
+		Duration:  4,
+		RPE:       1,
+		Extras:    map[string]string{"profile": "experiment & co"},
+	}
+	if err := AppendTodayLog(cfg.LogsDir, entry); err != nil {
+		t.Fatalf("failed to append: %v", err)
+	}
+
+	loaded, err := LoadDailyLog(cfg.LogsDir, time.Now())
+	if err != nil {
+		t.Fatalf("failed to load mixed log: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(loaded))
+	}
+	if loaded[0].Extras != nil {
+		t.Errorf("expected no extras on the old row, got %v", loaded[0].Extras)
+	}
+	if got := loaded[1].Extras["profile"]; got != "experiment & co" {
+		t.Errorf("expected profile extra to round trip, got %q", got)
+	}
+}
+
 func TestGetTodayStatsWithHistory(t *testing.T) {
 	tmpDir := t.TempDir()
 	cfg := TestConfig(tmpDir)
@@ -336,6 +386,45 @@ func TestGetTodayStatsWithHistory(t *testing.T) {
 	}
 }
 
+func TestGetTodayStatsWithPartial(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := TestConfig(tmpDir)
+
+	entries := []HistoryEntry{
+		{Timestamp: time.Now(), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 7},
+		{Timestamp: time.Now(), Code: "TS-pushups", Status: "partial", Duration: 2, RPE: 4},
+	}
+	for _, entry := range entries {
+		if err := AppendTodayLog(cfg.LogsDir, entry); err != nil {
+			t.Fatalf("failed to append history: %v", err)
+		}
+	}
+
+	stats, err := GetTodayStatsDaily(cfg.LogsDir)
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if len(stats.CompletedSnacks) != 1 {
+		t.Errorf("expected 1 completed snack, got %d", len(stats.CompletedSnacks))
+	}
+	if len(stats.PartialSnacks) != 1 {
+		t.Errorf("expected 1 partial snack, got %d", len(stats.PartialSnacks))
+	}
+	if stats.TotalDuration != 7 {
+		t.Errorf("expected total duration 7 (partial minutes count), got %d", stats.TotalDuration)
+	}
+
+	// Partials don't count toward max_per_day/min_per_day
+	count, _, err := GetCountTodayDaily(cfg.LogsDir, "TS-pushups")
+	if err != nil {
+		t.Fatalf("failed to count: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected count 1 (partial excluded), got %d", count)
+	}
+}
+
 func TestFilterSnacksByTags(t *testing.T) {
 	cfg := &Config{
 		MovosDir: "testdata/movos",
@@ -624,3 +713,121 @@ func TestEverydaySnacksPriority(t *testing.T) {
 		// Selection can be anything now
 	})
 }
+
+func TestFilterByPrerequisites(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "MOB-hips"},
+		{FullCode: "KB-carries", RequiresDoneToday: []string{"MOB-hips"}},
+		{FullCode: "KB-complex", RequiresDoneToday: []string{"MOB-hips", "BR-box"}},
+	}
+
+	t.Run("nothing done today", func(t *testing.T) {
+		filtered := filterByPrerequisites(snacks, DailyStats{})
+		if len(filtered) != 1 || filtered[0].FullCode != "MOB-hips" {
+			t.Errorf("expected only MOB-hips, got %v", filtered)
+		}
+	})
+
+	t.Run("one prerequisite done", func(t *testing.T) {
+		stats := DailyStats{CompletedSnacks: []HistoryEntry{{Code: "MOB-hips", Status: "done"}}}
+		filtered := filterByPrerequisites(snacks, stats)
+		if len(filtered) != 2 {
+			t.Errorf("expected MOB-hips and KB-carries, got %v", filtered)
+		}
+	})
+
+	t.Run("skips don't satisfy prerequisites", func(t *testing.T) {
+		stats := DailyStats{SkippedSnacks: []HistoryEntry{{Code: "MOB-hips", Status: "skip"}}}
+		filtered := filterByPrerequisites(snacks, stats)
+		if len(filtered) != 1 {
+			t.Errorf("expected only MOB-hips, got %v", filtered)
+		}
+	})
+}
+
+func TestFilterSnacksRecoverySafe(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "EB-eye-break", EffectiveRPE: 3, RecoverySafe: true, DurationMin: 1, DurationMax: 2},
+		{FullCode: "TS-light-move", EffectiveRPE: 3, DurationMin: 2, DurationMax: 3},
+		{FullCode: "BR-breath", EffectiveRPE: 5, RecoverySafe: true, DurationMin: 3, DurationMax: 5},
+	}
+
+	// Auto-recovery filters
+	filters := FilterOptions{MaxRPE: autoRecoveryMaxRPE, RecoverySafeMaxRPE: recoverySafeMaxRPE}
+	filtered := filterSnacks(snacks, filters)
+	if len(filtered) != 1 || filtered[0].FullCode != "EB-eye-break" {
+		t.Errorf("expected only EB-eye-break in recovery mode, got %v", filtered)
+	}
+
+	// Without recovery mode, recovery_safe has no effect on an explicit max RPE
+	filtered = filterSnacks(snacks, FilterOptions{MaxRPE: 2})
+	if len(filtered) != 0 {
+		t.Errorf("expected no snacks with max RPE 2, got %v", filtered)
+	}
+}
+
+func TestSnackHasAnyTag(t *testing.T) {
+	snack := Movo{AllTags: []string{"kbx", "swingx"}}
+
+	if !snack.HasAnyTag([]string{"snatchx", "SWINGX"}) {
+		t.Error("expected a match on swingx")
+	}
+	if snack.HasAnyTag([]string{"snatchx", "breathx"}) || snack.HasAnyTag(nil) {
+		t.Error("expected no match")
+	}
+
+	snacks := []Movo{
+		{FullCode: "KB-swings", AllTags: []string{"kbx", "swingx"}},
+		{FullCode: "KB-snatches", AllTags: []string{"kbx", "snatchx"}},
+		{FullCode: "KB-goblet", AllTags: []string{"kbx", "squatx"}},
+	}
+	filtered := filterSnacks(snacks, FilterOptions{AnyTags: []string{"swingx", "snatchx"}})
+	if len(filtered) != 2 || filtered[0].FullCode != "KB-swings" || filtered[1].FullCode != "KB-snatches" {
+		t.Errorf("expected swings and snatches, got %v", filtered)
+	}
+}
+
+func TestFilterSnacksByCategories(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "RB-box", CategoryCode: "RB", DurationMin: 2, DurationMax: 3},
+		{FullCode: "CF-flow", CategoryCode: "CF", DurationMin: 2, DurationMax: 3},
+		{FullCode: "TS-pushups", CategoryCode: "TS", DurationMin: 2, DurationMax: 3},
+	}
+	codes := func(filters FilterOptions) []string {
+		var out []string
+		for _, s := range filterSnacks(snacks, filters) {
+			out = append(out, s.FullCode)
+		}
+		return out
+	}
+
+	if got := codes(FilterOptions{Categories: []string{"RB", "CF"}}); !reflect.DeepEqual(got, []string{"RB-box", "CF-flow"}) {
+		t.Errorf("expected RB and CF movos, got %v", got)
+	}
+	if got := codes(FilterOptions{ExcludeCategories: []string{"TS"}}); !reflect.DeepEqual(got, []string{"RB-box", "CF-flow"}) {
+		t.Errorf("expected TS left out, got %v", got)
+	}
+	if got := codes(FilterOptions{Categories: []string{"RB", "TS"}, ExcludeCategories: []string{"TS"}}); !reflect.DeepEqual(got, []string{"RB-box"}) {
+		t.Errorf("expected the exclusion to win, got %v", got)
+	}
+}
+
+func TestFilterSnacksExcludesOffered(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "RB-box", CategoryCode: "RB", DurationMin: 2, DurationMax: 3},
+		{FullCode: "CF-flow", CategoryCode: "CF", DurationMin: 2, DurationMax: 3},
+	}
+	filtered := filterSnacks(snacks, FilterOptions{Exclude: []string{"RB-box"}})
+	if len(filtered) != 1 || filtered[0].FullCode != "CF-flow" {
+		t.Errorf("expected the offered movo left out, got %+v", filtered)
+	}
+}
+
+func TestCategoryCodes(t *testing.T) {
+	if got := categoryCodes(" rb, CF ,,ts"); !reflect.DeepEqual(got, []string{"RB", "CF", "TS"}) {
+		t.Errorf("unexpected codes %v", got)
+	}
+	if got := categoryCodes(""); got != nil {
+		t.Errorf("expected no codes, got %v", got)
+	}
+}
@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// outputJSON is set by the global --format json (or --json) flag. Read commands
+// (get, list, search, session, queue, fav, ban list, stats, status, program, packs list,
+// report, heatmap, everyday, weekly, subsets, config, analyze-weights) then print one
+// JSON document instead of text.
+var outputJSON bool
+
+// extractOutputFormat removes the global --format FORMAT and --json flags from args
+func extractOutputFormat(args []string) ([]string, bool, error) {
+	args, format, err := extractGlobalFlag(args, "format")
+	if err != nil {
+		return nil, false, err
+	}
+
+	asJSON := false
+	switch format {
+	case "", "text":
+	case "json":
+		asJSON = true
+	default:
+		return nil, false, fmt.Errorf("unknown --format %q (use: text, json)", format)
+	}
+
+	var rest []string
+	for _, arg := range args {
+		if arg == "--json" {
+			asJSON = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, asJSON, nil
+}
+
+// writeJSON prints v as indented JSON, exiting if it can't be encoded
+func writeJSON(w io.Writer, v any) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Error encoding JSON: %v\n"), err)
+		os.Exit(exitError)
+	}
+}
+
+// marshalJSON encodes v without HTML escaping, like writeJSON, for MarshalJSON methods
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// movoJSON is a movo as read commands output it
+type movoJSON struct {
+	Code         string   `json:"code"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description,omitempty"`
+	Category     string   `json:"category"`
+	DurationMin  int      `json:"duration_min"`
+	DurationMax  int      `json:"duration_max"`
+	RPE          int      `json:"rpe"`
+	Tags         []string `json:"tags"`
+	MinPerDay    int      `json:"min_per_day,omitempty"`
+	MinPerWeek   int      `json:"min_per_week,omitempty"`
+	MaxPerDay    int      `json:"max_per_day,omitempty"`
+	MaxPerWeek   int      `json:"max_per_week,omitempty"`
+	Targets      []string `json:"targets,omitempty"`
+	Equipment    []string `json:"equipment,omitempty"`
+	Sets         int      `json:"sets,omitempty"`
+	Reps         int      `json:"reps,omitempty"`
+	Prescription string   `json:"prescription,omitempty"`
+	// Set by 'get' when the pick was narrowed or randomized
+	RecoveryMode bool `json:"recovery_mode,omitempty"`
+	RestDay      bool `json:"rest_day,omitempty"`
+	Exploration  bool `json:"exploration,omitempty"`
+}
+
+func newMovoJSON(movo *Movo) movoJSON {
+	tags := movo.AllTags
+	if tags == nil {
+		tags = []string{}
+	}
+	return movoJSON{
+		Code:         movo.FullCode,
+		Title:        movo.Title,
+		Description:  movo.Description,
+		Category:     movo.CategoryName,
+		DurationMin:  movo.DurationMin,
+		DurationMax:  movo.DurationMax,
+		RPE:          movo.EffectiveRPE,
+		Tags:         tags,
+		MinPerDay:    movo.MinPerDay,
+		MinPerWeek:   movo.MinPerWeek,
+		MaxPerDay:    movo.MaxPerDay,
+		MaxPerWeek:   movo.MaxPerWeek,
+		Targets:      movo.Targets,
+		Equipment:    movo.Equipment,
+		Sets:         movo.Sets,
+		Reps:         movo.Reps,
+		Prescription: movo.Prescription,
+	}
+}
+
+// entryJSON is a logged history entry; the title comes from the library or the entry's snapshot
+type entryJSON struct {
+	Timestamp time.Time `json:"timestamp"`
+	Code      string    `json:"code"`
+	Title     string    `json:"title,omitempty"`
+	Status    string    `json:"status"`
+	Duration  int       `json:"duration"`
+	RPE       int       `json:"rpe"`
+	Subset    string    `json:"subset,omitempty"`
+	Sets      int       `json:"sets,omitempty"`
+	Reps      int       `json:"reps,omitempty"`
+	LoadKg    float64   `json:"load_kg,omitempty"`
+	Distance  float64   `json:"distance_km,omitempty"`
+	Steps     int       `json:"steps,omitempty"`
+	// Set when the movo has since been deprecated
+	Deprecated   bool   `json:"deprecated,omitempty"`
+	SupersededBy string `json:"superseded_by,omitempty"`
+}
+
+func newEntriesJSON(entries []HistoryEntry, movos map[string]*Movo) []entryJSON {
+	out := make([]entryJSON, 0, len(entries))
+	for _, entry := range entries {
+		e := entryJSON{
+			Timestamp: entry.Timestamp,
+			Code:      entry.Code,
+			Status:    entry.Status,
+			Duration:  entry.Duration,
+			RPE:       entry.RPE,
+			Subset:    entry.Subset,
+		}
+		if movo := entryMovo(entry, movos); movo != nil {
+			e.Title = movo.Title
+			e.Deprecated, e.SupersededBy = movo.Deprecated, movo.SupersededBy
+		}
+		e.Sets, e.Reps, _ = entrySetsReps(entry)
+		e.LoadKg = entryLoad(entry)
+		e.Distance, e.Steps = entryDistance(entry)
+		out = append(out, e)
+	}
+	return out
+}
+
+// movoStatsJSON is a movo's completions and load history, for 'stats'
+type movoStatsJSON struct {
+	Code  string     `json:"code"`
+	Title string     `json:"title"`
+	Done  int        `json:"done"`
+	Loads []loadJSON `json:"loads"`
+}
+
+// loadJSON is one logged load
+type loadJSON struct {
+	Timestamp time.Time `json:"timestamp"`
+	LoadKg    float64   `json:"load_kg"`
+	Sets      int       `json:"sets,omitempty"`
+	Reps      int       `json:"reps,omitempty"`
+}
+
+func newMovoStatsJSON(movo *Movo, done int, history []HistoryEntry) movoStatsJSON {
+	out := movoStatsJSON{Code: movo.FullCode, Title: movo.Title, Done: done, Loads: []loadJSON{}}
+	for _, entry := range history {
+		load := loadJSON{Timestamp: entry.Timestamp, LoadKg: entryLoad(entry)}
+		load.Sets, load.Reps, _ = entrySetsReps(entry)
+		out.Loads = append(out.Loads, load)
+	}
+	return out
+}
+
+// dayReportJSON is today's report
+type dayReportJSON struct {
+	Date      string      `json:"date"`
+	Movos     int         `json:"movos"`
+	Minutes   int         `json:"minutes"`
+	RPE       int         `json:"rpe"`
+	MaxRPE    int         `json:"max_rpe"`
+	Recovery  bool        `json:"recovery_mode"`
+	RestDay   bool        `json:"rest_day"`
+	Completed []entryJSON `json:"completed"`
+	Partial   []entryJSON `json:"partial"`
+	Skipped   []entryJSON `json:"skipped"`
+}
+
+func newDayReportJSON(stats DailyStats, movos map[string]*Movo) dayReportJSON {
+	return dayReportJSON{
+		Date:      dayKey(stats.Date),
+		Movos:     len(stats.CompletedSnacks),
+		Minutes:   stats.TotalDuration,
+		RPE:       stats.TotalRPE,
+		MaxRPE:    appConfig.MaxDailyRPE,
+		Recovery:  stats.TotalRPE >= appConfig.MaxDailyRPE,
+		RestDay:   isRestDay(appConfig, stats.Date),
+		Completed: newEntriesJSON(stats.CompletedSnacks, movos),
+		Partial:   newEntriesJSON(stats.PartialSnacks, movos),
+		Skipped:   newEntriesJSON(stats.SkippedSnacks, movos),
+	}
+}
+
+// subsetJSON is one subset from subsets.yaml
+type subsetJSON struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Codes       []string `json:"codes"`
+}
+
+// newSubsetsJSON lists subsets in name order
+func newSubsetsJSON(config *SubsetsConfig) []subsetJSON {
+	out := make([]subsetJSON, 0, len(config.Subsets))
+	for name, subset := range config.Subsets {
+		codes := subset.Codes
+		if codes == nil {
+			codes = []string{}
+		}
+		out = append(out, subsetJSON{Name: name, Description: subset.Description, Codes: codes})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// configJSON is the effective configuration
+type configJSON struct {
+	MovosDir         string   `json:"movos_dir"`
+	LogsDir          string   `json:"logs_dir"`
+	CurrentPath      string   `json:"current_file"`
+	ConfigPath       string   `json:"config_file"`
+	Profile          string   `json:"profile,omitempty"`
+	Profiles         []string `json:"profiles"`
+	MaxDailyRPE      int      `json:"max_daily_rpe"`
+	ActiveSubset     string   `json:"active_subset,omitempty"`
+	WeekStart        string   `json:"week_start"`
+	SkipPolicy       string   `json:"skip_policy"`
+	EverydayQueue    bool     `json:"everyday_queue"`
+	KidMode          bool     `json:"kid_mode"`
+	MaxEntriesPerDay int      `json:"max_entries_per_day"`
+	EODDir           string   `json:"eod_dir,omitempty"`
+	Queued           int      `json:"queued_entries"`
+	Movos            int      `json:"movos"`
+	Errors           []string `json:"errors,omitempty"`
+}
+
+// statusJSON is the current movo and today's totals for 'status'
+type statusJSON struct {
+	Current *movoJSON `json:"current"`
+	Today   struct {
+		Movos   int `json:"movos"`
+		Minutes int `json:"minutes"`
+		RPE     int `json:"rpe"`
+		MaxRPE  int `json:"max_rpe"`
+	} `json:"today"`
+}
+
+func newStatusJSON(current *Movo, stats DailyStats, maxRPE int) statusJSON {
+	var out statusJSON
+	if current != nil {
+		movo := newMovoJSON(current)
+		out.Current = &movo
+	}
+	out.Today.Movos = len(stats.CompletedSnacks)
+	out.Today.Minutes = stats.TotalDuration
+	out.Today.RPE = stats.TotalRPE
+	out.Today.MaxRPE = maxRPE
+	return out
+}
+
+// householdMemberJSON is one profile's day in the household report
+type householdMemberJSON struct {
+	Profile string      `json:"profile"`
+	KidMode bool        `json:"kid_mode"`
+	Done    int         `json:"done"`
+	Partial int         `json:"partial"`
+	Skipped int         `json:"skipped"`
+	Minutes int         `json:"minutes"`
+	RPE     int         `json:"rpe"`
+	Entries []entryJSON `json:"entries"`
+}
+
+func newHouseholdJSON(days []memberDay) []householdMemberJSON {
+	out := make([]householdMemberJSON, 0, len(days))
+	for _, day := range days {
+		t := day.Totals
+		out = append(out, householdMemberJSON{
+			Profile: day.Member.name(),
+			KidMode: day.Member.KidMode,
+			Done:    t.Done,
+			Partial: t.Partial,
+			Skipped: t.Skipped,
+			Minutes: t.Minutes,
+			RPE:     t.RPE,
+			Entries: newEntriesJSON(day.Entries, nil),
+		})
+	}
+	return out
+}
+
+// newConfigJSON describes cfg, with problems loading config.yaml, the spool, the
+// movos or overrides.yaml listed under errors
+func newConfigJSON(cfg *Config) configJSON {
+	out := configJSON{
+		MovosDir:         cfg.MovosDir,
+		LogsDir:          cfg.LogsDir,
+		CurrentPath:      cfg.CurrentPath,
+		ConfigPath:       cfg.ConfigPath,
+		Profile:          cfg.Profile,
+		Profiles:         cfg.ProfileNames,
+		MaxDailyRPE:      cfg.MaxDailyRPE,
+		ActiveSubset:     cfg.ActiveSubset,
+		WeekStart:        cfg.WeekStart.String(),
+		SkipPolicy:       cfg.SkipPolicy,
+		EverydayQueue:    cfg.EverydayQueue,
+		KidMode:          cfg.KidMode,
+		MaxEntriesPerDay: cfg.MaxEntriesPerDay,
+		EODDir:           cfg.EODDir,
+	}
+	if out.Profiles == nil {
+		out.Profiles = []string{}
+	}
+	if cfg.ConfigErr != nil {
+		out.Errors = append(out.Errors, cfg.ConfigErr.Error())
+	}
+	if queued, err := loadSpool(cfg.SpoolPath); err != nil {
+		out.Errors = append(out.Errors, err.Error())
+	} else {
+		out.Queued = len(queued)
+	}
+	if movos, err := loadMovosDir(cfg.MovosDir); err != nil {
+		out.Errors = append(out.Errors, err.Error())
+	} else {
+		out.Movos = len(movos)
+		if overrides, err := loadOverrides(cfg.OverridesPath); err != nil {
+			out.Errors = append(out.Errors, err.Error())
+		} else {
+			for _, code := range unknownOverrides(movos, overrides) {
+				out.Errors = append(out.Errors, fmt.Sprintf("overrides.yaml: no movo '%s'", code))
+			}
+		}
+	}
+	return out
+}
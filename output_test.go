@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractOutputFormat(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantArgs []string
+		wantJSON bool
+	}{
+		{[]string{"report", "week"}, []string{"report", "week"}, false},
+		{[]string{"--json", "get", "-c", "TS"}, []string{"get", "-c", "TS"}, true},
+		{[]string{"report", "--format", "json"}, []string{"report"}, true},
+		{[]string{"config", "--format=text"}, []string{"config"}, false},
+	}
+	for _, tt := range tests {
+		args, asJSON, err := extractOutputFormat(tt.args)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) || asJSON != tt.wantJSON {
+			t.Errorf("%v: got %v, %v; want %v, %v", tt.args, args, asJSON, tt.wantArgs, tt.wantJSON)
+		}
+	}
+
+	if _, _, err := extractOutputFormat([]string{"--format", "yaml"}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestDayReportJSON(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	today := time.Date(2025, 10, 14, 0, 0, 0, 0, time.Local)
+	done := HistoryEntry{Timestamp: today.Add(9 * time.Hour), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 6,
+		Extras: map[string]string{extraTitle: "Pushups"}}
+	stats := DailyStats{Date: today, TotalDuration: 5, TotalRPE: 6, CompletedSnacks: []HistoryEntry{done}}
+
+	var buf bytes.Buffer
+	writeJSON(&buf, newDayReportJSON(stats, nil))
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got["date"] != "2025-10-14" || got["movos"] != 1.0 || got["max_rpe"] != 30.0 {
+		t.Errorf("unexpected summary: %s", buf.String())
+	}
+	completed := got["completed"].([]any)
+	if len(completed) != 1 || completed[0].(map[string]any)["title"] != "Pushups" {
+		t.Errorf("expected the completed entry with its snapshot title: %s", buf.String())
+	}
+	// Empty lists are [] rather than null, so scripts can iterate without checks
+	if skipped, ok := got["skipped"].([]any); !ok || len(skipped) != 0 {
+		t.Errorf("expected an empty skipped list, got %v", got["skipped"])
+	}
+}
+
+func TestReportJSONDates(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)
+	month := buildMonthReport(nil, start, 31, nil)
+	program := Program{Start: "2025-03-03", Blocks: []ProgramBlock{{Name: "base", Weeks: 2}}}
+
+	var buf bytes.Buffer
+	writeJSON(&buf, month)
+	writeJSON(&buf, program.position("strength", start))
+
+	dec := json.NewDecoder(&buf)
+	var gotMonth struct {
+		Days  []struct{ Date string }
+		Weeks []struct{ Start, End string }
+	}
+	var gotProgram struct {
+		Start     string
+		NextStart string `json:"next_block_start"`
+	}
+	if err := dec.Decode(&gotMonth); err != nil {
+		t.Fatal(err)
+	}
+	if err := dec.Decode(&gotProgram); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every date is YYYY-MM-DD, like the day report's
+	if len(gotMonth.Days) != 31 || gotMonth.Days[0].Date != "2025-03-01" {
+		t.Errorf("unexpected days: %+v", gotMonth.Days)
+	}
+	if len(gotMonth.Weeks) == 0 || gotMonth.Weeks[0].Start != "2025-03-01" || gotMonth.Weeks[0].End != "2025-03-02" {
+		t.Errorf("unexpected weeks: %+v", gotMonth.Weeks)
+	}
+	if gotProgram.Start != "2025-03-03" || gotProgram.NextStart != "2025-03-03" {
+		t.Errorf("unexpected program dates: %+v", gotProgram)
+	}
+}
+
+func TestGetJSONReportsSelectionNotes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MOVODORO_MOVOS_DIR", "")
+	t.Setenv("MOVODORO_ACTIVE_SUBSET", "")
+	dir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(filepath.Join(dir, "movos"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("exploration_rate: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	movos := "category: Breath\ncode: TB\nmovos:\n  - code: box\n    title: Box breathing\n    duration_min: 2\n    duration_max: 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "movos", "breath.yaml"), []byte(movos), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	originalConfig, originalJSON := appConfig, outputJSON
+	appConfig, outputJSON = DefaultConfig(), true
+	defer func() { appConfig, outputJSON = originalConfig, originalJSON }()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	handleGet(nil)
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	// The whole of stdout must be one JSON document, notes included as fields
+	var got movoJSON
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("get --json should print only JSON: %v\n%s", err, out)
+	}
+	if got.Code != "TB-box" || !got.Exploration {
+		t.Errorf("expected an exploration pick of TB-box, got %+v", got)
+	}
+}
+
+// captureJSON runs fn with stdout redirected and decodes what it printed into v
+func captureJSON(t *testing.T, fn func(), v any) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	fn()
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if err := json.Unmarshal(out, v); err != nil {
+		t.Fatalf("expected only JSON: %v\n%s", err, out)
+	}
+}
+
+func TestStatusPacksProgramJSON(t *testing.T) {
+	cfg := setupWeeklyHome(t)
+	originalConfig, originalJSON := appConfig, outputJSON
+	appConfig, outputJSON = cfg, true
+	defer func() { appConfig, outputJSON = originalConfig, originalJSON }()
+
+	if err := saveCurrentSnack("STR-squats"); err != nil {
+		t.Fatal(err)
+	}
+	var status statusJSON
+	captureJSON(t, func() { handleStatus(nil) }, &status)
+	if status.Current == nil || status.Current.Code != "STR-squats" || status.Today.MaxRPE != cfg.MaxDailyRPE {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	var packs []installedPack
+	captureJSON(t, func() { handlePacks([]string{"list"}) }, &packs)
+	if packs == nil || len(packs) != 0 {
+		t.Errorf("expected an empty list of packs, got %+v", packs)
+	}
+
+	program := map[string]any{"program": "unset"}
+	captureJSON(t, func() { handleProgram(nil) }, &program)
+	if program != nil {
+		t.Errorf("expected null with no active program, got %+v", program)
+	}
+}
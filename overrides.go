@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// movoOverride personalizes one movo from a shared library (overrides.yaml).
+// Unset fields leave the library's value alone.
+type movoOverride struct {
+	Weight      *float64 `yaml:"weight,omitempty"` // Multiplies the movo's weight
+	RPE         *int     `yaml:"rpe,omitempty"`
+	DurationMin *int     `yaml:"duration_min,omitempty"`
+	DurationMax *int     `yaml:"duration_max,omitempty"`
+}
+
+// loadOverrides reads overrides.yaml, keyed by full code. A missing file means no
+// overrides.
+func loadOverrides(path string) (map[string]movoOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading overrides: %w", err)
+	}
+
+	var overrides map[string]movoOverride
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("overrides.yaml: %w", err)
+	}
+	for code, o := range overrides {
+		if o.Weight != nil && *o.Weight < 0 {
+			return nil, fmt.Errorf("overrides.yaml: %s: weight %g is negative", code, *o.Weight)
+		}
+		if o.RPE != nil && (*o.RPE < 1 || *o.RPE > 10) {
+			return nil, fmt.Errorf("overrides.yaml: %s: rpe %d is outside 1-10", code, *o.RPE)
+		}
+		if (o.DurationMin != nil && *o.DurationMin < 0) || (o.DurationMax != nil && *o.DurationMax < 0) {
+			return nil, fmt.Errorf("overrides.yaml: %s: durations can't be negative", code)
+		}
+	}
+	return overrides, nil
+}
+
+// applyOverrides merges overrides over the loaded movos in place
+func applyOverrides(movos []Movo, overrides map[string]movoOverride) error {
+	for i := range movos {
+		movo := &movos[i]
+		o, ok := overrides[movo.FullCode]
+		if !ok {
+			continue
+		}
+
+		if o.Weight != nil {
+			movo.Weight *= *o.Weight
+		}
+		if o.RPE != nil {
+			rpe := *o.RPE
+			movo.RPE = &rpe
+			movo.EffectiveRPE = rpe
+		}
+		if o.DurationMin != nil {
+			movo.DurationMin = *o.DurationMin
+		}
+		if o.DurationMax != nil {
+			movo.DurationMax = *o.DurationMax
+		}
+		if movo.DurationMin > movo.DurationMax {
+			return fmt.Errorf("overrides.yaml: %s: duration_min %d is above duration_max %d", movo.FullCode, movo.DurationMin, movo.DurationMax)
+		}
+	}
+	return nil
+}
+
+// unknownOverrides lists the override codes that match no movo (sorted), e.g. after
+// the library dropped or renamed one
+func unknownOverrides(movos []Movo, overrides map[string]movoOverride) []string {
+	var unknown []string
+	for code := range overrides {
+		if findMovo(movos, code) == nil {
+			unknown = append(unknown, code)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	os.WriteFile(path, []byte(`
+KB-swings:
+  weight: 0.5
+  rpe: 8
+RUN-tempo:
+  duration_max: 20
+GONE-movo:
+  weight: 2
+`), 0644)
+
+	overrides, err := loadOverrides(path)
+	if err != nil {
+		t.Fatalf("loadOverrides: %v", err)
+	}
+	rpe := 6
+	movos := []Movo{
+		{FullCode: "KB-swings", Weight: 2, RPE: &rpe, EffectiveRPE: 6},
+		{FullCode: "RUN-tempo", Weight: 1, DurationMin: 10, DurationMax: 15, EffectiveRPE: 7},
+	}
+	if err := applyOverrides(movos, overrides); err != nil {
+		t.Fatalf("applyOverrides: %v", err)
+	}
+
+	if movos[0].Weight != 1 || movos[0].EffectiveRPE != 8 || *movos[0].RPE != 8 {
+		t.Errorf("KB-swings = weight %g, RPE %d", movos[0].Weight, movos[0].EffectiveRPE)
+	}
+	if rpe != 6 {
+		t.Error("override changed the library's RPE value in place")
+	}
+	if movos[1].DurationMin != 10 || movos[1].DurationMax != 20 || movos[1].EffectiveRPE != 7 {
+		t.Errorf("RUN-tempo = %d-%d min, RPE %d", movos[1].DurationMin, movos[1].DurationMax, movos[1].EffectiveRPE)
+	}
+	if unknown := unknownOverrides(movos, overrides); len(unknown) != 1 || unknown[0] != "GONE-movo" {
+		t.Errorf("unknown = %v, want [GONE-movo]", unknown)
+	}
+}
+
+func TestOverridesRejectBadValues(t *testing.T) {
+	dir := t.TempDir()
+	for _, content := range []string{
+		"KB-swings: {rpe: 11}",
+		"KB-swings: {weight: -1}",
+		"KB-swings: {duration_min: -5}",
+		"KB-swings: [not, a, mapping]",
+	} {
+		path := filepath.Join(dir, "overrides.yaml")
+		os.WriteFile(path, []byte(content), 0644)
+		if _, err := loadOverrides(path); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+
+	minutes := 30
+	overrides := map[string]movoOverride{"KB-swings": {DurationMin: &minutes}}
+	movos := []Movo{{FullCode: "KB-swings", DurationMin: 5, DurationMax: 10}}
+	if err := applyOverrides(movos, overrides); err == nil {
+		t.Error("expected an error when duration_min ends up above duration_max")
+	}
+}
+
+func TestLoadOverridesMissingFile(t *testing.T) {
+	overrides, err := loadOverrides(filepath.Join(t.TempDir(), "overrides.yaml"))
+	if err != nil || len(overrides) != 0 {
+		t.Errorf("loadOverrides = %v, %v; want none", overrides, err)
+	}
+}
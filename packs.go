@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A pack is a themed category file (desk-mobility, kettlebell-basics, ...) installed
+// into the movos directory as pack-NAME.yaml, so it loads like any other category
+// under its own code and removing it is just deleting that file.
+const (
+	packPrefix       = "pack-"
+	packSourceHeader = "# Installed by 'movodoro packs install' from "
+	packFetchTimeout = 30 * time.Second
+)
+
+var packNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// packRegistry is the index a pack name is looked up in, e.g.
+//
+//	packs:
+//	  - name: desk-mobility
+//	    description: Mobility you can do without leaving your desk
+//	    url: desk-mobility.yaml
+//
+// Relative URLs are resolved against the registry's own location.
+type packRegistry struct {
+	Packs []packEntry `yaml:"packs"`
+}
+
+type packEntry struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	URL         string `yaml:"url"`
+}
+
+// installedPack describes a pack-NAME.yaml file in the movos directory
+type installedPack struct {
+	Name         string `json:"name"`
+	Source       string `json:"source"`
+	CategoryCode string `json:"category_code"`
+	Category     string `json:"category"`
+	Movos        int    `json:"movos"`
+}
+
+// isRemote reports whether source is an http(s) URL rather than a file path
+func isRemote(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// fetchPackSource reads a registry or pack from a URL or a local file
+func fetchPackSource(source string) ([]byte, error) {
+	if !isRemote(source) {
+		return os.ReadFile(source)
+	}
+	client := &http.Client{Timeout: packFetchTimeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// loadPackRegistry reads the registry at source
+func loadPackRegistry(source string) (*packRegistry, error) {
+	if source == "" {
+		return nil, fmt.Errorf("no pack registry configured (set pack_registry in config.yaml, or install from a URL or file)")
+	}
+	data, err := fetchPackSource(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading pack registry: %w", err)
+	}
+	var registry packRegistry
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("invalid pack registry %s: %w", source, err)
+	}
+	return &registry, nil
+}
+
+// resolvePackURL makes a registry entry's URL absolute relative to the registry
+func resolvePackURL(registry, ref string) (string, error) {
+	if isRemote(ref) || filepath.IsAbs(ref) {
+		return ref, nil
+	}
+	if isRemote(registry) {
+		base, err := url.Parse(registry)
+		if err != nil {
+			return "", err
+		}
+		rel, err := url.Parse(ref)
+		if err != nil {
+			return "", err
+		}
+		return base.ResolveReference(rel).String(), nil
+	}
+	return filepath.Join(filepath.Dir(registry), ref), nil
+}
+
+// resolvePack works out the pack name and where to fetch it from. A URL or a
+// path to a .yaml file is installed as-is under its base name; anything else is
+// looked up by name in the registry.
+func resolvePack(arg, registrySource string) (name, source string, err error) {
+	if isRemote(arg) || strings.HasSuffix(arg, ".yaml") || strings.HasSuffix(arg, ".yml") {
+		base := arg
+		if isRemote(arg) {
+			if u, err := url.Parse(arg); err == nil {
+				base = u.Path
+			}
+		}
+		name = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(base), ".yaml"), ".yml")
+		name = strings.TrimPrefix(strings.ToLower(name), packPrefix)
+		if !packNamePattern.MatchString(name) {
+			return "", "", fmt.Errorf("can't name a pack after %q (use lowercase letters, digits and dashes)", filepath.Base(base))
+		}
+		return name, arg, nil
+	}
+
+	registry, err := loadPackRegistry(registrySource)
+	if err != nil {
+		return "", "", err
+	}
+	for _, entry := range registry.Packs {
+		if entry.Name == arg {
+			source, err := resolvePackURL(registrySource, entry.URL)
+			if err != nil {
+				return "", "", fmt.Errorf("invalid url for pack %q: %w", arg, err)
+			}
+			return entry.Name, source, nil
+		}
+	}
+	return "", "", fmt.Errorf("no pack named %q in the registry (see 'movodoro packs available')", arg)
+}
+
+// parsePack checks that data is a usable category file
+func parsePack(data []byte) (*Category, error) {
+	category, err := decodeCategory("pack", data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pack:\n%w", err)
+	}
+	if category.Code == "" {
+		return nil, fmt.Errorf("invalid pack: no category code")
+	}
+	if len(category.Movos) == 0 {
+		return nil, fmt.Errorf("invalid pack: no movos")
+	}
+	for _, movo := range category.Movos {
+		if movo.Code == "" || movo.Title == "" {
+			return nil, fmt.Errorf("invalid pack: every movo needs a code and a title")
+		}
+	}
+	return category, nil
+}
+
+// packPath is where the named pack is installed
+func packPath(movosDir, name string) string {
+	return filepath.Join(movosDir, packPrefix+name+".yaml")
+}
+
+// installPack writes a fetched pack into movosDir. Its category code must not
+// already be used by another file; reinstalling the same pack replaces it.
+func installPack(movosDir, name, source string, data []byte) (*Category, error) {
+	if !packNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid pack name %q", name)
+	}
+	category, err := parsePack(data)
+	if err != nil {
+		return nil, err
+	}
+
+	path := packPath(movosDir, name)
+	files, err := filepath.Glob(filepath.Join(movosDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file == path {
+			continue
+		}
+		existing, err := loadCategory(file)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(existing.Code, category.Code) {
+			return nil, fmt.Errorf("category code %s is already used by %s", category.Code, filepath.Base(file))
+		}
+	}
+
+	if err := os.MkdirAll(movosDir, 0755); err != nil {
+		return nil, err
+	}
+	content := packSourceHeader + source + "\n" + string(data)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// removePack deletes an installed pack
+func removePack(movosDir, name string) error {
+	if !packNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid pack name %q", name)
+	}
+	err := os.Remove(packPath(movosDir, name))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("pack %q is not installed", name)
+	} else if err != nil {
+		return withExitCode(exitLibrary, err)
+	}
+	return nil
+}
+
+// installedPacks lists the packs in movosDir, by name
+func installedPacks(movosDir string) ([]installedPack, error) {
+	files, err := filepath.Glob(filepath.Join(movosDir, packPrefix+"*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var packs []installedPack
+	for _, file := range files {
+		pack := installedPack{
+			Name:   strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), packPrefix), ".yaml"),
+			Source: packSource(file),
+		}
+		if category, err := loadCategory(file); err == nil {
+			pack.CategoryCode = category.Code
+			pack.Category = category.Category
+			pack.Movos = len(category.Movos)
+		}
+		packs = append(packs, pack)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, nil
+}
+
+// packSource reads where an installed pack came from out of its header line
+func packSource(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	if !strings.HasPrefix(line, packSourceHeader) {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, packSourceHeader))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPack = `category: Desk mobility
+code: DSK
+default_rpe: 2
+movos:
+  - code: seated-twist
+    title: Seated twist
+    description: Twist gently each way.
+    duration_min: 1
+    duration_max: 2
+    weight: 1.0
+`
+
+func TestResolvePackFromRegistry(t *testing.T) {
+	dir := t.TempDir()
+	registry := filepath.Join(dir, "index.yaml")
+	os.WriteFile(registry, []byte("packs:\n  - name: desk-mobility\n    url: packs/desk.yaml\n"), 0644)
+
+	name, source, err := resolvePack("desk-mobility", registry)
+	if err != nil {
+		t.Fatalf("resolvePack() error = %v", err)
+	}
+	if name != "desk-mobility" || source != filepath.Join(dir, "packs", "desk.yaml") {
+		t.Errorf("resolvePack() = %q, %q", name, source)
+	}
+	if _, _, err := resolvePack("kettlebell-basics", registry); err == nil {
+		t.Error("expected an error for a pack missing from the registry")
+	}
+	if _, _, err := resolvePack("desk-mobility", ""); err == nil {
+		t.Error("expected an error without a registry")
+	}
+}
+
+func TestResolvePackFromURL(t *testing.T) {
+	name, source, err := resolvePack("https://example.com/packs/Pregnancy-Safe.yaml?v=2", "")
+	if err != nil {
+		t.Fatalf("resolvePack() error = %v", err)
+	}
+	if name != "pregnancy-safe" || source != "https://example.com/packs/Pregnancy-Safe.yaml?v=2" {
+		t.Errorf("resolvePack() = %q, %q", name, source)
+	}
+
+	got, err := resolvePackURL("https://example.com/packs/index.yaml", "kb.yaml")
+	if err != nil || got != "https://example.com/packs/kb.yaml" {
+		t.Errorf("resolvePackURL() = %q, %v", got, err)
+	}
+}
+
+func TestFetchPackSourceHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/desk.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testPack))
+	}))
+	defer server.Close()
+
+	data, err := fetchPackSource(server.URL + "/desk.yaml")
+	if err != nil || string(data) != testPack {
+		t.Errorf("fetchPackSource() = %q, %v", data, err)
+	}
+	if _, err := fetchPackSource(server.URL + "/missing.yaml"); err == nil {
+		t.Error("expected an error for a 404")
+	}
+}
+
+func TestInstallAndRemovePack(t *testing.T) {
+	movosDir := t.TempDir()
+	os.WriteFile(filepath.Join(movosDir, "breathing.yaml"), []byte("category: Breathing\ncode: BR\nmovos: []\n"), 0644)
+
+	category, err := installPack(movosDir, "desk-mobility", "https://example.com/desk.yaml", []byte(testPack))
+	if err != nil {
+		t.Fatalf("installPack() error = %v", err)
+	}
+	if category.Code != "DSK" {
+		t.Errorf("category code = %q, want DSK", category.Code)
+	}
+
+	// Reinstalling replaces the pack; another file with the same code is refused
+	if _, err := installPack(movosDir, "desk-mobility", "desk.yaml", []byte(testPack)); err != nil {
+		t.Errorf("reinstall error = %v", err)
+	}
+	if _, err := installPack(movosDir, "other", "other.yaml", []byte(testPack)); err == nil || !strings.Contains(err.Error(), "pack-desk-mobility.yaml") {
+		t.Errorf("expected a category code clash, got %v", err)
+	}
+	if _, err := installPack(movosDir, "empty", "empty.yaml", []byte("category: Empty\ncode: EMP\n")); err == nil {
+		t.Error("expected an error for a pack with no movos")
+	}
+
+	movos, err := loadMovosDir(movosDir)
+	if err != nil {
+		t.Fatalf("loadMovosDir() error = %v", err)
+	}
+	if len(movos) != 1 || movos[0].FullCode != "DSK-seated-twist" {
+		t.Errorf("loaded movos = %v", movos)
+	}
+
+	packs, err := installedPacks(movosDir)
+	if err != nil || len(packs) != 1 {
+		t.Fatalf("installedPacks() = %v, %v", packs, err)
+	}
+	if packs[0].Name != "desk-mobility" || packs[0].Source != "desk.yaml" || packs[0].Movos != 1 {
+		t.Errorf("installed pack = %+v", packs[0])
+	}
+
+	if err := removePack(movosDir, "../pack-desk-mobility"); err == nil {
+		t.Error("expected an error for a pack name with a path in it")
+	}
+	if err := removePack(movosDir, "desk-mobility"); err != nil {
+		t.Fatalf("removePack() error = %v", err)
+	}
+	if err := removePack(movosDir, "desk-mobility"); err == nil {
+		t.Error("expected an error removing a pack that isn't installed")
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Boosts for choosing a movo to go with one already picked (get --pair)
+const (
+	pairsWellBoost     = 5.0 // Either movo lists the other (or one of its tags) in pairs_well_with
+	otherCategoryBoost = 2.0 // Balance: a different category from the first movo
+	cooldownBoost      = 3.0 // A light movo (RPE ≤ 2) after an intense one
+	intenseRPE         = 6   // RPE from which a movo counts as intense
+)
+
+// pairsWith reports whether movo lists other's full code or one of its tags in pairs_well_with
+func (s *Movo) pairsWith(other *Movo) bool {
+	for _, entry := range s.PairsWellWith {
+		if strings.EqualFold(strings.TrimSpace(entry), other.FullCode) || other.HasAnyTag([]string{entry}) {
+			return true
+		}
+	}
+	return false
+}
+
+// complementMultiplier weighs how well second follows first
+func complementMultiplier(first, second *Movo) float64 {
+	multiplier := 1.0
+	if first.pairsWith(second) || second.pairsWith(first) {
+		multiplier *= pairsWellBoost
+	}
+	if first.CategoryCode != second.CategoryCode {
+		multiplier *= otherCategoryBoost
+	}
+	if first.EffectiveRPE >= intenseRPE && second.EffectiveRPE <= autoRecoveryMaxRPE {
+		multiplier *= cooldownBoost
+	}
+	return multiplier
+}
+
+// pickPartner chooses a movo to go with first from the weighted candidates, favoring
+// complementary ones. It returns nil if first is the only candidate.
+func pickPartner(r *rand.Rand, weighted []weightedSnack, first *Movo) *Movo {
+	var partners []weightedSnack
+	for _, w := range weighted {
+		if w.snack.FullCode == first.FullCode {
+			continue
+		}
+		partners = append(partners, weightedSnack{snack: w.snack, weight: w.weight * complementMultiplier(first, &w.snack)})
+	}
+	if len(partners) == 0 {
+		return nil
+	}
+	partner := weightedRandomSelect(r, partners)
+	return &partner
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestComplementMultiplier(t *testing.T) {
+	swings := &Movo{FullCode: "KB-swings", CategoryCode: "KB", EffectiveRPE: 7, PairsWellWith: []string{"breathx"}}
+	snatches := &Movo{FullCode: "KB-snatches", CategoryCode: "KB", EffectiveRPE: 8}
+	pushups := &Movo{FullCode: "TS-pushups", CategoryCode: "TS", EffectiveRPE: 5}
+	breath := &Movo{FullCode: "TB-box-breath", CategoryCode: "TB", EffectiveRPE: 1, AllTags: []string{"breathx"}}
+
+	tests := []struct {
+		name          string
+		first, second *Movo
+		want          float64
+	}{
+		{"same category", swings, snatches, 1},
+		{"other category", swings, pushups, otherCategoryBoost},
+		{"listed, other category, cooldown", swings, breath, pairsWellBoost * otherCategoryBoost * cooldownBoost},
+		{"listed by the second", breath, swings, pairsWellBoost * otherCategoryBoost},
+	}
+	for _, tt := range tests {
+		if got := complementMultiplier(tt.first, tt.second); got != tt.want {
+			t.Errorf("%s: got %f, want %f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPickPartner(t *testing.T) {
+	first := Movo{FullCode: "KB-swings", CategoryCode: "KB", EffectiveRPE: 7, PairsWellWith: []string{"TB-box-breath"}}
+	weighted := []weightedSnack{
+		{snack: first, weight: 100},
+		{snack: Movo{FullCode: "KB-snatches", CategoryCode: "KB", EffectiveRPE: 8}, weight: 1},
+		{snack: Movo{FullCode: "TB-box-breath", CategoryCode: "TB", EffectiveRPE: 1}, weight: 1},
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	counts := make(map[string]int)
+	for i := 0; i < 500; i++ {
+		counts[pickPartner(r, weighted, &first).FullCode]++
+	}
+	if counts["KB-swings"] != 0 {
+		t.Error("the first movo shouldn't be its own partner")
+	}
+	if counts["TB-box-breath"] < 450 {
+		t.Errorf("expected the complementary movo nearly every time, got %v", counts)
+	}
+
+	if partner := pickPartner(r, weighted[:1], &first); partner != nil {
+		t.Errorf("expected no partner from a pool of one, got %s", partner.FullCode)
+	}
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// periodDayFormat labels each day's row; the heading carries the full date
+const periodDayFormat = "Mon Jan 2"
+
+// dayTotals sums one day of history. Movos counts done and partial entries,
+// which are also the only ones that add minutes, RPE and distance.
+type dayTotals struct {
+	Date     time.Time `json:"date"`
+	Movos    int       `json:"movos"`
+	Minutes  int       `json:"minutes"`
+	RPE      int       `json:"rpe"`
+	Distance float64   `json:"distance_km,omitempty"`
+	Steps    int       `json:"steps,omitempty"`
+	Skipped  int       `json:"skipped"`
+	Rest     bool      `json:"rest,omitempty"` // A scheduled or ad-hoc rest day
+}
+
+// MarshalJSON writes the date as YYYY-MM-DD, like the day report's
+func (t dayTotals) MarshalJSON() ([]byte, error) {
+	type fields dayTotals
+	return marshalJSON(struct {
+		Date string `json:"date"`
+		fields
+	}{dayKey(t.Date), fields(t)})
+}
+
+// add counts entry towards the totals
+func (t *dayTotals) add(entry HistoryEntry) {
+	switch entry.Status {
+	case "done", "partial":
+		t.Movos++
+		t.Minutes += entry.Duration
+		t.RPE += entry.RPE
+		km, steps := entryDistance(entry)
+		t.Distance += km
+		t.Steps += steps
+	case "skip":
+		t.Skipped++
+	}
+}
+
+// distance describes the distance covered and steps taken ("" if neither was logged)
+func (t dayTotals) distance() string {
+	var parts []string
+	if t.Distance > 0 {
+		parts = append(parts, formatDistance(t.Distance))
+	}
+	if t.Steps > 0 {
+		parts = append(parts, trf("%d steps", t.Steps))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// periodReport is history grouped per day over a run of days
+type periodReport struct {
+	Days    []dayTotals   `json:"days"`
+	Total   dayTotals     `json:"total"`
+	Targets []targetCount `json:"targets,omitempty"` // Body regions worked, if movos have targets
+}
+
+// buildPeriodReport totals entries for each of the days days starting at start
+func buildPeriodReport(entries []HistoryEntry, start time.Time, days int) periodReport {
+	report := periodReport{Total: dayTotals{Date: start}}
+	index := make(map[string]int, days)
+	for i := 0; i < days; i++ {
+		day := start.AddDate(0, 0, i)
+		index[dayKey(day)] = i
+		report.Days = append(report.Days, dayTotals{Date: day})
+	}
+
+	for _, entry := range entries {
+		i, ok := index[dayKey(entry.Timestamp)]
+		if !ok {
+			continue
+		}
+		report.Days[i].add(entry)
+		report.Total.add(entry)
+	}
+	return report
+}
+
+// markRestDays flags the report's rest days
+func (r *periodReport) markRestDays(rest restSchedule) {
+	for i := range r.Days {
+		r.Days[i].Rest = rest.isRest(r.Days[i].Date)
+	}
+}
+
+// writePeriodReport renders a per-day table and the period's totals
+func writePeriodReport(w io.Writer, title, heading string, report periodReport) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, "  %s\n", title)
+	fmt.Fprintf(w, "  %s\n", heading)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	for _, day := range report.Days {
+		label := localizeDate(day.Date.Format(periodDayFormat))
+		rest := ""
+		if day.Rest {
+			rest = "  " + tr("🛌 rest day")
+		}
+		if day.Movos == 0 && day.Skipped == 0 {
+			fmt.Fprintf(w, "  %-10s ·%s\n", label, rest)
+			continue
+		}
+		fmt.Fprintf(w, tr("  %-10s %3d movos %5dm  RPE %3d"), label, day.Movos, day.Minutes, day.RPE)
+		if distance := day.distance(); distance != "" {
+			fmt.Fprintf(w, "  %s", distance)
+		}
+		if day.Skipped > 0 {
+			fmt.Fprintf(w, tr("  (%d skipped)"), day.Skipped)
+		}
+		fmt.Fprintln(w, rest)
+	}
+	fmt.Fprintln(w)
+
+	t := report.Total
+	fmt.Fprintln(w, tr("📊 Summary:"))
+	fmt.Fprintf(w, tr("   Total movos:     %d\n"), t.Movos)
+	fmt.Fprintf(w, tr("   Total duration:  %d minutes\n"), t.Minutes)
+	if distance := t.distance(); distance != "" {
+		fmt.Fprintf(w, tr("   Total distance:  %s\n"), distance)
+	}
+	fmt.Fprintf(w, tr("   Total RPE:       %d\n"), t.RPE)
+	if t.Skipped > 0 {
+		fmt.Fprintf(w, tr("   Skipped:         %d\n"), t.Skipped)
+	}
+
+	if len(report.Targets) > 0 {
+		fmt.Fprintln(w)
+		writeTargetCoverage(w, report.Targets)
+	}
+}
+
+// writePeriodReportMarkdown renders the same report as a markdown table
+func writePeriodReportMarkdown(w io.Writer, title string, report periodReport) {
+	fmt.Fprintf(w, "# %s\n\n", title)
+
+	fmt.Fprintln(w, "| Day | Movos | Minutes | RPE | Skipped |")
+	fmt.Fprintln(w, "|-----|------:|--------:|----:|--------:|")
+	for _, day := range report.Days {
+		label := day.Date.Format(periodDayFormat)
+		if day.Rest {
+			label += " 🛌"
+		}
+		fmt.Fprintf(w, "| %s | %d | %d | %d | %d |\n",
+			label, day.Movos, day.Minutes, day.RPE, day.Skipped)
+	}
+	fmt.Fprintln(w)
+
+	t := report.Total
+	fmt.Fprintln(w, "## Summary")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Total movos:** %d\n", t.Movos)
+	fmt.Fprintf(w, "- **Total duration:** %d minutes\n", t.Minutes)
+	if distance := t.distance(); distance != "" {
+		fmt.Fprintf(w, "- **Total distance:** %s\n", distance)
+	}
+	fmt.Fprintf(w, "- **Total RPE:** %d\n", t.RPE)
+	if t.Skipped > 0 {
+		fmt.Fprintf(w, "- **Skipped:** %d\n", t.Skipped)
+	}
+
+	if len(report.Targets) > 0 {
+		fmt.Fprintln(w)
+		writeTargetCoverageMarkdown(w, report.Targets)
+	}
+}
+
+// writeCompletedTableMarkdown lists a period's done and partial movos as a markdown
+// table (day, time, code, title, minutes, RPE, tags) for pasting into a training log
+func writeCompletedTableMarkdown(w io.Writer, entries []HistoryEntry, movos map[string]*Movo) {
+	fmt.Fprintln(w, "## Completed")
+	fmt.Fprintln(w)
+
+	cell := strings.NewReplacer("|", "\\|", "\n", " ")
+	fmt.Fprintln(w, "| Day | Time | Code | Title | Minutes | RPE | Tags |")
+	fmt.Fprintln(w, "|-----|------|------|-------|--------:|----:|------|")
+	for _, entry := range entries {
+		if entry.Status != "done" && entry.Status != "partial" {
+			continue
+		}
+		title, tags := "", ""
+		if movo := entryMovo(entry, movos); movo != nil {
+			title = movo.Title
+			tagList := make([]string, len(movo.AllTags))
+			for i, tag := range movo.AllTags {
+				tagList[i] = "#" + tag
+			}
+			tags = strings.Join(tagList, " ")
+		}
+		if entry.Status == "partial" {
+			title += " (partial)"
+		}
+		fmt.Fprintf(w, "| %s | %s | `%s` | %s | %d | %d | %s |\n",
+			entry.Timestamp.Format(periodDayFormat), appConfig.FormatClock(entry.Timestamp),
+			entry.Code, cell.Replace(title), entry.Duration, entry.RPE, tags)
+	}
+}
+
+// reportRange is an inclusive run of whole days chosen with --from and --to
+type reportRange struct {
+	From, To time.Time
+}
+
+// parseReportRange parses --from/--to dates (YYYY-MM-DD, local time). Both empty means
+// no range; a missing --to means today.
+func parseReportRange(from, to string, now time.Time) (*reportRange, error) {
+	if from == "" && to == "" {
+		return nil, nil
+	}
+	if from == "" {
+		return nil, fmt.Errorf("--to needs a --from date")
+	}
+
+	r := &reportRange{}
+	var err error
+	if r.From, err = time.ParseInLocation(dayKeyFormat, from, now.Location()); err != nil {
+		return nil, fmt.Errorf("invalid --from date %q (use YYYY-MM-DD)", from)
+	}
+	if to == "" {
+		r.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	} else if r.To, err = time.ParseInLocation(dayKeyFormat, to, now.Location()); err != nil {
+		return nil, fmt.Errorf("invalid --to date %q (use YYYY-MM-DD)", to)
+	}
+	if r.To.Before(r.From) {
+		return nil, fmt.Errorf("--to %s is before --from %s", to, from)
+	}
+	return r, nil
+}
+
+// days is the number of days in the range, counting both ends
+func (r reportRange) days() int {
+	n := 0
+	for day := r.From; !day.After(r.To); day = day.AddDate(0, 0, 1) {
+		n++
+	}
+	return n
+}
+
+// String describes the range for report headings
+func (r reportRange) String() string {
+	return trf("%s to %s", appConfig.FormatDate(r.From), appConfig.FormatDate(r.To))
+}
+
+// monthTopMovos is how many of the most frequent movos the month report lists
+const monthTopMovos = 5
+
+// weekRollup is the part of one ISO week that falls inside a report's days
+type weekRollup struct {
+	Year   int       `json:"year"`
+	Week   int       `json:"week"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Totals dayTotals `json:"totals"`
+}
+
+// MarshalJSON writes the start and end as YYYY-MM-DD
+func (w weekRollup) MarshalJSON() ([]byte, error) {
+	type fields weekRollup
+	return marshalJSON(struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+		fields
+	}{dayKey(w.Start), dayKey(w.End), fields(w)})
+}
+
+// movoCount is how often one movo was done (or partially done) in a period
+type movoCount struct {
+	Code    string `json:"code"`
+	Title   string `json:"title,omitempty"`
+	Count   int    `json:"count"`
+	Minutes int    `json:"minutes"`
+}
+
+// categoryTotals is one category's done and partial movos in a period
+type categoryTotals struct {
+	Category string `json:"category"`
+	Movos    int    `json:"movos"`
+	Minutes  int    `json:"minutes"`
+	RPE      int    `json:"rpe"`
+}
+
+// monthReport adds per-week, per-category and most-frequent-movo views to a run of days
+type monthReport struct {
+	periodReport
+	Weeks      []weekRollup     `json:"weeks"`
+	Categories []categoryTotals `json:"categories"`
+	Top        []movoCount      `json:"top_movos"`
+}
+
+// buildMonthReport totals entries for the days days starting at start (usually a calendar month)
+func buildMonthReport(entries []HistoryEntry, start time.Time, days int, movos map[string]*Movo) monthReport {
+	end := start.AddDate(0, 0, days)
+	report := monthReport{periodReport: buildPeriodReport(entries, start, days)}
+	report.Weeks = weekRollups(report.Days)
+
+	var active []HistoryEntry
+	for _, entry := range entries {
+		inRange := !entry.Timestamp.Before(start) && entry.Timestamp.Before(end)
+		if inRange && (entry.Status == "done" || entry.Status == "partial") {
+			active = append(active, entry)
+		}
+	}
+	report.Categories = []categoryTotals{}
+	if len(active) > 0 {
+		for _, group := range groupEntries(active, "category") {
+			duration, rpe := group.totals()
+			report.Categories = append(report.Categories, categoryTotals{
+				Category: group.Label, Movos: len(group.Entries), Minutes: duration, RPE: rpe,
+			})
+		}
+	}
+	report.Top = topMovos(active, movos, monthTopMovos)
+	return report
+}
+
+// weekRollups sums consecutive days by ISO week
+func weekRollups(days []dayTotals) []weekRollup {
+	var weeks []weekRollup
+	for _, day := range days {
+		year, week := day.Date.ISOWeek()
+		if len(weeks) == 0 || weeks[len(weeks)-1].Year != year || weeks[len(weeks)-1].Week != week {
+			weeks = append(weeks, weekRollup{Year: year, Week: week, Start: day.Date})
+		}
+		w := &weeks[len(weeks)-1]
+		w.End = day.Date
+		w.Totals.Movos += day.Movos
+		w.Totals.Minutes += day.Minutes
+		w.Totals.RPE += day.RPE
+		w.Totals.Distance += day.Distance
+		w.Totals.Steps += day.Steps
+		w.Totals.Skipped += day.Skipped
+	}
+	return weeks
+}
+
+// topMovos returns the n movos done most often, ties broken by code
+func topMovos(entries []HistoryEntry, movos map[string]*Movo, n int) []movoCount {
+	index := make(map[string]int)
+	counts := []movoCount{}
+	for _, entry := range entries {
+		i, ok := index[entry.Code]
+		if !ok {
+			i = len(counts)
+			index[entry.Code] = i
+			counts = append(counts, movoCount{Code: entry.Code})
+			if movo := entryMovo(entry, movos); movo != nil {
+				counts[i].Title = movo.Title
+			}
+		}
+		counts[i].Count++
+		counts[i].Minutes += entry.Duration
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Code < counts[j].Code
+	})
+	if len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// label names the week and the days of it inside the report
+func (w weekRollup) label() string {
+	end := w.End.Format("2")
+	if w.End.Month() != w.Start.Month() {
+		end = w.End.Format("Jan 2")
+	}
+	return trf("Week %d (%s-%s)", w.Week, localizeDate(w.Start.Format("Jan 2")), localizeDate(end))
+}
+
+// name is the movo's title with its code, or just the code if the title is unknown
+func (m movoCount) name() string {
+	if m.Title == "" {
+		return m.Code
+	}
+	return fmt.Sprintf("%s [%s]", m.Title, m.Code)
+}
+
+// writeMonthReport renders per-week totals, categories and the most frequent movos
+func writeMonthReport(w io.Writer, title, heading string, report monthReport) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, "  %s\n", title)
+	fmt.Fprintf(w, "  %s\n", heading)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, tr("📅 By week:"))
+	for _, week := range report.Weeks {
+		t := week.Totals
+		fmt.Fprintf(w, tr("   %-22s %3d movos %5dm  RPE %4d"), week.label(), t.Movos, t.Minutes, t.RPE)
+		if distance := t.distance(); distance != "" {
+			fmt.Fprintf(w, "  %s", distance)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w)
+
+	if len(report.Categories) > 0 {
+		fmt.Fprintln(w, tr("🗂️  By category:"))
+		for _, c := range report.Categories {
+			fmt.Fprintf(w, tr("   %-10s %3d movos %5dm  RPE %4d\n"), c.Category, c.Movos, c.Minutes, c.RPE)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Top) > 0 {
+		fmt.Fprintln(w, tr("🏆 Most frequent:"))
+		for _, m := range report.Top {
+			fmt.Fprintf(w, "   %3dx  %s (%dm)\n", m.Count, m.name(), m.Minutes)
+		}
+		fmt.Fprintln(w)
+	}
+
+	t := report.Total
+	fmt.Fprintln(w, tr("📊 Summary:"))
+	fmt.Fprintf(w, tr("   Total movos:     %d\n"), t.Movos)
+	fmt.Fprintf(w, tr("   Total duration:  %d minutes\n"), t.Minutes)
+	if distance := t.distance(); distance != "" {
+		fmt.Fprintf(w, tr("   Total distance:  %s\n"), distance)
+	}
+	fmt.Fprintf(w, tr("   Total RPE:       %d\n"), t.RPE)
+	if t.Skipped > 0 {
+		fmt.Fprintf(w, tr("   Skipped:         %d\n"), t.Skipped)
+	}
+}
+
+// writeMonthReportMarkdown renders the month report as markdown tables
+func writeMonthReportMarkdown(w io.Writer, title string, report monthReport) {
+	fmt.Fprintf(w, "# %s\n\n", title)
+
+	fmt.Fprintln(w, "## By Week")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Week | Movos | Minutes | RPE | Skipped |")
+	fmt.Fprintln(w, "|------|------:|--------:|----:|--------:|")
+	for _, week := range report.Weeks {
+		t := week.Totals
+		fmt.Fprintf(w, "| %s | %d | %d | %d | %d |\n", week.label(), t.Movos, t.Minutes, t.RPE, t.Skipped)
+	}
+	fmt.Fprintln(w)
+
+	if len(report.Categories) > 0 {
+		fmt.Fprintln(w, "## By Category")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Category | Movos | Minutes | RPE |")
+		fmt.Fprintln(w, "|----------|------:|--------:|----:|")
+		for _, c := range report.Categories {
+			fmt.Fprintf(w, "| %s | %d | %d | %d |\n", c.Category, c.Movos, c.Minutes, c.RPE)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Top) > 0 {
+		fmt.Fprintln(w, "## Most Frequent")
+		fmt.Fprintln(w)
+		for i, m := range report.Top {
+			name := fmt.Sprintf("`%s`", m.Code)
+			if m.Title != "" {
+				name = fmt.Sprintf("%s [`%s`]", m.Title, m.Code)
+			}
+			fmt.Fprintf(w, "%d. %s - %dx, %d min\n", i+1, name, m.Count, m.Minutes)
+		}
+		fmt.Fprintln(w)
+	}
+
+	t := report.Total
+	fmt.Fprintln(w, "## Summary")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "- **Total movos:** %d\n", t.Movos)
+	fmt.Fprintf(w, "- **Total duration:** %d minutes\n", t.Minutes)
+	if distance := t.distance(); distance != "" {
+		fmt.Fprintf(w, "- **Total distance:** %s\n", distance)
+	}
+	fmt.Fprintf(w, "- **Total RPE:** %d\n", t.RPE)
+	if t.Skipped > 0 {
+		fmt.Fprintf(w, "- **Skipped:** %d\n", t.Skipped)
+	}
+}
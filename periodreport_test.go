@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildPeriodReport(t *testing.T) {
+	start := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local) // Monday
+	at := func(day, hour int) time.Time { return start.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+
+	entries := []HistoryEntry{
+		{Timestamp: at(0, 9), Code: "A", Status: "done", Duration: 5, RPE: 3},
+		{Timestamp: at(0, 12), Code: "B", Status: "partial", Duration: 2, RPE: 2},
+		{Timestamp: at(0, 13), Code: "C", Status: "skip"},
+		{Timestamp: at(2, 8), Code: "A", Status: "done", Duration: 10, RPE: 4},
+		{Timestamp: at(7, 8), Code: "A", Status: "done", Duration: 99, RPE: 9}, // Next week
+	}
+
+	report := buildPeriodReport(entries, start, 7)
+	if len(report.Days) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(report.Days))
+	}
+
+	mon := report.Days[0]
+	if mon.Movos != 2 || mon.Minutes != 7 || mon.RPE != 5 || mon.Skipped != 1 {
+		t.Errorf("Monday: got %+v", mon)
+	}
+	if tue := report.Days[1]; tue.Movos != 0 || tue.Skipped != 0 {
+		t.Errorf("Tuesday should be empty, got %+v", tue)
+	}
+	if wed := report.Days[2]; wed.Movos != 1 || wed.Minutes != 10 {
+		t.Errorf("Wednesday: got %+v", wed)
+	}
+
+	total := report.Total
+	if total.Movos != 3 || total.Minutes != 17 || total.RPE != 9 || total.Skipped != 1 {
+		t.Errorf("total: got %+v", total)
+	}
+}
+
+func TestWritePeriodReportMarkdown(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	start := time.Date(2025, 3, 3, 0, 0, 0, 0, time.Local)
+	report := buildPeriodReport([]HistoryEntry{
+		{Timestamp: start.Add(9 * time.Hour), Code: "A", Status: "done", Duration: 5, RPE: 3},
+	}, start, 7)
+
+	var buf bytes.Buffer
+	writePeriodReportMarkdown(&buf, "Week", report)
+	out := buf.String()
+
+	if strings.Count(out, "\n| ") != 8 { // Header and one row per day
+		t.Errorf("expected a table with 7 day rows, got:\n%s", out)
+	}
+	if !strings.Contains(out, "| 5 | 3 | 0 |") || !strings.Contains(out, "- **Total movos:** 1") {
+		t.Errorf("missing Monday row or totals:\n%s", out)
+	}
+}
+
+func TestWriteCompletedTableMarkdown(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	at := time.Date(2025, 3, 4, 14, 30, 0, 0, time.Local)
+	movos := map[string]*Movo{"KB-swings": {FullCode: "KB-swings", Title: "Swings | heavy", AllTags: []string{"kbx", "swingx"}}}
+	entries := []HistoryEntry{
+		{Timestamp: at, Code: "KB-swings", Status: "done", Duration: 5, RPE: 7},
+		{Timestamp: at.Add(time.Hour), Code: "KB-swings", Status: "skip"},
+		{Timestamp: at.Add(2 * time.Hour), Code: "KB-swings", Status: "partial", Duration: 2, RPE: 5},
+	}
+
+	var buf bytes.Buffer
+	writeCompletedTableMarkdown(&buf, entries, movos)
+	out := buf.String()
+
+	want := "| Tue Mar 4 | 14:30 | `KB-swings` | Swings \\| heavy | 5 | 7 | #kbx #swingx |"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected row %q, got:\n%s", want, out)
+	}
+	if strings.Count(out, "\n| ") != 3 || !strings.Contains(out, "heavy (partial) | 2 | 5 |") {
+		t.Errorf("expected the done and partial rows only, got:\n%s", out)
+	}
+}
+
+func TestBuildMonthReport(t *testing.T) {
+	monthStart := time.Date(2025, 10, 1, 0, 0, 0, 0, time.Local) // A Wednesday
+	at := func(day int) time.Time { return monthStart.AddDate(0, 0, day-1).Add(9 * time.Hour) }
+
+	entries := []HistoryEntry{
+		{Timestamp: at(1), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 6},
+		{Timestamp: at(5), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 6},
+		{Timestamp: at(6), Code: "BR-box", Status: "partial", Duration: 2, RPE: 1},
+		{Timestamp: at(6), Code: "BR-box", Status: "skip"},
+		{Timestamp: at(31), Code: "MOB-hips", Status: "done", Duration: 8, RPE: 3,
+			Extras: map[string]string{extraTitle: "Hip circles"}},
+	}
+	movos := map[string]*Movo{"TS-pushups": {FullCode: "TS-pushups", Title: "Pushups"}}
+
+	report := buildMonthReport(entries, monthStart, 31, movos)
+	if len(report.Days) != 31 {
+		t.Fatalf("expected 31 days, got %d", len(report.Days))
+	}
+
+	// Oct 1-5 is the tail of ISO week 40, Oct 27-31 the start of week 44
+	if len(report.Weeks) != 5 {
+		t.Fatalf("expected 5 weeks, got %d", len(report.Weeks))
+	}
+	first, last := report.Weeks[0], report.Weeks[4]
+	if first.Week != 40 || first.Start.Day() != 1 || first.End.Day() != 5 || first.Totals.Movos != 2 {
+		t.Errorf("first week: got %+v", first)
+	}
+	if week := report.Weeks[1]; week.Totals.Movos != 1 || week.Totals.Skipped != 1 {
+		t.Errorf("second week: got %+v", week.Totals)
+	}
+	if last.Week != 44 || last.End.Day() != 31 || last.Totals.Minutes != 8 {
+		t.Errorf("last week: got %+v", last)
+	}
+
+	var categories []string
+	for _, group := range report.Categories {
+		categories = append(categories, group.Category)
+	}
+	if strings.Join(categories, ",") != "BR,MOB,TS" {
+		t.Errorf("expected categories BR,MOB,TS, got %v", categories)
+	}
+
+	if len(report.Top) != 3 {
+		t.Fatalf("expected 3 movos, got %+v", report.Top)
+	}
+	if top := report.Top[0]; top.Code != "TS-pushups" || top.Count != 2 || top.Title != "Pushups" {
+		t.Errorf("expected pushups first, got %+v", top)
+	}
+	if report.Top[1].Code != "BR-box" || report.Top[2].name() != "Hip circles [MOB-hips]" {
+		t.Errorf("expected ties by code with snapshot titles, got %+v", report.Top[1:])
+	}
+}
+
+func TestParseReportRange(t *testing.T) {
+	now := time.Date(2025, 10, 20, 15, 0, 0, 0, time.Local)
+
+	if rng, err := parseReportRange("", "", now); rng != nil || err != nil {
+		t.Errorf("expected no range without flags, got %v, %v", rng, err)
+	}
+
+	rng, err := parseReportRange("2025-10-01", "2025-10-14", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rng.days() != 14 || rng.From.Day() != 1 || rng.To.Day() != 14 {
+		t.Errorf("expected Oct 1-14 (14 days), got %v (%d days)", rng, rng.days())
+	}
+
+	rng, err = parseReportRange("2025-10-18", "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rng.To.Equal(time.Date(2025, 10, 20, 0, 0, 0, 0, time.Local)) || rng.days() != 3 {
+		t.Errorf("expected --to to default to today, got %v", rng.To)
+	}
+
+	for _, bad := range [][2]string{
+		{"", "2025-10-14"},
+		{"2025-10-14", "2025-10-01"},
+		{"10/01/2025", ""},
+		{"2025-10-01", "tomorrow"},
+	} {
+		if _, err := parseReportRange(bad[0], bad[1], now); err == nil {
+			t.Errorf("expected an error for --from %q --to %q", bad[0], bad[1])
+		}
+	}
+}
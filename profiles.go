@@ -0,0 +1,90 @@
+package main
+
+import (
+	"sort"
+)
+
+// defaultProfileName labels entries logged without a config profile
+const defaultProfileName = "(default)"
+
+// profileOutcome summarises what happened while a config profile was active
+type profileOutcome struct {
+	Profile string `json:"profile"`
+	Days    int    `json:"days"` // Distinct active days under this profile (see skip_policy)
+	Done    int    `json:"done"`
+	Partial int    `json:"partial"`
+	Skipped int    `json:"skipped"`
+	Minutes int    `json:"minutes"`
+	RPE     int    `json:"rpe"`
+}
+
+// add tallies one logged entry
+func (p *profileOutcome) add(entry HistoryEntry) {
+	switch entry.Status {
+	case "done":
+		p.Done++
+		p.Minutes += entry.Duration
+		p.RPE += entry.RPE
+	case "partial":
+		p.Partial++
+		p.Minutes += entry.Duration
+		p.RPE += entry.RPE
+	case "skip":
+		p.Skipped++
+	}
+}
+
+// perDay divides a total by the number of active days
+func (p profileOutcome) perDay(total int) float64 {
+	if p.Days == 0 {
+		return 0
+	}
+	return float64(total) / float64(p.Days)
+}
+
+// skipRate is the share of logged entries that were skips
+func (p profileOutcome) skipRate() float64 {
+	total := p.Done + p.Partial + p.Skipped
+	if total == 0 {
+		return 0
+	}
+	return float64(p.Skipped) / float64(total)
+}
+
+// compareProfiles groups history by the profile recorded in each entry's extras.
+// The default profile comes first, then profiles in name order.
+func compareProfiles(entries []HistoryEntry, skipPolicy string) []profileOutcome {
+	outcomes := make(map[string]*profileOutcome)
+	days := make(map[string]map[string]bool)
+
+	for _, entry := range entries {
+		name := entry.Extras["profile"]
+		if name == "" {
+			name = defaultProfileName
+		}
+
+		o, ok := outcomes[name]
+		if !ok {
+			o = &profileOutcome{Profile: name}
+			outcomes[name] = o
+			days[name] = make(map[string]bool)
+		}
+		if isActivity(entry, skipPolicy) {
+			days[name][dayKey(entry.Timestamp)] = true
+		}
+		o.add(entry)
+	}
+
+	result := make([]profileOutcome, 0, len(outcomes))
+	for name, o := range outcomes {
+		o.Days = len(days[name])
+		result = append(result, *o)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Profile == defaultProfileName) != (result[j].Profile == defaultProfileName) {
+			return result[i].Profile == defaultProfileName
+		}
+		return result[i].Profile < result[j].Profile
+	})
+	return result
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareProfiles(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 12, 0, 0, 0, time.UTC) }
+	withProfile := func(e HistoryEntry, profile string) HistoryEntry {
+		e.Extras = map[string]string{"profile": profile}
+		return e
+	}
+
+	entries := []HistoryEntry{
+		doneAt("TS-pushups", day(10)),
+		doneAt("TB-box-breath", day(11)),
+		withProfile(doneAt("TS-pushups", day(12)), "experiment"),
+		withProfile(doneAt("TS-squats", day(12)), "experiment"),
+		withProfile(HistoryEntry{Timestamp: day(12), Code: "TS-lunges", Status: "skip"}, "experiment"),
+	}
+
+	outcomes := compareProfiles(entries, skipNeutral)
+	if len(outcomes) != 2 || outcomes[0].Profile != defaultProfileName || outcomes[1].Profile != "experiment" {
+		t.Fatalf("unexpected outcomes: %+v", outcomes)
+	}
+
+	def, exp := outcomes[0], outcomes[1]
+	if def.Days != 2 || def.perDay(def.Done) != 1 {
+		t.Errorf("default: expected 2 days at 1 done/day, got %+v", def)
+	}
+	if exp.Days != 1 || exp.perDay(exp.Done) != 2 || exp.perDay(exp.Minutes) != 10 {
+		t.Errorf("experiment: expected 1 day at 2 done/day, got %+v", exp)
+	}
+	if rate := exp.skipRate(); rate < 0.33 || rate > 0.34 {
+		t.Errorf("experiment: expected skip rate 1/3, got %f", rate)
+	}
+}
+
+func TestCompareProfilesSkipOnlyDays(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 10, d, 12, 0, 0, 0, time.UTC) }
+	entries := []HistoryEntry{
+		doneAt("TS-pushups", day(10)),
+		{Timestamp: day(11), Code: "TS-lunges", Status: "skip"},
+	}
+
+	if got := compareProfiles(entries, skipNeutral)[0].Days; got != 1 {
+		t.Errorf("neutral: expected the skip-only day not to count, got %d days", got)
+	}
+	if got := compareProfiles(entries, skipExcuse)[0].Days; got != 2 {
+		t.Errorf("excuse: expected the skip-only day to count, got %d days", got)
+	}
+}
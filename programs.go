@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProgramsConfig represents the programs.yaml file structure
+type ProgramsConfig struct {
+	Active   string             `yaml:"active"` // Program that adjusts settings; empty for none
+	Programs map[string]Program `yaml:"programs"`
+}
+
+// Program is a periodized plan: consecutive blocks of weeks from a start date
+type Program struct {
+	Description string         `yaml:"description"`
+	Start       string         `yaml:"start"`  // YYYY-MM-DD, the first day of week 1
+	Repeat      bool           `yaml:"repeat"` // Start over after the last block
+	Blocks      []ProgramBlock `yaml:"blocks"`
+}
+
+// ProgramBlock is a run of weeks with its own settings; unset ones keep config.yaml's
+type ProgramBlock struct {
+	Name            string             `yaml:"name"`
+	Weeks           int                `yaml:"weeks"`
+	MaxDailyRPE     int                `yaml:"max_daily_rpe"`
+	CategoryWeights map[string]float64 `yaml:"category_weights"` // Multiply any profile weights
+	Subset          string             `yaml:"subset"`
+}
+
+// LoadPrograms loads and checks programs.yaml from the movos directory. A missing
+// file means no programs.
+func LoadPrograms(movosDir string) (*ProgramsConfig, error) {
+	data, err := os.ReadFile(filepath.Join(movosDir, "programs.yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &ProgramsConfig{}, nil
+		}
+		return nil, fmt.Errorf("error reading programs.yaml: %w", err)
+	}
+	config, err := parsePrograms(data)
+	if err != nil {
+		return nil, fmt.Errorf("programs.yaml: %w", err)
+	}
+	return config, nil
+}
+
+// parsePrograms decodes programs.yaml and checks every program in it
+func parsePrograms(data []byte) (*ProgramsConfig, error) {
+	var config ProgramsConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	for name, program := range config.Programs {
+		if err := program.check(); err != nil {
+			return nil, fmt.Errorf("program %s: %w", name, err)
+		}
+	}
+	if _, ok := config.Programs[config.Active]; config.Active != "" && !ok {
+		return nil, fmt.Errorf("active program %q not found", config.Active)
+	}
+	return &config, nil
+}
+
+// check validates a program's start date and blocks
+func (p Program) check() error {
+	if _, err := time.Parse(dayKeyFormat, p.Start); err != nil {
+		return fmt.Errorf("start must be a YYYY-MM-DD date, got %q", p.Start)
+	}
+	if len(p.Blocks) == 0 {
+		return fmt.Errorf("no blocks")
+	}
+	for i, block := range p.Blocks {
+		if block.Weeks <= 0 {
+			return fmt.Errorf("block %d (%s): weeks must be positive", i+1, block.Name)
+		}
+		if block.MaxDailyRPE < 0 {
+			return fmt.Errorf("block %d (%s): max_daily_rpe must not be negative", i+1, block.Name)
+		}
+	}
+	return nil
+}
+
+// totalWeeks is the length of one pass through the program
+func (p Program) totalWeeks() int {
+	total := 0
+	for _, block := range p.Blocks {
+		total += block.Weeks
+	}
+	return total
+}
+
+// programPosition is where a program is on a given day
+type programPosition struct {
+	Program     string        `json:"program"`
+	Start       time.Time     `json:"start"`
+	Block       *ProgramBlock `json:"-"`
+	BlockName   string        `json:"block,omitempty"`
+	BlockNumber int           `json:"block_number,omitempty"` // 1-based
+	Blocks      int           `json:"blocks"`
+	WeekInBlock int           `json:"week_in_block,omitempty"`
+	Week        int           `json:"week,omitempty"` // Week of the current pass, 1-based
+	TotalWeeks  int           `json:"total_weeks"`
+	Cycle       int           `json:"cycle,omitempty"` // Pass through a repeating program, 1-based
+	NextBlock   string        `json:"next_block,omitempty"`
+	NextStart   *time.Time    `json:"next_block_start,omitempty"`
+	Finished    bool          `json:"finished"` // Past the last block of a program that doesn't repeat
+}
+
+// MarshalJSON writes the start dates as YYYY-MM-DD
+func (p programPosition) MarshalJSON() ([]byte, error) {
+	type fields programPosition
+	out := struct {
+		Start     string `json:"start"`
+		NextStart string `json:"next_block_start,omitempty"`
+		fields
+	}{Start: dayKey(p.Start), fields: fields(p)}
+	if p.NextStart != nil {
+		out.NextStart = dayKey(*p.NextStart)
+	}
+	return marshalJSON(out)
+}
+
+// position finds the block containing now. Block is nil before the start and
+// once a program that doesn't repeat has finished.
+func (p Program) position(name string, now time.Time) programPosition {
+	start, _ := time.ParseInLocation(dayKeyFormat, p.Start, now.Location())
+	pos := programPosition{Program: name, Start: start, Blocks: len(p.Blocks), TotalWeeks: p.totalWeeks()}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	days := int(today.Sub(start).Hours()+12) / 24 // Rounded: DST days aren't 24h
+	if days < 0 {
+		pos.NextBlock, pos.NextStart = p.Blocks[0].Name, &start
+		return pos
+	}
+
+	week := days / 7
+	pos.Cycle = week/pos.TotalWeeks + 1
+	if pos.Cycle > 1 && !p.Repeat {
+		pos.Finished = true
+		return pos
+	}
+	week %= pos.TotalWeeks
+	pos.Week = week + 1
+
+	for i := range p.Blocks {
+		block := &p.Blocks[i]
+		if week >= block.Weeks {
+			week -= block.Weeks
+			continue
+		}
+		pos.Block = block
+		pos.BlockName = block.Name
+		pos.BlockNumber = i + 1
+		pos.WeekInBlock = week + 1
+
+		// The next block starts when this one's weeks run out
+		next := today.AddDate(0, 0, (block.Weeks-week)*7-days%7)
+		switch {
+		case i+1 < len(p.Blocks):
+			pos.NextBlock, pos.NextStart = p.Blocks[i+1].Name, &next
+		case p.Repeat:
+			pos.NextBlock, pos.NextStart = p.Blocks[0].Name, &next
+		}
+		break
+	}
+	return pos
+}
+
+// applyProgram adjusts settings for the active program's current block. The
+// MOVODORO_ACTIVE_SUBSET environment variable wins over a block's subset.
+func (c *Config) applyProgram(now time.Time) error {
+	programs, err := LoadPrograms(c.MovosDir)
+	if err != nil || programs.Active == "" {
+		return err
+	}
+	pos := programs.Programs[programs.Active].position(programs.Active, now)
+	c.Program = &pos
+
+	block := pos.Block
+	if block == nil {
+		return nil
+	}
+	if block.MaxDailyRPE > 0 {
+		c.MaxDailyRPE = block.MaxDailyRPE
+	}
+	if len(block.CategoryWeights) > 0 {
+		weights := make(map[string]float64, len(c.CategoryWeights)+len(block.CategoryWeights))
+		for code, weight := range c.CategoryWeights {
+			weights[code] = weight
+		}
+		for code, weight := range block.CategoryWeights {
+			code = strings.ToUpper(code)
+			if existing, ok := weights[code]; ok {
+				weight *= existing
+			}
+			weights[code] = weight
+		}
+		c.CategoryWeights = weights
+	}
+	if block.Subset != "" && c.ActiveSubset == "" {
+		c.ActiveSubset = block.Subset
+	}
+	return nil
+}
+
+// summary describes the position in a line, e.g. "build, week 2 of 3"
+func (p programPosition) summary() string {
+	switch {
+	case p.Finished:
+		return "finished"
+	case p.Block == nil:
+		return "not started"
+	}
+	name := p.BlockName
+	if name == "" {
+		name = fmt.Sprintf("block %d", p.BlockNumber)
+	}
+	return fmt.Sprintf("%s, week %d of %d", name, p.WeekInBlock, p.Block.Weeks)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testProgramsYAML = `active: strength
+programs:
+  strength:
+    start: 2025-03-03
+    repeat: true
+    blocks:
+      - name: build
+        weeks: 3
+        max_daily_rpe: 40
+        category_weights:
+          str: 1.5
+      - name: deload
+        weeks: 1
+        max_daily_rpe: 20
+        subset: recovery
+`
+
+func TestProgramPosition(t *testing.T) {
+	config, err := parsePrograms([]byte(testProgramsYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	program := config.Programs["strength"]
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 15, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		now       time.Time
+		block     string
+		week      int
+		cycle     int
+		nextBlock string
+		nextStart string
+	}{
+		{day(3), "build", 1, 1, "deload", "2025-03-24"},
+		{day(19), "build", 3, 1, "deload", "2025-03-24"},
+		{day(24), "deload", 1, 1, "build", "2025-03-31"},
+		{day(31).AddDate(0, 0, 8), "build", 2, 2, "deload", "2025-04-21"},
+	}
+	for _, tt := range tests {
+		pos := program.position("strength", tt.now)
+		if pos.BlockName != tt.block || pos.WeekInBlock != tt.week || pos.Cycle != tt.cycle {
+			t.Errorf("%s: got %s week %d cycle %d, want %s week %d cycle %d",
+				dayKey(tt.now), pos.BlockName, pos.WeekInBlock, pos.Cycle, tt.block, tt.week, tt.cycle)
+		}
+		if pos.NextStart == nil || pos.NextBlock != tt.nextBlock || dayKey(*pos.NextStart) != tt.nextStart {
+			t.Errorf("%s: expected %s next on %s, got %+v", dayKey(tt.now), tt.nextBlock, tt.nextStart, pos)
+		}
+	}
+
+	if pos := program.position("strength", day(1)); pos.Block != nil || pos.NextBlock != "build" {
+		t.Errorf("expected the program not started yet, got %+v", pos)
+	}
+	program.Repeat = false
+	if pos := program.position("strength", day(31)); !pos.Finished || pos.Block != nil {
+		t.Errorf("expected a one-off program to finish after four weeks, got %+v", pos)
+	}
+}
+
+func TestParseProgramsErrors(t *testing.T) {
+	tests := map[string]string{
+		"active: missing\nprograms: {}\n":                                    "not found",
+		"programs:\n  p:\n    start: soon\n    blocks: [{weeks: 1}]\n":       "start",
+		"programs:\n  p:\n    start: 2025-03-03\n":                           "no blocks",
+		"programs:\n  p:\n    start: 2025-03-03\n    blocks: [{weeks: 0}]\n": "weeks",
+	}
+	for data, want := range tests {
+		if _, err := parsePrograms([]byte(data)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected an error mentioning %q for %q, got %v", want, data, err)
+		}
+	}
+}
+
+func TestApplyProgram(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{"programs.yaml": testProgramsYAML})
+	cfg := TestConfig(t.TempDir())
+	cfg.MovosDir = dir
+	cfg.CategoryWeights = map[string]float64{"STR": 2, "MOB": 0.5}
+
+	if err := cfg.applyProgram(time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local)); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Program == nil || cfg.Program.summary() != "build, week 1 of 3" {
+		t.Fatalf("expected week 1 of the build block, got %+v", cfg.Program)
+	}
+	if cfg.MaxDailyRPE != 40 || cfg.CategoryWeights["STR"] != 3 || cfg.CategoryWeights["MOB"] != 0.5 {
+		t.Errorf("expected the build block's settings, got RPE %d weights %v", cfg.MaxDailyRPE, cfg.CategoryWeights)
+	}
+
+	// An explicit subset wins over the deload block's
+	cfg = TestConfig(t.TempDir())
+	cfg.MovosDir = dir
+	cfg.ActiveSubset = "desk"
+	if err := cfg.applyProgram(time.Date(2025, 3, 25, 9, 0, 0, 0, time.Local)); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MaxDailyRPE != 20 || cfg.ActiveSubset != "desk" {
+		t.Errorf("expected the deload RPE with the explicit subset kept, got %d %q", cfg.MaxDailyRPE, cfg.ActiveSubset)
+	}
+}
@@ -0,0 +1,560 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+)
+
+// A small QR code encoder (byte mode, error correction level M, versions 1-10),
+// enough for movo links without pulling in a dependency. Coordinates are (x, y):
+// column then row. See ISO/IEC 18004 for the layout rules followed here.
+
+// qrBlocks describes the error correction blocks for one version at level M
+type qrBlocks struct {
+	ecPerBlock int // EC codewords in every block
+	shortCount int // Blocks holding shortData data codewords
+	shortData  int
+	longCount  int // Blocks holding shortData+1 data codewords
+}
+
+// qrVersionsM is the level M block layout for versions 1-10 (index 0 is version 1)
+var qrVersionsM = []qrBlocks{
+	{10, 1, 16, 0},
+	{16, 1, 28, 0},
+	{26, 1, 44, 0},
+	{18, 2, 32, 0},
+	{24, 2, 43, 0},
+	{16, 4, 27, 0},
+	{18, 4, 31, 0},
+	{22, 2, 38, 2},
+	{22, 3, 36, 2},
+	{26, 4, 43, 1},
+}
+
+// qrAlignment lists alignment pattern centres per version (none for version 1)
+var qrAlignment = [][]int{
+	nil,
+	{6, 18},
+	{6, 22},
+	{6, 26},
+	{6, 30},
+	{6, 34},
+	{6, 22, 38},
+	{6, 24, 42},
+	{6, 26, 46},
+	{6, 28, 50},
+}
+
+const (
+	qrFormatM     = 0 // Format bits for error correction level M
+	qrQuietZone   = 4 // Light modules around the symbol
+	qrPNGScale    = 8 // Pixels per module in PNG output
+	qrMaxVersion  = 10
+	qrPadByteEven = 0xEC
+	qrPadByteOdd  = 0x11
+)
+
+// qrCode is an encoded symbol; Modules[y][x] is true for dark modules
+type qrCode struct {
+	Version int
+	Size    int
+	Mask    int
+	Modules [][]bool
+
+	function [][]bool // Modules reserved for patterns and format/version info
+}
+
+// dataCodewords is the number of data codewords a version holds at level M
+func (b qrBlocks) dataCodewords() int {
+	return b.shortCount*b.shortData + b.longCount*(b.shortData+1)
+}
+
+// encodeQR encodes text in byte mode at the smallest version that fits
+func encodeQR(text string) (*qrCode, error) {
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v <= qrMaxVersion; v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= qrVersionsM[v-1].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("text too long for a QR code (%d bytes)", len(data))
+	}
+
+	codewords := qrCodewords(data, version)
+
+	q := &qrCode{Version: version, Size: 17 + 4*version}
+	q.Modules = make([][]bool, q.Size)
+	q.function = make([][]bool, q.Size)
+	for i := range q.Modules {
+		q.Modules[i] = make([]bool, q.Size)
+		q.function[i] = make([]bool, q.Size)
+	}
+
+	q.drawFunctionPatterns()
+	q.drawCodewords(codewords)
+
+	// Pick the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask) // XOR again to undo
+	}
+	q.Mask = best
+	q.applyMask(best)
+	q.drawFormatBits(best)
+
+	return q, nil
+}
+
+// qrCodewords builds the data bit stream, pads it, adds Reed-Solomon EC per block
+// and interleaves the blocks
+func qrCodewords(data []byte, version int) []byte {
+	layout := qrVersionsM[version-1]
+	capacity := layout.dataCodewords()
+
+	var bits []bool
+	appendBits := func(value, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bits = append(bits, (value>>i)&1 == 1)
+		}
+	}
+	appendBits(0b0100, 4) // Byte mode
+	if version >= 10 {
+		appendBits(len(data), 16)
+	} else {
+		appendBits(len(data), 8)
+	}
+	for _, b := range data {
+		appendBits(int(b), 8)
+	}
+
+	// Terminator, then pad to a byte boundary and fill with alternating pad bytes
+	for i := 0; i < 4 && len(bits) < capacity*8; i++ {
+		bits = append(bits, false)
+	}
+	for len(bits)%8 != 0 {
+		bits = append(bits, false)
+	}
+	stream := make([]byte, 0, capacity)
+	for i := 0; i < len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		stream = append(stream, b)
+	}
+	for pad := qrPadByteEven; len(stream) < capacity; pad ^= qrPadByteEven ^ qrPadByteOdd {
+		stream = append(stream, byte(pad))
+	}
+
+	// Split into blocks and compute each block's EC codewords
+	divisor := reedSolomonDivisor(layout.ecPerBlock)
+	var blocks, ecs [][]byte
+	offset := 0
+	for i := 0; i < layout.shortCount+layout.longCount; i++ {
+		n := layout.shortData
+		if i >= layout.shortCount {
+			n++
+		}
+		block := stream[offset : offset+n]
+		offset += n
+		blocks = append(blocks, block)
+		ecs = append(ecs, reedSolomonRemainder(block, divisor))
+	}
+
+	// Interleave: the i-th codeword of every block in turn, data then EC
+	var result []byte
+	for i := 0; i <= layout.shortData; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ecPerBlock; i++ {
+		for _, ec := range ecs {
+			result = append(result, ec[i])
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// reedSolomonDivisor returns the generator polynomial of the given degree
+// (coefficients from highest to lowest, leading 1 omitted)
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// reedSolomonRemainder returns the EC codewords for data
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// setFunction sets a module that belongs to a pattern rather than data
+func (q *qrCode) setFunction(x, y int, dark bool) {
+	q.Modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// drawFunctionPatterns draws timing, finder and alignment patterns and reserves
+// the format and version areas
+func (q *qrCode) drawFunctionPatterns() {
+	for i := 0; i < q.Size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.Size-4, 3)
+	q.drawFinder(3, q.Size-4)
+
+	positions := qrAlignment[q.Version-1]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the three corners taken by finders
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			q.drawAlignment(x, y)
+		}
+	}
+
+	q.drawFormatBits(0) // Reserve the area; real bits are drawn after masking
+	q.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator around centre (x, y)
+func (q *qrCode) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= q.Size || yy < 0 || yy >= q.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			q.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment draws a 5x5 alignment pattern around centre (x, y)
+func (q *qrCode) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// qrFormatBits returns the 15 format bits for level M and a mask (BCH code, masked)
+func qrFormatBits(mask int) int {
+	data := qrFormatM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawFormatBits draws both copies of the format information
+func (q *qrCode) drawFormatBits(mask int) {
+	bits := qrFormatBits(mask)
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.Size-15+i, bit(i))
+	}
+	q.setFunction(8, q.Size-8, true) // Always-dark module
+}
+
+// qrVersionBits returns the 18 version information bits (versions 7 and up)
+func qrVersionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// drawVersion draws both copies of the version information (versions 7 and up)
+func (q *qrCode) drawVersion() {
+	if q.Version < 7 {
+		return
+	}
+	bits := qrVersionBits(q.Version)
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 == 1
+		a, b := q.Size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the codewords in the zigzag order, two columns at a time
+// from the bottom right, skipping the vertical timing column
+func (q *qrCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 { // Upward
+					y = q.Size - 1 - vert
+				}
+				if q.function[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				q.Modules[y][x] = (codewords[i>>3]>>(7-i&7))&1 == 1
+				i++
+			}
+		}
+	}
+}
+
+// qrMaskApplies reports whether mask flips the module at (x, y)
+func qrMaskApplies(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// applyMask XORs the mask over every data module (applying twice undoes it)
+func (q *qrCode) applyMask(mask int) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if !q.function[y][x] && qrMaskApplies(mask, x, y) {
+				q.Modules[y][x] = !q.Modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the symbol is to scan (lower is better): long runs,
+// 2x2 blocks, finder-like sequences and dark/light imbalance
+func (q *qrCode) penalty() int {
+	score := 0
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return q.Modules[x][y]
+		}
+		return q.Modules[y][x]
+	}
+
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for _, transpose := range []bool{false, true} {
+		for y := 0; y < q.Size; y++ {
+			run := 1
+			for x := 1; x <= q.Size; x++ {
+				if x < q.Size && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+
+			// 1:1:3:1:1 with four light modules on either side
+			for x := 0; x+len(finderLike) <= q.Size; x++ {
+				match := true
+				for k, dark := range finderLike {
+					if at(x+k, y, transpose) != dark {
+						match = false
+						break
+					}
+				}
+				if !match {
+					continue
+				}
+				if q.lightRun(x-4, x, y, transpose) || q.lightRun(x+7, x+11, y, transpose) {
+					score += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.Modules[y][x] {
+				dark++
+			}
+			if x+1 < q.Size && y+1 < q.Size {
+				c := q.Modules[y][x]
+				if c == q.Modules[y][x+1] && c == q.Modules[y+1][x] && c == q.Modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+	total := q.Size * q.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		score += k * 10
+	}
+	return score
+}
+
+// lightRun reports whether modules from..to (exclusive) along a line are all light;
+// positions outside the symbol count as light (the quiet zone)
+func (q *qrCode) lightRun(from, to, line int, transpose bool) bool {
+	for i := from; i < to; i++ {
+		if i < 0 || i >= q.Size {
+			continue
+		}
+		dark := q.Modules[line][i]
+		if transpose {
+			dark = q.Modules[i][line]
+		}
+		if dark {
+			return false
+		}
+	}
+	return true
+}
+
+// dark reports whether (x, y) is dark, treating the quiet zone as light
+func (q *qrCode) dark(x, y int) bool {
+	return x >= 0 && x < q.Size && y >= 0 && y < q.Size && q.Modules[y][x]
+}
+
+// writeQRText renders the symbol with half-block characters, two rows per line.
+// By default light modules are drawn (for dark terminals); invert draws dark ones.
+func writeQRText(w io.Writer, q *qrCode, invert bool) {
+	lit := func(x, y int) bool { return q.dark(x, y) == invert }
+
+	var b strings.Builder
+	for y := -qrQuietZone; y < q.Size+qrQuietZone; y += 2 {
+		for x := -qrQuietZone; x < q.Size+qrQuietZone; x++ {
+			top, bottom := lit(x, y), lit(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	io.WriteString(w, b.String())
+}
+
+// writeQRPNG saves the symbol as a black-on-white PNG with a quiet zone
+func writeQRPNG(path string, q *qrCode) error {
+	side := (q.Size + 2*qrQuietZone) * qrPNGScale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for py := 0; py < side; py++ {
+		for px := 0; px < side; px++ {
+			c := color.Gray{Y: 255}
+			if q.dark(px/qrPNGScale-qrQuietZone, py/qrPNGScale-qrQuietZone) {
+				c = color.Gray{Y: 0}
+			}
+			img.SetGray(px, py, c)
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
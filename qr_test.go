@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// Data and EC codewords of the well-known "HELLO WORLD" version 1-M symbol
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := reedSolomonRemainder(data, reedSolomonDivisor(10))
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestQRFormatAndVersionBits(t *testing.T) {
+	// Reference values from the QR specification's tables
+	if got := qrFormatBits(0); got != 0b101010000010010 {
+		t.Errorf("format bits M/mask 0: got %015b", got)
+	}
+	if got := qrFormatBits(5); got != 0b100000011001110 {
+		t.Errorf("format bits M/mask 5: got %015b", got)
+	}
+	if got := qrVersionBits(7); got != 0b000111110010010100 {
+		t.Errorf("version bits 7: got %018b", got)
+	}
+}
+
+func TestEncodeQRVersions(t *testing.T) {
+	tests := []struct {
+		length  int
+		version int
+	}{
+		{14, 1},
+		{15, 2},
+		{40, 3},
+		{213, 10},
+	}
+	for _, tt := range tests {
+		q, err := encodeQR(strings.Repeat("a", tt.length))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", tt.length, err)
+		}
+		if q.Version != tt.version || q.Size != 17+4*tt.version {
+			t.Errorf("%d bytes: expected version %d, got %d (size %d)", tt.length, tt.version, q.Version, q.Size)
+		}
+	}
+
+	if _, err := encodeQR(strings.Repeat("a", 214)); err == nil {
+		t.Error("expected an error for text too long for version 10")
+	}
+}
+
+func TestEncodeQRRoundTrip(t *testing.T) {
+	for _, text := range []string{
+		"movodoro://done/TS-pushups",
+		"movodoro done BWS-pushup-squats",
+		strings.Repeat("movodoro ", 18), // Version 7+: version info and two EC block sizes
+	} {
+		q, err := encodeQR(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := decodeQRForTest(t, q); got != text {
+			t.Errorf("round trip (version %d, mask %d): got %q, want %q", q.Version, q.Mask, got, text)
+		}
+	}
+}
+
+func TestWriteQRPNG(t *testing.T) {
+	q, err := encodeQR("movodoro://done/TS-pushups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "qr.png")
+	if err := writeQRPNG(path, q); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if side := (q.Size + 2*qrQuietZone) * qrPNGScale; img.Bounds().Dx() != side {
+		t.Errorf("expected %dpx wide, got %d", side, img.Bounds().Dx())
+	}
+}
+
+// decodeQRForTest reads a symbol back: format bits, unmasking, zigzag order,
+// de-interleaving and the byte mode segment
+func decodeQRForTest(t *testing.T, q *qrCode) string {
+	t.Helper()
+
+	// Format information (first copy, around the top-left finder)
+	var format int
+	read := func(i, x, y int) {
+		if q.Modules[y][x] {
+			format |= 1 << i
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		read(i, 8, i)
+	}
+	read(6, 8, 7)
+	read(7, 8, 8)
+	read(8, 7, 8)
+	for i := 9; i < 15; i++ {
+		read(i, 14-i, 8)
+	}
+	format ^= 0x5412
+	if level := format >> 13; level != qrFormatM {
+		t.Fatalf("expected level M, got format %015b", format)
+	}
+	mask := (format >> 10) & 7
+	if mask != q.Mask {
+		t.Fatalf("format says mask %d, encoder chose %d", mask, q.Mask)
+	}
+
+	// Which modules hold data comes from the function pattern layout
+	layoutOnly := &qrCode{Version: q.Version, Size: q.Size}
+	layoutOnly.Modules = make([][]bool, q.Size)
+	layoutOnly.function = make([][]bool, q.Size)
+	for i := range layoutOnly.Modules {
+		layoutOnly.Modules[i] = make([]bool, q.Size)
+		layoutOnly.function[i] = make([]bool, q.Size)
+	}
+	layoutOnly.drawFunctionPatterns()
+
+	var bits []bool
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vert
+				}
+				if layoutOnly.function[y][x] {
+					continue
+				}
+				bits = append(bits, q.Modules[y][x] != qrMaskApplies(mask, x, y))
+			}
+		}
+	}
+	var codewords []byte
+	for i := 0; i+8 <= len(bits); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			if bits[i+j] {
+				b |= 1 << (7 - j)
+			}
+		}
+		codewords = append(codewords, b)
+	}
+
+	// De-interleave the data codewords and check each block's EC
+	layout := qrVersionsM[q.Version-1]
+	numBlocks := layout.shortCount + layout.longCount
+	blocks := make([][]byte, numBlocks)
+	pos := 0
+	for i := 0; i <= layout.shortData; i++ {
+		for b := 0; b < numBlocks; b++ {
+			if i < layout.shortData || b >= layout.shortCount {
+				blocks[b] = append(blocks[b], codewords[pos])
+				pos++
+			}
+		}
+	}
+	divisor := reedSolomonDivisor(layout.ecPerBlock)
+	for b := range blocks {
+		var ec []byte
+		for i := 0; i < layout.ecPerBlock; i++ {
+			ec = append(ec, codewords[pos+i*numBlocks+b])
+		}
+		if !bytes.Equal(ec, reedSolomonRemainder(blocks[b], divisor)) {
+			t.Fatalf("block %d: EC codewords don't match its data", b)
+		}
+	}
+	var data []byte
+	for _, block := range blocks {
+		data = append(data, block...)
+	}
+
+	// Byte mode segment: 0100, length, bytes
+	if data[0]>>4 != 0b0100 {
+		t.Fatalf("expected byte mode, got %04b", data[0]>>4)
+	}
+	if q.Version >= 10 {
+		t.Fatal("test decoder only handles 8-bit lengths")
+	}
+	length := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+	text := make([]byte, length)
+	for i := range text {
+		text[i] = data[1+i]<<4 | data[2+i]>>4
+	}
+	return string(text)
+}
+
+func TestQRPayload(t *testing.T) {
+	tests := []struct {
+		name, base, token string
+		command           bool
+		want              string
+		wantErr           bool
+	}{
+		{"link", "http://192.168.1.20:8080", "", false, "http://192.168.1.20:8080/done/KB-swings", false},
+		{"link with token", "http://192.168.1.20:8080/", "s3cret", false, "http://192.168.1.20:8080/done/KB-swings?token=s3cret", false},
+		{"behind a path", "https://home.example/movodoro", "", false, "https://home.example/movodoro/done/KB-swings", false},
+		{"command", "", "", true, "movodoro done KB-swings", false},
+		{"no url", "", "s3cret", false, "", true},
+		{"not http", "movodoro://done", "", false, "", true},
+		{"no host", "http://", "", false, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := qrPayload("KB-swings", tt.base, tt.token, tt.command)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQRLinkLogsOnServe(t *testing.T) {
+	server := newTestServer(t, "s3cret")
+
+	// A camera scan opens the link with GET, which the token in it allows
+	link, err := qrPayload("BR-box-breathing", server.URL, "s3cret", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logged logResponse
+	if code := call(t, "GET", link, &logged); code != http.StatusOK {
+		t.Fatalf("expected 200 from %s, got %d", link, code)
+	}
+	if logged.Entry.Code != "BR-box-breathing" || logged.Entry.Status != "done" {
+		t.Errorf("expected BR-box-breathing logged as done, got %+v", logged.Entry)
+	}
+
+	// Without the token only a shortcut's POST logs it
+	link, err = qrPayload("BR-box-breathing", newTestServer(t, "").URL, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := call(t, "GET", link, nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("expected a tokenless GET refused, got %d", code)
+	}
+	if code := call(t, "POST", link, &logged); code != http.StatusOK {
+		t.Errorf("expected 200 from POST %s, got %d", link, code)
+	}
+}
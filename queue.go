@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// The queue file lines up specific movos for later today. It has the everyday
+// queue's format: the day on the first line, then one code per line.
+
+// loadQueue reads today's queue; one left over from an earlier day reads as empty
+func loadQueue(path string, now time.Time) (everydayQueue, error) {
+	q, err := loadEverydayQueue(path)
+	if err != nil {
+		return everydayQueue{}, fmt.Errorf("error reading queue: %w", err)
+	}
+	if today := dayKey(now); q.Day != today {
+		q = everydayQueue{Day: today}
+	}
+	return q, nil
+}
+
+// addToQueue appends codes to the end of today's queue
+func addToQueue(path string, codes []string, now time.Time) (everydayQueue, error) {
+	q, err := loadQueue(path, now)
+	if err != nil {
+		return q, err
+	}
+	q.Codes = append(q.Codes, codes...)
+	if err := saveEverydayQueue(path, q); err != nil {
+		return q, fmt.Errorf("error saving queue: %w", err)
+	}
+	return q, nil
+}
+
+// takeQueued removes and returns the first queued movo that matches the filters,
+// or nil if none does. Codes no longer in the library are dropped along the way.
+func takeQueued(path string, snacks []Movo, filters FilterOptions, now time.Time) (*Movo, error) {
+	q, err := loadQueue(path, now)
+	if err != nil || len(q.Codes) == 0 {
+		return nil, err
+	}
+
+	var taken *Movo
+	kept := q.Codes[:0]
+	for _, code := range q.Codes {
+		movo := findMovo(snacks, code)
+		switch {
+		case movo == nil:
+			continue
+		case taken == nil && len(filterSnacks([]Movo{*movo}, filters)) == 1:
+			taken = movo
+			continue
+		}
+		kept = append(kept, code)
+	}
+
+	q.Codes = kept
+	if err := saveEverydayQueue(path, q); err != nil {
+		return nil, fmt.Errorf("error saving queue: %w", err)
+	}
+	return taken, nil
+}
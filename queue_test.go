@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTakeQueued(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue")
+	now := time.Date(2025, 3, 5, 15, 0, 0, 0, time.Local)
+	snacks := []Movo{
+		{FullCode: "KB-swings", CategoryCode: "KB"},
+		{FullCode: "MOB-hips", CategoryCode: "MOB"},
+	}
+
+	if _, err := addToQueue(path, []string{"KB-swings", "GONE-old", "MOB-hips"}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first match for the filters is taken; a removed movo is dropped
+	movo, err := takeQueued(path, snacks, FilterOptions{Categories: []string{"MOB"}}, now)
+	if err != nil || movo == nil || movo.FullCode != "MOB-hips" {
+		t.Fatalf("expected MOB-hips, got %v %v", movo, err)
+	}
+	q, err := loadQueue(path, now)
+	if err != nil || len(q.Codes) != 1 || q.Codes[0] != "KB-swings" {
+		t.Fatalf("expected only KB-swings left, got %v %v", q.Codes, err)
+	}
+
+	if movo, _ := takeQueued(path, snacks, FilterOptions{}, now); movo == nil || movo.FullCode != "KB-swings" {
+		t.Errorf("expected KB-swings next, got %v", movo)
+	}
+	if movo, _ := takeQueued(path, snacks, FilterOptions{}, now); movo != nil {
+		t.Errorf("expected an empty queue, got %v", movo)
+	}
+}
+
+func TestQueueIsForToday(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue")
+	now := time.Date(2025, 3, 5, 15, 0, 0, 0, time.Local)
+	if _, err := addToQueue(path, []string{"KB-swings"}, now); err != nil {
+		t.Fatal(err)
+	}
+	q, err := loadQueue(path, now.AddDate(0, 0, 1))
+	if err != nil || len(q.Codes) != 0 {
+		t.Errorf("expected yesterday's queue to be gone, got %v %v", q.Codes, err)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+// quietOutput is set by the global --quiet (or --porcelain) flag, for scripts: get prints
+// just the full code, done and skip print nothing on success, and no command prompts.
+// Errors still go to stderr.
+var quietOutput bool
+
+// extractQuietFlag removes the global --quiet and --porcelain flags from args
+func extractQuietFlag(args []string) ([]string, bool) {
+	quiet := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--quiet" || arg == "--porcelain" {
+			quiet = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, quiet
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractQuietFlag(t *testing.T) {
+	for _, flag := range []string{"--quiet", "--porcelain"} {
+		rest, quiet := extractQuietFlag([]string{"get", flag, "-c", "BR"})
+		if !quiet || strings.Join(rest, " ") != "get -c BR" {
+			t.Errorf("extractQuietFlag(%s) = %v, %v", flag, rest, quiet)
+		}
+	}
+	if _, quiet := extractQuietFlag([]string{"done", "BR-box"}); quiet {
+		t.Error("extractQuietFlag() without the flag should be false")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand/v2"
+	"time"
+)
+
+// selectorRand is the random source for selection. It's seeded from crypto/rand so
+// invocations started at the same instant don't make the same picks; seedSelector
+// makes runs reproducible.
+var selectorRand = newSelectorRand()
+
+// newSelectorRand returns a PCG source seeded from crypto/rand
+func newSelectorRand() *rand.Rand {
+	var seed [16]byte
+	if _, err := crand.Read(seed[:]); err != nil {
+		// crypto/rand doesn't fail on supported platforms; fall back to the clock
+		now := uint64(time.Now().UnixNano())
+		return rand.New(rand.NewPCG(now, now>>32))
+	}
+	return rand.New(rand.NewPCG(binary.LittleEndian.Uint64(seed[:8]), binary.LittleEndian.Uint64(seed[8:])))
+}
+
+// seedSelector replaces the selection source with one seeded from seed (--seed / config seed)
+func seedSelector(seed uint64) {
+	selectorRand = rand.New(rand.NewPCG(seed, seed))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSeedSelectorReproducible(t *testing.T) {
+	weighted := []weightedSnack{
+		{snack: Movo{FullCode: "TS-pushups"}, weight: 2},
+		{snack: Movo{FullCode: "TB-box-breath"}, weight: 1},
+		{snack: Movo{FullCode: "MO-hip-circles"}, weight: 1},
+	}
+	saved := selectorRand
+	defer func() { selectorRand = saved }()
+
+	picks := func() []string {
+		seedSelector(42)
+		var codes []string
+		for i := 0; i < 20; i++ {
+			codes = append(codes, weightedRandomSelect(selectorRand, weighted).FullCode)
+		}
+		return codes
+	}
+
+	first, second := picks(), picks()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("pick %d differs with the same seed: %s vs %s", i, first[i], second[i])
+		}
+	}
+}
+
+func TestNewSelectorRandIndependent(t *testing.T) {
+	// Two sources created back to back must not share a seed
+	a, b := newSelectorRand(), newSelectorRand()
+	same := true
+	for i := 0; i < 4; i++ {
+		if a.Uint64() != b.Uint64() {
+			same = false
+		}
+	}
+	if same {
+		t.Error("expected independently seeded sources")
+	}
+}
+
+func TestExtractGlobalFlag(t *testing.T) {
+	args, seed, err := extractGlobalFlag([]string{"get", "--seed", "7", "-t", "kbx"}, "seed")
+	if err != nil || seed != "7" || len(args) != 3 || args[1] != "-t" {
+		t.Errorf("got %v, %q, %v", args, seed, err)
+	}
+
+	if _, seed, _ = extractGlobalFlag([]string{"--seed=9"}, "seed"); seed != "9" {
+		t.Errorf("expected 9, got %q", seed)
+	}
+}
+
+func TestExplorationPickIsLogged(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("exploration_rate: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalConfig, saved := appConfig, selectorRand
+	appConfig, selectorRand = DefaultConfig(), rand.New(rand.NewPCG(7, 7))
+	defer func() { appConfig, selectorRand = originalConfig, saved }()
+
+	movos := []Movo{
+		{FullCode: "TS-pushups", CategoryCode: "TS", Weight: 1, EffectiveRPE: 5},
+		{FullCode: "TB-box-breath", CategoryCode: "TB", Weight: 1, EffectiveRPE: 1},
+	}
+	snack, notes, err := pickSnack(movos, FilterOptions{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !notes.Exploration {
+		t.Fatal("exploration_rate: 1 should always make an exploration pick")
+	}
+	if err := saveCurrentPick(snack.FullCode, notes.Exploration); err != nil {
+		t.Fatal(err)
+	}
+	if code, err := loadCurrentSnack(); err != nil || code != snack.FullCode {
+		t.Fatalf("loadCurrentSnack() = %q, %v; want %q", code, err, snack.FullCode)
+	}
+
+	now := time.Now()
+	if err := appendLogEntry(HistoryEntry{Timestamp: now, Code: snack.FullCode, Status: "done", Duration: 3, RPE: 2}, snack); err != nil {
+		t.Fatal(err)
+	}
+	// Another movo logged meanwhile isn't marked
+	other := &movos[0]
+	if other.FullCode == snack.FullCode {
+		other = &movos[1]
+	}
+	if err := appendLogEntry(HistoryEntry{Timestamp: now, Code: other.FullCode, Status: "done", Duration: 3, RPE: 2}, other); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadDailyLog(appConfig.LogsDir, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	for _, entry := range entries {
+		want := ""
+		if entry.Code == snack.FullCode {
+			want = "1"
+		}
+		if got := entry.Extras[extraExploration]; got != want {
+			t.Errorf("%s: exploration = %q, want %q", entry.Code, got, want)
+		}
+	}
+}
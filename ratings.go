@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	minRating        = 1
+	maxRating        = 5
+	neutralRating    = 3.0
+	ratingWeightStep = 0.2 // Weight change per rating point above/below neutral
+	ratingWindowDays = 90  // Only ratings this recent feed selection
+)
+
+// extraRating is the log extras key holding the rating given right after completing a movo
+const extraRating = "rating"
+
+// recordRating stores a post-completion rating in the entry's extras (0 for none)
+func recordRating(entry *HistoryEntry, value int) {
+	if value < minRating || value > maxRating {
+		return
+	}
+	if entry.Extras == nil {
+		entry.Extras = make(map[string]string)
+	}
+	entry.Extras[extraRating] = strconv.Itoa(value)
+}
+
+// loggedRatings collects the ratings recorded with log entries
+func loggedRatings(entries []HistoryEntry) []Rating {
+	var ratings []Rating
+	for _, entry := range entries {
+		value, err := strconv.Atoi(entry.Extras[extraRating])
+		if err != nil || value < minRating || value > maxRating {
+			continue
+		}
+		ratings = append(ratings, Rating{Timestamp: entry.Timestamp, Code: entry.Code, Value: value})
+	}
+	return ratings
+}
+
+// loadAllRatings returns the ratings from 'rate' (ratings.csv) and those logged after done
+func loadAllRatings(cfg *Config) ([]Rating, error) {
+	ratings, err := LoadRatings(cfg.RatingsPath)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := LoadAllHistory(cfg.LogsDir)
+	if err != nil {
+		return nil, err
+	}
+	return append(ratings, loggedRatings(entries)...), nil
+}
+
+// loadRecentRatings returns the ratings from the last ratingWindowDays, so selection
+// follows what you enjoy now rather than what you liked a year ago
+func loadRecentRatings(cfg *Config, now time.Time) ([]Rating, error) {
+	since := now.AddDate(0, 0, -ratingWindowDays)
+	csvRatings, err := LoadRatings(cfg.RatingsPath)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := LoadHistoryRange(cfg.LogsDir, since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var ratings []Rating
+	for _, r := range append(csvRatings, loggedRatings(entries)...) {
+		if !r.Timestamp.Before(since) {
+			ratings = append(ratings, r)
+		}
+	}
+	return ratings, nil
+}
+
+// LoadRatings loads all ratings from the ratings CSV file
+func LoadRatings(path string) ([]Rating, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Rating{}, nil
+		}
+		return nil, fmt.Errorf("error opening ratings file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading ratings file: %w", err)
+	}
+
+	var ratings []Rating
+	for i, record := range records {
+		// Skip header row
+		if i == 0 && len(record) > 0 && record[0] == "timestamp" {
+			continue
+		}
+		if len(record) != 3 {
+			continue
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			continue
+		}
+		value, err := strconv.Atoi(record[2])
+		if err != nil || value < minRating || value > maxRating {
+			continue
+		}
+
+		ratings = append(ratings, Rating{Timestamp: timestamp, Code: record[1], Value: value})
+	}
+
+	return ratings, nil
+}
+
+// AppendRating appends a rating to the ratings CSV file
+func AppendRating(path string, rating Rating) error {
+	if rating.Value < minRating || rating.Value > maxRating {
+		return fmt.Errorf("rating must be between %d and %d", minRating, maxRating)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	fileInfo, err := os.Stat(path)
+	writeHeader := err != nil || fileInfo.Size() == 0
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening ratings file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if writeHeader {
+		writer.Write([]string{"timestamp", "code", "rating"})
+	}
+	writer.Write([]string{
+		rating.Timestamp.Format(time.RFC3339),
+		rating.Code,
+		strconv.Itoa(rating.Value),
+	})
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// SummarizeRatings returns the average rating per movo code
+func SummarizeRatings(ratings []Rating) map[string]RatingSummary {
+	totals := make(map[string]int)
+	summaries := make(map[string]RatingSummary)
+
+	for _, r := range ratings {
+		totals[r.Code] += r.Value
+		s := summaries[r.Code]
+		s.Count++
+		summaries[r.Code] = s
+	}
+
+	for code, s := range summaries {
+		s.Average = float64(totals[code]) / float64(s.Count)
+		summaries[code] = s
+	}
+
+	return summaries
+}
+
+// ratingMultiplier converts an average rating into a selection weight multiplier
+// (neutral rating = 1.0, each point above/below shifts the weight by ratingWeightStep)
+func ratingMultiplier(average float64) float64 {
+	return 1.0 + (average-neutralRating)*ratingWeightStep
+}
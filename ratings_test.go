@@ -0,0 +1,115 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRatingsReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratings.csv")
+
+	ratings := []Rating{
+		{Timestamp: time.Now(), Code: "TB-box-breath", Value: 5},
+		{Timestamp: time.Now(), Code: "TB-box-breath", Value: 4},
+		{Timestamp: time.Now(), Code: "TS-pushups", Value: 2},
+	}
+	for _, r := range ratings {
+		if err := AppendRating(path, r); err != nil {
+			t.Fatalf("failed to append rating: %v", err)
+		}
+	}
+
+	if err := AppendRating(path, Rating{Timestamp: time.Now(), Code: "TS-pushups", Value: 6}); err == nil {
+		t.Error("expected error for out-of-range rating")
+	}
+
+	loaded, err := LoadRatings(path)
+	if err != nil {
+		t.Fatalf("failed to load ratings: %v", err)
+	}
+	if len(loaded) != len(ratings) {
+		t.Fatalf("expected %d ratings, got %d", len(ratings), len(loaded))
+	}
+
+	summaries := SummarizeRatings(loaded)
+	if s := summaries["TB-box-breath"]; s.Count != 2 || s.Average != 4.5 {
+		t.Errorf("TB-box-breath: expected avg 4.5 over 2, got %.2f over %d", s.Average, s.Count)
+	}
+	if s := summaries["TS-pushups"]; s.Count != 1 || s.Average != 2 {
+		t.Errorf("TS-pushups: expected avg 2 over 1, got %.2f over %d", s.Average, s.Count)
+	}
+}
+
+func TestLoadRatingsMissingFile(t *testing.T) {
+	ratings, err := LoadRatings(filepath.Join(t.TempDir(), "missing.csv"))
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got: %v", err)
+	}
+	if len(ratings) != 0 {
+		t.Errorf("expected no ratings, got %d", len(ratings))
+	}
+}
+
+func TestRatingMultiplier(t *testing.T) {
+	tests := []struct {
+		average  float64
+		expected float64
+	}{
+		{3, 1.0},
+		{5, 1.4},
+		{1, 0.6},
+	}
+
+	for _, tt := range tests {
+		got := ratingMultiplier(tt.average)
+		if got < tt.expected-1e-9 || got > tt.expected+1e-9 {
+			t.Errorf("ratingMultiplier(%.1f) = %.2f, want %.2f", tt.average, got, tt.expected)
+		}
+	}
+}
+
+func TestLoggedRatings(t *testing.T) {
+	entry := HistoryEntry{Timestamp: time.Now(), Code: "TS-pushups", Status: "done"}
+	recordRating(&entry, 4)
+	unrated := HistoryEntry{Timestamp: time.Now(), Code: "TB-box-breath", Status: "done"}
+	recordRating(&unrated, 0)
+	if unrated.Extras != nil {
+		t.Errorf("a skipped rating should leave extras alone, got %v", unrated.Extras)
+	}
+
+	ratings := loggedRatings([]HistoryEntry{entry, unrated})
+	if len(ratings) != 1 || ratings[0].Code != "TS-pushups" || ratings[0].Value != 4 {
+		t.Errorf("loggedRatings = %+v", ratings)
+	}
+}
+
+func TestLoadRecentRatings(t *testing.T) {
+	dir := t.TempDir()
+	cfg := TestConfig(dir)
+	now := time.Now()
+
+	AppendRating(cfg.RatingsPath, Rating{Timestamp: now.AddDate(0, 0, -200), Code: "TS-pushups", Value: 1})
+	AppendRating(cfg.RatingsPath, Rating{Timestamp: now.AddDate(0, 0, -3), Code: "TS-pushups", Value: 5})
+	entry := HistoryEntry{Timestamp: now.Add(-time.Hour), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 5}
+	recordRating(&entry, 4)
+	if err := AppendDailyLog(cfg.LogsDir, entry); err != nil {
+		t.Fatalf("AppendDailyLog: %v", err)
+	}
+
+	ratings, err := loadRecentRatings(cfg, now)
+	if err != nil {
+		t.Fatalf("loadRecentRatings: %v", err)
+	}
+	if s := SummarizeRatings(ratings)["TS-pushups"]; s.Count != 2 || s.Average != 4.5 {
+		t.Errorf("expected the two recent ratings (avg 4.5), got %.2f over %d", s.Average, s.Count)
+	}
+
+	all, err := loadAllRatings(cfg)
+	if err != nil {
+		t.Fatalf("loadAllRatings: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("loadAllRatings returned %d ratings, want 3", len(all))
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// movoLibrary keeps the movos loaded for a long-running mode (interactive, watch) and
+// reloads them when a YAML file in the movos directory or overrides.yaml changes, so
+// an edited movo shows up in the next suggestion without restarting
+type movoLibrary struct {
+	Movos []Movo
+	stamp string
+}
+
+// loadLibrary loads the movos and remembers the state of the files they came from
+func loadLibrary() (*movoLibrary, error) {
+	stamp := libraryStamp(DefaultConfig())
+	movos, err := LoadSnacks()
+	if err != nil {
+		return nil, err
+	}
+	return &movoLibrary{Movos: movos, stamp: stamp}, nil
+}
+
+// refresh reloads the movos if any of their files was added, removed or modified since
+// the last load, reporting whether it did. A library that no longer loads (say a file
+// saved halfway through an edit) keeps the previous movos and is retried on the next change.
+func (l *movoLibrary) refresh() (bool, error) {
+	stamp := libraryStamp(DefaultConfig())
+	if stamp == l.stamp {
+		return false, nil
+	}
+	l.stamp = stamp
+	movos, err := LoadSnacks()
+	if err != nil {
+		return false, err
+	}
+	l.Movos = movos
+	return true, nil
+}
+
+// libraryStamp summarises the name, size and modification time of every file the
+// library is loaded from; polling it is cheap next to parsing the YAML
+func libraryStamp(cfg *Config) string {
+	files, _ := filepath.Glob(filepath.Join(cfg.MovosDir, "*.yaml"))
+	sort.Strings(files)
+	files = append(files, cfg.OverridesPath)
+
+	var b strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMovoLibraryRefresh(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	movosDir := filepath.Join(home, "movos")
+	t.Setenv("MOVODORO_MOVOS_DIR", movosDir)
+	if err := os.MkdirAll(movosDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(movosDir, "breathing.yaml")
+	write := func(title string, mtime time.Time) {
+		t.Helper()
+		yaml := "category: Breathing\ncode: BR\nmovos:\n  - code: box\n    title: " + title + "\n    duration_min: 2\n    duration_max: 4\n    weight: 1.0\n"
+		if err := os.WriteFile(file, []byte(yaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now().Add(-time.Hour)
+	write("Box breathing", start)
+
+	library, err := loadLibrary()
+	if err != nil {
+		t.Fatalf("loadLibrary() error = %v", err)
+	}
+	if reloaded, err := library.refresh(); reloaded || err != nil {
+		t.Errorf("refresh() with no changes = %v, %v", reloaded, err)
+	}
+
+	write("Square breathing", start.Add(time.Minute))
+	if reloaded, err := library.refresh(); !reloaded || err != nil {
+		t.Fatalf("refresh() after an edit = %v, %v", reloaded, err)
+	}
+	if library.Movos[0].Title != "Square breathing" {
+		t.Errorf("title = %q, want the edited one", library.Movos[0].Title)
+	}
+
+	// A broken file keeps the previous movos
+	if err := os.WriteFile(file, []byte("movos: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := library.refresh(); err == nil {
+		t.Error("expected an error for a broken file")
+	}
+	if len(library.Movos) != 1 || library.Movos[0].Title != "Square breathing" {
+		t.Errorf("movos after a failed reload = %v", library.Movos)
+	}
+
+	// A new file is picked up
+	extra := "category: Mobility\ncode: MOB\nmovos:\n  - code: hips\n    title: Hip circles\n    duration_min: 2\n    duration_max: 4\n    weight: 1.0\n"
+	write("Box breathing", start.Add(2*time.Minute))
+	if err := os.WriteFile(filepath.Join(movosDir, "mobility.yaml"), []byte(extra), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err := library.refresh(); !reloaded || err != nil || len(library.Movos) != 2 {
+		t.Errorf("refresh() after adding a file = %v, %v, %d movos", reloaded, err, len(library.Movos))
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	descriptionWidth = 80 // Widest a rendered description gets, even on wide terminals
+	ansiBold         = "\033[1m"
+	boldMarker       = "**"
+)
+
+// plainOutput is set by the global --plain flag: descriptions are printed exactly as
+// written in the YAML and no colour is used, for scripts that parse the output
+var plainOutput bool
+
+var numberedLine = regexp.MustCompile(`^\d+[.)]\s+`)
+
+// extractPlainFlag removes the global --plain flag from args
+func extractPlainFlag(args []string) ([]string, bool) {
+	plain := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--plain" {
+			plain = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, plain
+}
+
+// terminalWidth is the width to wrap descriptions at: the terminal's, capped at descriptionWidth
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || width > descriptionWidth {
+		return descriptionWidth
+	}
+	return width
+}
+
+// renderDescription formats a description written in light markdown for the terminal.
+// Lines keep their breaks but long ones wrap to width; "# heading" and **bold** are
+// shown bold (markers are dropped without colour), "-", "*" and "+" bullets become •
+// with a hanging indent, and numbered steps are renumbered 1, 2, 3... within each list.
+func renderDescription(text string, width int, color bool) string {
+	var out []string
+	step := 0
+	blank := false
+	for _, raw := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		line := strings.TrimRight(raw, " \t")
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := strings.Repeat("  ", (len(line)-len(trimmed))/2)
+
+		if trimmed == "" {
+			step = 0
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			step = 0
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			out = append(out, wrapStyled(boldMarker+heading+boldMarker, indent, width, color)...)
+		case isBullet(trimmed):
+			out = append(out, wrapStyled(trimmed[2:], indent+"• ", width, color)...)
+		case numberedLine.MatchString(trimmed):
+			step++
+			prefix := indent + strconv.Itoa(step) + ". "
+			out = append(out, wrapStyled(numberedLine.ReplaceAllString(trimmed, ""), prefix, width, color)...)
+		default:
+			if indent == "" {
+				step = 0
+			}
+			out = append(out, wrapStyled(trimmed, indent, width, color)...)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// isBullet reports whether a trimmed line is a "- ", "* " or "+ " list item
+func isBullet(line string) bool {
+	return len(line) > 2 && strings.ContainsRune("-*+", rune(line[0])) && line[1] == ' '
+}
+
+// wrapStyled word-wraps text to width after prefix, indenting continuation lines
+// under the text, then turns its **bold** spans into ANSI bold (or drops the markers)
+func wrapStyled(text, prefix string, width int, color bool) []string {
+	hang := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+	avail := width - utf8.RuneCountInString(prefix)
+
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		if current != "" && visibleWidth(current)+1+visibleWidth(word) > avail {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+	lines = append(lines, current)
+
+	bold := false
+	for i, line := range lines {
+		lead := hang
+		if i == 0 {
+			lead = prefix
+		}
+		lines[i] = lead + styleBold(line, &bold, color)
+	}
+	return lines
+}
+
+// visibleWidth is the number of columns text takes once bold markers are removed
+func visibleWidth(text string) int {
+	return utf8.RuneCountInString(strings.ReplaceAll(text, boldMarker, ""))
+}
+
+// styleBold replaces the bold markers in one wrapped line, carrying an open span
+// over to the next line so a bold phrase split by wrapping stays bold
+func styleBold(line string, bold *bool, color bool) string {
+	var b strings.Builder
+	if *bold && color {
+		b.WriteString(ansiBold)
+	}
+	parts := strings.Split(line, boldMarker)
+	for i, part := range parts {
+		if i > 0 {
+			*bold = !*bold
+			if color {
+				if *bold {
+					b.WriteString(ansiBold)
+				} else {
+					b.WriteString(ansiReset)
+				}
+			}
+		}
+		b.WriteString(part)
+	}
+	if *bold && color {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
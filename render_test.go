@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderDescriptionLists(t *testing.T) {
+	text := "Breathe in a 4-4-4-4 pattern:\n- Inhale for 4 counts\n* Hold for **4** counts\n\n1. Wrist circles\n1. Prayer stretch\n3) Reverse prayer\n"
+	got := renderDescription(text, 80, false)
+	want := strings.Join([]string{
+		"Breathe in a 4-4-4-4 pattern:",
+		"• Inhale for 4 counts",
+		"• Hold for 4 counts",
+		"",
+		"1. Wrist circles",
+		"2. Prayer stretch",
+		"3. Reverse prayer",
+	}, "\n")
+	if got != want {
+		t.Errorf("renderDescription() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderDescriptionWraps(t *testing.T) {
+	text := "- Stand tall with feet hip width apart and arms relaxed by your sides"
+	got := renderDescription(text, 30, false)
+	want := "• Stand tall with feet hip\n  width apart and arms relaxed\n  by your sides"
+	if got != want {
+		t.Errorf("renderDescription() =\n%s\nwant\n%s", got, want)
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if len([]rune(line)) > 30 {
+			t.Errorf("line %q is wider than 30 columns", line)
+		}
+	}
+}
+
+func TestRenderDescriptionBold(t *testing.T) {
+	got := renderDescription("# Setup\nKeep **your back flat** throughout", 16, true)
+	want := ansiBold + "Setup" + ansiReset + "\n" +
+		"Keep " + ansiBold + "your back" + ansiReset + "\n" +
+		ansiBold + "flat" + ansiReset + " throughout"
+	if got != want {
+		t.Errorf("renderDescription() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractPlainFlag(t *testing.T) {
+	rest, plain := extractPlainFlag([]string{"get", "--plain", "-c", "BR"})
+	if !plain || strings.Join(rest, " ") != "get -c BR" {
+		t.Errorf("extractPlainFlag() = %v, %v", rest, plain)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRepeatWindow = 1    // Don't show the same movo twice in a row
+	repeatPenalty       = 0.05 // Weight multiplier for movos inside the anti-repeat window
+	recentKept          = 50   // Shown movos remembered for the anti-repeat window
+)
+
+// recentShow is one movo shown by get, interactive mode, batch or serve
+type recentShow struct {
+	Code string
+	At   time.Time
+}
+
+// loadRecent reads the recent file ("CODE TIME" per line, oldest first).
+// A missing file means nothing has been shown.
+func loadRecent(path string) ([]recentShow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading recent movos: %w", err)
+	}
+
+	var recent []recentShow
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+		recent = append(recent, recentShow{Code: fields[0], At: at})
+	}
+	return recent, nil
+}
+
+// recordShown appends code to the recent file, keeping only the last recentKept
+func recordShown(path, code string, now time.Time) error {
+	recent, err := loadRecent(path)
+	if err != nil {
+		return err
+	}
+	recent = append(recent, recentShow{Code: code, At: now})
+	if len(recent) > recentKept {
+		recent = recent[len(recent)-recentKept:]
+	}
+
+	var b strings.Builder
+	for _, r := range recent {
+		fmt.Fprintf(&b, "%s %s\n", r.Code, r.At.Format(time.RFC3339))
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// repeatedRecently reports whether code falls inside the anti-repeat window: among
+// the last window movos shown, or shown or done (lastDone) within hours of now
+func repeatedRecently(recent []recentShow, code string, lastDone *time.Time, window, hours int, now time.Time) bool {
+	for i := len(recent) - 1; i >= 0 && i >= len(recent)-window; i-- {
+		if recent[i].Code == code {
+			return true
+		}
+	}
+
+	if hours <= 0 {
+		return false
+	}
+	since := now.Add(-time.Duration(hours) * time.Hour)
+	if lastDone != nil && lastDone.After(since) {
+		return true
+	}
+	for _, r := range recent {
+		if r.Code == code && r.At.After(since) {
+			return true
+		}
+	}
+	return false
+}
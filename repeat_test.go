@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordShownKeepsTheLatest(t *testing.T) {
+	path := t.TempDir() + "/recent"
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < recentKept+5; i++ {
+		code := "TS-pushups"
+		if i == recentKept+4 {
+			code = "TB-box-breath"
+		}
+		if err := recordShown(path, code, now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recent, err := loadRecent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != recentKept || recent[len(recent)-1].Code != "TB-box-breath" {
+		t.Fatalf("expected the last %d shown, newest last, got %d ending %+v", recentKept, len(recent), recent[len(recent)-1])
+	}
+}
+
+func TestRepeatedRecently(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	recent := []recentShow{
+		{Code: "KB-swings", At: now.Add(-3 * time.Hour)},
+		{Code: "TS-pushups", At: now.Add(-90 * time.Minute)},
+		{Code: "TB-box-breath", At: now.Add(-time.Minute)},
+	}
+	doneEarlier := now.Add(-30 * time.Minute)
+
+	tests := []struct {
+		name     string
+		code     string
+		lastDone *time.Time
+		window   int
+		hours    int
+		want     bool
+	}{
+		{"last shown", "TB-box-breath", nil, 1, 0, true},
+		{"outside the window", "TS-pushups", nil, 1, 0, false},
+		{"inside a wider window", "TS-pushups", nil, 2, 0, true},
+		{"shown within the hours", "TS-pushups", nil, 0, 2, true},
+		{"shown before the hours", "KB-swings", nil, 0, 2, false},
+		{"done within the hours", "CORE-plank", &doneEarlier, 0, 1, true},
+		{"everything off", "TB-box-breath", &doneEarlier, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := repeatedRecently(recent, tt.code, tt.lastDone, tt.window, tt.hours, now); got != tt.want {
+			t.Errorf("%s: repeatedRecently(%s) = %v, want %v", tt.name, tt.code, got, tt.want)
+		}
+	}
+}
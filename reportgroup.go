@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sessionGap is the longest pause between entries that still counts as one session
+const sessionGap = 30 * time.Minute
+
+// reportGroupings lists the valid values for 'report day --group-by'
+var reportGroupings = []string{"category", "session", "hour"}
+
+// entryGroup is a labelled run of report entries
+type entryGroup struct {
+	Label   string
+	Entries []HistoryEntry
+}
+
+// totals returns the summed duration and RPE of the group's entries
+func (g entryGroup) totals() (duration, rpe int) {
+	for _, entry := range g.Entries {
+		duration += entry.Duration
+		rpe += entry.RPE
+	}
+	return duration, rpe
+}
+
+// validateGrouping checks a --group-by value (empty means no grouping)
+func validateGrouping(groupBy string) error {
+	if groupBy == "" {
+		return nil
+	}
+	for _, g := range reportGroupings {
+		if g == groupBy {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown grouping %q (use: %s)", groupBy, strings.Join(reportGroupings, ", "))
+}
+
+// groupEntries groups chronological entries by category, session or hour.
+// Any other groupBy returns a single unlabelled group.
+func groupEntries(entries []HistoryEntry, groupBy string) []entryGroup {
+	switch groupBy {
+
+	case "category":
+		byCategory := make(map[string][]HistoryEntry)
+		for _, entry := range entries {
+			category := codeCategory(entry.Code)
+			byCategory[category] = append(byCategory[category], entry)
+		}
+		var categories []string
+		for category := range byCategory {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+
+		groups := make([]entryGroup, 0, len(categories))
+		for _, category := range categories {
+			groups = append(groups, entryGroup{Label: category, Entries: byCategory[category]})
+		}
+		return groups
+
+	case "session":
+		var groups []entryGroup
+		for i, entry := range entries {
+			if i == 0 || entry.Timestamp.Sub(entries[i-1].Timestamp) > sessionGap {
+				groups = append(groups, entryGroup{})
+			}
+			last := &groups[len(groups)-1]
+			last.Entries = append(last.Entries, entry)
+		}
+		for i := range groups {
+			first := groups[i].Entries[0].Timestamp
+			last := groups[i].Entries[len(groups[i].Entries)-1].Timestamp
+			groups[i].Label = fmt.Sprintf("Session %d, %s-%s", i+1,
+				appConfig.FormatClock(first), appConfig.FormatClock(last))
+		}
+		return groups
+
+	case "hour":
+		var groups []entryGroup
+		var current time.Time
+		for i, entry := range entries {
+			t := entry.Timestamp
+			hour := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+			if i == 0 || !hour.Equal(current) {
+				current = hour
+				groups = append(groups, entryGroup{Label: appConfig.FormatClock(hour)})
+			}
+			last := &groups[len(groups)-1]
+			last.Entries = append(last.Entries, entry)
+		}
+		return groups
+	}
+
+	return []entryGroup{{Entries: entries}}
+}
+
+// codeCategory returns the category code of a full movo code ("TS-pushups" -> "TS")
+func codeCategory(code string) string {
+	category, _, _ := strings.Cut(code, "-")
+	return category
+}
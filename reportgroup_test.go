@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupEntries(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2025, 10, 12, h, m, 0, 0, time.UTC) }
+	entries := []HistoryEntry{
+		doneAt("TS-pushups", at(9, 0)),
+		doneAt("TB-box-breath", at(9, 20)),
+		doneAt("TS-squats", at(9, 45)),
+		doneAt("TB-box-breath", at(14, 10)),
+	}
+
+	t.Run("category", func(t *testing.T) {
+		groups := groupEntries(entries, "category")
+		if len(groups) != 2 || groups[0].Label != "TB" || groups[1].Label != "TS" {
+			t.Fatalf("unexpected groups: %+v", groups)
+		}
+		if len(groups[0].Entries) != 2 || len(groups[1].Entries) != 2 {
+			t.Errorf("expected 2 entries per category, got %d and %d", len(groups[0].Entries), len(groups[1].Entries))
+		}
+	})
+
+	t.Run("session", func(t *testing.T) {
+		groups := groupEntries(entries, "session")
+		if len(groups) != 2 {
+			t.Fatalf("expected 2 sessions, got %d", len(groups))
+		}
+		if len(groups[0].Entries) != 3 {
+			t.Errorf("expected first session to have 3 entries, got %d", len(groups[0].Entries))
+		}
+	})
+
+	t.Run("hour", func(t *testing.T) {
+		groups := groupEntries(entries, "hour")
+		if len(groups) != 2 {
+			t.Fatalf("expected 2 hours, got %d", len(groups))
+		}
+		duration, _ := groups[0].totals()
+		if duration != 15 {
+			t.Errorf("expected 15 minutes in the 9:00 group, got %d", duration)
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		groups := groupEntries(entries, "")
+		if len(groups) != 1 || groups[0].Label != "" || len(groups[0].Entries) != 4 {
+			t.Errorf("expected a single unlabelled group, got %+v", groups)
+		}
+	})
+}
+
+func TestValidateGrouping(t *testing.T) {
+	for _, g := range []string{"", "category", "session", "hour"} {
+		if err := validateGrouping(g); err != nil {
+			t.Errorf("validateGrouping(%q) = %v", g, err)
+		}
+	}
+	if err := validateGrouping("tag"); err == nil {
+		t.Error("expected an error for an unknown grouping")
+	}
+}
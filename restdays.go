@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	restDayMaxRPE          = 2 // Max RPE the selector offers on a rest day
+	defaultRestEverydayRPE = 2 // Everyday minimums above this RPE are waived on rest days
+)
+
+// restSchedule says which days are rest days: every rest_days weekday plus the
+// dates taken off with 'rest'
+type restSchedule struct {
+	Weekdays []time.Weekday
+	Dates    map[string]bool
+}
+
+// loadRestSchedule combines rest_days from config.yaml with the ad-hoc rest dates file
+func loadRestSchedule(cfg *Config) (restSchedule, error) {
+	dates, err := loadRestDates(cfg.RestDatesPath)
+	return restSchedule{Weekdays: cfg.RestDays, Dates: dates}, err
+}
+
+// isRest reports whether t falls on a rest day
+func (r restSchedule) isRest(t time.Time) bool {
+	return slices.Contains(r.Weekdays, t.Weekday()) || r.Dates[dayKey(t)]
+}
+
+// isRestDay reports whether t is a rest day, treating an unreadable rest dates file as none
+func isRestDay(cfg *Config, t time.Time) bool {
+	rest, _ := loadRestSchedule(cfg)
+	return rest.isRest(t)
+}
+
+// waivedOnRestDay reports whether an everyday movo's minimum is waived on a rest day
+func waivedOnRestDay(snack *Movo, cfg *Config) bool {
+	return snack.MinPerDay > 0 && snack.EffectiveRPE > cfg.RestEverydayRPE
+}
+
+// dropWaivedMinimums removes everyday movos whose minimums are waived today
+func dropWaivedMinimums(snacks []Movo, cfg *Config, now time.Time) []Movo {
+	if !isRestDay(cfg, now) {
+		return snacks
+	}
+	var kept []Movo
+	for i := range snacks {
+		if !waivedOnRestDay(&snacks[i], cfg) {
+			kept = append(kept, snacks[i])
+		}
+	}
+	return kept
+}
+
+// loadRestDates reads the ad-hoc rest dates file (one YYYY-MM-DD per line). A missing
+// file means there are none.
+func loadRestDates(path string) (map[string]bool, error) {
+	dates := make(map[string]bool)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return dates, nil
+		}
+		return dates, fmt.Errorf("error reading rest days: %w", err)
+	}
+	for _, line := range strings.Fields(string(data)) {
+		dates[line] = true
+	}
+	return dates, nil
+}
+
+// setRestDate marks day as a rest day, or clears it when rest is false
+func setRestDate(path string, day time.Time, rest bool) error {
+	dates, err := loadRestDates(path)
+	if err != nil {
+		return err
+	}
+	if rest {
+		dates[dayKey(day)] = true
+	} else {
+		delete(dates, dayKey(day))
+	}
+
+	keys := make([]string, 0, len(dates))
+	for key := range dates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintln(&b, key)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// parseRestDay reads the day argument to 'rest': today, tomorrow or YYYY-MM-DD
+func parseRestDay(arg string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(arg) {
+	case "", "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+	day, err := time.ParseInLocation(dayKeyFormat, arg, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day %q (use today, tomorrow or YYYY-MM-DD)", arg)
+	}
+	return day, nil
+}
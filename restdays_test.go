@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDefaultConfigRestDays(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(content string) *Config {
+		if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return DefaultConfig()
+	}
+
+	cfg := write("rest_days: [sunday, Wed, sunday]\nrest_everyday_rpe: 3\n")
+	if cfg.ConfigErr != nil || len(cfg.RestDays) != 2 || cfg.RestDays[0] != time.Sunday || cfg.RestEverydayRPE != 3 {
+		t.Errorf("unexpected rest config: %v %v %d", cfg.ConfigErr, cfg.RestDays, cfg.RestEverydayRPE)
+	}
+	if cfg := write("rest_days: [someday]\n"); cfg.ConfigErr == nil {
+		t.Error("expected an error for an unknown weekday")
+	}
+	if cfg := write("rest_days: [mon, tue, wed, thu, fri, sat, sun]\n"); cfg.ConfigErr == nil || cfg.RestDays != nil {
+		t.Error("expected a whole week of rest days to be rejected")
+	}
+}
+
+func TestSetRestDate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rest-days")
+	friday := time.Date(2025, 3, 14, 0, 0, 0, 0, time.Local)
+
+	if err := setRestDate(path, friday, true); err != nil {
+		t.Fatal(err)
+	}
+	rest := restSchedule{Weekdays: []time.Weekday{time.Sunday}}
+	rest.Dates, _ = loadRestDates(path)
+	if !rest.isRest(friday.Add(15*time.Hour)) || !rest.isRest(friday.AddDate(0, 0, 2)) || rest.isRest(friday.AddDate(0, 0, 1)) {
+		t.Errorf("expected Friday and Sundays to be rest days, got %+v", rest)
+	}
+
+	if err := setRestDate(path, friday, false); err != nil {
+		t.Fatal(err)
+	}
+	if dates, _ := loadRestDates(path); len(dates) != 0 {
+		t.Errorf("expected no rest dates after cancelling, got %v", dates)
+	}
+}
+
+func TestParseRestDay(t *testing.T) {
+	now := time.Date(2025, 3, 14, 16, 30, 0, 0, time.Local)
+	for arg, want := range map[string]string{"": "2025-03-14", "today": "2025-03-14", "Tomorrow": "2025-03-15", "2025-04-01": "2025-04-01"} {
+		if got, err := parseRestDay(arg, now); err != nil || dayKey(got) != want {
+			t.Errorf("parseRestDay(%q) = %v, %v; want %s", arg, got, err, want)
+		}
+	}
+	if _, err := parseRestDay("someday", now); err == nil {
+		t.Error("expected an error for an unknown day")
+	}
+}
+
+func TestStreakBridgesRestDays(t *testing.T) {
+	counts := map[string]int{"2025-10-10": 1, "2025-10-11": 1, "2025-10-13": 1}
+	today := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	// Sunday the 12th was missed
+	if got := streakWithSkips(counts, nil, restSchedule{}, 1, today, skipNeutral); got != 1 {
+		t.Errorf("expected the missed Sunday to break the streak, got %d", got)
+	}
+	rest := restSchedule{Weekdays: []time.Weekday{time.Sunday}}
+	if got := streakWithSkips(counts, nil, rest, 1, today, skipNeutral); got != 3 {
+		t.Errorf("expected the rest day bridged, got %d", got)
+	}
+}
+
+func TestWeighCandidatesOnRestDay(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cfg := DefaultConfig()
+	if err := os.MkdirAll(filepath.Dir(cfg.RestDatesPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := setRestDate(cfg.RestDatesPath, time.Now(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	movos := []Movo{
+		{FullCode: "STR-deadlift", EffectiveRPE: 8, Weight: 1, MinPerDay: 1},
+		{FullCode: "STR-squats", EffectiveRPE: 5, Weight: 1},
+		{FullCode: "EYE-break", EffectiveRPE: 3, Weight: 1, RecoverySafe: true},
+		{FullCode: "BR-box", EffectiveRPE: 1, Weight: 1},
+		{FullCode: "MOB-hips", EffectiveRPE: 2, Weight: 1},
+	}
+	weighted, _, err := weighCandidates(movos, FilterOptions{}, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The deadlift's everyday minimum is waived, leaving the light movos to pick from
+	if len(weighted) != 2 || weighted[0].snack.FullCode != "BR-box" || weighted[1].snack.FullCode != "MOB-hips" {
+		t.Errorf("expected only the RPE ≤ 2 movos, got %+v", weighted)
+	}
+
+	// An everyday movo within rest_everyday_rpe is still prioritized
+	movos[4].MinPerDay = 1
+	weighted, _, err = weighCandidates(movos, FilterOptions{}, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(weighted) != 1 || weighted[0].snack.FullCode != "MOB-hips" {
+		t.Errorf("expected the light everyday movo first, got %+v", weighted)
+	}
+}
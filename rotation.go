@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Selection modes: weighted random across every candidate, or rotating through
+// categories round-robin over the day and picking weighted within the category
+const (
+	selectionWeighted = "weighted"
+	selectionRotation = "rotation"
+)
+
+// parseSelectionMode validates a selection_mode value ("" means weighted)
+func parseSelectionMode(s string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(s)); mode {
+	case "":
+		return selectionWeighted, nil
+	case selectionWeighted, selectionRotation:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("selection_mode must be weighted or rotation, got %q", s)
+	}
+}
+
+// rotationOrder lists every category code in rotation order: those named in
+// preferred first, then the rest as they appear in the library
+func rotationOrder(snacks []Movo, preferred []string) []string {
+	var order []string
+	for _, code := range preferred {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code != "" && !slices.Contains(order, code) {
+			order = append(order, code)
+		}
+	}
+	for _, snack := range snacks {
+		if !slices.Contains(order, snack.CategoryCode) {
+			order = append(order, snack.CategoryCode)
+		}
+	}
+	return order
+}
+
+// lastCategoryToday returns the category of today's most recent completion
+// (partials included), or "" if nothing has been done yet
+func lastCategoryToday(stats DailyStats, snacks []Movo) string {
+	var last *HistoryEntry
+	for _, entries := range [][]HistoryEntry{stats.CompletedSnacks, stats.PartialSnacks} {
+		for i := range entries {
+			if last == nil || !entries[i].Timestamp.Before(last.Timestamp) {
+				last = &entries[i]
+			}
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	if movo := findMovo(snacks, last.Code); movo != nil {
+		return movo.CategoryCode
+	}
+	category, _, _ := strings.Cut(last.Code, "-")
+	return category
+}
+
+// nextRotationCategory is the first category after last in order with a candidate,
+// wrapping around. With nothing done yet (or last not in order) it starts at the top.
+func nextRotationCategory(order []string, last string, candidates []Movo) string {
+	available := make(map[string]bool)
+	for _, snack := range candidates {
+		available[snack.CategoryCode] = true
+	}
+
+	start := slices.Index(order, last) + 1 // 0 when last isn't found
+	for i := range order {
+		if category := order[(start+i)%len(order)]; available[category] {
+			return category
+		}
+	}
+	return ""
+}
+
+// filterToCategory keeps the candidates in one category
+func filterToCategory(candidates []Movo, category string) []Movo {
+	var filtered []Movo
+	for _, snack := range candidates {
+		if snack.CategoryCode == category {
+			filtered = append(filtered, snack)
+		}
+	}
+	return filtered
+}
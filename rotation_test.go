@@ -0,0 +1,60 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRotationOrder(t *testing.T) {
+	snacks := []Movo{{CategoryCode: "CF"}, {CategoryCode: "MOB"}, {CategoryCode: "RB"}, {CategoryCode: "TS"}}
+	got := rotationOrder(snacks, []string{"rb", "TS", "rb"})
+	if want := []string{"RB", "TS", "CF", "MOB"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNextRotationCategory(t *testing.T) {
+	order := []string{"RB", "TS", "CF", "MOB"}
+	candidates := []Movo{{CategoryCode: "RB"}, {CategoryCode: "CF"}, {CategoryCode: "MOB"}}
+
+	tests := []struct {
+		last string
+		want string
+	}{
+		{"", "RB"},      // Nothing done yet: top of the order
+		{"RB", "CF"},    // TS has no candidates
+		{"MOB", "RB"},   // Wraps around
+		{"OTHER", "RB"}, // Unknown category starts over
+	}
+	for _, tt := range tests {
+		if got := nextRotationCategory(order, tt.last, candidates); got != tt.want {
+			t.Errorf("after %q: got %q, want %q", tt.last, got, tt.want)
+		}
+	}
+
+	// A lone category comes round again
+	if got := nextRotationCategory(order, "CF", []Movo{{CategoryCode: "CF"}}); got != "CF" {
+		t.Errorf("expected CF again, got %q", got)
+	}
+}
+
+func TestLastCategoryToday(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	snacks := []Movo{{FullCode: "TS-pushups", CategoryCode: "TS"}}
+	stats := DailyStats{
+		CompletedSnacks: []HistoryEntry{{Code: "TS-pushups", Timestamp: now.Add(-2 * time.Hour)}},
+		PartialSnacks:   []HistoryEntry{{Code: "CF-burpees", Timestamp: now.Add(-time.Hour)}},
+	}
+	if got := lastCategoryToday(stats, snacks); got != "CF" {
+		t.Errorf("expected the later partial's category from its code, got %q", got)
+	}
+
+	stats.PartialSnacks = nil
+	if got := lastCategoryToday(stats, snacks); got != "TS" {
+		t.Errorf("expected TS, got %q", got)
+	}
+	if got := lastCategoryToday(DailyStats{}, snacks); got != "" {
+		t.Errorf("expected nothing done yet, got %q", got)
+	}
+}
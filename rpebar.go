@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	rpeBarWidth   = 20
+	rpeBarYellow  = 0.6  // Share of the daily budget where the bar turns yellow
+	rpeBarRed     = 0.85 // ...and red
+	ansiGreen     = "\033[32m"
+	ansiYellow    = "\033[33m"
+	ansiRed       = "\033[31m"
+	ansiReset     = "\033[0m"
+	rpeBarFilled  = "█"
+	rpeBarPending = "░"
+)
+
+// useColor reports whether stdout is a terminal that should get ANSI colours (honours NO_COLOR and --plain)
+func useColor() bool {
+	return !plainOutput && os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// rpeBarColor picks the colour for a bar filled to used/max: green, then yellow, then red near the cap
+func rpeBarColor(used, max int) string {
+	share := float64(used) / float64(max)
+	switch {
+	case share >= rpeBarRed:
+		return ansiRed
+	case share >= rpeBarYellow:
+		return ansiYellow
+	default:
+		return ansiGreen
+	}
+}
+
+// rpeBar renders today's RPE against the daily budget, e.g. [██████░░░░░░░░░░░░░░] 9/30
+func rpeBar(used, max int, color bool) string {
+	if max <= 0 {
+		return fmt.Sprintf("%d", used)
+	}
+
+	filled := used * rpeBarWidth / max
+	if filled > rpeBarWidth {
+		filled = rpeBarWidth
+	}
+	if filled < 0 {
+		filled = 0
+	}
+
+	bar := strings.Repeat(rpeBarFilled, filled)
+	if color && filled > 0 {
+		bar = rpeBarColor(used, max) + bar + ansiReset
+	}
+	return fmt.Sprintf("[%s%s] %d/%d", bar, strings.Repeat(rpeBarPending, rpeBarWidth-filled), used, max)
+}
+
+// printRPEBudget shows today's RPE budget as a bar, so pacing is visible when picking a movo
+func printRPEBudget() {
+	if appConfig.KidMode {
+		return
+	}
+	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		return
+	}
+	fmt.Printf(tr("🔋 RPE budget: %s\n"), rpeBar(stats.TotalRPE, appConfig.MaxDailyRPE, useColor()))
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRPEBar(t *testing.T) {
+	bar := rpeBar(15, 30, false)
+	if bar != "["+strings.Repeat("█", 10)+strings.Repeat("░", 10)+"] 15/30" {
+		t.Errorf("half budget: got %q", bar)
+	}
+
+	if bar := rpeBar(45, 30, false); strings.Count(bar, "█") != rpeBarWidth || !strings.HasSuffix(bar, "45/30") {
+		t.Errorf("over budget should fill the bar, got %q", bar)
+	}
+	if bar := rpeBar(0, 30, true); strings.Contains(bar, "\033") {
+		t.Errorf("empty bar shouldn't be coloured, got %q", bar)
+	}
+}
+
+func TestRPEBarColor(t *testing.T) {
+	tests := []struct {
+		used int
+		want string
+	}{
+		{5, ansiGreen},
+		{17, ansiGreen},
+		{18, ansiYellow},
+		{25, ansiYellow},
+		{26, ansiRed},
+		{40, ansiRed},
+	}
+	for _, tt := range tests {
+		if got := rpeBarColor(tt.used, 30); got != tt.want {
+			t.Errorf("rpeBarColor(%d, 30) = %q, want %q", tt.used, got, tt.want)
+		}
+		if bar := rpeBar(tt.used, 30, true); !strings.HasPrefix(bar, "["+tt.want) {
+			t.Errorf("rpeBar(%d, 30) not coloured %q: %q", tt.used, tt.want, bar)
+		}
+	}
+}
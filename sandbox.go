@@ -0,0 +1,115 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+//go:embed movos-examples/*.yaml
+var demoMovos embed.FS
+
+// sandboxEnv lists environment variables that would point a sandbox at real data
+var sandboxEnv = []string{"MOVODORO_MOVOS_DIR", "MOVODORO_ACTIVE_SUBSET", "MOVODORO_PROFILE"}
+
+// setupSandbox creates a movodoro home in dir (dir/.movodoro) with the demo movos and
+// days of synthetic history before now. The same seed always writes the same history.
+func setupSandbox(dir string, now time.Time, days int, seed uint64) error {
+	base := filepath.Join(dir, ".movodoro")
+	movosDir := filepath.Join(base, "movos")
+	if err := os.MkdirAll(movosDir, 0755); err != nil {
+		return err
+	}
+
+	files, err := demoMovos.ReadDir("movos-examples")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := demoMovos.ReadFile(path.Join("movos-examples", file.Name()))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(movosDir, file.Name()), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	movos, err := loadMovosDir(movosDir)
+	if err != nil {
+		return err
+	}
+	logsDir := filepath.Join(base, "logs")
+	for _, entry := range syntheticHistory(movos, now, days, rand.New(rand.NewPCG(seed, seed))) {
+		if err := AppendDailyLog(logsDir, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syntheticHistory makes plausible history for the days before now: everyday movos
+// on most days, plus a few random movos with the odd partial and skip
+func syntheticHistory(movos []Movo, now time.Time, days int, r *rand.Rand) []HistoryEntry {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var entries []HistoryEntry
+	for d := days; d >= 1; d-- {
+		day := today.AddDate(0, 0, -d)
+		var picks []*Movo
+		for i := range movos {
+			if movos[i].MinPerDay > 0 && r.Float64() < 0.8 {
+				picks = append(picks, &movos[i])
+			}
+		}
+		for n := 1 + r.IntN(4); n > 0; n-- {
+			picks = append(picks, &movos[r.IntN(len(movos))])
+		}
+
+		var dayEntries []HistoryEntry
+		for _, movo := range picks {
+			// Between 8:00 and 19:00
+			entry := HistoryEntry{
+				Timestamp: day.Add(8*time.Hour + time.Duration(r.IntN(11*60))*time.Minute),
+				Code:      movo.FullCode,
+				Status:    "done",
+				Duration:  movo.GetDefaultDuration(),
+				RPE:       movo.EffectiveRPE,
+			}
+			switch roll := r.Float64(); {
+			case roll < 0.1:
+				entry.Status, entry.Duration, entry.RPE = "skip", 0, 0
+			case roll < 0.2:
+				entry.Status, entry.Duration = "partial", (entry.Duration+1)/2
+			}
+			snapshotMovo(&entry, movo)
+			dayEntries = append(dayEntries, entry)
+		}
+		sort.Slice(dayEntries, func(i, j int) bool { return dayEntries[i].Timestamp.Before(dayEntries[j].Timestamp) })
+		entries = append(entries, dayEntries...)
+	}
+	return entries
+}
+
+// enterSandbox points this process at a sandbox home and reloads the config
+func enterSandbox(dir string) {
+	for _, name := range sandboxEnv {
+		os.Unsetenv(name)
+	}
+	os.Setenv("HOME", dir)
+	appConfig = DefaultConfig()
+}
+
+// sandboxNote is printed when the sandbox starts
+func sandboxNote(dir string, keep bool) string {
+	note := fmt.Sprintf("🧪 Sandbox: demo movos and history in %s\n   Nothing here touches your real movodoro data.\n", dir)
+	if keep {
+		note += fmt.Sprintf("   Kept afterwards; explore it with: HOME=%s movodoro report -v\n", dir)
+	}
+	return note
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetupSandboxIsDeterministic(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.Local)
+	dirA, dirB := t.TempDir(), t.TempDir()
+	if err := setupSandbox(dirA, now, 7, 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := setupSandbox(dirB, now, 7, 42); err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := now.AddDate(0, 0, -7), now.AddDate(0, 0, -1)
+	a, err := LoadHistoryRange(dirA+"/.movodoro/logs", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadHistoryRange(dirB+"/.movodoro/logs", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a) == 0 || !reflect.DeepEqual(a, b) {
+		t.Errorf("expected the same non-empty history for the same seed, got %d and %d entries", len(a), len(b))
+	}
+
+	today, err := LoadDailyLog(dirA+"/.movodoro/logs", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(today) != 0 {
+		t.Errorf("expected no history for today, got %d entries", len(today))
+	}
+}
+
+// TestSandboxEndToEnd runs the select/log/report path against a fresh sandbox home
+func TestSandboxEndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	if err := setupSandbox(dir, time.Now(), 14, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	originalConfig := appConfig
+	defer func() { appConfig = originalConfig }()
+	// t.Setenv restores whatever enterSandbox changes
+	for _, name := range append(sandboxEnv, "HOME") {
+		t.Setenv(name, os.Getenv(name))
+	}
+	enterSandbox(dir)
+
+	movos, err := LoadSnacks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movos) == 0 {
+		t.Fatal("expected demo movos")
+	}
+
+	history, err := LoadHistoryRange(appConfig.LogsDir, time.Now().AddDate(0, 0, -14), time.Now().AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range history {
+		if movo := entryMovo(entry, nil); movo == nil || movo.Title == "" {
+			t.Fatalf("expected a snapshot on %s %s", entry.Timestamp.Format("2006-01-02"), entry.Code)
+		}
+	}
+
+	movo, err := SelectSnack(movos, FilterOptions{}, appConfig.MaxDailyRPE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      movo.FullCode,
+		Status:    "done",
+		Duration:  usualDuration(movo),
+		RPE:       movo.EffectiveRPE,
+	}
+	if err := appendLogEntry(entry, movo); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalMovos != 1 || stats.TotalDuration != entry.Duration {
+		t.Errorf("expected 1 movo of %dm today, got %d of %dm", entry.Duration, stats.TotalMovos, stats.TotalDuration)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Effort words map to a fixed RPE when logging by voice
+var sayEffort = map[string]int{
+	"easy": 3, "light": 3, "gentle": 3, "chill": 3,
+	"medium": 5, "moderate": 5, "okay": 5, "ok": 5,
+	"hard": 8, "tough": 8, "intense": 8, "brutal": 9,
+}
+
+var sayNumbers = map[string]int{
+	"a": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13,
+	"fourteen": 14, "fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18,
+	"nineteen": 19, "twenty": 20, "thirty": 30, "forty": 40, "fifty": 50, "sixty": 60,
+}
+
+var sayMinuteWords = map[string]bool{"minute": true, "minutes": true, "min": true, "mins": true, "m": true}
+
+// Words that carry no meaning for matching a movo
+var sayFiller = map[string]bool{
+	"i": true, "did": true, "done": true, "just": true, "finished": true, "completed": true,
+	"the": true, "some": true, "for": true, "of": true, "and": true, "with": true,
+	"was": true, "it": true, "my": true, "an": true, "log": true, "logged": true,
+	"pretty": true, "really": true, "very": true, "quite": true, "a": true,
+}
+
+// utterance is what was understood from a spoken sentence
+type utterance struct {
+	Words    []string // Remaining words, used to find the movo
+	Duration int      // 0 if not said
+	RPE      int      // 0 if no effort word was said
+}
+
+// parseUtterance pulls a duration ("five minutes", "5 min", "10m") and an effort word
+// out of a sentence, leaving the words that should name the movo
+func parseUtterance(text string) utterance {
+	var u utterance
+	tokens := strings.Fields(strings.ToLower(text))
+	for i := range tokens {
+		tokens[i] = strings.Trim(tokens[i], ".,!?;:'\"")
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if tok == "" {
+			continue
+		}
+
+		if rpe, ok := sayEffort[tok]; ok {
+			u.RPE = rpe
+			continue
+		}
+
+		// A number counts as a duration when a minute word follows (or is attached)
+		if n, next, ok := sayNumber(tokens, i); ok {
+			if next < len(tokens) && sayMinuteWords[tokens[next]] {
+				u.Duration = n
+				i = next
+				continue
+			}
+		}
+		if n, ok := attachedMinutes(tok); ok {
+			u.Duration = n
+			continue
+		}
+
+		if sayMinuteWords[tok] || sayFiller[tok] {
+			continue
+		}
+		u.Words = append(u.Words, tok)
+	}
+	return u
+}
+
+// sayNumber reads a number at tokens[i] (digits, or words like "twenty five") and
+// returns it with the index of the following token
+func sayNumber(tokens []string, i int) (int, int, bool) {
+	if n, err := strconv.Atoi(tokens[i]); err == nil {
+		return n, i + 1, true
+	}
+	n, ok := sayNumbers[tokens[i]]
+	if !ok {
+		return 0, i, false
+	}
+	if n >= 20 && i+1 < len(tokens) {
+		if unit, ok := sayNumbers[tokens[i+1]]; ok && unit < 10 && tokens[i+1] != "a" {
+			return n + unit, i + 2, true
+		}
+	}
+	return n, i + 1, true
+}
+
+// attachedMinutes parses forms like "5min" or "10m"
+func attachedMinutes(tok string) (int, bool) {
+	digits := strings.TrimRightFunc(tok, func(r rune) bool { return r < '0' || r > '9' })
+	if digits == "" || !sayMinuteWords[tok[len(digits):]] {
+		return 0, false
+	}
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
+
+// wordsMatch treats words as the same if one is a prefix of the other
+// (at least four letters), so "breathing" matches "breath"
+func wordsMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) < 4 || len(b) < 4 {
+		return false
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// sayMaxMinutes bounds a spoken duration; anything longer is more likely misheard
+// ("fifteen" as "fifty") than a movement snack, so it's refused rather than logged
+const sayMaxMinutes = 120
+
+// checkSpokenDuration refuses a duration that can't be a movo
+func checkSpokenDuration(minutes int) error {
+	if minutes > sayMaxMinutes {
+		return fmt.Errorf("%d minutes is more than say logs (up to %d); use 'movodoro done CODE -d %d' if that's right", minutes, sayMaxMinutes, minutes)
+	}
+	return nil
+}
+
+// matchSpokenMovo finds the movo whose title (or code) best matches the spoken words.
+// It refuses a tie on score, or a best match that misses more than half the words,
+// naming the candidates so the next attempt can say more of the name.
+func matchSpokenMovo(words []string, movos []Movo) (*Movo, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("couldn't tell which movo you did")
+	}
+
+	var best []*Movo
+	bestScore := 0
+	for i := range movos {
+		movo := &movos[i]
+		name := strings.Fields(strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(movo.Title + " " + movo.FullCode)))
+
+		score := 0
+		for _, w := range words {
+			for _, n := range name {
+				if wordsMatch(w, n) {
+					score++
+					break
+				}
+			}
+		}
+		switch {
+		case score == 0 || score < bestScore:
+		case score > bestScore:
+			best, bestScore = []*Movo{movo}, score
+		default:
+			best = append(best, movo)
+		}
+	}
+
+	said := strings.Join(words, " ")
+	switch {
+	case len(best) == 0:
+		return nil, fmt.Errorf("no movo matches %q", said)
+	case len(best) > 1:
+		return nil, fmt.Errorf("%q could be %s; say more of the name", said, spokenCandidates(best))
+	case bestScore*2 < len(words):
+		return nil, fmt.Errorf("only %d of %d words in %q match %s; say more of the name", bestScore, len(words), said, spokenCandidates(best))
+	}
+	return best[0], nil
+}
+
+// spokenCandidates lists movos as "TITLE (CODE)", joined with "or"
+func spokenCandidates(movos []*Movo) string {
+	names := make([]string, len(movos))
+	for i, movo := range movos {
+		names[i] = fmt.Sprintf("%s (%s)", movo.Title, movo.FullCode)
+	}
+	return strings.Join(names, " or ")
+}
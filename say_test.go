@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseUtterance(t *testing.T) {
+	tests := []struct {
+		text     string
+		words    string
+		duration int
+		rpe      int
+	}{
+		{"did box breathing five minutes easy", "box breathing", 5, 3},
+		{"I just did kettlebell swings for 10 min, pretty hard!", "kettlebell swings", 10, 8},
+		{"plank twenty five minutes", "plank", 25, 0},
+		{"did a minute of wall sit", "wall sit", 1, 0},
+		{"hip circles 3m medium", "hip circles", 3, 5},
+		{"two rounds of burpees", "two rounds burpees", 0, 0},
+	}
+	for _, tt := range tests {
+		u := parseUtterance(tt.text)
+		if got := strings.Join(u.Words, " "); got != tt.words || u.Duration != tt.duration || u.RPE != tt.rpe {
+			t.Errorf("parseUtterance(%q) = %q, %d min, RPE %d; want %q, %d min, RPE %d",
+				tt.text, got, u.Duration, u.RPE, tt.words, tt.duration, tt.rpe)
+		}
+	}
+}
+
+func TestMatchSpokenMovo(t *testing.T) {
+	movos := []Movo{
+		{FullCode: "TB-box-breath", Title: "Box breathing"},
+		{FullCode: "TB-478-breath", Title: "4-7-8 breathing"},
+		{FullCode: "KB-swings", Title: "Kettlebell swings"},
+		{FullCode: "BWS-pushup-squats", Title: "Push-ups and squats"},
+	}
+
+	tests := []struct {
+		words string
+		want  string
+	}{
+		{"box breathing", "TB-box-breath"},
+		{"box breathing outside", "TB-box-breath"}, // Two of three words is enough
+		{"kettlebell", "KB-swings"},
+		{"pushups", "BWS-pushup-squats"},
+	}
+	for _, tt := range tests {
+		movo, err := matchSpokenMovo(strings.Fields(tt.words), movos)
+		if err != nil {
+			t.Errorf("%q: %v", tt.words, err)
+			continue
+		}
+		if movo.FullCode != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.words, movo.FullCode, tt.want)
+		}
+	}
+
+	if _, err := matchSpokenMovo([]string{"yoga"}, movos); err == nil {
+		t.Error("expected no match for an unknown movo")
+	}
+
+	refused := []struct {
+		words      string
+		candidates []string
+	}{
+		{"breathing", []string{"Box breathing (TB-box-breath)", "4-7-8 breathing (TB-478-breath)"}}, // Tie on score
+		{"squats in the yard", []string{"Push-ups and squats (BWS-pushup-squats)"}},                 // One of three words ("the" is filler)
+	}
+	for _, tt := range refused {
+		movo, err := matchSpokenMovo(strings.Fields(tt.words), movos)
+		if err == nil {
+			t.Errorf("%q: expected a refusal, got %s", tt.words, movo.FullCode)
+			continue
+		}
+		for _, candidate := range tt.candidates {
+			if !strings.Contains(err.Error(), candidate) {
+				t.Errorf("%q: expected %q listed in %q", tt.words, candidate, err)
+			}
+		}
+	}
+}
+
+func TestCheckSpokenDuration(t *testing.T) {
+	for minutes, ok := range map[int]bool{0: true, 5: true, sayMaxMinutes: true, sayMaxMinutes + 1: false, 500: false} {
+		if err := checkSpokenDuration(minutes); (err == nil) != ok {
+			t.Errorf("%d minutes: expected ok %v, got %v", minutes, ok, err)
+		}
+	}
+	if u := parseUtterance("box breathing 500 minutes"); checkSpokenDuration(u.Duration) == nil {
+		t.Error("expected 500 minutes refused")
+	}
+}
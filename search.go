@@ -0,0 +1,83 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// How much a query term counts towards a movo's search score, by where it matched
+const (
+	searchTitleScore       = 4
+	searchTagScore         = 3
+	searchCodeScore        = 2
+	searchDescriptionScore = 1
+	searchWordStartBonus   = 1 // The term starts a word in the title, e.g. "hip" in "Hip circles"
+)
+
+// searchResult is a movo that matched every query term
+type searchResult struct {
+	Movo  *Movo
+	Score int
+}
+
+// searchMovos finds movos whose title, tags, code or description contain every
+// whitespace-separated term of query (case-insensitive), best matches first
+func searchMovos(movos []Movo, query string) []searchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var results []searchResult
+	for i := range movos {
+		movo := &movos[i]
+		total := 0
+		for _, term := range terms {
+			score := searchTermScore(movo, term)
+			if score == 0 {
+				total = 0
+				break
+			}
+			total += score
+		}
+		if total > 0 {
+			results = append(results, searchResult{Movo: movo, Score: total})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Movo.FullCode < results[j].Movo.FullCode
+	})
+	return results
+}
+
+// searchTermScore scores one term against a movo (0 if it doesn't match anywhere)
+func searchTermScore(movo *Movo, term string) int {
+	score := 0
+	title := strings.ToLower(movo.Title)
+	if strings.Contains(title, term) {
+		score += searchTitleScore
+		for _, word := range strings.Fields(title) {
+			if strings.HasPrefix(word, term) {
+				score += searchWordStartBonus
+				break
+			}
+		}
+	}
+	for _, tag := range movo.AllTags {
+		if strings.Contains(strings.ToLower(tag), term) {
+			score += searchTagScore
+			break
+		}
+	}
+	if strings.Contains(strings.ToLower(movo.FullCode), term) {
+		score += searchCodeScore
+	}
+	if strings.Contains(strings.ToLower(movo.Description), term) {
+		score += searchDescriptionScore
+	}
+	return score
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchMovos(t *testing.T) {
+	movos := []Movo{
+		{FullCode: "MOB-hip-circles", Title: "Hip circles and leg swings", AllTags: []string{"mobilityx"}},
+		{FullCode: "MOB-pigeon", Title: "Pigeon stretch", Description: "Opens the hips and glutes.", AllTags: []string{"mobilityx", "hipx"}},
+		{FullCode: "TS-chip-lifts", Title: "Chip lifts", AllTags: []string{"strengthx"}},
+		{FullCode: "BR-box", Title: "Box breathing", Description: "Breathe in a 4-4-4-4 pattern.", AllTags: []string{"breathx"}},
+	}
+
+	codes := func(query string) string {
+		var out []string
+		for _, r := range searchMovos(movos, query) {
+			out = append(out, r.Movo.FullCode)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// Title word starts rank first, then mid-word title matches, then tag/description matches
+	if got := codes("HIP"); got != "MOB-hip-circles,TS-chip-lifts,MOB-pigeon" {
+		t.Errorf("hip: got %s", got)
+	}
+	if got := codes("hip glutes"); got != "MOB-pigeon" {
+		t.Errorf("every term must match: got %s", got)
+	}
+	if got := codes("breath pattern"); got != "BR-box" {
+		t.Errorf("title and description: got %s", got)
+	}
+	if got := codes("   "); got != "" {
+		t.Errorf("empty query should match nothing, got %s", got)
+	}
+}
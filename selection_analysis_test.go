@@ -201,10 +201,11 @@ func TestWeightBoostEffects(t *testing.T) {
 	}
 
 	// Calculate weights for everyday snack
-	weight, err := calculateWeight(*everydayMovo)
+	inputs, err := loadWeightInputs(DefaultConfig(), time.Now())
 	if err != nil {
 		t.Fatalf("Failed to calculate weight: %v", err)
 	}
+	weight := calculateWeight(*everydayMovo, inputs)
 
 	t.Logf("\n=== Boost Effect Analysis ===")
 	t.Logf("Everyday snack: %s", everydayMovo.FullCode)
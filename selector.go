@@ -2,40 +2,130 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
+	"io"
+	"math/rand/v2"
+	"os"
+	"slices"
 	"time"
 )
 
 const (
 	minPerDayBoost     = 10.0 // Boost for snacks with incomplete min_per_day
+	minPerWeekBoost    = 5.0  // Boost for snacks with incomplete min_per_week (the week has more chances)
 	neverDoneBoost     = 3.0  // Boost for snacks never completed
 	recencyBoost       = 2.0  // Boost for snacks not done in 7+ days
 	recencyDays        = 7    // Days threshold for recency boost
+	partialRecencyDays = 3    // Partial completions only count as recent for this long
 	autoRecoveryMaxRPE = 2    // What the max RPE ends up as if we hit the daily threshold
+	recoverySafeMaxRPE = 4    // Max RPE for recovery_safe snacks in auto-recovery mode
 )
 
 // SelectSnack selects a random snack based on weights and constraints
 func SelectSnack(snacks []Movo, filters FilterOptions, maxDailyRPE int) (*Movo, error) {
+	selected, notes, err := pickSnack(snacks, filters, maxDailyRPE)
+	if err != nil {
+		return nil, err
+	}
+	printSelectionNotes(notes)
+	return selected, nil
+}
+
+// extraExploration is the Extras key (and current snack marker) for exploration picks
+const extraExploration = "exploration"
+
+// selectionNotes records why a pick was narrowed or randomized
+type selectionNotes struct {
+	RecoveryMode bool
+	RestDay      bool
+	Exploration  bool
+}
+
+// pickSnack is SelectSnack without the notes, for callers that report them another way
+func pickSnack(snacks []Movo, filters FilterOptions, maxDailyRPE int) (*Movo, selectionNotes, error) {
+	cfg := DefaultConfig()
+
+	weighted, inRecoveryMode, err := weighCandidates(snacks, filters, maxDailyRPE)
+	if err != nil {
+		return nil, selectionNotes{}, err
+	}
+	notes := selectionNotes{
+		RecoveryMode: inRecoveryMode,
+		RestDay:      isRestDay(cfg, time.Now()),
+	}
+
+	// Exploration: occasionally ignore weights to counteract rich-get-richer effects
+	if cfg.ExplorationRate > 0 && selectorRand.Float64() < cfg.ExplorationRate {
+		notes.Exploration = true
+		selected := weighted[selectorRand.IntN(len(weighted))].snack
+		return &selected, notes, nil
+	}
+
+	// Select using weighted random
+	selected := weightedRandomSelect(selectorRand, weighted)
+	return &selected, notes, nil
+}
+
+// printSelectionNotes explains a narrowed or randomized pick; JSON and quiet output
+// carry these as fields or not at all
+func printSelectionNotes(notes selectionNotes) {
+	if quietOutput || outputJSON {
+		return
+	}
+	writeSelectionNotes(os.Stdout, notes)
+}
+
+// writeSelectionNotes writes a line for each way notes narrowed or randomized the pick
+func writeSelectionNotes(w io.Writer, notes selectionNotes) {
+	if notes.RecoveryMode {
+		fmt.Fprintln(w, tr("🔋 Auto-recovery mode: limiting to RPE ≤ 2"))
+	}
+	if notes.RestDay {
+		fmt.Fprintln(w, tr("🛌 Rest day: limiting to RPE ≤ 2"))
+	}
+	if notes.Exploration {
+		fmt.Fprintln(w, tr("🎲 Exploration pick: ignoring weights this time"))
+	}
+}
+
+// weighCandidates applies every selection filter and returns the eligible snacks with
+// their final weights, and whether auto-recovery mode is active
+func weighCandidates(snacks []Movo, filters FilterOptions, maxDailyRPE int) ([]weightedSnack, bool, error) {
 	cfg := DefaultConfig()
 
 	// Get today's stats
 	todayStats, err := GetTodayStatsDaily(cfg.LogsDir)
 	if err != nil {
-		return nil, fmt.Errorf("error loading today's stats: %w", err)
+		return nil, false, withExitCode(exitStorage, fmt.Errorf("error loading today's stats: %w", err))
 	}
 
 	// Check if we're in auto-recovery mode
 	inRecoveryMode := todayStats.TotalRPE >= maxDailyRPE
 	if inRecoveryMode {
-		// Override max RPE to 2 for recovery
+		// Override max RPE to 2 for recovery (recovery_safe snacks get a little more room)
 		filters.MaxRPE = autoRecoveryMaxRPE
-		fmt.Println("🔋 Auto-recovery mode: limiting to RPE ≤ 2")
+		filters.RecoverySafeMaxRPE = recoverySafeMaxRPE
+	}
+
+	// Rest days only offer the lightest movos, though everyday minimums that
+	// aren't waived stay eligible
+	if isRestDay(cfg, time.Now()) {
+		if filters.MaxRPE == 0 || filters.MaxRPE > restDayMaxRPE {
+			filters.MaxRPE = restDayMaxRPE
+		}
+		filters.RecoverySafeMaxRPE = 0
+		filters.EverydayMaxRPE = cfg.RestEverydayRPE
 	}
 
 	// Filter snacks
 	candidates := filterSnacks(snacks, filters)
 	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no snacks match the specified filters")
+		return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("no snacks match the specified filters"))
+	}
+
+	// Silently leave out anything conflicting with avoid: in config.yaml
+	candidates = filterContraindicated(candidates, cfg.Avoid)
+	if len(candidates) == 0 {
+		return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("every matching snack has a contraindication listed in avoid"))
 	}
 
 	// Apply subset filter if active
@@ -43,19 +133,54 @@ func SelectSnack(snacks []Movo, filters FilterOptions, maxDailyRPE int) (*Movo,
 		var err error
 		candidates, err = filterBySubset(candidates, filters.Subset, cfg.MovosDir)
 		if err != nil {
-			return nil, fmt.Errorf("error applying subset filter: %w", err)
+			return nil, inRecoveryMode, withExitCode(exitLibrary, fmt.Errorf("error applying subset filter: %w", err))
+		}
+		if len(candidates) == 0 {
+			return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("no snacks match the subset '%s' (after applying other filters)", filters.Subset))
 		}
+	}
+
+	// Remove snacks whose requires_done_today prerequisites aren't met yet
+	candidates = filterByPrerequisites(candidates, todayStats)
+	if len(candidates) == 0 {
+		return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("all matching snacks are waiting on prerequisites (requires_done_today)"))
+	}
+
+	// Remove snacks outside their time_window (unless overridden)
+	if !filters.IgnoreTimeWindows {
+		candidates = filterByTimeWindow(candidates, time.Now())
 		if len(candidates) == 0 {
-			return nil, fmt.Errorf("no snacks match the subset '%s' (after applying other filters)", filters.Subset)
+			return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("all matching snacks are outside their time windows (use --ignore-time-windows)"))
 		}
 	}
 
+	// Remove snacks put off with 'movodoro snooze'
+	snoozes, err := loadSnoozes(cfg.SnoozePath, time.Now())
+	if err != nil {
+		return nil, inRecoveryMode, err
+	}
+	candidates = filterSnoozed(candidates, snoozes)
+	if len(candidates) == 0 {
+		return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("all matching snacks are snoozed"))
+	}
+
+	// Remove snacks banned with 'movodoro ban'
+	bans, err := loadBans(cfg.BansPath, time.Now())
+	if err != nil {
+		return nil, inRecoveryMode, err
+	}
+	candidates = filterBanned(candidates, bans)
+	if len(candidates) == 0 {
+		return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("all matching snacks are banned (see 'movodoro ban list')"))
+	}
+
 	// Apply min_per_day priority (unless explicitly skipped)
 	if !filters.SkipMinimums {
 		minimumCandidates, err := filterToIncompleteMinimums(candidates, cfg.LogsDir)
 		if err != nil {
-			return nil, err
+			return nil, inRecoveryMode, err
 		}
+		minimumCandidates = dropWaivedMinimums(minimumCandidates, cfg, time.Now())
 		// If there are incomplete minimum snacks, use only those
 		if len(minimumCandidates) > 0 {
 			candidates = minimumCandidates
@@ -65,31 +190,50 @@ func SelectSnack(snacks []Movo, filters FilterOptions, maxDailyRPE int) (*Movo,
 	// Remove snacks that have hit their max_per_day limit
 	candidates, err = filterByFrequency(candidates)
 	if err != nil {
-		return nil, err
+		return nil, inRecoveryMode, err
 	}
 
 	if len(candidates) == 0 {
-		return nil, fmt.Errorf("all matching snacks have reached their daily limit")
+		return nil, inRecoveryMode, withExitCode(exitNoMatch, fmt.Errorf("all matching snacks have reached their daily limit"))
+	}
+
+	// Rotation mode: only the category after the one last done today
+	if filters.Rotate {
+		order := rotationOrder(snacks, cfg.Rotation)
+		category := nextRotationCategory(order, lastCategoryToday(todayStats, snacks), candidates)
+		candidates = filterToCategory(candidates, category)
+	}
+
+	// Weekly balance across the whole library (balance_by in config.yaml)
+	balance, err := loadBalance(cfg, snacks, time.Now())
+	if err != nil {
+		return nil, inRecoveryMode, err
 	}
 
 	// Calculate weights
+	inputs, err := loadWeightInputs(cfg, time.Now())
+	if err != nil {
+		return nil, inRecoveryMode, err
+	}
 	weighted := make([]weightedSnack, len(candidates))
 	for i, snack := range candidates {
-		weight, err := calculateWeight(snack)
-		if err != nil {
-			return nil, err
-		}
-		weighted[i] = weightedSnack{snack: snack, weight: weight}
+		weight, factors := explainWeight(snack, inputs)
+		weight *= fitMultiplier(snack, filters.Fit) * balanceFor(balance, snack.FullCode)
+		weighted[i] = weightedSnack{snack: snack, weight: weight, factors: factors}
 	}
 
-	// Select using weighted random
-	selected := weightedRandomSelect(weighted)
-	return &selected, nil
+	// Sharpen or flatten the weights for the variety level
+	if err := applyVariety(weighted, filters.Variety); err != nil {
+		return nil, inRecoveryMode, err
+	}
+
+	return weighted, inRecoveryMode, nil
 }
 
 type weightedSnack struct {
-	snack  Movo
-	weight float64
+	snack   Movo
+	weight  float64
+	factors []weightFactor // What went into weight, before fit and balance
 }
 
 // filterSnacks applies all filters to the snack list
@@ -97,8 +241,16 @@ func filterSnacks(snacks []Movo, filters FilterOptions) []Movo {
 	var filtered []Movo
 
 	for _, snack := range snacks {
-		// Category filter
-		if filters.Category != "" && snack.CategoryCode != filters.Category {
+		// Deprecated movos are only kept for their history
+		if snack.Deprecated {
+			continue
+		}
+
+		// Category filters
+		if len(filters.Categories) > 0 && !slices.Contains(filters.Categories, snack.CategoryCode) {
+			continue
+		}
+		if slices.Contains(filters.ExcludeCategories, snack.CategoryCode) {
 			continue
 		}
 
@@ -106,12 +258,32 @@ func filterSnacks(snacks []Movo, filters FilterOptions) []Movo {
 		if !snack.HasAllTags(filters.Tags) {
 			continue
 		}
+		if len(filters.AnyTags) > 0 && !snack.HasAnyTag(filters.AnyTags) {
+			continue
+		}
+
+		// Target filter
+		if len(filters.Targets) > 0 && !snack.HasAnyTarget(filters.Targets) {
+			continue
+		}
+
+		// Equipment filter
+		if filters.LimitEquipment && !snack.HasEquipment(filters.Equipment) {
+			continue
+		}
 
 		// RPE filters
 		if filters.MinRPE > 0 && snack.EffectiveRPE < filters.MinRPE {
 			continue
 		}
-		if filters.MaxRPE > 0 && snack.EffectiveRPE > filters.MaxRPE {
+		maxRPE := filters.MaxRPE
+		if snack.RecoverySafe && maxRPE > 0 && filters.RecoverySafeMaxRPE > maxRPE {
+			maxRPE = filters.RecoverySafeMaxRPE
+		}
+		if snack.MinPerDay > 0 && maxRPE > 0 && filters.EverydayMaxRPE > maxRPE {
+			maxRPE = filters.EverydayMaxRPE
+		}
+		if maxRPE > 0 && snack.EffectiveRPE > maxRPE {
 			continue
 		}
 
@@ -139,6 +311,16 @@ func filterSnacks(snacks []Movo, filters FilterOptions) []Movo {
 			}
 		}
 
+		// Already offered (interactive rerolls)
+		if slices.Contains(filters.Exclude, snack.FullCode) {
+			continue
+		}
+
+		// Time budget: leave out movos that can't be done in the minutes available
+		if filters.Fit > 0 && !fitsMinutes(snack, filters.Fit) {
+			continue
+		}
+
 		filtered = append(filtered, snack)
 	}
 
@@ -201,6 +383,40 @@ func filterBySubset(snacks []Movo, subsetName string, movosDir string) ([]Movo,
 	return filtered, nil
 }
 
+// filterByPrerequisites removes snacks whose requires_done_today codes haven't been completed today
+func filterByPrerequisites(snacks []Movo, todayStats DailyStats) []Movo {
+	doneToday := completedToday(todayStats)
+
+	var filtered []Movo
+	for _, snack := range snacks {
+		if len(missingPrerequisites(snack, doneToday)) == 0 {
+			filtered = append(filtered, snack)
+		}
+	}
+
+	return filtered
+}
+
+// completedToday is the set of codes completed today
+func completedToday(todayStats DailyStats) map[string]bool {
+	doneToday := make(map[string]bool)
+	for _, entry := range todayStats.CompletedSnacks {
+		doneToday[entry.Code] = true
+	}
+	return doneToday
+}
+
+// missingPrerequisites returns the requires_done_today codes not yet completed
+func missingPrerequisites(snack Movo, doneToday map[string]bool) []string {
+	var missing []string
+	for _, code := range snack.RequiresDoneToday {
+		if !doneToday[code] {
+			missing = append(missing, code)
+		}
+	}
+	return missing
+}
+
 // filterByFrequency removes snacks that have hit their daily/weekly limits
 func filterByFrequency(snacks []Movo) ([]Movo, error) {
 	cfg := DefaultConfig()
@@ -217,7 +433,16 @@ func filterByFrequency(snacks []Movo) ([]Movo, error) {
 			continue
 		}
 
-		// TODO: Implement max_per_week check if needed
+		// Check max_per_week
+		if snack.MaxPerWeek > 0 {
+			doneThisWeek, err := GetCountWeekDaily(cfg.LogsDir, snack.FullCode, cfg.WeekStartDate(time.Now()))
+			if err != nil {
+				return nil, err
+			}
+			if doneThisWeek >= snack.MaxPerWeek {
+				continue
+			}
+		}
 
 		filtered = append(filtered, snack)
 	}
@@ -225,48 +450,186 @@ func filterByFrequency(snacks []Movo) ([]Movo, error) {
 	return filtered, nil
 }
 
-// calculateWeight calculates the final weight for a snack with all boosts
-func calculateWeight(snack Movo) (float64, error) {
-	cfg := DefaultConfig()
-	weight := snack.Weight
+// weightInputs is the history and settings weighing reads that are the same for every
+// candidate, loaded once per selection rather than once per movo
+type weightInputs struct {
+	cfg         *Config
+	now         time.Time
+	doneToday   map[string]int
+	doneWeek    map[string]int
+	lastDone    map[string]time.Time
+	lastPartial map[string]time.Time
+	favorites   []string
+	skips       map[string][]time.Time
+	recent      []recentShow
+	ratings     map[string]RatingSummary
+	energy      int
+}
 
-	// Min per day boost - applies when snack has minimum and hasn't met it yet
-	if snack.MinPerDay > 0 {
-		doneToday, _, err := GetCountTodayDaily(cfg.LogsDir, snack.FullCode)
-		if err != nil {
-			return 0, err
+// loadWeightInputs reads everything explainWeight needs in one pass over each source
+func loadWeightInputs(cfg *Config, now time.Time) (*weightInputs, error) {
+	in := &weightInputs{
+		cfg:         cfg,
+		now:         now,
+		doneToday:   make(map[string]int),
+		doneWeek:    make(map[string]int),
+		lastDone:    make(map[string]time.Time),
+		lastPartial: make(map[string]time.Time),
+	}
+
+	today, err := LoadDailyLog(cfg.LogsDir, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range today {
+		if entry.Status == "done" {
+			in.doneToday[entry.Code]++
 		}
-		if doneToday < snack.MinPerDay {
-			weight *= minPerDayBoost
+	}
+
+	weekStart := cfg.WeekStartDate(now)
+	week, err := LoadHistoryRange(cfg.LogsDir, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range week {
+		if entry.Status == "done" {
+			in.doneWeek[entry.Code]++
 		}
 	}
 
-	// Never done boost
-	everDone, err := HasEverBeenDoneDaily(cfg.LogsDir, snack.FullCode)
+	// History is in order, so the last entry seen for a code is its latest
+	history, err := LoadAllHistory(cfg.LogsDir)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	for _, entry := range history {
+		switch entry.Status {
+		case "done":
+			in.lastDone[entry.Code] = entry.Timestamp
+		case "partial":
+			in.lastPartial[entry.Code] = entry.Timestamp
+		}
+	}
+
+	if in.favorites, err = loadFavorites(cfg.FavoritesPath); err != nil {
+		return nil, err
+	}
+	if in.skips, err = loadRecentSkips(cfg.LogsDir, now); err != nil {
+		return nil, err
+	}
+	if cfg.RepeatWindow > 0 || cfg.RepeatHours > 0 {
+		if in.recent, err = loadRecent(cfg.RecentPath); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RatingWeight {
+		ratings, err := loadRecentRatings(cfg, now)
+		if err != nil {
+			return nil, err
+		}
+		in.ratings = SummarizeRatings(ratings)
+	}
+	if in.energy, _, err = latestCheckin(cfg.LogsDir, cfg.Profile, now); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// calculateWeight calculates the final weight for a snack with all boosts
+func calculateWeight(snack Movo, in *weightInputs) float64 {
+	weight, _ := explainWeight(snack, in)
+	return weight
+}
+
+// minPerDayFactor names the boost for a daily that hasn't met its minimum
+const minPerDayFactor = "min_per_day not met"
+
+// weightFactor is one boost or multiplier applied to a snack's weight
+type weightFactor struct {
+	Name       string  `json:"name"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// explainWeight calculates a snack's final weight and lists the factors that went into it
+func explainWeight(snack Movo, in *weightInputs) (float64, []weightFactor) {
+	cfg, now := in.cfg, in.now
+	weight := snack.Weight
+	var factors []weightFactor
+	apply := func(name string, multiplier float64) {
+		weight *= multiplier
+		factors = append(factors, weightFactor{Name: name, Multiplier: multiplier})
+	}
+
+	// Min per day boost - applies when snack has minimum and hasn't met it yet
+	if snack.MinPerDay > 0 && in.doneToday[snack.FullCode] < snack.MinPerDay {
+		apply(minPerDayFactor, minPerDayBoost)
+	}
+
+	// Min per week boost - the same, over the current week
+	if snack.MinPerWeek > 0 && in.doneWeek[snack.FullCode] < snack.MinPerWeek {
+		apply("min_per_week not met", minPerWeekBoost)
 	}
-	if !everDone {
-		weight *= neverDoneBoost
+
+	// Partials are a weaker recency signal: they suppress boosts for a shorter window
+	lastPartial, partial := in.lastPartial[snack.FullCode]
+	recentPartial := partial && now.Sub(lastPartial).Hours()/24 < float64(partialRecencyDays)
+
+	// Never done boost
+	lastDone, everDone := in.lastDone[snack.FullCode]
+	if !everDone && !recentPartial {
+		apply("never done", cfg.NeverDoneBoost)
 	}
 
 	// Recency boost
-	lastDone, err := GetLastDoneDaily(cfg.LogsDir, snack.FullCode)
-	if err != nil {
-		return 0, err
+	if everDone && !recentPartial {
+		daysSince := now.Sub(lastDone).Hours() / 24
+		if daysSince >= float64(cfg.RecencyDays) {
+			apply(fmt.Sprintf("not done in %d+ days", cfg.RecencyDays), cfg.RecencyBoost)
+		}
+	}
+
+	// Personal favorites ('fav add'), kept out of the shared YAML
+	if slices.Contains(in.favorites, snack.FullCode) {
+		apply("favorite", cfg.FavoriteBoost)
+	}
+
+	// Movos skipped again and again come up less, recovering as the skips age
+	if times := in.skips[snack.FullCode]; len(times) > 0 {
+		apply(fmt.Sprintf("skipped %d× lately", len(times)), skipMultiplier(times, now))
 	}
-	if lastDone != nil {
-		daysSince := time.Since(*lastDone).Hours() / 24
-		if daysSince >= float64(recencyDays) {
-			weight *= recencyBoost
+
+	// Anti-repeat penalty, beyond max_per_day, so small pools don't repeat back to back
+	if cfg.RepeatWindow > 0 || cfg.RepeatHours > 0 {
+		var lastDonePtr *time.Time
+		if everDone {
+			lastDonePtr = &lastDone
+		}
+		if repeatedRecently(in.recent, snack.FullCode, lastDonePtr, cfg.RepeatWindow, cfg.RepeatHours, now) {
+			apply("shown or done recently", repeatPenalty)
 		}
 	}
 
-	return weight, nil
+	// Rating multiplier from the rolling average rating (opt-in via rating_weight in config.yaml)
+	if summary, ok := in.ratings[snack.FullCode]; ok {
+		apply(fmt.Sprintf("rated %.1f", summary.Average), ratingMultiplier(summary.Average))
+	}
+
+	// Energy from a recent 'checkin': easier movos when low, harder ones when high
+	if energy := in.energy; energy != 0 && energy != neutralEnergy && snack.EffectiveRPE != midRPE {
+		apply(fmt.Sprintf("energy %d/5", energy), energyMultiplier(energy, snack.EffectiveRPE))
+	}
+
+	// Category multiplier from the active config profile
+	if multiplier, ok := cfg.CategoryWeights[snack.CategoryCode]; ok {
+		apply("category weight "+snack.CategoryCode, multiplier)
+	}
+
+	return weight, factors
 }
 
 // weightedRandomSelect selects a snack using weighted random selection
-func weightedRandomSelect(weighted []weightedSnack) Movo {
+func weightedRandomSelect(r *rand.Rand, weighted []weightedSnack) Movo {
 	// Calculate total weight
 	totalWeight := 0.0
 	for _, w := range weighted {
@@ -274,12 +637,12 @@ func weightedRandomSelect(weighted []weightedSnack) Movo {
 	}
 
 	// Random selection
-	r := rand.Float64() * totalWeight
+	target := r.Float64() * totalWeight
 	cumulative := 0.0
 
 	for _, w := range weighted {
 		cumulative += w.weight
-		if r <= cumulative {
+		if target <= cumulative {
 			return w.snack
 		}
 	}
@@ -287,8 +650,3 @@ func weightedRandomSelect(weighted []weightedSnack) Movo {
 	// Fallback (shouldn't happen)
 	return weighted[len(weighted)-1].snack
 }
-
-func init() {
-	// Seed random number generator
-	rand.Seed(time.Now().UnixNano())
-}
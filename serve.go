@@ -0,0 +1,357 @@
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// movoServer answers HTTP requests with the same selector and history code as the CLI
+type movoServer struct {
+	token string
+	mu    sync.Mutex // Serialises reading the current movo and writing the log
+}
+
+// errNotFound marks lookups that should answer 404
+var errNotFound = errors.New("not found")
+
+// errEntryCap marks a done/skip refused by max_entries_per_day
+var errEntryCap = errors.New("daily entry cap reached")
+
+// handler routes the API, checking the token first if one is set
+func (s *movoServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /get", s.handleGet)
+	mux.HandleFunc("/done", s.handleLog("done"))
+	mux.HandleFunc("/done/{code}", s.handleLog("done"))
+	mux.HandleFunc("/skip", s.handleLog("skip"))
+	mux.HandleFunc("/skip/{code}", s.handleLog("skip"))
+	mux.HandleFunc("GET /report", s.handleReport)
+	mux.HandleFunc("GET /everyday", s.handleEveryday)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" && !s.authorized(r) {
+			writeJSONError(w, http.StatusUnauthorized, errors.New("missing or wrong token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// authorized checks for the token as a Bearer header or a ?token= parameter
+// (Shortcuts and Stream Deck URL actions can't always set headers)
+func (s *movoServer) authorized(r *http.Request) bool {
+	given := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		given = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(s.token)) == 1
+}
+
+// handleGet selects a movo using the get flags as query parameters and makes it current
+func (s *movoServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	filters, err := queryFilterOptions(r.URL.Query())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("error loading snacks: %w", err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snack, notes, err := pickSnack(snacks, filters, appConfig.MaxDailyRPE)
+	if err != nil {
+		writeJSONError(w, httpStatusOf(err, http.StatusInternalServerError), err)
+		return
+	}
+	if err := saveCurrentPick(snack.FullCode, notes.Exploration); err != nil {
+		fmt.Fprintf(os.Stderr, tr("Warning: could not save current snack: %v\n"), err)
+	}
+	out := newMovoJSON(snack)
+	out.RecoveryMode, out.RestDay, out.Exploration = notes.RecoveryMode, notes.RestDay, notes.Exploration
+	writeJSONResponse(w, http.StatusOK, out)
+}
+
+// queryFilterOptions parses query parameters as get flags, e.g. ?tags=neck&max-duration=5
+func queryFilterOptions(query url.Values) (FilterOptions, error) {
+	fs, g := newGetFlagSet("get", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	// Sorted so the same query always parses (and fails) the same way
+	var keys []string
+	for key := range query {
+		if key != "token" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var args []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			args = append(args, "--"+key+"="+value)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return FilterOptions{}, err
+	}
+	return g.filterOptions(), nil
+}
+
+// logResponse is what done and skip answer with
+type logResponse struct {
+	Entry entryJSON `json:"entry"`
+	Today struct {
+		Movos   int `json:"movos"`
+		Minutes int `json:"minutes"`
+		RPE     int `json:"rpe"`
+	} `json:"today"`
+}
+
+// handleLog logs the movo named in the path, or the current one, as done or skipped.
+// Done takes optional duration, rpe and partial parameters.
+func (s *movoServer) handleLog(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Any web page could log with an <img> pointing at a GET, so GET needs the token,
+		// or with a plain <form> POST, so without one a browser's cross-site POST is refused
+		allowed := r.Method == http.MethodPost || (r.Method == http.MethodGet && s.token != "")
+		if !allowed {
+			if s.token != "" {
+				w.Header().Set("Allow", "GET, POST")
+			} else {
+				w.Header().Set("Allow", "POST")
+			}
+			writeJSONError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		if s.token == "" && crossSite(r) {
+			writeJSONError(w, http.StatusForbidden, errors.New("cross-site requests need the token"))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		logged, err := logFromRequest(status, r.PathValue("code"), r.Form)
+		switch {
+		case errors.Is(err, errNotFound):
+			writeJSONError(w, http.StatusNotFound, err)
+			return
+		case errors.Is(err, errEntryCap):
+			writeJSONError(w, http.StatusConflict, err)
+			return
+		case err != nil:
+			writeJSONError(w, httpStatusOf(err, http.StatusBadRequest), err)
+			return
+		}
+
+		var resp logResponse
+		resp.Entry = logged
+		stats, _ := GetTodayStatsDaily(appConfig.LogsDir)
+		resp.Today.Movos = stats.TotalMovos
+		resp.Today.Minutes = stats.TotalDuration
+		resp.Today.RPE = stats.TotalRPE
+		writeJSONResponse(w, http.StatusOK, resp)
+	}
+}
+
+// logFromRequest builds and appends the entry for a done or skip request
+func logFromRequest(status, code string, form url.Values) (entryJSON, error) {
+	current, _ := loadCurrentSnack()
+	if code == "" {
+		if current == "" {
+			return entryJSON{}, fmt.Errorf("no current movo: GET /get first or give a code: %w", errNotFound)
+		}
+		code = current
+	}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		return entryJSON{}, withExitCode(exitLibrary, fmt.Errorf("error loading snacks: %w", err))
+	}
+	var snack *Movo
+	for i := range snacks {
+		if snacks[i].FullCode == code {
+			snack = &snacks[i]
+			break
+		}
+	}
+	if snack == nil {
+		return entryJSON{}, fmt.Errorf("movo code '%s': %w", code, errNotFound)
+	}
+
+	entry := HistoryEntry{
+		Timestamp: time.Now(),
+		Code:      code,
+		Status:    status,
+		Subset:    appConfig.ActiveSubset,
+	}
+	if status == "done" {
+		partial, err := formBool(form, "partial")
+		if err != nil {
+			return entryJSON{}, err
+		}
+		entry.Duration = usualDuration(snack)
+		if partial {
+			entry.Status = "partial"
+			entry.Duration = partialDefaultDuration(snack)
+		}
+		entry.RPE = snack.EffectiveRPE
+		if entry.Duration, err = formInt(form, "duration", entry.Duration); err != nil {
+			return entryJSON{}, err
+		}
+		if entry.RPE, err = formInt(form, "rpe", entry.RPE); err != nil {
+			return entryJSON{}, err
+		}
+	}
+
+	// Nobody is at a terminal to confirm going over the cap, so refuse up front
+	if today, err := LoadDailyLog(appConfig.LogsDir, entry.Timestamp); err == nil &&
+		overEntryCap(len(today), appConfig.MaxEntriesPerDay) {
+		return entryJSON{}, fmt.Errorf("already %d entries today (max_entries_per_day: %d): %w",
+			len(today), appConfig.MaxEntriesPerDay, errEntryCap)
+	}
+
+	if err := appendLogEntry(entry, snack); err != nil {
+		return entryJSON{}, withExitCode(exitCodeOf(err, exitStorage), fmt.Errorf("error saving to history: %w", err))
+	}
+	if code == current {
+		os.Remove(appConfig.CurrentPath)
+	}
+	return newEntriesJSON([]HistoryEntry{entry}, map[string]*Movo{code: snack})[0], nil
+}
+
+// httpStatusOf answers an error by the exit code it carries: 404 when no movo matched,
+// 500 when the library or storage failed, otherwise fallback
+func httpStatusOf(err error, fallback int) int {
+	switch exitCodeOf(err, exitError) {
+	case exitNoMatch:
+		return http.StatusNotFound
+	case exitLibrary, exitStorage:
+		return http.StatusInternalServerError
+	}
+	return fallback
+}
+
+// crossSite reports whether a browser says the request comes from another site's page.
+// Clients outside a browser (curl, Shortcuts, Stream Deck) send neither header.
+func crossSite(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+	default:
+		return true
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err != nil || u.Host != r.Host
+}
+
+// formInt reads a non-negative integer parameter, or def if it's absent
+func formInt(form url.Values, name string, def int) (int, error) {
+	value := form.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+// formBool reads a boolean parameter; a bare ?partial counts as true
+func formBool(form url.Values, name string) (bool, error) {
+	values, ok := form[name]
+	if !ok {
+		return false, nil
+	}
+	if len(values) == 0 || values[0] == "" {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(values[0])
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q", name, values[0])
+	}
+	return b, nil
+}
+
+// handleReport answers the day, week or month report as the report command's JSON
+func (s *movoServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	switch period := r.URL.Query().Get("period"); period {
+	case "", "day", "today":
+		stats, err := GetTodayStatsDaily(appConfig.LogsDir)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("error loading stats: %w", err))
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, newDayReportJSON(stats, reportMovoMap()))
+	case "week":
+		report, err := loadWeekReport(appConfig.WeekStartDate(now))
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("error loading history: %w", err))
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, report)
+	case "month":
+		report, err := loadMonthReport(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()))
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("error loading history: %w", err))
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, report)
+	default:
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("unknown report period: %s (use: day, week, month)", period))
+	}
+}
+
+// handleEveryday answers the everyday command's JSON
+func (s *movoServer) handleEveryday(w http.ResponseWriter, r *http.Request) {
+	status, err := loadEverydayStatus(appConfig, time.Now())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, status)
+}
+
+func writeJSONResponse(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	writeJSON(w, v)
+}
+
+func writeJSONError(w http.ResponseWriter, code int, err error) {
+	writeJSONResponse(w, code, map[string]string{"error": err.Error()})
+}
+
+// isLoopback reports whether host only accepts connections from this machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
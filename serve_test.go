@@ -0,0 +1,269 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestServer serves the API from a fresh sandbox home with no history
+func newTestServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	dir := t.TempDir()
+	if err := setupSandbox(dir, time.Now(), 0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	originalConfig := appConfig
+	t.Cleanup(func() { appConfig = originalConfig })
+	for _, name := range append(sandboxEnv, "HOME") {
+		t.Setenv(name, os.Getenv(name))
+	}
+	enterSandbox(dir)
+
+	server := httptest.NewServer((&movoServer{token: token}).handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// call makes a request and decodes the JSON reply into v, returning the status code
+func call(t *testing.T, method, url string, v any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServeGetThenDone(t *testing.T) {
+	server := newTestServer(t, "")
+
+	var movo movoJSON
+	if code := call(t, "GET", server.URL+"/get?category=BR", &movo); code != http.StatusOK {
+		t.Fatalf("expected 200 from /get, got %d", code)
+	}
+	if movo.Code == "" || movo.Title == "" {
+		t.Fatalf("expected a movo, got %+v", movo)
+	}
+
+	var logged logResponse
+	if code := call(t, "POST", server.URL+"/done?duration=4&rpe=2", &logged); code != http.StatusOK {
+		t.Fatalf("expected 200 from /done, got %d", code)
+	}
+	if logged.Entry.Code != movo.Code || logged.Entry.Title != movo.Title || logged.Entry.Duration != 4 || logged.Entry.RPE != 2 {
+		t.Errorf("expected %s logged for 4m at RPE 2, got %+v", movo.Code, logged.Entry)
+	}
+	if logged.Today.Movos != 1 || logged.Today.Minutes != 4 {
+		t.Errorf("expected 1 movo and 4 minutes today, got %+v", logged.Today)
+	}
+
+	// Logging clears the current movo, so a second tap doesn't log it twice
+	var errResp map[string]string
+	if code := call(t, "POST", server.URL+"/done", &errResp); code != http.StatusNotFound {
+		t.Errorf("expected 404 with no current movo, got %d", code)
+	}
+
+	var report dayReportJSON
+	if code := call(t, "GET", server.URL+"/report", &report); code != http.StatusOK {
+		t.Fatalf("expected 200 from /report, got %d", code)
+	}
+	if len(report.Completed) != 1 || report.Completed[0].Code != movo.Code {
+		t.Errorf("expected today's report to show %s, got %+v", movo.Code, report.Completed)
+	}
+}
+
+func TestServeSkipByCode(t *testing.T) {
+	server := newTestServer(t, "")
+
+	var logged logResponse
+	if code := call(t, "POST", server.URL+"/skip/BR-box-breathing", &logged); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if logged.Entry.Status != "skip" || logged.Entry.Duration != 0 {
+		t.Errorf("expected a skip entry, got %+v", logged.Entry)
+	}
+
+	if code := call(t, "POST", server.URL+"/skip/NOPE-missing", nil); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown code, got %d", code)
+	}
+}
+
+func TestServeRefusesPastEntryCap(t *testing.T) {
+	server := newTestServer(t, "")
+	appConfig.MaxEntriesPerDay = 1
+
+	if code := call(t, "POST", server.URL+"/done/BR-box-breathing", nil); code != http.StatusOK {
+		t.Fatalf("expected the first entry to log, got %d", code)
+	}
+	if code := call(t, "POST", server.URL+"/done/BR-box-breathing", nil); code != http.StatusConflict {
+		t.Errorf("expected 409 past max_entries_per_day, got %d", code)
+	}
+}
+
+func TestServeBadRequests(t *testing.T) {
+	server := newTestServer(t, "")
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{"GET", "/get?bogus=1", http.StatusBadRequest},
+		{"GET", "/get?max-duration=soon", http.StatusBadRequest},
+		{"POST", "/done/BR-box-breathing?rpe=hard", http.StatusBadRequest},
+		{"DELETE", "/done", http.StatusMethodNotAllowed},
+		{"GET", "/report?period=year", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		var errResp map[string]string
+		if code := call(t, tt.method, server.URL+tt.path, &errResp); code != tt.want || errResp["error"] == "" {
+			t.Errorf("%s %s: expected %d with an error, got %d %v", tt.method, tt.path, tt.want, code, errResp)
+		}
+	}
+}
+
+func TestServeToken(t *testing.T) {
+	server := newTestServer(t, "s3cret")
+
+	if code := call(t, "GET", server.URL+"/everyday", nil); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without the token, got %d", code)
+	}
+	var status everydayStatus
+	if code := call(t, "GET", server.URL+"/everyday?token=s3cret", &status); code != http.StatusOK {
+		t.Errorf("expected 200 with the token, got %d", code)
+	}
+	if status.Total == 0 || status.Completed != 0 {
+		t.Errorf("expected everyday movos with none done, got %+v", status)
+	}
+}
+
+func TestServeLogNeedsPostWithoutToken(t *testing.T) {
+	server := newTestServer(t, "")
+
+	// A GET could come from an <img> on any page, so without a token it logs nothing
+	var errResp map[string]string
+	if code := call(t, "GET", server.URL+"/done/BR-box-breathing", &errResp); code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for an unauthenticated GET /done, got %d", code)
+	}
+	if code := call(t, "GET", server.URL+"/skip", &errResp); code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for an unauthenticated GET /skip, got %d", code)
+	}
+	var report dayReportJSON
+	if call(t, "GET", server.URL+"/report", &report); len(report.Completed)+len(report.Skipped) != 0 {
+		t.Errorf("expected nothing logged, got %+v", report)
+	}
+
+	// With a token set, GET with the token still works for URL-only clients
+	tokenServer := newTestServer(t, "s3cret")
+	if code := call(t, "GET", tokenServer.URL+"/done/BR-box-breathing?token=s3cret", nil); code != http.StatusOK {
+		t.Errorf("expected 200 for GET /done with the token, got %d", code)
+	}
+}
+
+func TestServeRefusesCrossSitePostWithoutToken(t *testing.T) {
+	server := newTestServer(t, "")
+
+	post := func(url string, headers map[string]string) int {
+		t.Helper()
+		req, err := http.NewRequest("POST", url, strings.NewReader("duration=5"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// What a <form method=POST> on another site sends
+	if code := post(server.URL+"/done/BR-box-breathing", map[string]string{"Origin": "https://evil.example"}); code != http.StatusForbidden {
+		t.Errorf("expected 403 for a cross-site Origin, got %d", code)
+	}
+	if code := post(server.URL+"/skip/BR-box-breathing", map[string]string{"Sec-Fetch-Site": "cross-site"}); code != http.StatusForbidden {
+		t.Errorf("expected 403 for Sec-Fetch-Site: cross-site, got %d", code)
+	}
+	var report dayReportJSON
+	if call(t, "GET", server.URL+"/report", &report); len(report.Completed)+len(report.Skipped) != 0 {
+		t.Errorf("expected nothing logged, got %+v", report)
+	}
+
+	// Same-origin pages and clients outside a browser still log
+	if code := post(server.URL+"/done/BR-box-breathing", map[string]string{"Origin": server.URL, "Sec-Fetch-Site": "same-origin"}); code != http.StatusOK {
+		t.Errorf("expected 200 for a same-origin POST, got %d", code)
+	}
+	if code := post(server.URL+"/done/BR-box-breathing", nil); code != http.StatusOK {
+		t.Errorf("expected 200 for a POST without browser headers, got %d", code)
+	}
+
+	// With a token, the token is the protection
+	tokenServer := newTestServer(t, "s3cret")
+	if code := post(tokenServer.URL+"/done/BR-box-breathing?token=s3cret", map[string]string{"Origin": "https://evil.example"}); code != http.StatusOK {
+		t.Errorf("expected 200 for a cross-site POST with the token, got %d", code)
+	}
+}
+
+func TestServeErrorStatusFromExitCode(t *testing.T) {
+	server := newTestServer(t, "")
+
+	var errResp map[string]string
+	if code := call(t, "GET", server.URL+"/get?category=NOPE", &errResp); code != http.StatusNotFound {
+		t.Errorf("expected 404 when nothing matches, got %d %v", code, errResp)
+	}
+
+	// Today's log can't be read: a storage failure, not \"nothing matched\"
+	if err := os.MkdirAll(GetTodayLogPath(appConfig.LogsDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if code := call(t, "GET", server.URL+"/get", &errResp); code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when history can't be read, got %d %v", code, errResp)
+	}
+
+	for status, want := range map[error]int{
+		withExitCode(exitNoMatch, errors.New("none")): http.StatusNotFound,
+		withExitCode(exitLibrary, errors.New("yaml")): http.StatusInternalServerError,
+		withExitCode(exitStorage, errors.New("disk")): http.StatusInternalServerError,
+		withExitCode(exitCancelled, errors.New("no")): http.StatusBadRequest,
+		errors.New("invalid rpe"):                     http.StatusBadRequest,
+	} {
+		if got := httpStatusOf(status, http.StatusBadRequest); got != want {
+			t.Errorf("httpStatusOf(%v) = %d, want %d", status, got, want)
+		}
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	for host, want := range map[string]bool{
+		"127.0.0.1": true,
+		"localhost": true,
+		"::1":       true,
+		"0.0.0.0":   false,
+		"":          false,
+		"10.0.0.5":  false,
+	} {
+		if got := isLoopback(host); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
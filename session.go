@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// sessionPlan is a block of movos chosen to fit a time budget
+type sessionPlan struct {
+	Minutes      int           `json:"minutes"` // The budget asked for
+	TotalMinutes int           `json:"total_minutes"`
+	TotalRPE     int           `json:"total_rpe"`
+	Items        []sessionItem `json:"items"`
+}
+
+// sessionItem is one planned movo and the minutes set aside for it
+type sessionItem struct {
+	movoJSON
+	Minutes int  `json:"minutes"`
+	Daily   bool `json:"daily"` // Planned first as an incomplete min_per_day movo
+	movo    *Movo
+}
+
+// planSession picks movos for a session of the given minutes with the usual selection
+// filters: incomplete dailies first, then weighted picks, each at most once and within
+// what's left of today's RPE budget
+func planSession(snacks []Movo, filters FilterOptions, minutes int) (sessionPlan, error) {
+	cfg := DefaultConfig()
+
+	todayStats, err := GetTodayStatsDaily(cfg.LogsDir)
+	if err != nil {
+		return sessionPlan{}, fmt.Errorf("error loading today's stats: %w", err)
+	}
+
+	// Weigh everything, then pull the dailies out to go first
+	all := filters
+	all.SkipMinimums = true
+	weighted, _, err := weighCandidates(snacks, all, cfg.MaxDailyRPE)
+	if err != nil {
+		return sessionPlan{}, err
+	}
+
+	dailies := make(map[string]bool)
+	if !filters.SkipMinimums {
+		candidates := make([]Movo, len(weighted))
+		for i, w := range weighted {
+			candidates[i] = w.snack
+		}
+		minimums, err := filterToIncompleteMinimums(candidates, cfg.LogsDir)
+		if err != nil {
+			return sessionPlan{}, err
+		}
+		for _, snack := range dropWaivedMinimums(minimums, cfg, time.Now()) {
+			dailies[snack.FullCode] = true
+		}
+	}
+
+	plan := buildSessionPlan(selectorRand, weighted, dailies, minutes, cfg.MaxDailyRPE-todayStats.TotalRPE, usualDuration)
+	if len(plan.Items) == 0 {
+		return plan, fmt.Errorf("no matching snacks fit in %d minutes", minutes)
+	}
+	return plan, nil
+}
+
+// buildSessionPlan fills the minutes with dailies (lowest RPE first), then weighted
+// random picks from the rest. A movo fits if its minutes are left and its RPE is within
+// rpeBudget, though light movos (RPE ≤ 2, as in auto-recovery) always fit.
+func buildSessionPlan(r *rand.Rand, weighted []weightedSnack, dailies map[string]bool, minutes, rpeBudget int, duration func(*Movo) int) sessionPlan {
+	plan := sessionPlan{Minutes: minutes, Items: []sessionItem{}}
+
+	fits := func(movo *Movo) bool {
+		if duration(movo) > minutes-plan.TotalMinutes {
+			return false
+		}
+		return movo.EffectiveRPE <= autoRecoveryMaxRPE || plan.TotalRPE+movo.EffectiveRPE <= rpeBudget
+	}
+	add := func(movo Movo) {
+		mins := duration(&movo)
+		plan.Items = append(plan.Items, sessionItem{movoJSON: newMovoJSON(&movo), Minutes: mins, Daily: dailies[movo.FullCode], movo: &movo})
+		plan.TotalMinutes += mins
+		plan.TotalRPE += movo.EffectiveRPE
+	}
+
+	var daily, rest []weightedSnack
+	for _, w := range weighted {
+		if dailies[w.snack.FullCode] {
+			daily = append(daily, w)
+		} else {
+			rest = append(rest, w)
+		}
+	}
+
+	sort.SliceStable(daily, func(i, j int) bool {
+		if daily[i].snack.EffectiveRPE != daily[j].snack.EffectiveRPE {
+			return daily[i].snack.EffectiveRPE < daily[j].snack.EffectiveRPE
+		}
+		return daily[i].snack.FullCode < daily[j].snack.FullCode
+	})
+	for i := range daily {
+		if fits(&daily[i].snack) {
+			add(daily[i].snack)
+		}
+	}
+
+	for {
+		var fitting []weightedSnack
+		for _, w := range rest {
+			if fits(&w.snack) {
+				fitting = append(fitting, w)
+			}
+		}
+		if len(fitting) == 0 {
+			return plan
+		}
+		picked := weightedRandomSelect(r, fitting)
+		for i := range rest {
+			if rest[i].snack.FullCode == picked.FullCode {
+				add(rest[i].snack)
+				rest = append(rest[:i], rest[i+1:]...)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestBuildSessionPlan(t *testing.T) {
+	weighted := []weightedSnack{
+		{snack: Movo{FullCode: "KB-swings", EffectiveRPE: 7, DurationMin: 5, DurationMax: 5}, weight: 5},
+		{snack: Movo{FullCode: "MB-hips", EffectiveRPE: 2, DurationMin: 3, DurationMax: 3}, weight: 1},
+		{snack: Movo{FullCode: "BR-box", EffectiveRPE: 1, DurationMin: 2, DurationMax: 2}, weight: 1},
+		{snack: Movo{FullCode: "BW-squats", EffectiveRPE: 5, DurationMin: 4, DurationMax: 4}, weight: 3},
+		{snack: Movo{FullCode: "ST-long", EffectiveRPE: 3, DurationMin: 30, DurationMax: 30}, weight: 3},
+	}
+	dailies := map[string]bool{"MB-hips": true, "BR-box": true}
+	duration := func(m *Movo) int { return m.DurationMax }
+
+	for seed := uint64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewPCG(seed, seed))
+		plan := buildSessionPlan(r, weighted, dailies, 12, 30, duration)
+
+		if len(plan.Items) < 2 || plan.Items[0].Code != "BR-box" || plan.Items[1].Code != "MB-hips" {
+			t.Fatalf("expected the dailies first, lowest RPE first, got %+v", plan.Items)
+		}
+		seen := make(map[string]bool)
+		minutes, rpe := 0, 0
+		for _, item := range plan.Items {
+			if seen[item.Code] {
+				t.Fatalf("%s planned twice", item.Code)
+			}
+			seen[item.Code] = true
+			minutes += item.Minutes
+			rpe += item.RPE
+		}
+		if minutes > 12 || minutes != plan.TotalMinutes || rpe != plan.TotalRPE {
+			t.Errorf("expected at most 12 minutes with matching totals, got %d/%d RPE %d/%d", minutes, plan.TotalMinutes, rpe, plan.TotalRPE)
+		}
+		if seen["ST-long"] {
+			t.Error("a 30 minute movo shouldn't fit in 12 minutes")
+		}
+		// 7 minutes are left after the dailies: room for swings or squats, not both
+		if len(plan.Items) != 3 {
+			t.Errorf("expected one pick after the dailies, got %+v", plan.Items)
+		}
+	}
+}
+
+func TestBuildSessionPlanRPEBudget(t *testing.T) {
+	weighted := []weightedSnack{
+		{snack: Movo{FullCode: "KB-swings", EffectiveRPE: 7, DurationMin: 5, DurationMax: 5}, weight: 1},
+		{snack: Movo{FullCode: "BR-box", EffectiveRPE: 1, DurationMin: 2, DurationMax: 2}, weight: 1},
+	}
+	r := rand.New(rand.NewPCG(1, 1))
+	plan := buildSessionPlan(r, weighted, nil, 30, 4, func(m *Movo) int { return m.DurationMax })
+	if len(plan.Items) != 1 || plan.Items[0].Code != "BR-box" {
+		t.Errorf("expected only the light movo within an RPE budget of 4, got %+v", plan.Items)
+	}
+}
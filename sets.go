@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Extras keys holding the sets and reps actually done
+const (
+	extraSets = "sets"
+	extraReps = "reps"
+)
+
+// prescription describes the sets and reps to do, e.g. "3×10" ("" if none are given).
+// A prescription string in the YAML wins over sets/reps.
+func (s *Movo) prescription() string {
+	switch {
+	case s.Prescription != "":
+		return s.Prescription
+	case s.Sets > 0 && s.Reps > 0:
+		return fmt.Sprintf("%d×%d", s.Sets, s.Reps)
+	case s.Sets > 0:
+		return fmt.Sprintf("%d sets", s.Sets)
+	case s.Reps > 0:
+		return fmt.Sprintf("%d reps", s.Reps)
+	}
+	return ""
+}
+
+// parseSetsReps reads sets×reps written like 3x10, 3×10 or 3*10
+func parseSetsReps(s string) (sets, reps int, err error) {
+	normalized := strings.NewReplacer("×", "x", "*", "x", "X", "x", " ", "").Replace(s)
+	setsStr, repsStr, ok := strings.Cut(normalized, "x")
+	if ok {
+		sets, err = strconv.Atoi(setsStr)
+		if err == nil {
+			reps, err = strconv.Atoi(repsStr)
+		}
+	}
+	if !ok || err != nil || sets <= 0 || reps <= 0 {
+		return 0, 0, fmt.Errorf("invalid sets×reps %q (use e.g. 3x10)", s)
+	}
+	return sets, reps, nil
+}
+
+// recordSetsReps stores the sets and reps done in the entry's extras (0 for none)
+func recordSetsReps(entry *HistoryEntry, sets, reps int) {
+	if sets <= 0 || reps <= 0 {
+		return
+	}
+	if entry.Extras == nil {
+		entry.Extras = make(map[string]string)
+	}
+	entry.Extras[extraSets] = strconv.Itoa(sets)
+	entry.Extras[extraReps] = strconv.Itoa(reps)
+}
+
+// entrySetsReps returns the sets and reps logged with an entry (ok false if none)
+func entrySetsReps(entry HistoryEntry) (sets, reps int, ok bool) {
+	sets, err := strconv.Atoi(entry.Extras[extraSets])
+	if err != nil || sets <= 0 {
+		return 0, 0, false
+	}
+	reps, err = strconv.Atoi(entry.Extras[extraReps])
+	if err != nil || reps <= 0 {
+		return 0, 0, false
+	}
+	return sets, reps, true
+}
+
+// entryDetails lists what was logged with an entry beyond minutes and RPE, ready to
+// append inside a report line's parentheses (e.g. ", 3×10")
+func entryDetails(entry HistoryEntry) string {
+	var details string
+	if sets, reps, ok := entrySetsReps(entry); ok {
+		details += fmt.Sprintf(", %d×%d", sets, reps)
+	}
+	if load := entryLoad(entry); load > 0 {
+		details += ", " + formatLoad(load)
+	}
+	km, steps := entryDistance(entry)
+	if km > 0 {
+		details += ", " + formatDistance(km)
+	}
+	if steps > 0 {
+		details += fmt.Sprintf(", %d steps", steps)
+	}
+	return details
+}
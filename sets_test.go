@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrescription(t *testing.T) {
+	tests := []struct {
+		movo Movo
+		want string
+	}{
+		{Movo{Sets: 3, Reps: 10}, "3×10"},
+		{Movo{Sets: 5}, "5 sets"},
+		{Movo{Reps: 20}, "20 reps"},
+		{Movo{Sets: 3, Reps: 10, Prescription: "EMOM 10: 5 swings"}, "EMOM 10: 5 swings"},
+		{Movo{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.movo.prescription(); got != tt.want {
+			t.Errorf("prescription(%+v) = %q, want %q", tt.movo, got, tt.want)
+		}
+	}
+}
+
+func TestParseSetsReps(t *testing.T) {
+	for _, input := range []string{"3x10", "3×10", "3*10", "3 X 10"} {
+		sets, reps, err := parseSetsReps(input)
+		if err != nil || sets != 3 || reps != 10 {
+			t.Errorf("parseSetsReps(%q) = %d, %d, %v", input, sets, reps, err)
+		}
+	}
+	for _, input := range []string{"", "3", "x10", "0x10", "3x-1", "threexten"} {
+		if _, _, err := parseSetsReps(input); err == nil {
+			t.Errorf("parseSetsReps(%q): expected an error", input)
+		}
+	}
+	if _, _, err := parseSetsReps("3 X ten"); err == nil || !strings.Contains(err.Error(), `"3 X ten"`) {
+		t.Errorf("expected the error to quote the argument as typed, got %v", err)
+	}
+}
+
+func TestSetsRepsRoundTrip(t *testing.T) {
+	entry := HistoryEntry{Code: "KB-press", Status: "done", Duration: 6, RPE: 7}
+	recordSetsReps(&entry, 0, 0)
+	if entry.Extras != nil {
+		t.Errorf("nothing should be recorded without sets, got %v", entry.Extras)
+	}
+
+	recordSetsReps(&entry, 3, 8)
+	parsed, err := parseCSVRecord(entryRecord(entry))
+	if err != nil {
+		t.Fatalf("parseCSVRecord: %v", err)
+	}
+	if sets, reps, ok := entrySetsReps(parsed); !ok || sets != 3 || reps != 8 {
+		t.Errorf("entrySetsReps = %d, %d, %v; want 3, 8", sets, reps, ok)
+	}
+	if details := entryDetails(parsed); details != ", 3×8" {
+		t.Errorf("entryDetails = %q", details)
+	}
+}
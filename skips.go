@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Repeated skips gradually lower a movo's weight. Each skip within skipRecoveryDays
+// counts fully when fresh and fades linearly to nothing, so the weight recovers
+// over a few weeks once the skipping stops.
+const (
+	skipDecay         = 0.8  // Weight multiplier per fresh skip
+	skipRecoveryDays  = 28   // Days for a skip to stop counting
+	minSkipMultiplier = 0.25 // Skips never push a movo below this share of its weight
+)
+
+// skipSummary is one movo's recent skips and what they currently cost it
+type skipSummary struct {
+	Code        string    `json:"code"`
+	Title       string    `json:"title"`
+	Skips       int       `json:"skips"`
+	LastSkipped time.Time `json:"last_skipped"`
+	Multiplier  float64   `json:"multiplier"`
+}
+
+// loadRecentSkips returns when each movo was skipped within skipRecoveryDays of now
+func loadRecentSkips(logsDir string, now time.Time) (map[string][]time.Time, error) {
+	entries, err := LoadHistoryRange(logsDir, now.AddDate(0, 0, -skipRecoveryDays), now)
+	if err != nil {
+		return nil, err
+	}
+	skips := make(map[string][]time.Time)
+	for _, entry := range entries {
+		if entry.Status == "skip" {
+			skips[entry.Code] = append(skips[entry.Code], entry.Timestamp)
+		}
+	}
+	return skips, nil
+}
+
+// skipMultiplier is the weight multiplier for a movo skipped at these times
+func skipMultiplier(skips []time.Time, now time.Time) float64 {
+	pressure := 0.0
+	for _, at := range skips {
+		age := now.Sub(at).Hours() / 24
+		if age < 0 {
+			age = 0
+		}
+		if age < skipRecoveryDays {
+			pressure += 1 - age/skipRecoveryDays
+		}
+	}
+	return math.Max(minSkipMultiplier, math.Pow(skipDecay, pressure))
+}
+
+// summarizeSkips lists the movos skipped recently, the most held back first
+func summarizeSkips(skips map[string][]time.Time, movos map[string]*Movo, now time.Time) []skipSummary {
+	summaries := []skipSummary{}
+	for code, times := range skips {
+		summary := skipSummary{Code: code, Skips: len(times), Multiplier: skipMultiplier(times, now)}
+		for _, at := range times {
+			if at.After(summary.LastSkipped) {
+				summary.LastSkipped = at
+			}
+		}
+		if movo, ok := movos[code]; ok {
+			summary.Title = movo.Title
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Multiplier != summaries[j].Multiplier {
+			return summaries[i].Multiplier < summaries[j].Multiplier
+		}
+		return summaries[i].Code < summaries[j].Code
+	})
+	return summaries
+}
+
+// writeSkipReport prints what's been skipped lately and how much less it comes up
+func writeSkipReport(w io.Writer, summaries []skipSummary) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, tr("  SKIPPED IN THE LAST %d DAYS\n"), skipRecoveryDays)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, tr("Nothing skipped. 🎉"))
+		return
+	}
+
+	fmt.Fprintf(w, "%-32s %5s  %-12s %s\n", tr("Code"), tr("Skips"), tr("Last skip"), tr("Weight"))
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%-32s %5d  %-12s %3.0f%%\n",
+			s.Code, s.Skips, localizeDate(s.LastSkipped.Format("Jan 2")), s.Multiplier*100)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, tr("Weight is how much of its usual weight each movo keeps; it recovers as skips age."))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSkipMultiplier(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days float64) time.Time {
+		return now.Add(-time.Duration(days * 24 * float64(time.Hour)))
+	}
+
+	tests := []struct {
+		name  string
+		skips []time.Time
+		want  float64
+	}{
+		{"never skipped", nil, 1},
+		{"one fresh skip", []time.Time{now}, skipDecay},
+		{"half recovered", []time.Time{daysAgo(skipRecoveryDays / 2)}, math.Pow(skipDecay, 0.5)},
+		{"fully recovered", []time.Time{daysAgo(skipRecoveryDays + 1)}, 1},
+		{"two fresh skips", []time.Time{now, now}, skipDecay * skipDecay},
+		{"floored", []time.Time{now, now, now, now, now, now, now, now, now, now}, minSkipMultiplier},
+	}
+	for _, tt := range tests {
+		if got := skipMultiplier(tt.skips, now); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: got %f, want %f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeSkips(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	skips := map[string][]time.Time{
+		"KB-swings":     {now.AddDate(0, 0, -20)},
+		"TS-pushups":    {now.AddDate(0, 0, -3), now.AddDate(0, 0, -1)},
+		"TB-box-breath": {now.AddDate(0, 0, -20)},
+	}
+	movos := map[string]*Movo{"TS-pushups": {FullCode: "TS-pushups", Title: "Push-ups"}}
+
+	summaries := summarizeSkips(skips, movos, now)
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 summaries, got %+v", summaries)
+	}
+	first := summaries[0]
+	if first.Code != "TS-pushups" || first.Title != "Push-ups" || first.Skips != 2 || !first.LastSkipped.Equal(now.AddDate(0, 0, -1)) {
+		t.Errorf("expected TS-pushups, the most held back, first: %+v", first)
+	}
+	if summaries[1].Code != "KB-swings" || summaries[2].Code != "TB-box-breath" {
+		t.Errorf("expected ties ordered by code, got %+v", summaries)
+	}
+}
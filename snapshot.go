@@ -0,0 +1,46 @@
+package main
+
+import "strings"
+
+// Extras keys holding the movo's details at the time it was logged, so reports stay
+// descriptive after the movo is renamed or removed from the library
+const (
+	extraTitle    = "title"
+	extraCategory = "category"
+	extraTags     = "tags"
+)
+
+// snapshotMovo records the movo's title, category and tags in the entry's extras
+func snapshotMovo(entry *HistoryEntry, movo *Movo) {
+	if movo == nil {
+		return
+	}
+	if entry.Extras == nil {
+		entry.Extras = make(map[string]string)
+	}
+	entry.Extras[extraTitle] = movo.Title
+	if movo.CategoryName != "" {
+		entry.Extras[extraCategory] = movo.CategoryName
+	}
+	if len(movo.AllTags) > 0 {
+		entry.Extras[extraTags] = strings.Join(movo.AllTags, ",")
+	}
+}
+
+// entryMovo returns the movo an entry refers to: from the library if it's still there,
+// otherwise rebuilt from the snapshot logged with it (nil if there's neither)
+func entryMovo(entry HistoryEntry, movos map[string]*Movo) *Movo {
+	if movo := movos[entry.Code]; movo != nil {
+		return movo
+	}
+
+	title := entry.Extras[extraTitle]
+	if title == "" {
+		return nil
+	}
+	movo := &Movo{FullCode: entry.Code, Title: title, CategoryName: entry.Extras[extraCategory]}
+	if tags := entry.Extras[extraTags]; tags != "" {
+		movo.AllTags = strings.Split(tags, ",")
+	}
+	return movo
+}
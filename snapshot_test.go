@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnapshotSurvivesLibraryRemoval(t *testing.T) {
+	logsDir := t.TempDir()
+	movo := &Movo{
+		FullCode:     "TS-pushups",
+		Title:        "Push-ups, slow & controlled",
+		CategoryName: "Tiny Strength",
+		AllTags:      []string{"strengthx", "upperx"},
+	}
+
+	entry := HistoryEntry{Timestamp: time.Now(), Code: movo.FullCode, Status: "done", Duration: 5, RPE: 4}
+	snapshotMovo(&entry, movo)
+	if err := AppendTodayLog(logsDir, entry); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadDailyLog(logsDir, time.Now())
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %v, %v", entries, err)
+	}
+
+	// The library no longer has the movo
+	got := entryMovo(entries[0], map[string]*Movo{})
+	if got == nil {
+		t.Fatal("expected a movo rebuilt from the snapshot")
+	}
+	if got.Title != movo.Title || got.CategoryName != movo.CategoryName || len(got.AllTags) != 2 || got.AllTags[1] != "upperx" {
+		t.Errorf("snapshot mismatch: got %+v", got)
+	}
+
+	// The library wins while the movo still exists
+	current := &Movo{FullCode: "TS-pushups", Title: "Push-ups"}
+	if got := entryMovo(entries[0], map[string]*Movo{"TS-pushups": current}); got != current {
+		t.Errorf("expected the library movo, got %+v", got)
+	}
+}
+
+func TestEntryMovoWithoutSnapshot(t *testing.T) {
+	entry := HistoryEntry{Code: "TS-old"}
+	if got := entryMovo(entry, nil); got != nil {
+		t.Errorf("expected nil for an entry logged before snapshots, got %+v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSnooze = 30 * time.Minute
+
+// parseSnoozeDuration reads a snooze window: a Go duration (45m, 1h30m) or plain minutes
+func parseSnoozeDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return defaultSnooze, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		minutes, atoiErr := strconv.Atoi(s)
+		if atoiErr != nil {
+			return 0, fmt.Errorf("invalid snooze duration %q (e.g. 30m, 1h)", s)
+		}
+		d = time.Duration(minutes) * time.Minute
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("snooze duration must be positive, got %s", s)
+	}
+	return d, nil
+}
+
+// loadSnoozes reads the snooze file ("CODE UNTIL" per line), returning the movos
+// still snoozed at now. A missing file means nothing is snoozed.
+func loadSnoozes(path string, now time.Time) (map[string]time.Time, error) {
+	snoozes := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snoozes, nil
+		}
+		return nil, fmt.Errorf("error reading snoozes: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		until, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil || !until.After(now) {
+			continue
+		}
+		snoozes[fields[0]] = until
+	}
+	return snoozes, nil
+}
+
+// snoozeMovo snoozes code until the given time, dropping snoozes that have run out
+func snoozeMovo(path, code string, until, now time.Time) error {
+	snoozes, err := loadSnoozes(path, now)
+	if err != nil {
+		return err
+	}
+	snoozes[code] = until
+
+	codes := make([]string, 0, len(snoozes))
+	for c := range snoozes {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+
+	var b strings.Builder
+	for _, c := range codes {
+		fmt.Fprintf(&b, "%s %s\n", c, snoozes[c].Format(time.RFC3339))
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// filterSnoozed removes snoozed movos from selection
+func filterSnoozed(snacks []Movo, snoozes map[string]time.Time) []Movo {
+	if len(snoozes) == 0 {
+		return snacks
+	}
+	var filtered []Movo
+	for _, snack := range snacks {
+		if _, snoozed := snoozes[snack.FullCode]; !snoozed {
+			filtered = append(filtered, snack)
+		}
+	}
+	return filtered
+}
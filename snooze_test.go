@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSnoozeDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", defaultSnooze},
+		{"45m", 45 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"20", 20 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got, err := parseSnoozeDuration(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseSnoozeDuration(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"soon", "0", "-5m"} {
+		if _, err := parseSnoozeDuration(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestSnoozeMovo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozed")
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+
+	if err := snoozeMovo(path, "STR-squats", now.Add(10*time.Minute), now); err != nil {
+		t.Fatal(err)
+	}
+	if err := snoozeMovo(path, "BR-box", now.Add(time.Hour), now); err != nil {
+		t.Fatal(err)
+	}
+
+	snoozes, err := loadSnoozes(path, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snoozes) != 2 {
+		t.Fatalf("expected 2 snoozes, got %v", snoozes)
+	}
+
+	// Twenty minutes later only the hour-long snooze is still running
+	later := now.Add(20 * time.Minute)
+	snoozes, err = loadSnoozes(path, later)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snoozes["BR-box"]; len(snoozes) != 1 || !ok {
+		t.Errorf("expected only BR-box still snoozed, got %v", snoozes)
+	}
+
+	movos := []Movo{{FullCode: "STR-squats"}, {FullCode: "BR-box"}}
+	if got := filterSnoozed(movos, snoozes); len(got) != 1 || got[0].FullCode != "STR-squats" {
+		t.Errorf("expected BR-box filtered out, got %+v", got)
+	}
+}
+
+func TestWeighCandidatesSkipsSnoozed(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	cfg := DefaultConfig()
+
+	movos := []Movo{
+		{FullCode: "STR-squats", EffectiveRPE: 3, Weight: 1},
+		{FullCode: "BR-box", EffectiveRPE: 1, Weight: 1},
+	}
+	now := time.Now()
+	if err := os.MkdirAll(filepath.Dir(cfg.SnoozePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := snoozeMovo(cfg.SnoozePath, "BR-box", now.Add(time.Hour), now); err != nil {
+		t.Fatal(err)
+	}
+
+	weighted, _, err := weighCandidates(movos, FilterOptions{SkipMinimums: true}, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(weighted) != 1 || weighted[0].snack.FullCode != "STR-squats" {
+		t.Errorf("expected only STR-squats eligible, got %+v", weighted)
+	}
+
+	if _, _, err := weighCandidates(movos[1:], FilterOptions{SkipMinimums: true}, 30); err == nil {
+		t.Error("expected an error when every match is snoozed")
+	}
+}
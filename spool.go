@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// The spool is a local CSV (same format as daily logs) holding entries that couldn't be
+// written because the logs dir was unavailable, e.g. an offline network mount.
+// Entries are delivered to their own day's log, oldest first, once it's reachable.
+
+// loadSpool reads the spooled entries (none if there's no spool)
+func loadSpool(spoolPath string) ([]HistoryEntry, error) {
+	data, err := os.ReadFile(spoolPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading spool: %w", err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing spool: %w", err)
+	}
+
+	var entries []HistoryEntry
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && record[0] == "timestamp" {
+			continue
+		}
+		entry, err := parseCSVRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("spool line %d: %w", i+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// saveSpool rewrites the spool with entries, removing it when there are none
+func saveSpool(spoolPath string, entries []HistoryEntry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(spoolPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing spool: %w", err)
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(logHeader)
+	for _, entry := range entries {
+		writer.Write(entryRecord(entry))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing spool: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(spoolPath), 0755); err != nil {
+		return fmt.Errorf("error writing spool: %w", err)
+	}
+	tmp := spoolPath + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error writing spool: %w", err)
+	}
+	return os.Rename(tmp, spoolPath)
+}
+
+// spoolEntry queues an entry for later delivery, returning how many are now pending
+func spoolEntry(spoolPath string, entry HistoryEntry) (int, error) {
+	entries, err := loadSpool(spoolPath)
+	if err != nil {
+		return 0, err
+	}
+	entries = append(entries, entry)
+	return len(entries), saveSpool(spoolPath, entries)
+}
+
+// flushSpool delivers spooled entries to their daily logs in order, stopping at the
+// first failure so the rest keep their order. Returns how many were delivered and how
+// many are still pending; lastErr is why delivery stopped.
+func flushSpool(spoolPath, logsDir string) (flushed, pending int, lastErr error) {
+	entries, err := loadSpool(spoolPath)
+	if err != nil || len(entries) == 0 {
+		return 0, len(entries), err
+	}
+
+	for _, entry := range entries {
+		if err := AppendDailyLog(logsDir, entry); err != nil {
+			lastErr = err
+			break
+		}
+		flushed++
+	}
+
+	if flushed == 0 {
+		return 0, len(entries), lastErr
+	}
+	remaining := entries[flushed:]
+	if err := saveSpool(spoolPath, remaining); err != nil {
+		return flushed, len(remaining), err
+	}
+	return flushed, len(remaining), lastErr
+}
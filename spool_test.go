@@ -0,0 +1,113 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSpoolFlushWhenReachable(t *testing.T) {
+	dir := t.TempDir()
+	spoolPath := filepath.Join(dir, "spool.csv")
+
+	// A logs "dir" that is really a file can't be written to, like an offline mount
+	offline := filepath.Join(dir, "offline")
+	if err := os.WriteFile(offline, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Truncate(time.Second)
+	entries := []HistoryEntry{
+		{Timestamp: yesterday, Code: "TS-pushups", Status: "done", Duration: 5, RPE: 3},
+		{Timestamp: time.Now().Truncate(time.Second), Code: "TB-box-breath", Status: "done", Duration: 3, RPE: 1,
+			Extras: map[string]string{"profile": "kids"}},
+	}
+	for i, entry := range entries {
+		pending, err := spoolEntry(spoolPath, entry)
+		if err != nil || pending != i+1 {
+			t.Fatalf("spoolEntry: pending %d, err %v", pending, err)
+		}
+	}
+
+	flushed, pending, err := flushSpool(spoolPath, offline)
+	if flushed != 0 || pending != 2 || err == nil {
+		t.Fatalf("offline flush: expected 0 flushed, 2 pending and an error; got %d, %d, %v", flushed, pending, err)
+	}
+
+	logsDir := filepath.Join(dir, "logs")
+	flushed, pending, err = flushSpool(spoolPath, logsDir)
+	if flushed != 2 || pending != 0 || err != nil {
+		t.Fatalf("online flush: expected 2 flushed, 0 pending; got %d, %d, %v", flushed, pending, err)
+	}
+	if _, err := os.Stat(spoolPath); !os.IsNotExist(err) {
+		t.Error("expected the spool to be removed once empty")
+	}
+
+	// Each entry lands in the log for the day it was logged
+	past, err := LoadDailyLog(logsDir, yesterday)
+	if err != nil || len(past) != 1 || past[0].Code != "TS-pushups" {
+		t.Errorf("yesterday's log: got %+v, %v", past, err)
+	}
+	today, err := LoadDailyLog(logsDir, time.Now())
+	if err != nil || len(today) != 1 || today[0].Extras["profile"] != "kids" {
+		t.Errorf("today's log: got %+v, %v", today, err)
+	}
+}
+
+func TestLoadSpoolMissing(t *testing.T) {
+	entries, err := loadSpool(filepath.Join(t.TempDir(), "spool.csv"))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected no entries and no error, got %v, %v", entries, err)
+	}
+}
+
+func TestAppendLogLeavesMissingMountAlone(t *testing.T) {
+	// The logs dir's parent is missing, like an unmounted network drive
+	mount := filepath.Join(t.TempDir(), "mnt")
+	logsDir := filepath.Join(mount, "logs")
+
+	entry := HistoryEntry{Timestamp: time.Now().Truncate(time.Second), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 3}
+	if err := AppendDailyLog(logsDir, entry); err == nil {
+		t.Fatal("expected an error writing to a logs dir on a missing mount")
+	}
+	if _, err := os.Stat(mount); !os.IsNotExist(err) {
+		t.Errorf("expected the missing mount not to be created, got %v", err)
+	}
+
+	if err := os.Mkdir(mount, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendDailyLog(logsDir, entry); err != nil {
+		t.Fatalf("expected the logs dir to be created under a present parent, got %v", err)
+	}
+}
+
+func TestReadThenAppendWithMountMissing(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	// Logs on a mount that isn't there; get reads history before done appends
+	mount := filepath.Join(t.TempDir(), "mnt")
+	appConfig.LogsDir = filepath.Join(mount, "movodoro", "logs")
+	if _, err := loadWeightInputs(appConfig, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(mount); !os.IsNotExist(err) {
+		t.Fatalf("expected reading history to leave the mount alone, got %v", err)
+	}
+
+	entry := HistoryEntry{Timestamp: time.Now().Truncate(time.Second), Code: "TS-pushups", Status: "done", Duration: 5, RPE: 3}
+	if err := writeLogEntry(entry, nil, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	spooled, err := loadSpool(appConfig.SpoolPath)
+	if err != nil || len(spooled) != 1 || spooled[0].Code != "TS-pushups" {
+		t.Errorf("expected the entry in the spool, got %+v, %v", spooled, err)
+	}
+	if _, err := os.Stat(mount); !os.IsNotExist(err) {
+		t.Errorf("expected the entry not written under the missing mount, got %v", err)
+	}
+}
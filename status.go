@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xbarStatus is what the menu bar shows: the current movo and today's totals
+type xbarStatus struct {
+	Exe     string // movodoro binary the menu items run
+	Profile string // Passed along to every command when set
+	Current *Movo  // nil if there's no current movo
+	Stats   DailyStats
+	MaxRPE  int
+}
+
+// xbarText makes text safe for an xbar line ("|" starts the parameters)
+func xbarText(s string) string {
+	return strings.ReplaceAll(s, "|", "¦")
+}
+
+// xbarAction is a menu item that runs a movodoro command when clicked
+func (s xbarStatus) xbarAction(label string, terminal bool, args ...string) string {
+	if s.Profile != "" {
+		args = append([]string{"--config-profile", s.Profile}, args...)
+	}
+	line := fmt.Sprintf("%s | bash=%q", label, s.Exe)
+	for i, arg := range args {
+		line += fmt.Sprintf(" param%d=%s", i+1, arg)
+	}
+	return line + fmt.Sprintf(" terminal=%t refresh=true", terminal)
+}
+
+// writeXbar writes the xbar/SwiftBar plugin format: a title line, then "---" and menu items
+func writeXbar(w io.Writer, s xbarStatus) {
+	fmt.Fprintf(w, "🏃 %d · RPE %d/%d\n", len(s.Stats.CompletedSnacks), s.Stats.TotalRPE, s.MaxRPE)
+	fmt.Fprintln(w, "---")
+
+	if s.Current != nil {
+		fmt.Fprintf(w, "%s (%s)\n", xbarText(s.Current.Title), s.Current.FullCode)
+		fmt.Fprintln(w, s.xbarAction("✅ Done", false, "done"))
+		fmt.Fprintln(w, s.xbarAction("⏭️ Skip", false, "skip"))
+		fmt.Fprintln(w, s.xbarAction("🎲 Another movo", false, "get"))
+	} else {
+		fmt.Fprintln(w, tr("No current movo"))
+		fmt.Fprintln(w, s.xbarAction("🎲 Get a movo", false, "get"))
+	}
+
+	fmt.Fprintln(w, "---")
+	fmt.Fprintf(w, tr("Today: %d movos, %d minutes\n"), len(s.Stats.CompletedSnacks), s.Stats.TotalDuration)
+	fmt.Fprintln(w, s.xbarAction("📊 Today's report", true, "report", "-v"))
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteXbar(t *testing.T) {
+	var buf bytes.Buffer
+	writeXbar(&buf, xbarStatus{
+		Exe:     "/usr/local/bin/movodoro",
+		Profile: "kids",
+		Current: &Movo{FullCode: "TS-pushups", Title: "Push-ups | squats"},
+		Stats: DailyStats{
+			CompletedSnacks: []HistoryEntry{{Code: "TB-box-breath"}},
+			TotalDuration:   5,
+			TotalRPE:        3,
+		},
+		MaxRPE: 30,
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "🏃 1 · RPE 3/30" || lines[1] != "---" {
+		t.Fatalf("unexpected title section: %q", lines[:2])
+	}
+	if lines[2] != "Push-ups ¦ squats (TS-pushups)" {
+		t.Errorf("expected the title's | to be escaped, got %q", lines[2])
+	}
+	done := `✅ Done | bash="/usr/local/bin/movodoro" param1=--config-profile param2=kids param3=done terminal=false refresh=true`
+	if lines[3] != done {
+		t.Errorf("done item:\n got %q\nwant %q", lines[3], done)
+	}
+}
+
+func TestWriteXbarNoCurrent(t *testing.T) {
+	var buf bytes.Buffer
+	writeXbar(&buf, xbarStatus{Exe: "movodoro", MaxRPE: 30})
+
+	out := buf.String()
+	if !strings.Contains(out, "No current movo") || strings.Contains(out, "✅ Done") {
+		t.Errorf("expected only a get item without a current movo, got:\n%s", out)
+	}
+	if !strings.Contains(out, "🎲 Get a movo | bash=\"movodoro\" param1=get terminal=false refresh=true") {
+		t.Errorf("missing get item:\n%s", out)
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	stravaAPIURL   = "https://www.strava.com/api/v3"
+	stravaTokenURL = "https://www.strava.com/oauth/token"
+
+	// Short snacks would clutter the feed, so only longer ones are mirrored by default
+	defaultStravaMinDuration = 10
+	defaultStravaSportType   = "Workout"
+)
+
+// StravaConfig holds the OAuth app and token from the strava section of config.yaml
+type StravaConfig struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	MinDuration  int    // Only completions at least this many minutes long are uploaded
+	SportType    string // Strava sport_type for uploaded activities
+}
+
+// stravaFileConfig mirrors the strava section of config.yaml
+type stravaFileConfig struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	RefreshToken string `yaml:"refresh_token"`
+	MinDuration  *int   `yaml:"min_duration"`
+	SportType    string `yaml:"sport_type"`
+}
+
+// apply overrides the Strava defaults with what config.yaml sets
+func (fc stravaFileConfig) apply(s *StravaConfig) error {
+	s.ClientID = fc.ClientID
+	s.ClientSecret = fc.ClientSecret
+	s.RefreshToken = fc.RefreshToken
+	if fc.MinDuration != nil {
+		if *fc.MinDuration < 0 {
+			return fmt.Errorf("strava min_duration must not be negative, got %d", *fc.MinDuration)
+		}
+		s.MinDuration = *fc.MinDuration
+	}
+	if fc.SportType != "" {
+		s.SportType = fc.SportType
+	}
+	return nil
+}
+
+// stravaToken is the OAuth token, saved because Strava rotates refresh tokens
+type stravaToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+// stravaClient uploads activities, refreshing its access token when it expires
+type stravaClient struct {
+	cfg       StravaConfig
+	tokenPath string
+	token     stravaToken
+	apiURL    string
+	tokenURL  string
+	http      *http.Client
+}
+
+// newStravaClient loads the saved token, falling back to the refresh token in config.yaml
+func newStravaClient(cfg StravaConfig, tokenPath string) (*stravaClient, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("set strava client_id and client_secret in config.yaml")
+	}
+
+	c := &stravaClient{
+		cfg:       cfg,
+		tokenPath: tokenPath,
+		apiURL:    stravaAPIURL,
+		tokenURL:  stravaTokenURL,
+		http:      &http.Client{Timeout: 30 * time.Second},
+	}
+	data, err := os.ReadFile(tokenPath)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &c.token); err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", tokenPath, err)
+		}
+	case os.IsNotExist(err):
+		c.token.RefreshToken = cfg.RefreshToken
+	default:
+		return nil, fmt.Errorf("error reading Strava token: %w", err)
+	}
+
+	if c.token.RefreshToken == "" {
+		return nil, fmt.Errorf("set strava refresh_token in config.yaml (authorize with the activity:write scope)")
+	}
+	return c, nil
+}
+
+// accessToken returns a valid access token, refreshing (and saving) it if needed
+func (c *stravaClient) accessToken(now time.Time) (string, error) {
+	// A minute's slack so the token can't expire mid-request
+	if c.token.AccessToken != "" && now.Add(time.Minute).Unix() < c.token.ExpiresAt {
+		return c.token.AccessToken, nil
+	}
+
+	form := url.Values{
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {c.token.RefreshToken},
+	}
+	var token stravaToken
+	if err := c.post(c.tokenURL, "", form, &token); err != nil {
+		return "", fmt.Errorf("error refreshing Strava token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("error refreshing Strava token: no access token in response")
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = c.token.RefreshToken
+	}
+	c.token = token
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.tokenPath), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(c.tokenPath, data, 0600); err != nil {
+		return "", fmt.Errorf("error saving Strava token: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+// stravaActivity is a manual activity as the create activity endpoint takes it
+type stravaActivity struct {
+	Name        string
+	SportType   string
+	Start       time.Time
+	Duration    time.Duration
+	Description string
+}
+
+// newStravaActivity describes a completed entry; it started duration minutes before it was logged
+func newStravaActivity(entry HistoryEntry, movo *Movo, sportType string) stravaActivity {
+	name := entry.Code
+	if movo != nil {
+		name = movo.Title
+	}
+	duration := time.Duration(entry.Duration) * time.Minute
+	return stravaActivity{
+		Name:        name,
+		SportType:   sportType,
+		Start:       entry.Timestamp.Add(-duration),
+		Duration:    duration,
+		Description: fmt.Sprintf("Movement snack %s, RPE %d (via movodoro)", entry.Code, entry.RPE),
+	}
+}
+
+// createActivity uploads a manual activity, returning its Strava ID
+func (c *stravaClient) createActivity(a stravaActivity, now time.Time) (int64, error) {
+	token, err := c.accessToken(now)
+	if err != nil {
+		return 0, err
+	}
+
+	form := url.Values{
+		"name":             {a.Name},
+		"sport_type":       {a.SportType},
+		"start_date_local": {a.Start.Format("2006-01-02T15:04:05")},
+		"elapsed_time":     {strconv.Itoa(int(a.Duration.Seconds()))},
+		"description":      {a.Description},
+	}
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.post(c.apiURL+"/activities", token, form, &created); err != nil {
+		return 0, fmt.Errorf("error creating Strava activity: %w", err)
+	}
+	return created.ID, nil
+}
+
+// post sends a form and decodes the JSON reply into v
+func (c *stravaClient) post(endpoint, token string, form url.Values, v any) error {
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// stravaEntryKey identifies an entry in the synced file
+func stravaEntryKey(entry HistoryEntry) string {
+	return entry.Timestamp.Format(time.RFC3339) + " " + entry.Code
+}
+
+// loadStravaSynced reads which entries have been uploaded, keyed by stravaEntryKey
+func loadStravaSynced(path string) (map[string]bool, error) {
+	synced := make(map[string]bool)
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return synced, nil
+		}
+		return nil, fmt.Errorf("error opening Strava sync file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading Strava sync file: %w", err)
+	}
+	for i, record := range records {
+		// Skip header row
+		if i == 0 && len(record) > 0 && record[0] == "timestamp" {
+			continue
+		}
+		if len(record) != 3 {
+			continue
+		}
+		synced[record[0]+" "+record[1]] = true
+	}
+	return synced, nil
+}
+
+// appendStravaSynced records that entry was uploaded as activity id
+func appendStravaSynced(path string, entry HistoryEntry, id int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	fileInfo, err := os.Stat(path)
+	writeHeader := err != nil || fileInfo.Size() == 0
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening Strava sync file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if writeHeader {
+		writer.Write([]string{"timestamp", "code", "activity_id"})
+	}
+	writer.Write([]string{
+		entry.Timestamp.Format(time.RFC3339),
+		entry.Code,
+		strconv.FormatInt(id, 10),
+	})
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// stravaCandidates returns the completed entries long enough to upload that haven't been
+func stravaCandidates(entries []HistoryEntry, synced map[string]bool, minDuration int) []HistoryEntry {
+	var out []HistoryEntry
+	for _, entry := range entries {
+		if entry.Status != "done" || entry.Duration <= 0 || entry.Duration < minDuration {
+			continue
+		}
+		if synced[stravaEntryKey(entry)] {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out
+}
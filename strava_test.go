@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStravaCandidates(t *testing.T) {
+	at := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+	entries := []HistoryEntry{
+		{Timestamp: at, Code: "STR-squats", Status: "done", Duration: 12},
+		{Timestamp: at.Add(time.Hour), Code: "BR-box", Status: "done", Duration: 3},
+		{Timestamp: at.Add(2 * time.Hour), Code: "STR-plank", Status: "partial", Duration: 10},
+		{Timestamp: at.Add(3 * time.Hour), Code: "STR-lunges", Status: "skip"},
+		{Timestamp: at.Add(4 * time.Hour), Code: "STR-pushups", Status: "done", Duration: 10},
+	}
+	synced := map[string]bool{stravaEntryKey(entries[0]): true}
+
+	got := stravaCandidates(entries, synced, 10)
+	if len(got) != 1 || got[0].Code != "STR-pushups" {
+		t.Errorf("expected only the unsynced 10 minute completion, got %+v", got)
+	}
+
+	// A zero threshold still never uploads empty activities
+	if got := stravaCandidates(entries[3:4], nil, 0); len(got) != 0 {
+		t.Errorf("expected skips to stay local, got %+v", got)
+	}
+}
+
+func TestStravaSyncedRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strava-synced.csv")
+	entry := HistoryEntry{Timestamp: time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local), Code: "STR-squats"}
+
+	if err := appendStravaSynced(path, entry, 42); err != nil {
+		t.Fatal(err)
+	}
+	synced, err := loadStravaSynced(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(synced) != 1 || !synced[stravaEntryKey(entry)] {
+		t.Errorf("expected the entry to be marked synced, got %v", synced)
+	}
+}
+
+func TestNewStravaActivity(t *testing.T) {
+	logged := time.Date(2025, 3, 10, 9, 15, 0, 0, time.Local)
+	entry := HistoryEntry{Timestamp: logged, Code: "STR-squats", Status: "done", Duration: 15, RPE: 6}
+
+	a := newStravaActivity(entry, &Movo{Title: "Goblet squats"}, "Workout")
+	if a.Name != "Goblet squats" || a.Duration != 15*time.Minute || a.SportType != "Workout" {
+		t.Errorf("unexpected activity %+v", a)
+	}
+	if want := logged.Add(-15 * time.Minute); !a.Start.Equal(want) {
+		t.Errorf("expected the activity to start at %v, got %v", want, a.Start)
+	}
+
+	if a := newStravaActivity(entry, nil, "Workout"); a.Name != "STR-squats" {
+		t.Errorf("expected the code as the name without a movo, got %q", a.Name)
+	}
+}
+
+func TestStravaClientRefreshesAndUploads(t *testing.T) {
+	var refreshes int
+	var form map[string]string
+	strava := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.URL.Path {
+		case "/oauth/token":
+			refreshes++
+			if r.Form.Get("refresh_token") != "from-config" {
+				http.Error(w, `{"message":"bad refresh token"}`, http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(stravaToken{AccessToken: "access", RefreshToken: "rotated", ExpiresAt: time.Now().Add(6 * time.Hour).Unix()})
+		case "/api/v3/activities":
+			if r.Header.Get("Authorization") != "Bearer access" {
+				http.Error(w, `{"message":"unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			form = map[string]string{}
+			for key := range r.Form {
+				form[key] = r.Form.Get(key)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 1234}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer strava.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "strava-token.json")
+	cfg := StravaConfig{ClientID: "1", ClientSecret: "secret", RefreshToken: "from-config", SportType: "Workout"}
+	client, err := newStravaClient(cfg, tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.apiURL = strava.URL + "/api/v3"
+	client.tokenURL = strava.URL + "/oauth/token"
+
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.Local)
+	activity := stravaActivity{Name: "Goblet squats", SportType: "Workout", Start: start, Duration: 15 * time.Minute}
+	for range 2 {
+		id, err := client.createActivity(activity, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != 1234 {
+			t.Errorf("expected activity 1234, got %d", id)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("expected the access token to be reused, got %d refreshes", refreshes)
+	}
+	if form["elapsed_time"] != "900" || form["start_date_local"] != "2025-03-10T09:00:00" || form["name"] != "Goblet squats" {
+		t.Errorf("unexpected activity form %v", form)
+	}
+
+	// The rotated refresh token is saved for next time
+	data, err := os.ReadFile(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved stravaToken
+	if err := json.Unmarshal(data, &saved); err != nil || saved.RefreshToken != "rotated" {
+		t.Errorf("expected the rotated token to be saved, got %s", data)
+	}
+}
+
+func TestNewStravaClientNeedsCredentials(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "strava-token.json")
+	if _, err := newStravaClient(StravaConfig{RefreshToken: "x"}, tokenPath); err == nil {
+		t.Error("expected an error without client credentials")
+	}
+	if _, err := newStravaClient(StravaConfig{ClientID: "1", ClientSecret: "s"}, tokenPath); err == nil {
+		t.Error("expected an error without a refresh token")
+	}
+}
+
+func TestDefaultConfigStrava(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if cfg := DefaultConfig(); cfg.Strava.MinDuration != defaultStravaMinDuration || cfg.Strava.SportType != defaultStravaSportType {
+		t.Errorf("expected Strava defaults, got %+v", cfg.Strava)
+	}
+
+	configDir := filepath.Join(home, ".movodoro")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	yaml := "strava:\n  client_id: \"123\"\n  client_secret: shh\n  refresh_token: tok\n  min_duration: 0\n  sport_type: Yoga\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultConfig()
+	want := StravaConfig{ClientID: "123", ClientSecret: "shh", RefreshToken: "tok", MinDuration: 0, SportType: "Yoga"}
+	if cfg.ConfigErr != nil || cfg.Strava != want {
+		t.Errorf("expected %+v, got %+v (%v)", want, cfg.Strava, cfg.ConfigErr)
+	}
+}
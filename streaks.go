@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const dayKeyFormat = "2006-01-02"
+
+// Skip policies decide what a logged skip means for streaks and activity days.
+// Skips never count toward duration or RPE totals.
+const (
+	skipNeutral = "neutral" // Skips change nothing: streaks need completions, skip-only days aren't active
+	skipExcuse  = "excuse"  // A day the movo was skipped doesn't break its streak, and counts as active
+	skipBreak   = "break"   // A skip ends the movo's streak, even on a day its minimum was met
+)
+
+// parseSkipPolicy validates a skip_policy value ("" means neutral)
+func parseSkipPolicy(s string) (string, error) {
+	switch policy := strings.ToLower(strings.TrimSpace(s)); policy {
+	case "":
+		return skipNeutral, nil
+	case skipNeutral, skipExcuse, skipBreak:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("skip_policy must be neutral, excuse or break, got %q", s)
+	}
+}
+
+// isActivity reports whether an entry makes its day an active day under policy
+func isActivity(entry HistoryEntry, policy string) bool {
+	switch entry.Status {
+	case "done", "partial":
+		return true
+	case "skip":
+		return policy == skipExcuse
+	}
+	return false
+}
+
+// dayKey returns the calendar day key for t
+func dayKey(t time.Time) string {
+	return t.Format(dayKeyFormat)
+}
+
+// attributeCompletions counts completions of code per day for streak purposes.
+// A completion logged before graceUntil (time since midnight) counts toward the
+// previous day if that day hadn't met minPerDay yet. Entries must be chronological.
+func attributeCompletions(entries []HistoryEntry, code string, minPerDay int, graceUntil time.Duration) map[string]int {
+	counts := make(map[string]int)
+
+	for _, entry := range entries {
+		if entry.Code != code || entry.Status != "done" {
+			continue
+		}
+
+		ts := entry.Timestamp
+		midnight := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, ts.Location())
+
+		if graceUntil > 0 && ts.Sub(midnight) < graceUntil {
+			yesterday := dayKey(midnight.AddDate(0, 0, -1))
+			if counts[yesterday] < minPerDay {
+				counts[yesterday]++
+				continue
+			}
+		}
+
+		counts[dayKey(midnight)]++
+	}
+
+	return counts
+}
+
+// skippedDays returns the days on which code was skipped
+func skippedDays(entries []HistoryEntry, code string) map[string]bool {
+	days := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Code == code && entry.Status == "skip" {
+			days[dayKey(entry.Timestamp)] = true
+		}
+	}
+	return days
+}
+
+// currentStreak returns the number of consecutive days up to today on which the
+// minimum was met. Today only counts once met, so an unfinished today doesn't
+// break a streak that ran through yesterday.
+func currentStreak(counts map[string]int, minPerDay int, today time.Time) int {
+	return streakWithSkips(counts, nil, restSchedule{}, minPerDay, today, skipNeutral)
+}
+
+// streakWithSkips is currentStreak with skipped days treated according to policy:
+// excused days are bridged without adding to the streak, and under "break" a skip
+// (even today's) ends it. Rest days are bridged the same way as excused days.
+func streakWithSkips(counts map[string]int, skipped map[string]bool, rest restSchedule, minPerDay int, today time.Time, policy string) int {
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	if policy == skipBreak && skipped[dayKey(day)] {
+		return 0
+	}
+	if counts[dayKey(day)] < minPerDay {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	streak := 0
+	for {
+		key := dayKey(day)
+		if policy == skipBreak && skipped[key] {
+			break
+		}
+		if counts[key] >= minPerDay {
+			streak++
+		} else if (policy != skipExcuse || !skipped[key]) && !rest.isRest(day) {
+			break
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func doneAt(code string, ts time.Time) HistoryEntry {
+	return HistoryEntry{Timestamp: ts, Code: code, Status: "done", Duration: 5, RPE: 1}
+}
+
+func TestAttributeCompletionsGrace(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2025, 10, d, h, 0, 0, 0, time.UTC) }
+
+	entries := []HistoryEntry{
+		doneAt("TB-box-breath", day(10, 12)),
+		// Missed the 11th, caught up at 8am on the 12th
+		doneAt("TB-box-breath", day(12, 8)),
+		// Early entry on the 13th, but the 12th is already satisfied by a later entry
+		doneAt("TB-box-breath", day(12, 20)),
+		doneAt("TB-box-breath", day(13, 7)),
+		doneAt("TS-pushups", day(13, 7)),
+	}
+
+	t.Run("without grace", func(t *testing.T) {
+		counts := attributeCompletions(entries, "TB-box-breath", 1, 0)
+		if counts["2025-10-11"] != 0 || counts["2025-10-12"] != 2 || counts["2025-10-13"] != 1 {
+			t.Errorf("unexpected counts: %v", counts)
+		}
+	})
+
+	t.Run("with grace until 10:00", func(t *testing.T) {
+		counts := attributeCompletions(entries, "TB-box-breath", 1, 10*time.Hour)
+		if counts["2025-10-11"] != 1 {
+			t.Errorf("expected 8am completion to count toward the 11th, got %v", counts)
+		}
+		if counts["2025-10-12"] != 1 {
+			t.Errorf("expected one completion on the 12th, got %v", counts)
+		}
+		if counts["2025-10-13"] != 1 {
+			t.Errorf("expected 7am completion to stay on the 13th (12th already met), got %v", counts)
+		}
+	})
+}
+
+func TestCurrentStreak(t *testing.T) {
+	counts := map[string]int{
+		"2025-10-09": 1,
+		"2025-10-11": 1,
+		"2025-10-12": 2,
+		"2025-10-13": 1,
+	}
+	today := time.Date(2025, 10, 14, 15, 0, 0, 0, time.UTC)
+
+	// Today isn't done yet, so the streak runs through yesterday
+	if got := currentStreak(counts, 1, today); got != 3 {
+		t.Errorf("expected streak 3, got %d", got)
+	}
+
+	counts["2025-10-14"] = 1
+	if got := currentStreak(counts, 1, today); got != 4 {
+		t.Errorf("expected streak 4 once today is done, got %d", got)
+	}
+
+	// Higher minimum breaks the streak on days with a single completion
+	if got := currentStreak(counts, 2, today); got != 0 {
+		t.Errorf("expected streak 0 with min 2, got %d", got)
+	}
+}
+
+func TestStreakWithSkips(t *testing.T) {
+	counts := map[string]int{
+		"2025-10-09": 1,
+		"2025-10-11": 1,
+		"2025-10-12": 1,
+		"2025-10-13": 1,
+	}
+	skipped := map[string]bool{"2025-10-10": true, "2025-10-12": true}
+	today := time.Date(2025, 10, 14, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		policy string
+		want   int
+	}{
+		{skipNeutral, 3}, // 11-13; the 10th had no completion
+		{skipExcuse, 4},  // The skipped 10th is bridged but not counted
+		{skipBreak, 1},   // The skip on the 12th ends it despite the completion
+	}
+	for _, tt := range tests {
+		if got := streakWithSkips(counts, skipped, restSchedule{}, 1, today, tt.policy); got != tt.want {
+			t.Errorf("%s: expected streak %d, got %d", tt.policy, tt.want, got)
+		}
+	}
+
+	skipped["2025-10-14"] = true
+	if got := streakWithSkips(counts, skipped, restSchedule{}, 1, today, skipBreak); got != 0 {
+		t.Errorf("break: expected a skip today to end the streak, got %d", got)
+	}
+}
+
+func TestParseSkipPolicy(t *testing.T) {
+	for in, want := range map[string]string{"": skipNeutral, "Excuse": skipExcuse, " break ": skipBreak} {
+		if got, err := parseSkipPolicy(in); err != nil || got != want {
+			t.Errorf("parseSkipPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseSkipPolicy("forgive"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tagTotals is what was done under one tag in a report's period
+type tagTotals struct {
+	Tag     string `json:"tag"`
+	Movos   int    `json:"movos"`
+	Minutes int    `json:"minutes"`
+	RPE     int    `json:"rpe"`
+}
+
+// tagReport splits a period's done and partial movos by tag ('report --by-tag')
+type tagReport struct {
+	From string      `json:"from"`
+	To   string      `json:"to"`
+	Tags []tagTotals `json:"tags"`
+}
+
+// periodRange returns the days of the day, week or month report containing now
+func periodRange(period string, now time.Time) (reportRange, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case "day", "today":
+		return reportRange{From: today, To: today}, nil
+	case "week":
+		start := appConfig.WeekStartDate(now)
+		return reportRange{From: start, To: start.AddDate(0, 0, 6)}, nil
+	case "month":
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return reportRange{From: start, To: start.AddDate(0, 1, -1)}, nil
+	}
+	return reportRange{}, fmt.Errorf("--by-tag works with the day, week and month reports, not %s", period)
+}
+
+// buildTagReport totals done and partial entries under each of their movo's tags,
+// most movos first. A movo with several tags counts toward each of them.
+func buildTagReport(entries []HistoryEntry, movos map[string]*Movo, rng reportRange) tagReport {
+	totals := make(map[string]*tagTotals)
+	for _, entry := range entries {
+		if entry.Status != "done" && entry.Status != "partial" {
+			continue
+		}
+		movo := entryMovo(entry, movos)
+		if movo == nil {
+			continue
+		}
+		for _, tag := range movo.AllTags {
+			tag = strings.ToLower(tag)
+			if totals[tag] == nil {
+				totals[tag] = &tagTotals{Tag: tag}
+			}
+			totals[tag].Movos++
+			totals[tag].Minutes += entry.Duration
+			totals[tag].RPE += entry.RPE
+		}
+	}
+
+	report := tagReport{From: dayKey(rng.From), To: dayKey(rng.To), Tags: make([]tagTotals, 0, len(totals))}
+	for _, t := range totals {
+		report.Tags = append(report.Tags, *t)
+	}
+	sort.Slice(report.Tags, func(i, j int) bool {
+		if report.Tags[i].Movos != report.Tags[j].Movos {
+			return report.Tags[i].Movos > report.Tags[j].Movos
+		}
+		return report.Tags[i].Tag < report.Tags[j].Tag
+	})
+	return report
+}
+
+// writeTagReport prints each tag's movos, minutes and RPE
+func writeTagReport(w io.Writer, heading string, report tagReport) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, "  %s\n", tr("MOVODORO REPORT BY TAG"))
+	fmt.Fprintf(w, "  %s\n", heading)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	if len(report.Tags) == 0 {
+		fmt.Fprintln(w, tr("No tagged movos done in this period"))
+		return
+	}
+	for _, t := range report.Tags {
+		fmt.Fprintf(w, tr("🔖 %-14s %3d movos %5dm  RPE %d\n"), t.Tag, t.Movos, t.Minutes, t.RPE)
+	}
+}
+
+// writeTagReportMarkdown is writeTagReport as a markdown table
+func writeTagReportMarkdown(w io.Writer, heading string, report tagReport) {
+	fmt.Fprintf(w, "# Movodoro Report by Tag - %s\n\n", heading)
+	if len(report.Tags) == 0 {
+		fmt.Fprintln(w, "No tagged movos done in this period.")
+		return
+	}
+	fmt.Fprintln(w, "| Tag | Movos | Minutes | RPE |")
+	fmt.Fprintln(w, "|-----|------:|--------:|----:|")
+	for _, t := range report.Tags {
+		fmt.Fprintf(w, "| %s | %d | %d | %d |\n", t.Tag, t.Movos, t.Minutes, t.RPE)
+	}
+}
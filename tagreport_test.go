@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuildTagReport(t *testing.T) {
+	now := time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local)
+	movos := map[string]*Movo{
+		"KB-swings": {FullCode: "KB-swings", AllTags: []string{"strengthx", "kbx"}},
+		"MOB-hips":  {FullCode: "MOB-hips", AllTags: []string{"mobilityx"}},
+	}
+	entries := []HistoryEntry{
+		{Timestamp: now, Code: "KB-swings", Status: "done", Duration: 5, RPE: 7},
+		{Timestamp: now, Code: "KB-swings", Status: "partial", Duration: 2, RPE: 5},
+		{Timestamp: now, Code: "MOB-hips", Status: "done", Duration: 6, RPE: 3},
+		{Timestamp: now, Code: "MOB-hips", Status: "skip"},
+		// Removed from the library since; tags come from the snapshot
+		{Timestamp: now, Code: "OLD-walk", Status: "done", Duration: 10, RPE: 2,
+			Extras: map[string]string{extraTitle: "Walk", extraTags: "Cardiox,mobilityx"}},
+	}
+
+	report := buildTagReport(entries, movos, reportRange{From: now, To: now})
+	want := []tagTotals{
+		{Tag: "kbx", Movos: 2, Minutes: 7, RPE: 12},
+		{Tag: "mobilityx", Movos: 2, Minutes: 16, RPE: 5},
+		{Tag: "strengthx", Movos: 2, Minutes: 7, RPE: 12},
+		{Tag: "cardiox", Movos: 1, Minutes: 10, RPE: 2},
+	}
+	if !reflect.DeepEqual(report.Tags, want) {
+		t.Errorf("got %+v, want %+v", report.Tags, want)
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	now := time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local) // A Wednesday
+	tests := map[string][2]string{
+		"day":   {"2025-03-05", "2025-03-05"},
+		"week":  {"2025-03-03", "2025-03-09"},
+		"month": {"2025-03-01", "2025-03-31"},
+	}
+	for period, want := range tests {
+		rng, err := periodRange(period, now)
+		if err != nil || dayKey(rng.From) != want[0] || dayKey(rng.To) != want[1] {
+			t.Errorf("%s: got %s to %s (%v), want %s to %s", period, dayKey(rng.From), dayKey(rng.To), err, want[0], want[1])
+		}
+	}
+	if _, err := periodRange("profiles", now); err == nil {
+		t.Error("expected an error for the profiles report")
+	}
+}
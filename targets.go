@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// HasAnyTarget reports whether the snack works at least one of the body regions
+func (s *Movo) HasAnyTarget(targets []string) bool {
+	for _, want := range targets {
+		for _, target := range s.Targets {
+			if strings.EqualFold(target, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// targetCount is how often one body region was worked in a period
+type targetCount struct {
+	Target  string `json:"target"`
+	Count   int    `json:"count"`
+	Minutes int    `json:"minutes"`
+}
+
+// targetCoverage counts done and partial movos per target. Every target in the
+// library is listed, so neglected regions show up with a count of zero; most worked first.
+func targetCoverage(entries []HistoryEntry, movos map[string]*Movo) []targetCount {
+	counts := make(map[string]*targetCount)
+	for _, movo := range movos {
+		for _, target := range movo.Targets {
+			target = strings.ToLower(target)
+			if counts[target] == nil {
+				counts[target] = &targetCount{Target: target}
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.Status != "done" && entry.Status != "partial" {
+			continue
+		}
+		movo := movos[entry.Code]
+		if movo == nil {
+			continue
+		}
+		for _, target := range movo.Targets {
+			c := counts[strings.ToLower(target)]
+			c.Count++
+			c.Minutes += entry.Duration
+		}
+	}
+
+	coverage := make([]targetCount, 0, len(counts))
+	for _, c := range counts {
+		coverage = append(coverage, *c)
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		if coverage[i].Count != coverage[j].Count {
+			return coverage[i].Count > coverage[j].Count
+		}
+		return coverage[i].Target < coverage[j].Target
+	})
+	return coverage
+}
+
+// writeTargetCoverage lists each target's movos and minutes, flagging untouched ones
+func writeTargetCoverage(w io.Writer, coverage []targetCount) {
+	fmt.Fprintln(w, tr("🎯 Targets:"))
+	for _, c := range coverage {
+		if c.Count == 0 {
+			fmt.Fprintf(w, tr("   %-14s ⚠️  not worked\n"), c.Target)
+			continue
+		}
+		fmt.Fprintf(w, tr("   %-14s %3d movos %5dm\n"), c.Target, c.Count, c.Minutes)
+	}
+}
+
+// writeTargetCoverageMarkdown is writeTargetCoverage as a markdown table
+func writeTargetCoverageMarkdown(w io.Writer, coverage []targetCount) {
+	fmt.Fprintln(w, "## Targets")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Target | Movos | Minutes |")
+	fmt.Fprintln(w, "|--------|------:|--------:|")
+	for _, c := range coverage {
+		fmt.Fprintf(w, "| %s | %d | %d |\n", c.Target, c.Count, c.Minutes)
+	}
+}
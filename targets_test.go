@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFilterSnacksByTarget(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "MOB-hips", Targets: []string{"hips"}, DurationMin: 2, DurationMax: 3},
+		{FullCode: "MOB-thoracic", Targets: []string{"T-spine", "shoulders"}, DurationMin: 2, DurationMax: 3},
+		{FullCode: "BR-box", DurationMin: 2, DurationMax: 3},
+	}
+	var got []string
+	for _, s := range filterSnacks(snacks, FilterOptions{Targets: []string{"t-spine", "hips"}}) {
+		got = append(got, s.FullCode)
+	}
+	if want := []string{"MOB-hips", "MOB-thoracic"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestTargetCoverage(t *testing.T) {
+	movos := map[string]*Movo{
+		"MOB-hips":     {FullCode: "MOB-hips", Targets: []string{"hips"}},
+		"MOB-thoracic": {FullCode: "MOB-thoracic", Targets: []string{"t-spine", "Shoulders"}},
+		"KB-swings":    {FullCode: "KB-swings", Targets: []string{"hips", "grip"}},
+	}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	entries := []HistoryEntry{
+		{Timestamp: now, Code: "MOB-hips", Status: "done", Duration: 5},
+		{Timestamp: now, Code: "KB-swings", Status: "partial", Duration: 3},
+		{Timestamp: now, Code: "MOB-thoracic", Status: "skip"},
+		{Timestamp: now, Code: "KB-swings", Status: "done", Duration: 6},
+	}
+
+	coverage := targetCoverage(entries, movos)
+	want := []targetCount{
+		{"hips", 3, 14},
+		{"grip", 2, 9},
+		{"shoulders", 0, 0},
+		{"t-spine", 0, 0},
+	}
+	if !reflect.DeepEqual(coverage, want) {
+		t.Errorf("got %+v, want %+v", coverage, want)
+	}
+
+	var buf bytes.Buffer
+	writeTargetCoverage(&buf, coverage)
+	if !strings.Contains(buf.String(), "t-spine        ⚠️  not worked") {
+		t.Errorf("expected neglected regions flagged, got\n%s", buf.String())
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// runTimer counts down total on out, redrawing the remaining time on each tick, until
+// the time is up (ringing the terminal bell) or stop fires. It returns the time elapsed
+// since start and whether the countdown finished.
+func runTimer(out io.Writer, total time.Duration, start time.Time, ticks <-chan time.Time, stop <-chan os.Signal) (time.Duration, bool) {
+	fmt.Fprintf(out, "\r"+tr("⏳ %s remaining  (Ctrl+C to stop early)"), formatCountdown(total))
+	for {
+		select {
+		case now := <-ticks:
+			elapsed := now.Sub(start)
+			if elapsed >= total {
+				fmt.Fprintf(out, "\r\033[K"+tr("🔔 Time's up! %s")+"\a\n", formatCountdown(total))
+				return elapsed, true
+			}
+			fmt.Fprintf(out, "\r"+tr("⏳ %s remaining  (Ctrl+C to stop early)"), formatCountdown(total-elapsed))
+		case <-stop:
+			elapsed := time.Since(start)
+			fmt.Fprintf(out, "\r\033[K"+tr("⏹️  Stopped after %s")+"\n", formatCountdown(elapsed))
+			return elapsed, false
+		}
+	}
+}
+
+// formatCountdown formats d as MM:SS, rounding up so the display never reads 00:00 early
+func formatCountdown(d time.Duration) string {
+	secs := int((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// elapsedMinutes rounds a timed duration to whole minutes for logging (at least 1)
+func elapsedMinutes(d time.Duration) int {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
+
+// timeMovo runs a countdown of the movo's usual duration in the terminal and
+// returns the minutes actually spent. Ctrl+C stops the timer rather than movodoro.
+func timeMovo(movo *Movo) int {
+	total := time.Duration(usualDuration(movo)) * time.Minute
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	defer signal.Stop(stop)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	fmt.Println()
+	elapsed, _ := runTimer(os.Stdout, total, time.Now(), ticker.C, stop)
+	return elapsedMinutes(elapsed)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunTimerFinishes(t *testing.T) {
+	start := time.Date(2025, 10, 14, 9, 0, 0, 0, time.UTC)
+	ticks := make(chan time.Time, 3)
+	ticks <- start.Add(1 * time.Second)
+	ticks <- start.Add(90 * time.Second)
+	ticks <- start.Add(2 * time.Minute)
+
+	var out bytes.Buffer
+	elapsed, finished := runTimer(&out, 2*time.Minute, start, ticks, make(chan os.Signal))
+	if !finished || elapsed != 2*time.Minute {
+		t.Errorf("expected the timer to finish after 2m, got %v (finished %v)", elapsed, finished)
+	}
+
+	for _, want := range []string{"02:00 remaining", "01:59 remaining", "00:30 remaining", "Time's up!", "\a"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output: %q", want, out.String())
+		}
+	}
+}
+
+func TestRunTimerStopped(t *testing.T) {
+	stop := make(chan os.Signal, 1)
+	stop <- os.Interrupt
+
+	var out bytes.Buffer
+	_, finished := runTimer(&out, time.Minute, time.Now(), make(chan time.Time), stop)
+	if finished {
+		t.Error("expected a stopped timer not to finish")
+	}
+	if !strings.Contains(out.String(), "Stopped after") || strings.Contains(out.String(), "\a") {
+		t.Errorf("expected a quiet stop message, got %q", out.String())
+	}
+}
+
+func TestElapsedMinutes(t *testing.T) {
+	tests := []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{10 * time.Second, 1},
+		{4*time.Minute + 29*time.Second, 4},
+		{4*time.Minute + 30*time.Second, 5},
+		{5 * time.Minute, 5},
+	}
+	for _, tt := range tests {
+		if got := elapsedMinutes(tt.elapsed); got != tt.want {
+			t.Errorf("elapsedMinutes(%v) = %d, want %d", tt.elapsed, got, tt.want)
+		}
+	}
+}
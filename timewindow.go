@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeWindow limits a movo to part of the day. Either end may be left open, and a
+// window whose after is later than its before wraps past midnight (after: "21:00",
+// before: "02:00").
+type TimeWindow struct {
+	After  string `yaml:"after,omitempty"`  // HH:MM, inclusive
+	Before string `yaml:"before,omitempty"` // HH:MM, exclusive
+}
+
+// bounds parses the window's ends as times since midnight; open ends are -1
+func (w TimeWindow) bounds() (after, before time.Duration, err error) {
+	after, before = -1, -1
+	if w.After != "" {
+		if after, err = parseTimeOfDay(w.After); err != nil {
+			return 0, 0, fmt.Errorf("time_window after: %w", err)
+		}
+	}
+	if w.Before != "" {
+		if before, err = parseTimeOfDay(w.Before); err != nil {
+			return 0, 0, fmt.Errorf("time_window before: %w", err)
+		}
+	}
+	return after, before, nil
+}
+
+// contains reports whether the local time of day of t falls in the window
+func (w TimeWindow) contains(t time.Time) (bool, error) {
+	after, before, err := w.bounds()
+	if err != nil {
+		return false, err
+	}
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	switch {
+	case after < 0 && before < 0:
+		return true, nil
+	case after < 0:
+		return now < before, nil
+	case before < 0:
+		return now >= after, nil
+	case after <= before:
+		return now >= after && now < before, nil
+	default: // Wraps past midnight
+		return now >= after || now < before, nil
+	}
+}
+
+// filterByTimeWindow removes snacks whose time window doesn't include now. A window
+// that doesn't parse leaves its snack in ('validate' reports it).
+func filterByTimeWindow(snacks []Movo, now time.Time) []Movo {
+	var filtered []Movo
+	for _, snack := range snacks {
+		if snack.TimeWindow != nil {
+			if open, err := snack.TimeWindow.contains(now); err == nil && !open {
+				continue
+			}
+		}
+		filtered = append(filtered, snack)
+	}
+	return filtered
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWindowContains(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2025, 3, 5, h, m, 0, 0, time.Local) }
+	tests := []struct {
+		window TimeWindow
+		now    time.Time
+		want   bool
+	}{
+		{TimeWindow{After: "06:00", Before: "11:00"}, at(6, 0), true},
+		{TimeWindow{After: "06:00", Before: "11:00"}, at(11, 0), false},
+		{TimeWindow{After: "06:00", Before: "11:00"}, at(5, 59), false},
+		{TimeWindow{Before: "20:00"}, at(21, 30), false},
+		{TimeWindow{After: "17:00"}, at(18, 0), true},
+		{TimeWindow{After: "21:00", Before: "02:00"}, at(23, 0), true},
+		{TimeWindow{After: "21:00", Before: "02:00"}, at(1, 30), true},
+		{TimeWindow{After: "21:00", Before: "02:00"}, at(12, 0), false},
+		{TimeWindow{}, at(3, 0), true},
+	}
+	for _, tt := range tests {
+		got, err := tt.window.contains(tt.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%+v at %s: got %v, want %v", tt.window, tt.now.Format("15:04"), got, tt.want)
+		}
+	}
+
+	if _, err := (TimeWindow{After: "6am"}).contains(at(7, 0)); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
+
+func TestFilterByTimeWindow(t *testing.T) {
+	snacks := []Movo{
+		{FullCode: "YG-sun", TimeWindow: &TimeWindow{After: "06:00", Before: "11:00"}},
+		{FullCode: "KB-heavy", TimeWindow: &TimeWindow{Before: "20:00"}},
+		{FullCode: "BW-squats"},
+		{FullCode: "BR-typo", TimeWindow: &TimeWindow{After: "later"}},
+	}
+	var codes []string
+	for _, s := range filterByTimeWindow(snacks, time.Date(2025, 3, 5, 21, 0, 0, 0, time.Local)) {
+		codes = append(codes, s.FullCode)
+	}
+	if len(codes) != 2 || codes[0] != "BW-squats" || codes[1] != "BR-typo" {
+		t.Errorf("expected only the unrestricted snacks in the evening, got %v", codes)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// loadReportLocation resolves a --tz value: "" keeps logged offsets,
+// "local" uses this machine's zone, anything else is an IANA zone name
+func loadReportLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "":
+		return nil, nil
+	case "local":
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// In converts every entry's timestamp to loc (nil leaves them as logged)
+func (s *DailyStats) In(loc *time.Location) {
+	if loc == nil {
+		return
+	}
+	for _, entries := range [][]HistoryEntry{s.CompletedSnacks, s.PartialSnacks, s.SkippedSnacks} {
+		for i := range entries {
+			entries[i].Timestamp = entries[i].Timestamp.In(loc)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportTimesKeepLoggedOffset(t *testing.T) {
+	// Logged at 9am in Tokyo
+	entry, err := parseCSVRecord([]string{"2025-10-12T09:00:00+09:00", "TS-pushups", "done", "5", "4", ""})
+	if err != nil {
+		t.Fatalf("parseCSVRecord: %v", err)
+	}
+	if got := entry.Timestamp.Format("15:04"); got != "09:00" {
+		t.Errorf("expected original local time 09:00, got %s", got)
+	}
+
+	stats := DailyStats{CompletedSnacks: []HistoryEntry{entry}}
+	stats.In(nil)
+	if got := stats.CompletedSnacks[0].Timestamp.Format("15:04"); got != "09:00" {
+		t.Errorf("nil location should keep logged time, got %s", got)
+	}
+
+	stats.In(time.UTC)
+	if got := stats.CompletedSnacks[0].Timestamp.Format("15:04"); got != "00:00" {
+		t.Errorf("expected 00:00 UTC, got %s", got)
+	}
+}
+
+func TestLoadReportLocation(t *testing.T) {
+	if loc, err := loadReportLocation(""); err != nil || loc != nil {
+		t.Errorf("empty zone: got %v, %v", loc, err)
+	}
+	if loc, err := loadReportLocation("local"); err != nil || loc != time.Local {
+		t.Errorf("local zone: got %v, %v", loc, err)
+	}
+	if loc, err := loadReportLocation("UTC"); err != nil || loc.String() != "UTC" {
+		t.Errorf("UTC zone: got %v, %v", loc, err)
+	}
+	if _, err := loadReportLocation("Not/AZone"); err == nil {
+		t.Error("expected an error for an unknown zone")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+// A movo can carry its text in other languages, so one library serves a bilingual
+// household:
+//
+//	- code: hip-circles
+//	  title: Hip circles
+//	  translations:
+//	    es:
+//	      title: Círculos de cadera
+//	      description: 10 en cada dirección
+
+// translateMovos swaps in each movo's title and description for locale, where it
+// has them; anything untranslated stays in the default language
+func translateMovos(movos []Movo, locale string) {
+	if locale == defaultLocale {
+		return
+	}
+	for i := range movos {
+		t, ok := movos[i].Translations[locale]
+		if !ok {
+			continue
+		}
+		if t.Title != "" {
+			movos[i].Title = t.Title
+		}
+		if t.Description != "" {
+			movos[i].Description = t.Description
+		}
+	}
+}
+
+// translationLocales lists the locales a movo can be translated into
+func translationLocales() []string {
+	var locales []string
+	for _, locale := range supportedLocales() {
+		if locale != defaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslateMovos(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{
+		"mobility.yaml": `category: Mobility
+code: MOB
+movos:
+  - code: hips
+    title: Hip circles
+    description: 10 each direction
+    translations:
+      es:
+        title: Círculos de cadera
+  - code: neck
+    title: Neck rolls
+`,
+	})
+	movos, err := loadMovosDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translateMovos(movos, defaultLocale)
+	if movos[0].Title != "Hip circles" {
+		t.Errorf("English title = %q", movos[0].Title)
+	}
+	translateMovos(movos, "es")
+	if movos[0].Title != "Círculos de cadera" || movos[0].Description != "10 each direction" {
+		t.Errorf("Spanish movo = %q / %q, want the translated title and the English description", movos[0].Title, movos[0].Description)
+	}
+	if movos[1].Title != "Neck rolls" {
+		t.Errorf("untranslated title = %q", movos[1].Title)
+	}
+}
+
+func TestValidateTranslations(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{
+		"mobility.yaml": `category: Mobility
+code: MOB
+movos:
+  - code: hips
+    title: Hip circles
+    translations:
+      es:
+        title: Círculos de cadera
+      fr:
+        title: Cercles de hanches
+`,
+	})
+	diagnostics, _, err := validateMovosDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	want := `mobility.yaml:9: MOB-hips: translations: unsupported language "fr" (use: es)`
+	if strings.Join(got, "\n") != want {
+		t.Errorf("diagnostics =\n%s\nwant\n%s", strings.Join(got, "\n"), want)
+	}
+}
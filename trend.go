@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTrendDays = 30
+	trendBarWidth    = 20
+)
+
+// sparkTicks are the sparkline's levels, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as one character each, scaled to the largest.
+// Zero shows as · so empty days stand out from light ones.
+func sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if v <= 0 {
+			b.WriteRune('·')
+			continue
+		}
+		level := v * (len(sparkTicks) - 1) / peak
+		b.WriteRune(sparkTicks[level])
+	}
+	return b.String()
+}
+
+// trendBar renders value as a bar of up to trendBarWidth cells, scaled to peak
+func trendBar(value, peak int) string {
+	if peak <= 0 || value <= 0 {
+		return strings.Repeat(" ", trendBarWidth)
+	}
+	filled := min(max(value*trendBarWidth/peak, 1), trendBarWidth)
+	return strings.Repeat(rpeBarFilled, filled) + strings.Repeat(" ", trendBarWidth-filled)
+}
+
+// trendRange is the last days days, ending today
+func trendRange(days int, now time.Time) reportRange {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return reportRange{From: today.AddDate(0, 0, 1-days), To: today}
+}
+
+// writeTrend charts daily minutes and RPE: a sparkline of each over the whole
+// period, then a bar per day. RPE bars are scaled to the daily budget (or the
+// peak, if a day went over it) and coloured like the budget bar.
+func writeTrend(w io.Writer, heading string, report periodReport, maxDailyRPE int, color bool) {
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintf(w, "  %s\n", tr("MOVODORO TREND"))
+	fmt.Fprintf(w, "  %s\n", heading)
+	fmt.Fprintln(w, "═══════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	minutes := make([]int, len(report.Days))
+	rpe := make([]int, len(report.Days))
+	peakMinutes, peakRPE := 0, 0
+	for i, day := range report.Days {
+		minutes[i], rpe[i] = day.Minutes, day.RPE
+		peakMinutes, peakRPE = max(peakMinutes, day.Minutes), max(peakRPE, day.RPE)
+	}
+	days := float64(max(len(report.Days), 1))
+
+	fmt.Fprintf(w, tr("⏱️  Minutes  %s  avg %.0f/day, peak %d\n"), sparkline(minutes), float64(report.Total.Minutes)/days, peakMinutes)
+	fmt.Fprintf(w, tr("💪 RPE      %s  avg %.0f/day, peak %d\n"), sparkline(rpe), float64(report.Total.RPE)/days, peakRPE)
+	fmt.Fprintln(w)
+
+	rpeScale := max(maxDailyRPE, peakRPE)
+	for _, day := range report.Days {
+		rpeBar := trendBar(day.RPE, rpeScale)
+		if color && day.RPE > 0 && maxDailyRPE > 0 {
+			rpeBar = rpeBarColor(day.RPE, maxDailyRPE) + rpeBar + ansiReset
+		}
+		fmt.Fprintf(w, "  %-10s %s %4dm │ %s %3d\n", localizeDate(day.Date.Format(periodDayFormat)), trendBar(day.Minutes, peakMinutes), day.Minutes, rpeBar, day.RPE)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 1, 4, 8, 0}); got != "·▁▄█·" {
+		t.Errorf("got %q", got)
+	}
+	if got := sparkline([]int{0, 0}); got != "··" {
+		t.Errorf("expected only dots for empty days, got %q", got)
+	}
+}
+
+func TestTrendBar(t *testing.T) {
+	if got := trendBar(10, 20); got != strings.Repeat("█", 10)+strings.Repeat(" ", 10) {
+		t.Errorf("expected a half bar, got %q", got)
+	}
+	if got := trendBar(1, 100); !strings.HasPrefix(got, "█ ") {
+		t.Errorf("expected a light day to still show, got %q", got)
+	}
+	if got := trendBar(0, 20); strings.TrimSpace(got) != "" {
+		t.Errorf("expected an empty bar, got %q", got)
+	}
+}
+
+func TestWriteTrend(t *testing.T) {
+	rng := trendRange(3, time.Date(2025, 3, 5, 18, 0, 0, 0, time.Local))
+	if dayKey(rng.From) != "2025-03-03" || dayKey(rng.To) != "2025-03-05" {
+		t.Fatalf("expected the last 3 days, got %s", rng)
+	}
+
+	entries := []HistoryEntry{
+		doneAt("KB-swings", time.Date(2025, 3, 3, 9, 0, 0, 0, time.Local)),
+		doneAt("KB-swings", time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local)),
+		doneAt("KB-swings", time.Date(2025, 3, 5, 12, 0, 0, 0, time.Local)),
+	}
+	var b strings.Builder
+	writeTrend(&b, "heading", buildPeriodReport(entries, rng.From, rng.days()), 30, false)
+
+	out := b.String()
+	if !strings.Contains(out, "▄·█") {
+		t.Errorf("expected a minutes sparkline with an empty middle day, got:\n%s", out)
+	}
+	if strings.Count(out, "│") != 3 {
+		t.Errorf("expected a bar row per day, got:\n%s", out)
+	}
+}
@@ -0,0 +1,707 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	tuiSidebarWidth = 32 // Everyday checklist, hidden below tuiSidebarMin columns
+	tuiSidebarMin   = 76
+	tuiStatusLines  = 3 // Most recent notices kept above the key hints
+)
+
+// tuiMode is what the bottom of the screen is showing
+type tuiMode int
+
+const (
+	tuiMenu        tuiMode = iota // Key hints
+	tuiTimer                      // Countdown running
+	tuiDoneForm                   // Logging a completion
+	tuiSkipConfirm                // Confirming a skip
+)
+
+// tuiResult is how the full-screen session ended: with a completion to log, or done
+// nil when the user quit with the movo saved for later
+type tuiResult struct {
+	movo *Movo
+	done *doneInput
+}
+
+// timerTick redraws the countdown of the timer with the given id
+type timerTick struct {
+	id  int
+	now time.Time
+}
+
+// tuiStyles are the lipgloss styles of the screen, plain when colour is off
+type tuiStyles struct {
+	header, title, dim, key, focus, err, pane, sidebar lipgloss.Style
+}
+
+func newTUIStyles(color bool) tuiStyles {
+	s := tuiStyles{
+		header:  lipgloss.NewStyle().Bold(true),
+		title:   lipgloss.NewStyle().Bold(true),
+		dim:     lipgloss.NewStyle(),
+		key:     lipgloss.NewStyle().Bold(true),
+		focus:   lipgloss.NewStyle().Bold(true),
+		err:     lipgloss.NewStyle(),
+		pane:    lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1),
+		sidebar: lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1),
+	}
+	if color {
+		s.dim = s.dim.Faint(true)
+		s.key = s.key.Foreground(lipgloss.Color("6"))
+		s.focus = s.focus.Foreground(lipgloss.Color("6"))
+		s.err = s.err.Foreground(lipgloss.Color("1"))
+		s.pane = s.pane.BorderForeground(lipgloss.Color("6"))
+		s.sidebar = s.sidebar.BorderForeground(lipgloss.Color("8"))
+	}
+	return s
+}
+
+// tuiModel is the full-screen interactive mode: today's stats across the top, the
+// movo card with the everyday checklist beside it, and key hints or a form below
+type tuiModel struct {
+	session *interactiveSession
+	subset  string
+	movo    *Movo
+	color   bool
+	styles  tuiStyles
+
+	width, height int
+	mode          tuiMode
+	showInfo      bool // Card pane shows the details from [i] instead of the card
+	scroll        int  // First line of the card pane shown
+	status        []string
+
+	stats    DailyStats
+	everyday everydayStatus
+
+	timerID    int
+	timerTotal time.Duration
+	timerStart time.Time
+	timerNow   time.Time
+	timed      int // Minutes the last timer ran for on this movo (0 if untimed)
+
+	form      *doneForm
+	capPrompt string // Skip confirmation past max_entries_per_day ("" if under the cap)
+
+	result tuiResult
+	err    error
+}
+
+// runInteractiveTUI runs interactive mode full screen until a movo is done or the user
+// quits. notices are shown in the status line of the first movo.
+func runInteractiveTUI(session *interactiveSession, subset, notices string) (tuiResult, error) {
+	m, err := newTUIModel(session, subset, notices, useColor())
+	if err != nil {
+		return tuiResult{}, err
+	}
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	if err != nil {
+		return tuiResult{}, err
+	}
+	fm := final.(*tuiModel)
+	return fm.result, fm.err
+}
+
+// newTUIModel picks the first movo before the screen is taken over, so a library or
+// history error is reported like any other
+func newTUIModel(session *interactiveSession, subset, notices string, color bool) (*tuiModel, error) {
+	m := &tuiModel{
+		session: session,
+		subset:  subset,
+		color:   color,
+		styles:  newTUIStyles(color),
+		width:   descriptionWidth,
+		height:  24,
+	}
+	m.addStatus(notices)
+	if err := m.advance(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+// addStatus adds each line of text to the status line, keeping the most recent ones
+func (m *tuiModel) addStatus(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			m.status = append(m.status, line)
+		}
+	}
+	if len(m.status) > tuiStatusLines {
+		m.status = m.status[len(m.status)-tuiStatusLines:]
+	}
+}
+
+// advance moves on to the next movo from the session
+func (m *tuiModel) advance() error {
+	var notices bytes.Buffer
+	movo, err := m.session.next(&notices, &notices)
+	m.addStatus(notices.String())
+	if err != nil {
+		return err
+	}
+	m.show(movo)
+	return nil
+}
+
+// show puts movo in the card pane and refreshes the stats around it
+func (m *tuiModel) show(movo *Movo) {
+	m.movo = movo
+	m.mode = tuiMenu
+	m.showInfo = false
+	m.scroll = 0
+	m.timed = 0
+	m.form = nil
+	m.refresh()
+}
+
+// refresh reloads today's stats and everyday progress; either is left out if unreadable
+func (m *tuiModel) refresh() {
+	m.stats, _ = GetTodayStatsDaily(appConfig.LogsDir)
+	cfg := *appConfig
+	cfg.ActiveSubset = m.subset
+	m.everyday, _ = loadEverydayStatus(&cfg, time.Now())
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case timerTick:
+		return m, m.tick(msg)
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case tuiTimer:
+			return m, m.timerKey(msg)
+		case tuiDoneForm:
+			return m, m.formKey(msg)
+		case tuiSkipConfirm:
+			return m, m.skipKey(msg)
+		default:
+			return m, m.menuKey(msg)
+		}
+	}
+	return m, nil
+}
+
+// menuKey handles a key pressed with the key hints showing
+func (m *tuiModel) menuKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	switch key {
+	case "up", "k":
+		m.scroll = max(m.scroll-1, 0)
+		return nil
+	case "down", "j":
+		m.scroll++
+		return nil
+	case "pgup":
+		m.scroll = max(m.scroll-m.paneHeight(), 0)
+		return nil
+	case "pgdown", " ":
+		m.scroll += m.paneHeight()
+		return nil
+	case "q":
+		return tea.Quit
+	case "d":
+		return m.openForm(false)
+	case "s":
+		m.openSkip()
+		return nil
+	}
+	if appConfig.KidMode {
+		return nil
+	}
+
+	switch key {
+	case "t":
+		return m.startTimer()
+	case "i":
+		m.showInfo = !m.showInfo
+		m.scroll = 0
+	case "p":
+		return m.openForm(true)
+	case "a":
+		if len(m.session.alternatives(m.movo)) > 0 {
+			alt := m.session.swapAlternative(m.movo)
+			m.addStatus(trf("\n🔄 Swapping in %s\n", alt.Title))
+			return m.next()
+		}
+	case "x":
+		if m.movo.MinPerDay > 0 {
+			m.addStatus(tr("\n⏭️  Skipping dailies for now...\n"))
+			m.session.skipDailies(m.movo)
+			return m.next()
+		}
+	case "b":
+		if m.session.canGoBack() {
+			var warnings bytes.Buffer
+			prev := m.session.back(&warnings)
+			m.addStatus(trf("\n↩️  Back to %s\n", prev.Title))
+			m.addStatus(warnings.String())
+			return m.next()
+		}
+	}
+	return nil
+}
+
+// next advances to the next movo, ending the session on an error
+func (m *tuiModel) next() tea.Cmd {
+	if err := m.advance(); err != nil {
+		m.err = err
+		return tea.Quit
+	}
+	return nil
+}
+
+// startTimer counts down the movo's usual duration
+func (m *tuiModel) startTimer() tea.Cmd {
+	m.timerID++
+	m.timerTotal = time.Duration(usualDuration(m.movo)) * time.Minute
+	m.timerStart = time.Now()
+	m.timerNow = m.timerStart
+	m.mode = tuiTimer
+	return m.scheduleTick()
+}
+
+func (m *tuiModel) scheduleTick() tea.Cmd {
+	id := m.timerID
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return timerTick{id: id, now: t} })
+}
+
+// tick redraws the countdown, ringing the bell when the time is up. Ticks of a timer
+// already stopped are dropped.
+func (m *tuiModel) tick(msg timerTick) tea.Cmd {
+	if m.mode != tuiTimer || msg.id != m.timerID {
+		return nil
+	}
+	m.timerNow = msg.now
+	if m.timerNow.Sub(m.timerStart) < m.timerTotal {
+		return m.scheduleTick()
+	}
+	m.stopTimer()
+	m.addStatus(trf("🔔 Time's up! %s", formatCountdown(m.timerTotal)))
+	return func() tea.Msg {
+		fmt.Fprint(os.Stdout, "\a")
+		return nil
+	}
+}
+
+// stopTimer ends the countdown, keeping the minutes it ran to offer when logging
+func (m *tuiModel) stopTimer() {
+	m.timed = elapsedMinutes(m.timerNow.Sub(m.timerStart))
+	m.mode = tuiMenu
+}
+
+// timerKey handles a key pressed while the timer runs: [t] or esc stops it, and
+// [d] or [p] stops it to log straight away
+func (m *tuiModel) timerKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "t", "esc":
+		m.timerNow = time.Now()
+		m.stopTimer()
+		m.addStatus(trf("⏹️  Stopped after %s", formatCountdown(m.timerNow.Sub(m.timerStart))))
+	case "d", "p":
+		m.timerNow = time.Now()
+		m.stopTimer()
+		return m.openForm(msg.String() == "p")
+	}
+	return nil
+}
+
+// openForm asks for what to log with a completion inline; with nothing to ask (kid
+// mode without ratings) the movo is done straight away
+func (m *tuiModel) openForm(partial bool) tea.Cmd {
+	m.form = newDoneForm(m.movo, partial, m.timed)
+	if len(m.form.fields) == 0 {
+		return m.finish(m.form.in)
+	}
+	m.mode = tuiDoneForm
+	return nil
+}
+
+// finish ends the session with movo done; it's logged once the screen is restored
+func (m *tuiModel) finish(in doneInput) tea.Cmd {
+	m.result = tuiResult{movo: m.movo, done: &in}
+	return tea.Quit
+}
+
+// formKey handles a key pressed in the done form
+func (m *tuiModel) formKey(msg tea.KeyMsg) tea.Cmd {
+	f := m.form
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.form = nil
+		m.mode = tuiMenu
+	case tea.KeyTab, tea.KeyDown:
+		f.move(1)
+	case tea.KeyShiftTab, tea.KeyUp:
+		f.move(-1)
+	case tea.KeyEnter:
+		if f.focus < len(f.fields)-1 {
+			f.move(1)
+			return nil
+		}
+		if in, ok := f.submit(); ok {
+			return m.finish(in)
+		}
+	case tea.KeyBackspace:
+		field := &f.fields[f.focus]
+		if r := []rune(field.value); len(r) > 0 {
+			field.value = string(r[:len(r)-1])
+		}
+	case tea.KeySpace:
+		f.fields[f.focus].value += " "
+	case tea.KeyRunes:
+		f.fields[f.focus].value += string(msg.Runes)
+	}
+	return nil
+}
+
+// openSkip asks to confirm a skip, warning first if the day's entry cap is reached
+func (m *tuiModel) openSkip() {
+	m.capPrompt = ""
+	if count, which, over := entryCapReached(time.Now()); over {
+		m.capPrompt = trf("⚠️  Already %d entries %s (max_entries_per_day: %d). Log anyway? [y/N]: ",
+			count, which, appConfig.MaxEntriesPerDay)
+	}
+	m.mode = tuiSkipConfirm
+}
+
+// skipKey handles a key pressed while confirming a skip
+func (m *tuiModel) skipKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+	confirmed := key == "y" || (m.capPrompt == "" && (key == "s" || key == "enter"))
+	if !confirmed {
+		if m.capPrompt != "" {
+			m.addStatus(tr("not logged: daily entry cap reached"))
+		}
+		m.mode = tuiMenu
+		return nil
+	}
+
+	entry := skipEntry(m.movo)
+	var warnings bytes.Buffer
+	if err := writeLogEntry(entry, m.movo, &warnings); err != nil {
+		m.addStatus(trf("Error saving to history: %v\n", err))
+		m.mode = tuiMenu
+		return nil
+	}
+	m.session.skipped(m.movo, entry, &warnings)
+	m.addStatus(trf("⏭️  Skipped '%s'\n", m.movo.Title))
+	m.addStatus(warnings.String())
+	return m.next()
+}
+
+// paneHeight is the number of card lines that fit between the header and footer
+func (m *tuiModel) paneHeight() int {
+	// The gap under the header and the pane's border take three more
+	return max(m.height-lipgloss.Height(m.headerView())-lipgloss.Height(m.footerView())-3, 3)
+}
+
+func (m *tuiModel) View() string {
+	s := m.styles
+	sidebar := m.sidebarView()
+	paneWidth := m.width - 2
+	if sidebar != "" {
+		paneWidth -= tuiSidebarWidth
+	}
+	contentWidth := max(paneWidth-2, 20)
+
+	var card bytes.Buffer
+	if m.showInfo {
+		writeMovoInfo(&card, m.movo, m.session.snacks())
+	} else {
+		writeMovoCard(&card, m.movo, min(contentWidth, descriptionWidth), m.color)
+	}
+	lines := strings.Split(strings.Trim(card.String(), "\n"), "\n")
+	height := m.paneHeight()
+	m.scroll = min(m.scroll, max(len(lines)-height, 0))
+	lines = lines[m.scroll:min(m.scroll+height, len(lines))]
+
+	pane := s.pane.Width(paneWidth).Height(height).
+		Render(lipgloss.NewStyle().MaxWidth(contentWidth).Render(strings.Join(lines, "\n")))
+	body := pane
+	if sidebar != "" {
+		body = lipgloss.JoinHorizontal(lipgloss.Top, pane, sidebar)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, m.headerView(), "", body, m.footerView())
+}
+
+// headerView is today's totals and RPE budget, or the sticker count in kid mode
+func (m *tuiModel) headerView() string {
+	s := m.styles
+	title := "🍿 movodoro"
+	if m.subset != "" {
+		title += "  " + s.dim.Render("🎯 "+m.subset)
+	}
+	header := s.header.Render(title) + "   "
+	if appConfig.KidMode {
+		header += tuiLine(tr("⭐ Stickers today: %d\n"), len(m.stats.CompletedSnacks)+len(m.stats.PartialSnacks))
+	} else {
+		header += tuiLine(tr("📊 Today: %d movos, %d minutes, %d RPE\n"), m.stats.TotalMovos, m.stats.TotalDuration, m.stats.TotalRPE) + "   " +
+			tuiLine(tr("🔋 RPE budget: %s\n"), rpeBar(m.stats.TotalRPE, appConfig.MaxDailyRPE, m.color))
+	}
+	return lipgloss.NewStyle().Width(max(m.width, 20)).Render(header)
+}
+
+// tuiLine formats a catalog message written for the line-based output as one line
+func tuiLine(format string, args ...any) string {
+	return strings.TrimSpace(fmt.Sprintf(format, args...))
+}
+
+// sidebarView is today's everyday checklist, or "" without everyday movos or room
+func (m *tuiModel) sidebarView() string {
+	status := m.everyday
+	if len(status.Movos) == 0 || m.width < tuiSidebarMin {
+		return ""
+	}
+
+	inner := tuiSidebarWidth - 4
+	lines := []string{m.styles.title.Render(tuiLine(tr("📋 Every day (%d/%d)"), status.Completed, status.Total))}
+	if status.RestDay {
+		lines = append(lines, m.styles.dim.Render(tr("🛌 Rest day")))
+	}
+	for _, item := range status.Movos {
+		mark := "⬜"
+		switch {
+		case item.Waived:
+			mark = "🛌"
+		case item.Complete:
+			mark = "✅"
+		}
+		cursor := "  "
+		if m.movo != nil && item.Code == m.movo.FullCode {
+			cursor = "▸ "
+		}
+		line := fmt.Sprintf("%s%s %s %d/%d", cursor, mark, item.Title, item.DoneToday, item.MinPerDay)
+		lines = append(lines, lipgloss.NewStyle().MaxWidth(inner).Render(line))
+	}
+	return m.styles.sidebar.Width(tuiSidebarWidth - 2).Render(strings.Join(lines, "\n"))
+}
+
+// footerView is the status line above the key hints, the countdown, or the open form
+func (m *tuiModel) footerView() string {
+	s := m.styles
+	var lines []string
+	for _, line := range m.status {
+		lines = append(lines, s.dim.Render(line))
+	}
+
+	switch m.mode {
+	case tuiTimer:
+		remaining := m.timerTotal - m.timerNow.Sub(m.timerStart)
+		lines = append(lines, trf("⏳ %s remaining", formatCountdown(remaining))+"  "+m.hints(
+			[2]string{"t/esc", tr("stop")}, [2]string{"d", tr("done")}, [2]string{"p", tr("partial")}))
+	case tuiSkipConfirm:
+		if m.capPrompt != "" {
+			lines = append(lines, s.err.Render(strings.TrimSpace(m.capPrompt)))
+		} else {
+			lines = append(lines, trf("⏭️  Skip '%s'?", m.movo.Title)+"  "+m.hints(
+				[2]string{"s/enter", tr("skip")}, [2]string{"esc", tr("cancel")}))
+		}
+	case tuiDoneForm:
+		lines = append(lines, m.form.view(s))
+	default:
+		lines = append(lines, m.menuHints())
+	}
+	// Wrapped here so the pane's height accounts for every line
+	return lipgloss.NewStyle().Width(max(m.width, 20)).Render(strings.Join(lines, "\n"))
+}
+
+// menuHints lists the keys that apply to the current movo
+func (m *tuiModel) menuHints() string {
+	if appConfig.KidMode {
+		return m.hints([2]string{"d", tr("I did it! 🎉")}, [2]string{"s", tr("Something else 🔀")}, [2]string{"q", tr("Later 👋")})
+	}
+	info := tr("info")
+	if m.showInfo {
+		info = tr("card")
+	}
+	keys := [][2]string{{"t", tr("timer")}, {"i", info}, {"d", tr("done")}, {"p", tr("partial")}, {"s", tr("skip")}}
+	if len(m.session.alternatives(m.movo)) > 0 {
+		keys = append(keys, [2]string{"a", tr("alternative")})
+	}
+	if m.movo.MinPerDay > 0 {
+		keys = append(keys, [2]string{"x", tr("skip dailies")})
+	}
+	if m.session.canGoBack() {
+		keys = append(keys, [2]string{"b", tr("back")})
+	}
+	keys = append(keys, [2]string{"↑↓", tr("scroll")}, [2]string{"q", tr("quit")})
+	return m.hints(keys...)
+}
+
+// hints renders key/action pairs, e.g. [d] done  [s] skip
+func (m *tuiModel) hints(keys ...[2]string) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = m.styles.key.Render("["+k[0]+"]") + " " + k[1]
+	}
+	return strings.Join(parts, "  ")
+}
+
+// doneForm is the inline form asking what to log with a completion
+type doneForm struct {
+	title  string
+	in     doneInput // Defaults, filled in from the fields on submit
+	fields []formField
+	focus  int
+	err    string
+}
+
+// formField is one answer in the done form; set stores it in the input
+type formField struct {
+	label, value, hint string
+	set                func(in *doneInput, value string) error
+}
+
+// newDoneForm asks for what handleDoneInteractive prompts for: minutes and RPE, the
+// details that apply to movo and a rating. Kid mode only asks for the rating.
+func newDoneForm(movo *Movo, partial bool, timed int) *doneForm {
+	f := &doneForm{
+		title: tr("✅ Log completion"),
+		in:    doneInput{Partial: partial, Duration: doneDefaultDuration(movo, partial, timed), RPE: movo.EffectiveRPE},
+	}
+	if partial {
+		f.title = tr("◐ Log partial completion")
+	}
+
+	if !appConfig.KidMode {
+		f.fields = append(f.fields,
+			formField{label: tr("Minutes"), value: strconv.Itoa(f.in.Duration), set: func(in *doneInput, v string) error {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 0 {
+					return fmt.Errorf("invalid minutes %q", v)
+				}
+				in.Duration = n
+				return nil
+			}},
+			formField{label: "RPE", value: strconv.Itoa(f.in.RPE), set: func(in *doneInput, v string) error {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 0 {
+					return fmt.Errorf("invalid RPE %q", v)
+				}
+				in.RPE = n
+				return nil
+			}},
+		)
+
+		if prescription := movo.prescription(); prescription != "" {
+			value := ""
+			if movo.Sets > 0 && movo.Reps > 0 {
+				value = fmt.Sprintf("%dx%d", movo.Sets, movo.Reps)
+			}
+			f.fields = append(f.fields, formField{label: tr("Sets×reps"), value: value, hint: prescription,
+				set: func(in *doneInput, v string) (err error) {
+					if v != "" {
+						in.Details.Sets, in.Details.Reps, err = parseSetsReps(v)
+					}
+					return err
+				}})
+		}
+
+		if movo.tracksLoad() {
+			value := ""
+			if entries, err := LoadAllHistory(appConfig.LogsDir); err == nil {
+				if history := loadHistory(entries, movo.FullCode); len(history) > 0 {
+					value = strconv.FormatFloat(entryLoad(history[len(history)-1]), 'f', -1, 64)
+				}
+			}
+			f.fields = append(f.fields, formField{label: tr("Load (kg)"), value: value,
+				set: func(in *doneInput, v string) (err error) {
+					if v != "" {
+						in.Details.Load, err = parseLoad(v)
+					}
+					return err
+				}})
+		}
+
+		if movo.tracksDistance() {
+			f.fields = append(f.fields, formField{label: tr("Distance"), hint: tr("e.g. 2.5km, 800m or 4000 steps"),
+				set: func(in *doneInput, v string) (err error) {
+					if v != "" {
+						in.Details.Distance, in.Details.Steps, err = parseDistance(v)
+					}
+					return err
+				}})
+		}
+	}
+
+	if appConfig.RateAfterDone {
+		f.fields = append(f.fields, formField{label: tr("Rating"), hint: fmt.Sprintf("%d-%d", minRating, maxRating),
+			set: func(in *doneInput, v string) error {
+				if v == "" {
+					return nil
+				}
+				n, err := strconv.Atoi(v)
+				if err != nil || n < minRating || n > maxRating {
+					return fmt.Errorf("invalid rating %q (%d-%d)", v, minRating, maxRating)
+				}
+				in.Rating = n
+				return nil
+			}})
+	}
+	return f
+}
+
+// move focuses the field delta away, wrapping around
+func (f *doneForm) move(delta int) {
+	f.focus = (f.focus + delta + len(f.fields)) % len(f.fields)
+}
+
+// submit reads every field into the input. On a bad answer it focuses that field,
+// shows the error and reports false.
+func (f *doneForm) submit() (doneInput, bool) {
+	in := f.in
+	for i, field := range f.fields {
+		if err := field.set(&in, strings.TrimSpace(field.value)); err != nil {
+			f.focus, f.err = i, err.Error()
+			return doneInput{}, false
+		}
+	}
+	f.err = ""
+	return in, true
+}
+
+func (f *doneForm) view(s tuiStyles) string {
+	lines := []string{s.title.Render(f.title)}
+	for i, field := range f.fields {
+		label := fmt.Sprintf("  %-12s", field.label+":")
+		value := field.value
+		if i == f.focus {
+			label = s.focus.Render("▸ " + strings.TrimPrefix(label, "  "))
+			value += "▏"
+		}
+		line := label + " " + value
+		if field.hint != "" {
+			line += "  " + s.dim.Render("("+field.hint+")")
+		}
+		lines = append(lines, line)
+	}
+	if f.err != "" {
+		lines = append(lines, s.err.Render(f.err))
+	}
+	lines = append(lines, s.dim.Render(tr("enter next/save · tab move · esc cancel")))
+	return strings.Join(lines, "\n")
+}
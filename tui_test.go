@@ -0,0 +1,120 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestDoneFormSubmit(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	appConfig.RateAfterDone = true
+	defer func() { appConfig = originalConfig }()
+
+	movo := &Movo{FullCode: "KB-swings", DurationMin: 4, DurationMax: 6, EffectiveRPE: 6, Sets: 3, Reps: 10, AllTags: []string{"kbx"}}
+	form := newDoneForm(movo, false, 7)
+
+	var labels []string
+	for _, field := range form.fields {
+		labels = append(labels, field.label)
+	}
+	if got := strings.Join(labels, ","); got != "Minutes,RPE,Sets×reps,Load (kg),Rating" {
+		t.Fatalf("unexpected fields %s", got)
+	}
+	if form.fields[0].value != "7" || form.fields[2].value != "3x10" {
+		t.Errorf("expected the timer's minutes and the prescription prefilled, got %+v", form.fields)
+	}
+
+	form.fields[2].value = "3x"
+	if _, ok := form.submit(); ok || form.focus != 2 || form.err == "" {
+		t.Fatalf("expected bad sets×reps to be refused and focused, got focus %d err %q", form.focus, form.err)
+	}
+
+	form.fields[2].value = "4x8"
+	form.fields[3].value = "24"
+	form.fields[4].value = "5"
+	in, ok := form.submit()
+	if !ok {
+		t.Fatalf("unexpected error %q", form.err)
+	}
+	want := doneInput{Duration: 7, RPE: 6, Details: doneDetails{Sets: 4, Reps: 8, Load: 24}, Rating: 5}
+	if in != want {
+		t.Errorf("expected %+v, got %+v", want, in)
+	}
+}
+
+func TestDoneFormKidMode(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	appConfig.KidMode = true
+	defer func() { appConfig = originalConfig }()
+
+	form := newDoneForm(&Movo{FullCode: "KB-swings", DurationMin: 4, DurationMax: 6, EffectiveRPE: 6, Sets: 3, Reps: 10}, false, 0)
+	if len(form.fields) != 0 {
+		t.Errorf("expected no questions in kid mode, got %+v", form.fields)
+	}
+}
+
+func TestTUIModelSkipThenDone(t *testing.T) {
+	cfg := setupWeeklyHome(t)
+	originalConfig := appConfig
+	appConfig = cfg
+	defer func() { appConfig = originalConfig }()
+
+	session, err := newInteractiveSession("", io.Discard, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := newTUIModel(session, "", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := m.movo
+	if !strings.Contains(m.View(), first.Title) {
+		t.Fatalf("expected the card to show %s", first.Title)
+	}
+
+	// [s] asks first; esc takes it back without logging
+	m.Update(runeKey("s"))
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != tuiMenu || m.movo != first {
+		t.Fatalf("expected the skip cancelled, got mode %d", m.mode)
+	}
+
+	m.Update(runeKey("s"))
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	entries, err := LoadDailyLog(cfg.LogsDir, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Status != "skip" || entries[0].Code != first.FullCode {
+		t.Fatalf("expected a skip of %s logged, got %+v", first.FullCode, entries)
+	}
+	if m.movo.FullCode == first.FullCode || !session.canGoBack() {
+		t.Errorf("expected the other movo offered next with [b] available, got %s", m.movo.FullCode)
+	}
+
+	// The form ends the session with the completion to log
+	m.Update(runeKey("d"))
+	if m.mode != tuiDoneForm {
+		t.Fatalf("expected the done form, got mode %d", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(runeKey("9"))
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.result.done == nil || m.result.movo != m.movo {
+		t.Fatalf("expected the session to end with a completion, got %+v", m.result)
+	}
+	if want := m.form.in.Duration/10*10 + 9; m.result.done.Duration != want {
+		t.Errorf("expected the edited duration %d, got %d", want, m.result.done.Duration)
+	}
+}
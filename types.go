@@ -10,6 +10,8 @@ type Category struct {
 	DefaultRPE int      `yaml:"default_rpe"`
 	Tags       []string `yaml:"tags"`
 	Movos      []Movo  `yaml:"movos"`
+	// Keep every movo in this category eligible during auto-recovery
+	RecoverySafe bool `yaml:"recovery_safe,omitempty"`
 }
 
 // Movo represents a single movement snack
@@ -24,36 +26,87 @@ type Movo struct {
 	MaxPerWeek  int      `yaml:"max_per_week,omitempty"`
 	Weight      float64  `yaml:"weight"`
 	MinPerDay   int      `yaml:"min_per_day,omitempty"` // Minimum times per day (for priority)
+	MinPerWeek  int      `yaml:"min_per_week,omitempty"` // Minimum times per week (boosted until met)
 	Tags        []string `yaml:"tags"`
+	// Sets and reps to do, or a free-form prescription such as "5×5 @ moderate"
+	Sets         int    `yaml:"sets,omitempty"`
+	Reps         int    `yaml:"reps,omitempty"`
+	Prescription string `yaml:"prescription,omitempty"`
+	// Body regions worked, e.g. hips, t-spine, shoulders, grip
+	Targets []string `yaml:"targets,omitempty"`
+	// Part of the day this movo may be selected in (local time)
+	TimeWindow *TimeWindow `yaml:"time_window,omitempty"`
+	// Movement patterns to keep away from when listed in avoid: (e.g. spinal-flexion, overhead)
+	Contraindications []string `yaml:"contraindications,omitempty"`
+	// Gear this movo needs, e.g. kettlebell, band ("none" or unset for nothing)
+	Equipment []string `yaml:"equipment,omitempty"`
+	// Full codes that must be completed today before this movo is eligible
+	RequiresDoneToday []string `yaml:"requires_done_today,omitempty"`
+	// Full codes of variations to swap in interactively, e.g. a wall version of a floor movo
+	Alternatives []string `yaml:"alternatives,omitempty"`
+	// Full codes or tags of movos that go well after or before this one (get --pair)
+	PairsWellWith []string `yaml:"pairs_well_with,omitempty"`
+	// Stays eligible in auto-recovery mode up to recoverySafeMaxRPE
+	RecoverySafe bool `yaml:"recovery_safe,omitempty"`
+	// Retired from selection but kept so history still resolves; superseded_by names the replacement
+	Deprecated   bool   `yaml:"deprecated,omitempty"`
+	SupersededBy string `yaml:"superseded_by,omitempty"`
+	// Title and description in other languages, keyed by locale (e.g. es)
+	Translations map[string]MovoTranslation `yaml:"translations,omitempty"`
 
 	// Computed fields (not in YAML)
 	CategoryCode string  `yaml:"-"`
+	CategoryName string  `yaml:"-"`
 	FullCode     string  `yaml:"-"`
 	AllTags      []string `yaml:"-"`
 	EffectiveRPE int     `yaml:"-"`
+	SourceFile   string  `yaml:"-"` // Category file the movo was loaded from
+}
+
+// MovoTranslation is a movo's text in another language; a field left out falls
+// back to the movo's own
+type MovoTranslation struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
 }
 
 // HistoryEntry represents a single log entry
 type HistoryEntry struct {
 	Timestamp time.Time
 	Code      string
-	Status    string // "done" or "skip"
+	Status    string // "done", "partial" or "skip"
 	Duration  int    // actual duration in minutes
 	RPE       int    // RPE value
 	Subset    string // Active subset when entry was logged (empty if none)
+	Extras    map[string]string // Optional extra fields, e.g. "profile"
 }
 
 // FilterOptions contains all filtering options for snack selection
 type FilterOptions struct {
-	Tags           []string
-	Category       string
-	MinDuration    int
-	MaxDuration    int
-	ExactDuration  int
-	MinRPE         int
-	MaxRPE         int
-	SkipMinimums   bool // If true, ignore min_per_day priority
-	Subset         string // Name of subset to restrict selection to
+	Tags              []string // All of these ("a|b" for either)
+	AnyTags           []string // At least one of these
+	Categories        []string // Any of these category codes (empty for all)
+	ExcludeCategories []string // None of these category codes
+	Targets           []string // Any of these body regions
+	MinDuration       int
+	MaxDuration       int
+	ExactDuration     int
+	MinRPE            int
+	MaxRPE            int
+	SkipMinimums      bool   // If true, ignore min_per_day priority
+	Subset            string // Name of subset to restrict selection to
+	// Max RPE for recovery_safe snacks when it should exceed MaxRPE (auto-recovery)
+	RecoverySafeMaxRPE int
+	// Max RPE for everyday movos when it should exceed MaxRPE (rest days)
+	EverydayMaxRPE int
+	IgnoreTimeWindows bool // Select movos outside their time_window too
+	// Only movos needing no more than Equipment (--equipment, --no-equipment or config.yaml)
+	LimitEquipment bool
+	Equipment      []string
+	Variety        string   // low, normal or high (--variety or config.yaml)
+	Fit            int      // Minutes available: longer movos are left out, the best fillers preferred
+	Rotate         bool     // Rotate through categories over the day (selection_mode: rotation or --rotate)
+	Exclude        []string // Full codes already offered this interactive session
 }
 
 // DailyStats contains statistics for a given day
@@ -64,6 +117,7 @@ type DailyStats struct {
 	TotalRPE      int
 	CompletedSnacks []HistoryEntry
 	SkippedSnacks   []HistoryEntry
+	PartialSnacks   []HistoryEntry // Stopped early: minutes and RPE count, limits don't
 }
 
 // Subset represents a named collection of movo codes
@@ -76,3 +130,16 @@ type Subset struct {
 type SubsetsConfig struct {
 	Subsets map[string]Subset `yaml:"subsets"`
 }
+
+// Rating represents a 1-5 enjoyment rating for a movo
+type Rating struct {
+	Timestamp time.Time
+	Code      string
+	Value     int
+}
+
+// RatingSummary aggregates the ratings given to a single movo
+type RatingSummary struct {
+	Average float64
+	Count   int
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// diagnostic is a problem found in a movos file, at a 1-based line
+type diagnostic struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (d diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+}
+
+// Keys allowed in category files and in each movo, from the yaml struct tags
+var (
+	categoryKeys = yamlKeys(reflect.TypeOf(Category{}))
+	movoKeys     = yamlKeys(reflect.TypeOf(Movo{}))
+)
+
+// yamlKeys lists the mapping keys a struct decodes, skipping fields tagged "-"
+func yamlKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// movoValidator collects diagnostics across every file in a movos directory
+type movoValidator struct {
+	diagnostics []diagnostic
+	seen        map[string]diagnostic // Full code -> where it was first defined
+	movos       int
+	files       int
+	// superseded_by values, checked once every code has been seen
+	supersededBy []supersession
+}
+
+// supersession is a movo's superseded_by, with where it was given
+type supersession struct {
+	diagnostic
+	Code, Target string
+}
+
+// validateMovosDir checks every category file, subsets.yaml and programs.yaml in dir. It returns the
+// diagnostics (sorted by file and line) and how many movos were checked.
+func validateMovosDir(dir string) ([]diagnostic, int, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error finding YAML files: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, 0, fmt.Errorf("no YAML files found in %s", dir)
+	}
+
+	v := &movoValidator{seen: make(map[string]diagnostic)}
+	var subsetsData []byte
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, 0, err
+		}
+		switch filepath.Base(file) {
+		case "subsets.yaml":
+			subsetsData = data
+			continue
+		case "programs.yaml":
+			if _, err := parsePrograms(data); err != nil {
+				v.report("programs.yaml", yamlErrorLine(err), "%v", err)
+			}
+			continue
+		case lintRulesFile:
+			if _, err := parseLintRules(data); err != nil {
+				v.report(lintRulesFile, yamlErrorLine(err), "%v", err)
+			}
+			continue
+		}
+		v.files++
+		v.checkCategoryFile(filepath.Base(file), data)
+	}
+	if subsetsData != nil {
+		v.checkSubsets("subsets.yaml", subsetsData)
+	}
+	for _, s := range v.supersededBy {
+		switch _, ok := v.seen[s.Target]; {
+		case s.Target == s.Code:
+			v.report(s.File, s.Line, "%s: superseded_by names the movo itself", s.Code)
+		case !ok:
+			v.report(s.File, s.Line, "%s: superseded_by %s doesn't match any movo", s.Code, s.Target)
+		}
+	}
+
+	sortDiagnostics(v.diagnostics)
+	return v.diagnostics, v.movos, nil
+}
+
+// sortDiagnostics orders diagnostics by file and line
+func sortDiagnostics(diagnostics []diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+}
+
+func (v *movoValidator) report(file string, line int, format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, diagnostic{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkUnknownKeys reports mapping keys that the loader would silently ignore
+func (v *movoValidator) checkUnknownKeys(file string, mapping *yaml.Node, known map[string]bool, where string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		if !known[key.Value] {
+			v.report(file, key.Line, "%sunknown field %q", where, key.Value)
+		}
+	}
+}
+
+func (v *movoValidator) checkCategoryFile(file string, data []byte) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		v.report(file, yamlErrorLine(err), "%v", err)
+		return
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		v.report(file, 1, "expected a category mapping (category, code, movos)")
+		return
+	}
+	root := doc.Content[0]
+	v.checkUnknownKeys(file, root, categoryKeys, "")
+
+	var category Category
+	if err := root.Decode(&category); err != nil {
+		v.report(file, yamlErrorLine(err), "%v", err)
+		return
+	}
+	if category.Code == "" {
+		v.report(file, root.Line, "category has no code")
+	}
+	if node := mappingValue(root, "default_rpe"); node != nil && (category.DefaultRPE < 1 || category.DefaultRPE > 10) {
+		v.report(file, node.Line, "default_rpe %d is outside 1-10", category.DefaultRPE)
+	}
+
+	movosNode := mappingValue(root, "movos")
+	if movosNode == nil {
+		return
+	}
+	for _, item := range movosNode.Content {
+		if item.Kind != yaml.MappingNode {
+			v.report(file, item.Line, "expected a movo mapping")
+			continue
+		}
+		v.movos++
+		v.checkMovo(file, category.Code, item)
+	}
+}
+
+func (v *movoValidator) checkMovo(file, categoryCode string, item *yaml.Node) {
+	var movo Movo
+	if err := item.Decode(&movo); err != nil {
+		v.report(file, item.Line, "%v", err)
+		return
+	}
+
+	name := "movo: "
+	if movo.Code == "" {
+		v.report(file, item.Line, "movo has no code")
+	} else {
+		fullCode := categoryCode + "-" + movo.Code
+		name = fullCode + ": "
+		if first, ok := v.seen[fullCode]; ok {
+			v.report(file, item.Line, "%sduplicate code (first defined at %s:%d)", name, first.File, first.Line)
+		} else {
+			v.seen[fullCode] = diagnostic{File: file, Line: item.Line}
+		}
+	}
+
+	v.checkUnknownKeys(file, item, movoKeys, name)
+	if strings.TrimSpace(movo.Title) == "" {
+		v.report(file, item.Line, "%smissing title", name)
+	}
+	if movo.DurationMin > movo.DurationMax {
+		v.report(file, mappingValue(item, "duration_min").Line, "%sduration_min %d is greater than duration_max %d",
+			name, movo.DurationMin, movo.DurationMax)
+	}
+	if movo.RPE != nil && (*movo.RPE < 1 || *movo.RPE > 10) {
+		v.report(file, mappingValue(item, "rpe").Line, "%srpe %d is outside 1-10", name, *movo.RPE)
+	}
+	if node := mappingValue(item, "superseded_by"); node != nil {
+		if !movo.Deprecated {
+			v.report(file, node.Line, "%ssuperseded_by without deprecated: true", name)
+		}
+		if movo.Code != "" {
+			v.supersededBy = append(v.supersededBy, supersession{
+				diagnostic: diagnostic{File: file, Line: node.Line},
+				Code:       categoryCode + "-" + movo.Code,
+				Target:     movo.SupersededBy,
+			})
+		}
+	}
+	if node := mappingValue(item, "translations"); node != nil {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if _, ok := catalogs[key.Value]; !ok || key.Value == defaultLocale {
+				v.report(file, key.Line, "%stranslations: unsupported language %q (use: %s)",
+					name, key.Value, strings.Join(translationLocales(), ", "))
+			}
+		}
+	}
+	if movo.TimeWindow != nil {
+		if _, _, err := movo.TimeWindow.bounds(); err != nil {
+			v.report(file, mappingValue(item, "time_window").Line, "%s%v", name, err)
+		}
+	}
+}
+
+// checkSubsets reports subset codes that don't name a movo (run after every category file)
+func (v *movoValidator) checkSubsets(file string, data []byte) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		v.report(file, yamlErrorLine(err), "%v", err)
+		return
+	}
+	if len(doc.Content) == 0 {
+		return
+	}
+	subsets := mappingValue(doc.Content[0], "subsets")
+	if subsets == nil || subsets.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(subsets.Content); i += 2 {
+		name, subset := subsets.Content[i].Value, subsets.Content[i+1]
+		if subset.Kind != yaml.MappingNode {
+			v.report(file, subset.Line, "subset %s: expected a mapping with description and codes", name)
+			continue
+		}
+		codes := mappingValue(subset, "codes")
+		if codes == nil {
+			continue
+		}
+		for _, code := range codes.Content {
+			if _, ok := v.seen[code.Value]; !ok {
+				v.report(file, code.Line, "subset %s: unknown movo code %q", name, code.Value)
+			}
+		}
+	}
+}
+
+// yamlErrorLine pulls the line number out of a yaml.v3 error ("yaml: line 12: ..."), or 1
+func yamlErrorLine(err error) int {
+	var line int
+	msg := err.Error()
+	if i := strings.Index(msg, "line "); i >= 0 {
+		fmt.Sscanf(msg[i:], "line %d", &line)
+	}
+	if line < 1 {
+		return 1
+	}
+	return line
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeMovosFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestValidateMovosDir(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{
+		"strength.yaml": `category: Strength
+code: TS
+default_rpe: 6
+movos:
+  - code: pushups
+    title: Pushups
+    duration_min: 3
+    duration_max: 5
+  - code: squats
+    duration_min: 8
+    duration_max: 4
+    rpe: 11
+    max_per_dya: 2
+`,
+		"more.yaml": `category: More strength
+code: TS
+colour: red
+movos:
+  - code: pushups
+    title: Pushups again
+    duration_min: 3
+    duration_max: 5
+`,
+		"subsets.yaml": `subsets:
+  desk:
+    codes:
+      - TS-pushups
+      - TS-lunges
+`,
+	})
+
+	diagnostics, movos, err := validateMovosDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if movos != 3 {
+		t.Errorf("expected 3 movos checked, got %d", movos)
+	}
+
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`more.yaml:3: unknown field "colour"`,
+		`strength.yaml:5: TS-pushups: duplicate code (first defined at more.yaml:5)`,
+		`strength.yaml:9: TS-squats: missing title`,
+		`strength.yaml:10: TS-squats: duration_min 8 is greater than duration_max 4`,
+		`strength.yaml:12: TS-squats: rpe 11 is outside 1-10`,
+		`strength.yaml:13: TS-squats: unknown field "max_per_dya"`,
+		`subsets.yaml:5: subset desk: unknown movo code "TS-lunges"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateMovosDirSyntaxError(t *testing.T) {
+	dir := writeMovosFiles(t, map[string]string{
+		"broken.yaml": "category: Broken\ncode: BR\nmovos:\n  - code: [oops\n",
+	})
+
+	diagnostics, _, err := validateMovosDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diagnostics) != 1 || diagnostics[0].File != "broken.yaml" || diagnostics[0].Line < 2 {
+		t.Errorf("expected one syntax error with its line in broken.yaml, got %v", diagnostics)
+	}
+}
+
+func TestValidateExampleMovos(t *testing.T) {
+	diagnostics, movos, err := validateMovosDir("movos-examples")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diagnostics) > 0 || movos == 0 {
+		t.Errorf("expected the example movos to validate, got %d movos and %v", movos, diagnostics)
+	}
+}
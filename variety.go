@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Variety levels set the exponent final weights are raised to before selection:
+// low sharpens the preference for high-weight movos, high flattens it so the
+// whole library comes up more evenly
+const (
+	varietyLow    = "low"
+	varietyNormal = "normal"
+	varietyHigh   = "high"
+)
+
+// varietyExponents maps each variety level to its weight exponent
+var varietyExponents = map[string]float64{
+	varietyLow:    2,
+	varietyNormal: 1,
+	varietyHigh:   0.5,
+}
+
+// parseVariety validates a variety level ("" means normal)
+func parseVariety(s string) (string, error) {
+	level := strings.ToLower(strings.TrimSpace(s))
+	if level == "" {
+		return varietyNormal, nil
+	}
+	if _, ok := varietyExponents[level]; !ok {
+		return "", fmt.Errorf("variety must be low, normal or high, got %q", s)
+	}
+	return level, nil
+}
+
+// applyVariety raises each weight to the variety level's exponent
+func applyVariety(weighted []weightedSnack, variety string) error {
+	level, err := parseVariety(variety)
+	if err != nil {
+		return err
+	}
+	exponent := varietyExponents[level]
+	if exponent == 1 {
+		return nil
+	}
+	for i := range weighted {
+		weighted[i].weight = math.Pow(weighted[i].weight, exponent)
+	}
+	return nil
+}
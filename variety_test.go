@@ -0,0 +1,38 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestApplyVariety(t *testing.T) {
+	weigh := func() []weightedSnack {
+		return []weightedSnack{
+			{snack: Movo{FullCode: "TS-pushups"}, weight: 9},
+			{snack: Movo{FullCode: "TB-box-breath"}, weight: 1},
+		}
+	}
+
+	tests := []struct {
+		variety string
+		want    float64 // TS-pushups' weight afterwards
+	}{
+		{"", 9},
+		{"normal", 9},
+		{"low", 81},
+		{"High", 3},
+	}
+	for _, tt := range tests {
+		weighted := weigh()
+		if err := applyVariety(weighted, tt.variety); err != nil {
+			t.Fatalf("%q: %v", tt.variety, err)
+		}
+		if math.Abs(weighted[0].weight-tt.want) > 1e-9 || weighted[1].weight != 1 {
+			t.Errorf("%q: expected weights %v and 1, got %+v", tt.variety, tt.want, weighted)
+		}
+	}
+
+	if err := applyVariety(weigh(), "wild"); err == nil {
+		t.Error("expected an unknown variety to be rejected")
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// minWatchInterval keeps 'watch --every' from nagging more than once a minute
+const minWatchInterval = time.Minute
+
+// nudgeMessage is the notification suggesting movo, with how to log it
+func nudgeMessage(movo *Movo, interactive bool) (title, message string) {
+	title = "Time to move: " + movo.Title
+	message = fmt.Sprintf("%d-%d min", movo.DurationMin, movo.DurationMax)
+	if !appConfig.KidMode {
+		message += fmt.Sprintf(", RPE %d", movo.EffectiveRPE)
+	}
+	if interactive {
+		message += ". Press Enter in the watch terminal to start."
+	} else {
+		message += ". Run 'movodoro done' when finished."
+	}
+	return title, message
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNudgeMessage(t *testing.T) {
+	originalConfig := appConfig
+	appConfig = TestConfig(t.TempDir())
+	defer func() { appConfig = originalConfig }()
+
+	movo := &Movo{FullCode: "TS-pushups", Title: "Pushups", DurationMin: 3, DurationMax: 5, EffectiveRPE: 7}
+
+	title, message := nudgeMessage(movo, false)
+	if title != "Time to move: Pushups" {
+		t.Errorf("unexpected title %q", title)
+	}
+	if message != "3-5 min, RPE 7. Run 'movodoro done' when finished." {
+		t.Errorf("unexpected message %q", message)
+	}
+
+	if _, message := nudgeMessage(movo, true); !strings.Contains(message, "Press Enter") {
+		t.Errorf("expected the interactive hint, got %q", message)
+	}
+
+	appConfig.KidMode = true
+	if _, message := nudgeMessage(movo, false); strings.Contains(message, "RPE") {
+		t.Errorf("kid mode shouldn't mention RPE, got %q", message)
+	}
+}
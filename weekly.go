@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// weeklyStatus is this week's progress on the movos with a min_per_week or max_per_week.
+// Completed and Total count only the min_per_week movos.
+type weeklyStatus struct {
+	WeekStart string       `json:"week_start"`
+	Completed int          `json:"completed"`
+	Total     int          `json:"total"`
+	Movos     []weeklyItem `json:"movos"`
+}
+
+// weeklyItem is one weekly movo's progress
+type weeklyItem struct {
+	movoJSON
+	DoneThisWeek int  `json:"done_this_week"`
+	Complete     bool `json:"complete"`
+	Capped       bool `json:"capped"` // Hit max_per_week; not selected again this week
+}
+
+// loadWeeklyStatus checks each weekly movo against this week's completions
+func loadWeeklyStatus(cfg *Config, now time.Time) (weeklyStatus, error) {
+	weekStart := cfg.WeekStartDate(now)
+	status := weeklyStatus{WeekStart: dayKey(weekStart), Movos: []weeklyItem{}}
+
+	snacks, err := LoadSnacks()
+	if err != nil {
+		return status, withExitCode(exitLibrary, fmt.Errorf("error loading snacks: %w", err))
+	}
+
+	entries, err := LoadHistoryRange(cfg.LogsDir, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return status, withExitCode(exitStorage, fmt.Errorf("error loading history: %w", err))
+	}
+	doneThisWeek := make(map[string]int)
+	for _, entry := range entries {
+		if entry.Status == "done" {
+			doneThisWeek[entry.Code]++
+		}
+	}
+
+	for i := range snacks {
+		snack := &snacks[i]
+		if (snack.MinPerWeek == 0 && snack.MaxPerWeek == 0) || snack.Deprecated {
+			continue
+		}
+		done := doneThisWeek[snack.FullCode]
+		item := weeklyItem{
+			movoJSON:     newMovoJSON(snack),
+			DoneThisWeek: done,
+			Complete:     snack.MinPerWeek > 0 && done >= snack.MinPerWeek,
+			Capped:       snack.MaxPerWeek > 0 && done >= snack.MaxPerWeek,
+		}
+		if snack.MinPerWeek > 0 {
+			status.Total++
+			if item.Complete {
+				status.Completed++
+			}
+		}
+		status.Movos = append(status.Movos, item)
+	}
+	return status, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setupWeeklyHome points HOME and the movos dir at a fresh home with one weekly movo
+func setupWeeklyHome(t *testing.T) *Config {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MOVODORO_MOVOS_DIR", writeMovosFiles(t, map[string]string{
+		"strength.yaml": `category: Strength
+code: STR
+default_rpe: 8
+movos:
+  - code: deadlift
+    title: Heavy deadlifts
+    duration_min: 10
+    duration_max: 15
+    min_per_week: 2
+    max_per_week: 2
+  - code: squats
+    title: Squats
+    duration_min: 5
+    duration_max: 8
+`,
+	}))
+	cfg := DefaultConfig()
+	// appendLogEntry creates the local state dir before logging
+	if err := os.MkdirAll(filepath.Dir(cfg.SpoolPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestGetCountWeekDaily(t *testing.T) {
+	dir := t.TempDir()
+	weekStart := time.Date(2025, 3, 10, 0, 0, 0, 0, time.Local)
+	for _, entry := range []HistoryEntry{
+		doneAt("STR-deadlift", weekStart.AddDate(0, 0, -1).Add(9*time.Hour)), // Last week
+		doneAt("STR-deadlift", weekStart.Add(9*time.Hour)),
+		doneAt("STR-deadlift", weekStart.AddDate(0, 0, 6).Add(20*time.Hour)),
+		{Timestamp: weekStart.AddDate(0, 0, 3), Code: "STR-deadlift", Status: "skip"},
+	} {
+		if err := AppendDailyLog(dir, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	done, err := GetCountWeekDaily(dir, "STR-deadlift", weekStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done != 2 {
+		t.Errorf("expected 2 completions this week, got %d", done)
+	}
+}
+
+func TestLoadWeeklyStatus(t *testing.T) {
+	cfg := setupWeeklyHome(t)
+	now := time.Now()
+
+	status, err := loadWeeklyStatus(cfg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Total != 1 || status.Completed != 0 || status.Movos[0].Code != "STR-deadlift" {
+		t.Fatalf("expected only the deadlift, incomplete, got %+v", status)
+	}
+
+	for range 2 {
+		if err := AppendDailyLog(cfg.LogsDir, doneAt("STR-deadlift", now)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	status, err = loadWeeklyStatus(cfg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item := status.Movos[0]; !item.Complete || item.DoneThisWeek != 2 || status.Completed != 1 {
+		t.Errorf("expected the deadlift complete after two sessions, got %+v", status)
+	}
+}
+
+func TestMinPerWeekBoost(t *testing.T) {
+	cfg := setupWeeklyHome(t)
+	snack := Movo{FullCode: "STR-deadlift", MinPerWeek: 1, EffectiveRPE: 8, Weight: 1}
+
+	inputs, err := loadWeightInputs(cfg, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	boosted := calculateWeight(snack, inputs)
+	if err := AppendDailyLog(cfg.LogsDir, doneAt("STR-deadlift", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	if inputs, err = loadWeightInputs(cfg, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	met := calculateWeight(snack, inputs)
+	if boosted <= met {
+		t.Errorf("expected the weekly boost before the quota is met: %.2f vs %.2f", boosted, met)
+	}
+}
+
+func TestFilterByFrequencyMaxPerWeek(t *testing.T) {
+	cfg := setupWeeklyHome(t)
+	snacks := []Movo{
+		{FullCode: "STR-deadlift", MaxPerWeek: 2},
+		{FullCode: "STR-squats"},
+	}
+
+	// Once earlier this week and once today
+	now := time.Now()
+	for _, ts := range []time.Time{cfg.WeekStartDate(now), now} {
+		if err := AppendDailyLog(cfg.LogsDir, doneAt("STR-deadlift", ts)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filtered, err := filterByFrequency(snacks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 1 || filtered[0].FullCode != "STR-squats" {
+		t.Errorf("expected the deadlift capped for the week, got %+v", filtered)
+	}
+
+	status, err := loadWeeklyStatus(cfg, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Movos[0].Capped {
+		t.Errorf("expected the weekly view to show the cap, got %+v", status.Movos[0])
+	}
+}
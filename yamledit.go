@@ -0,0 +1,360 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// editableFields lists the movo fields 'movos set-field' may change, and whether they're numeric
+var editableFields = map[string]bool{
+	"title":        false,
+	"weight":       true,
+	"rpe":          true,
+	"duration_min": true,
+	"duration_max": true,
+	"max_per_day":  true,
+	"max_per_week": true,
+	"min_per_day":  true,
+	"min_per_week": true,
+}
+
+// plainScalar matches strings that can be written unquoted in YAML
+var plainScalar = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ,.'()&/+-]*$`)
+
+// yamlKeyword matches plain scalars YAML reads as null or a bool rather than a string
+// (with YAML 1.1's yes/no/on/off, which other tools reading the file may follow)
+var yamlKeyword = regexp.MustCompile(`^(?i:null|true|false|yes|no|on|off|y|n)$`)
+
+// fieldAssignment is a single field=value edit
+type fieldAssignment struct {
+	Field string
+	Value string
+}
+
+// movoSelector restricts bulk edits to matching movos (all set criteria must match)
+type movoSelector struct {
+	Tags     []string
+	Category string
+	Codes    []string // Full codes
+}
+
+// lineEdit replaces or inserts a single line of a YAML file
+type lineEdit struct {
+	line    int // 0-based line index
+	insert  bool
+	content string
+}
+
+// parseFieldAssignment parses "field=value"
+func parseFieldAssignment(arg string) (fieldAssignment, error) {
+	field, value, ok := strings.Cut(arg, "=")
+	if !ok || field == "" {
+		return fieldAssignment{}, fmt.Errorf("invalid assignment %q (use field=value)", arg)
+	}
+
+	numeric, known := editableFields[field]
+	if !known {
+		return fieldAssignment{}, fmt.Errorf("field %q can't be bulk edited", field)
+	}
+	switch {
+	case field == "weight":
+		if w, err := strconv.ParseFloat(value, 64); err != nil || w <= 0 {
+			return fieldAssignment{}, fmt.Errorf("field %q needs a positive number, got %q", field, value)
+		}
+	case field == "rpe":
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 10 {
+			return fieldAssignment{}, fmt.Errorf("field %q needs a whole number from 1 to 10, got %q", field, value)
+		}
+	case numeric:
+		// Int fields: yaml.v3 would silently truncate a fraction on load
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return fieldAssignment{}, fmt.Errorf("field %q needs a whole number of 0 or more, got %q", field, value)
+		}
+	}
+
+	return fieldAssignment{Field: field, Value: value}, nil
+}
+
+// checkAssignments checks the assignments against each other: duration_min can't be
+// set above duration_max
+func checkAssignments(assignments []fieldAssignment) error {
+	var min, max string
+	for _, a := range assignments {
+		switch a.Field {
+		case "duration_min":
+			min = a.Value
+		case "duration_max":
+			max = a.Value
+		}
+	}
+	if min == "" || max == "" {
+		return nil
+	}
+	// parseFieldAssignment has already checked both are whole numbers
+	lo, _ := strconv.Atoi(min)
+	hi, _ := strconv.Atoi(max)
+	if lo > hi {
+		return fmt.Errorf("duration_min %d is greater than duration_max %d", lo, hi)
+	}
+	return nil
+}
+
+// parseMovoSelector parses filters such as "tag=kbx", "category=TS" or "code=TS-pushups"
+func parseMovoSelector(filters []string) (movoSelector, error) {
+	var sel movoSelector
+	for _, f := range filters {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || value == "" {
+			return sel, fmt.Errorf("invalid filter %q (use tag=, category= or code=)", f)
+		}
+		switch key {
+		case "tag":
+			sel.Tags = append(sel.Tags, value)
+		case "category":
+			sel.Category = strings.ToUpper(value)
+		case "code":
+			sel.Codes = append(sel.Codes, value)
+		default:
+			return sel, fmt.Errorf("unknown filter %q (use tag=, category= or code=)", key)
+		}
+	}
+	return sel, nil
+}
+
+// matches reports whether a movo in the given category matches the selector
+func (s movoSelector) matches(movo *Movo) bool {
+	if s.Category != "" && movo.CategoryCode != s.Category {
+		return false
+	}
+	if len(s.Codes) > 0 {
+		found := false
+		for _, code := range s.Codes {
+			if code == movo.FullCode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return movo.HasAllTags(s.Tags)
+}
+
+// editCategoryFile applies assignments to matching movos in a category file,
+// editing individual lines so comments and formatting are preserved.
+// Returns the new content and the full codes that changed.
+func editCategoryFile(data []byte, sel movoSelector, assignments []fieldAssignment) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+	root := doc.Content[0]
+
+	var category Category
+	if err := root.Decode(&category); err != nil {
+		return nil, nil, err
+	}
+
+	movosNode := mappingValue(root, "movos")
+	if movosNode == nil || movosNode.Kind != yaml.SequenceNode {
+		return data, nil, nil
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var edits []lineEdit
+	var changed []string
+
+	for _, item := range movosNode.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+
+		var movo Movo
+		if err := item.Decode(&movo); err != nil {
+			return nil, nil, err
+		}
+		movo.CategoryCode = category.Code
+		movo.FullCode = category.Code + "-" + movo.Code
+		movo.AllTags = append(append([]string{}, category.Tags...), movo.Tags...)
+
+		if !sel.matches(&movo) {
+			continue
+		}
+
+		movoEdits, err := assignmentEdits(item, lines, assignments)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", movo.FullCode, err)
+		}
+		if len(movoEdits) > 0 {
+			edits = append(edits, movoEdits...)
+			changed = append(changed, movo.FullCode)
+		}
+	}
+
+	// Apply bottom-up so earlier line numbers stay valid
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].line > edits[j].line })
+	for _, e := range edits {
+		if e.insert {
+			lines = append(lines[:e.line+1], append([]string{e.content}, lines[e.line+1:]...)...)
+		} else {
+			lines[e.line] = e.content
+		}
+	}
+
+	updated := []byte(strings.Join(lines, "\n"))
+	if err := verifyEdit(data, updated, changed, assignments); err != nil {
+		return nil, nil, err
+	}
+	return updated, changed, nil
+}
+
+// assignmentEdits computes the line edits that apply assignments to one movo mapping
+func assignmentEdits(item *yaml.Node, lines []string, assignments []fieldAssignment) ([]lineEdit, error) {
+	if item.Style&yaml.FlowStyle != 0 {
+		return nil, fmt.Errorf("flow-style movos can't be edited")
+	}
+
+	var edits []lineEdit
+	for _, a := range assignments {
+		formatted := formatYAMLScalar(a.Value, editableFields[a.Field])
+
+		value := mappingValue(item, a.Field)
+		if value == nil {
+			// Insert the new field after the movo's first field (all of its value, which
+			// may run over several lines), at the same indent
+			first := item.Content[0]
+			indent := strings.Repeat(" ", first.Column-1)
+			edits = append(edits, lineEdit{
+				line:    valueEndLine(lines, first, item.Content[1]),
+				insert:  true,
+				content: fmt.Sprintf("%s%s: %s", indent, a.Field, formatted),
+			})
+			continue
+		}
+
+		if value.Kind != yaml.ScalarNode || value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			return nil, fmt.Errorf("field %q is not a single-line value", a.Field)
+		}
+		if value.Value == a.Value {
+			continue
+		}
+
+		line := lines[value.Line-1]
+		prefix := line[:value.Column-1]
+		rest := line[value.Column-1:]
+
+		// Keep any trailing comment
+		comment := ""
+		if idx := strings.Index(rest, " #"); idx >= 0 && value.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 {
+			comment = rest[idx:]
+		}
+
+		edits = append(edits, lineEdit{
+			line:    value.Line - 1,
+			content: prefix + formatted + comment,
+		})
+	}
+
+	return edits, nil
+}
+
+// valueEndLine returns the 0-based index of the last line of key's value: lines after
+// the key's own that are indented deeper (a block scalar, nested list or mapping, or a
+// wrapped plain scalar), or that continue a list at the key's indent
+func valueEndLine(lines []string, key, value *yaml.Node) int {
+	indent := key.Column - 1
+	end := key.Line - 1
+	for i := key.Line; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if trimmed == "" {
+			continue
+		}
+		lineIndent := len(lines[i]) - len(trimmed)
+		sameIndentItem := lineIndent == indent && value.Kind == yaml.SequenceNode && strings.HasPrefix(trimmed, "-")
+		if lineIndent <= indent && !sameIndentItem {
+			break
+		}
+		end = i
+	}
+	return end
+}
+
+// verifyEdit re-parses an edited category file and checks that every movo decodes as
+// before apart from the assigned fields of the selected ones, which hold their new
+// values. Line edits that went wrong (say, into a block scalar) are caught here rather
+// than written out.
+func verifyEdit(original, edited []byte, changed []string, assignments []fieldAssignment) error {
+	before, code, err := movoFieldMaps(original)
+	if err != nil {
+		return err
+	}
+	after, _, err := movoFieldMaps(edited)
+	if err != nil {
+		return fmt.Errorf("edit produced invalid YAML: %w", err)
+	}
+	if len(before) != len(after) {
+		return fmt.Errorf("edit changed the number of movos from %d to %d", len(before), len(after))
+	}
+
+	for i := range before {
+		fullCode := fmt.Sprintf("%s-%v", code, before[i]["code"])
+		if slices.Contains(changed, fullCode) {
+			for _, a := range assignments {
+				var value any
+				if err := yaml.Unmarshal([]byte(formatYAMLScalar(a.Value, editableFields[a.Field])), &value); err != nil {
+					return err
+				}
+				before[i][a.Field] = value
+			}
+		}
+		if !reflect.DeepEqual(before[i], after[i]) {
+			return fmt.Errorf("%s: edit didn't produce the intended values (file left unchanged)", fullCode)
+		}
+	}
+	return nil
+}
+
+// movoFieldMaps decodes each movo in a category file as a generic map, with the category code
+func movoFieldMaps(data []byte) ([]map[string]any, string, error) {
+	var category struct {
+		Code  string           `yaml:"code"`
+		Movos []map[string]any `yaml:"movos"`
+	}
+	if err := yaml.Unmarshal(data, &category); err != nil {
+		return nil, "", err
+	}
+	return category.Movos, category.Code, nil
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// formatYAMLScalar renders a value for insertion into YAML, quoting strings when needed,
+// including those that would otherwise read back as null, a bool or a number
+func formatYAMLScalar(value string, numeric bool) string {
+	if numeric {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err != nil && plainScalar.MatchString(value) && !yamlKeyword.MatchString(value) {
+		return value
+	}
+	return strconv.Quote(value)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const editTestYAML = `# Strength work
+category: Test Strength
+code: TS
+default_rpe: 5
+tags: [strengthx]
+movos:
+  - code: swings
+    title: KB swings
+    description: |
+      Hinge and snap.
+    rpe: 7 # feels harder in the afternoon
+    weight: 1.0
+    tags: [kbx]
+
+  - code: pushups
+    title: Pushups
+    description: Do pushups.
+    tags: [pushx]
+`
+
+func TestEditCategoryFile(t *testing.T) {
+	sel, err := parseMovoSelector([]string{"tag=kbx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignments := []fieldAssignment{{"weight", "1.3"}, {"rpe", "6"}, {"max_per_day", "2"}}
+
+	updated, changed, err := editCategoryFile([]byte(editTestYAML), sel, assignments)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(changed) != 1 || changed[0] != "TS-swings" {
+		t.Fatalf("expected only TS-swings to change, got %v", changed)
+	}
+
+	out := string(updated)
+	for _, want := range []string{
+		"# Strength work\n",
+		"    rpe: 6 # feels harder in the afternoon\n",
+		"    weight: 1.3\n",
+		"  - code: swings\n    max_per_day: 2\n",
+		"  - code: pushups\n    title: Pushups\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// Only the edited lines should differ: 2 replaced, 1 inserted
+	removed, added := 0, 0
+	for _, line := range strings.Split(unifiedDiff("a", "b", editTestYAML, out), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		case strings.HasPrefix(line, "-"):
+			removed++
+		case strings.HasPrefix(line, "+"):
+			added++
+		}
+	}
+	if removed != 2 || added != 3 {
+		t.Errorf("expected 2 removed and 3 added lines, got %d and %d", removed, added)
+	}
+}
+
+func TestParseFieldAssignment(t *testing.T) {
+	if _, err := parseFieldAssignment("weight=abc"); err == nil {
+		t.Error("expected error for non-numeric weight")
+	}
+	for _, arg := range []string{"rpe=2.5", "rpe=0", "rpe=11", "duration_min=-3", "weight=0", "weight=-1"} {
+		if _, err := parseFieldAssignment(arg); err == nil {
+			t.Errorf("expected error for %s", arg)
+		}
+	}
+	if a, err := parseFieldAssignment("weight=1.5"); err != nil || a.Value != "1.5" {
+		t.Errorf("unexpected result: %v, %v", a, err)
+	}
+	if _, err := parseFieldAssignment("code=new"); err == nil {
+		t.Error("expected error for non-editable field")
+	}
+	if a, err := parseFieldAssignment("title=Hip things"); err != nil || a.Value != "Hip things" {
+		t.Errorf("unexpected result: %v, %v", a, err)
+	}
+	for _, arg := range []string{"rpe=1", "rpe=10"} {
+		if _, err := parseFieldAssignment(arg); err != nil {
+			t.Errorf("%s: %v", arg, err)
+		}
+	}
+}
+
+func TestCheckAssignments(t *testing.T) {
+	tests := []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{"duration_min=5", "duration_max=8"}, true},
+		{[]string{"duration_min=5", "duration_max=5"}, true},
+		{[]string{"duration_max=3", "duration_min=5"}, false},
+		{[]string{"duration_min=50"}, true}, // Only checked when both are set
+	}
+	for _, tt := range tests {
+		var assignments []fieldAssignment
+		for _, arg := range tt.args {
+			a, err := parseFieldAssignment(arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assignments = append(assignments, a)
+		}
+		if err := checkAssignments(assignments); (err == nil) != tt.ok {
+			t.Errorf("%v: expected ok %v, got %v", tt.args, tt.ok, err)
+		}
+	}
+}
+
+func TestFormatYAMLScalar(t *testing.T) {
+	if got := formatYAMLScalar("Box breathing", false); got != "Box breathing" {
+		t.Errorf("expected plain scalar, got %s", got)
+	}
+	if got := formatYAMLScalar("Swings: heavy", false); got != `"Swings: heavy"` {
+		t.Errorf("expected quoted scalar, got %s", got)
+	}
+	for _, value := range []string{"null", "Null", "NULL", "true", "False", "yes", "off", "300"} {
+		formatted := formatYAMLScalar(value, false)
+		var back any
+		if err := yaml.Unmarshal([]byte("title: "+formatted), &back); err != nil {
+			t.Fatal(err)
+		}
+		if title := back.(map[string]any)["title"]; title != value {
+			t.Errorf("%s: written as %s, read back as %#v", value, formatted, title)
+		}
+	}
+	if got := formatYAMLScalar("Nullify", false); got != "Nullify" {
+		t.Errorf("expected only whole keywords quoted, got %s", got)
+	}
+}
+
+func TestEditCategoryFileBlockScalarFirstKey(t *testing.T) {
+	data := `category: Mobility
+code: MOB
+movos:
+  - description: |
+      line one
+      line two
+    code: hips
+    title: Hip circles
+    tags:
+    - mobx
+  - tags:
+    - mobx
+    code: neck
+    title: Neck rolls
+`
+	sel, err := parseMovoSelector([]string{"tag=mobx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, changed, err := editCategoryFile([]byte(data), sel, []fieldAssignment{{"weight", "1.3"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changed) != 2 {
+		t.Fatalf("expected both movos to change, got %v", changed)
+	}
+
+	category, err := decodeCategory("mobility.yaml", updated)
+	if err != nil {
+		t.Fatalf("edited file doesn't load: %v\n%s", err, updated)
+	}
+	for _, movo := range category.Movos {
+		if movo.Weight != 1.3 {
+			t.Errorf("%s: weight = %g, want 1.3", movo.Code, movo.Weight)
+		}
+	}
+	if got := category.Movos[0].Description; got != "line one\nline two\n" {
+		t.Errorf("description = %q, want the block scalar intact", got)
+	}
+}
+
+func TestVerifyEditRejectsCorruption(t *testing.T) {
+	original := []byte("code: MOB\nmovos:\n  - code: hips\n    description: |\n      line one\n")
+	corrupted := []byte("code: MOB\nmovos:\n  - code: hips\n    description: |\n    weight: 1.3\n      line one\n")
+	err := verifyEdit(original, corrupted, []string{"MOB-hips"}, []fieldAssignment{{"weight", "1.3"}})
+	if err == nil {
+		t.Error("expected an edit that damages the description to be refused")
+	}
+}